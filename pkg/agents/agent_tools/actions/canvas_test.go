@@ -127,6 +127,57 @@ func TestResolvePatchDraftAutoLayout_DefaultsLayoutOnlyUpdatesToFullCanvas(t *te
 	assert.Empty(t, layout.NodeIds)
 }
 
+func TestBuildDraftChangeset_MoveNode(t *testing.T) {
+	changeset := requireDraftChangeset(t, []PatchOperation{
+		{
+			Op:     "move_node",
+			NodeID: "node-1",
+			Node:   &PatchNode{Name: "ignored", Position: &PatchPosition{X: 100, Y: -40}},
+		},
+		{
+			Op:   "set_position",
+			Node: &PatchNode{ID: "node-2", Position: &PatchPosition{X: 100, Y: 200}},
+		},
+	})
+
+	require.Len(t, changeset.Changes, 2)
+	for _, change := range changeset.Changes {
+		assert.Equal(t, changesets.ChangeTypeUpdateNode, change.Type)
+		assert.True(t, isPositionOnlyNodeChange(change.Node))
+	}
+
+	assert.Equal(t, "node-1", changeset.Changes[0].Node.ID)
+	assert.Equal(t, int32(100), changeset.Changes[0].Node.Position.X)
+	assert.Equal(t, int32(-40), changeset.Changes[0].Node.Position.Y)
+	assert.Empty(t, changeset.Changes[0].Node.Name)
+	assert.Equal(t, "node-2", changeset.Changes[1].Node.ID)
+}
+
+func TestBuildDraftChangeset_MoveNodeRequiresNodeIDAndPosition(t *testing.T) {
+	_, err := buildDraftChangeset([]PatchOperation{
+		{Op: "move_node", Node: &PatchNode{Position: &PatchPosition{X: 1, Y: 2}}},
+	})
+	require.ErrorContains(t, err, "patch_operations[0]: node_id is required")
+
+	_, err = buildDraftChangeset([]PatchOperation{
+		{Op: "move_node", NodeID: "node-1"},
+	})
+	require.ErrorContains(t, err, "patch_operations[0]: node.position is required")
+}
+
+func TestResolvePatchDraftAutoLayout_SkipsMovedNodes(t *testing.T) {
+	changeset := requireDraftChangeset(t, []PatchOperation{
+		{
+			Op:     "move_node",
+			NodeID: "moved-node",
+			Node:   &PatchNode{Position: &PatchPosition{X: 0, Y: 300}},
+		},
+	})
+
+	layout := resolvePatchStagingAutoLayout(nil, changeset, nil, []models.Node{{ID: "moved-node"}})
+	assert.Nil(t, layout)
+}
+
 func TestResolveLiveCanvasVersion_ResolvesLiveVersion(t *testing.T) {
 	r := support.Setup(t)
 	defer r.Close()
@@ -255,6 +306,10 @@ func TestAppAgentTool_PatchStagingStagesSmallGraphEdits(t *testing.T) {
 	assert.Equal(t, liveVersion.ID.String(), update.VersionID)
 	assert.Equal(t, 2, update.Summary.NodeCount)
 	assert.Equal(t, 1, update.Summary.EdgeCount)
+	assert.Equal(t, "3 changes:\n"+
+		"- Add node \"First\" (noop)\n"+
+		"- Add node \"Second\" (noop)\n"+
+		"- Connect \"First\" to \"Second\" on the \"default\" channel", update.Changes)
 
 	staging, err := canvasRepository.GetCanvasStaging(ctx, r.Organization.ID.String(), canvas.ID.String())
 	require.NoError(t, err)
@@ -273,6 +328,78 @@ func TestAppAgentTool_PatchStagingStagesSmallGraphEdits(t *testing.T) {
 	assert.Equal(t, "default", patched.GetSpec().GetEdges()[0].GetChannel())
 }
 
+func TestAppAgentTool_PatchStagingMovesNodes(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+
+	canvas, _ := support.CreateCanvas(t, r.Organization.ID, r.User, []models.CanvasNode{}, []models.Edge{})
+	liveVersion := requireLiveVersion(t, canvas.ID)
+
+	ctx := authentication.SetUserIdInMetadata(context.Background(), r.User.String())
+	registry := NewDefaultRegistry(Dependencies{
+		Encryptor:      r.Encryptor,
+		Registry:       r.Registry,
+		AuthService:    r.AuthService,
+		WebhookBaseURL: "https://hooks.example.test",
+	})
+
+	session := agents.AgentSessionContext{
+		SessionID:      "session-1",
+		OrganizationID: r.Organization.ID.String(),
+		UserID:         r.User.String(),
+		CanvasID:       canvas.ID.String(),
+	}
+
+	_, err := registry.Execute(ctx, session, Input{
+		Action: "patch_staging",
+		PatchOperations: []PatchOperation{
+			{Op: "add_node", Node: &PatchNode{ID: "first-node", Name: "First", Component: "noop"}},
+			{Op: "add_node", Node: &PatchNode{ID: "second-node", Name: "Second", Component: "noop"}},
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("valid node IDs are moved to the given coordinates", func(t *testing.T) {
+		_, err := registry.Execute(ctx, session, Input{
+			Action: "patch_staging",
+			PatchOperations: []PatchOperation{
+				{Op: "move_node", NodeID: "first-node", Node: &PatchNode{Position: &PatchPosition{X: 0, Y: 0}}},
+				{Op: "move_node", NodeID: "second-node", Node: &PatchNode{Position: &PatchPosition{X: 0, Y: 250}}},
+			},
+		})
+		require.NoError(t, err)
+
+		staged, err := canvasRepository.ReadRepositorySpecFileStaged(ctx, canvas, &liveVersion, canvasRepository.CanvasYAMLRepositoryPath)
+		require.NoError(t, err)
+
+		patched, err := canvasyaml.ParseCanvasResource([]byte(staged))
+		require.NoError(t, err)
+		require.Len(t, patched.GetSpec().GetNodes(), 2)
+		assert.Equal(t, "First", patched.GetSpec().GetNodes()[0].GetName())
+		assert.Equal(t, int32(0), patched.GetSpec().GetNodes()[0].GetPosition().GetY())
+		assert.Equal(t, int32(0), patched.GetSpec().GetNodes()[1].GetPosition().GetX())
+		assert.Equal(t, int32(250), patched.GetSpec().GetNodes()[1].GetPosition().GetY())
+	})
+
+	t.Run("unknown node ID rejects the patch without staging it", func(t *testing.T) {
+		_, err := registry.Execute(ctx, session, Input{
+			Action: "patch_staging",
+			PatchOperations: []PatchOperation{
+				{Op: "move_node", NodeID: "first-node", Node: &PatchNode{Position: &PatchPosition{X: 500, Y: 500}}},
+				{Op: "move_node", NodeID: "missing-node", Node: &PatchNode{Position: &PatchPosition{X: 0, Y: 0}}},
+			},
+		})
+		require.ErrorContains(t, err, "node missing-node not found")
+
+		staged, err := canvasRepository.ReadRepositorySpecFileStaged(ctx, canvas, &liveVersion, canvasRepository.CanvasYAMLRepositoryPath)
+		require.NoError(t, err)
+
+		patched, err := canvasyaml.ParseCanvasResource([]byte(staged))
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), patched.GetSpec().GetNodes()[0].GetPosition().GetX())
+	})
+}
+
 func TestAppAgentTool_PatchStagingAddsIntegrationBackedNode(t *testing.T) {
 	r := support.Setup(t)
 	defer r.Close()
@@ -958,6 +1085,150 @@ func TestReadRuntimeAction_RejectsUnknownResource(t *testing.T) {
 	assert.Contains(t, err.Error(), "unsupported runtime resource")
 }
 
+func TestReadRuntimeAction_ReadsNodeEventsOnChannel(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+
+	canvas, _ := support.CreateCanvas(t, r.Organization.ID, r.User, []models.CanvasNode{
+		{
+			NodeID: "trigger-1",
+			Type:   models.NodeTypeTrigger,
+			Ref: datatypes.NewJSONType(models.NodeRef{
+				Trigger: &models.TriggerRef{Name: "start"},
+			}),
+		},
+	}, nil)
+	passed := support.EmitCanvasEventForNode(t, canvas.ID, "trigger-1", "passed", nil)
+	failed := support.EmitCanvasEventForNode(t, canvas.ID, "trigger-1", "failed", nil)
+
+	action := readRuntimeAction{
+		registry: r.Registry,
+		auth:     allowingPermissionChecker{},
+	}
+	session := agents.AgentSessionContext{
+		OrganizationID: r.Organization.ID.String(),
+		UserID:         r.User.String(),
+		CanvasID:       canvas.ID.String(),
+	}
+
+	eventIDs := func(result any) []string {
+		read, ok := result.(runtimeReadResult)
+		require.True(t, ok)
+		payload, ok := read.Payload.(map[string]any)
+		require.True(t, ok)
+		events, _ := payload["events"].([]any)
+		ids := make([]string, 0, len(events))
+		for _, event := range events {
+			ids = append(ids, event.(map[string]any)["id"].(string))
+		}
+		return ids
+	}
+
+	t.Run("channel-scoped request only returns that channel's events", func(t *testing.T) {
+		result, err := action.Execute(context.Background(), session, Input{
+			Resource: "node_events",
+			NodeID:   "trigger-1",
+			Channel:  "failed",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{failed.ID.String()}, eventIDs(result))
+		assert.EqualValues(t, 1, result.(runtimeReadResult).Payload.(map[string]any)["total_count"])
+	})
+
+	t.Run("no channel returns events on every channel", func(t *testing.T) {
+		result, err := action.Execute(context.Background(), session, Input{
+			Resource: "node_events",
+			NodeID:   "trigger-1",
+		})
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{passed.ID.String(), failed.ID.String()}, eventIDs(result))
+	})
+
+	t.Run("since_seconds excludes events older than the window", func(t *testing.T) {
+		weekAgo := time.Now().Add(-7 * 24 * time.Hour)
+		require.NoError(t, database.Conn().Model(&models.CanvasEvent{}).Where("id = ?", passed.ID).Update("created_at", weekAgo).Error)
+
+		result, err := action.Execute(context.Background(), session, Input{
+			Resource:     "node_events",
+			NodeID:       "trigger-1",
+			SinceSeconds: 3600,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{failed.ID.String()}, eventIDs(result))
+		assert.EqualValues(t, 1, result.(runtimeReadResult).Payload.(map[string]any)["total_count"])
+
+		result, err = action.Execute(context.Background(), session, Input{
+			Resource: "node_events",
+			NodeID:   "trigger-1",
+		})
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{passed.ID.String(), failed.ID.String()}, eventIDs(result))
+	})
+}
+
+func TestReadRuntimeAction_CapsNodeEvents(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+	t.Setenv("SUPERPLANE_AGENT_MAX_NODE_EVENTS", "2")
+
+	canvas, _ := support.CreateCanvas(t, r.Organization.ID, r.User, []models.CanvasNode{
+		{
+			NodeID: "trigger-1",
+			Type:   models.NodeTypeTrigger,
+			Ref: datatypes.NewJSONType(models.NodeRef{
+				Trigger: &models.TriggerRef{Name: "start"},
+			}),
+		},
+	}, nil)
+	for range 3 {
+		support.EmitCanvasEventForNode(t, canvas.ID, "trigger-1", "default", nil)
+	}
+
+	action := readRuntimeAction{
+		registry: r.Registry,
+		auth:     allowingPermissionChecker{},
+	}
+	session := agents.AgentSessionContext{
+		OrganizationID: r.Organization.ID.String(),
+		UserID:         r.User.String(),
+		CanvasID:       canvas.ID.String(),
+	}
+
+	result, err := action.Execute(context.Background(), session, Input{
+		Resource: "node_events",
+		NodeID:   "trigger-1",
+		Limit:    10,
+	})
+
+	require.NoError(t, err)
+	payload := result.(runtimeReadResult).Payload.(map[string]any)
+	assert.Len(t, payload["events"], 2)
+	assert.EqualValues(t, 3, payload["total_count"])
+}
+
+func TestNodeEventsLimit(t *testing.T) {
+	t.Run("omitted limit uses the backend default", func(t *testing.T) {
+		t.Setenv("SUPERPLANE_AGENT_MAX_NODE_EVENTS", "")
+		assert.EqualValues(t, canvasRepository.DefaultLimit, nodeEventsLimit(0))
+	})
+
+	t.Run("requested limit is capped at the configured value", func(t *testing.T) {
+		t.Setenv("SUPERPLANE_AGENT_MAX_NODE_EVENTS", "5")
+		assert.EqualValues(t, 5, nodeEventsLimit(0))
+		assert.EqualValues(t, 5, nodeEventsLimit(20))
+		assert.EqualValues(t, 3, nodeEventsLimit(3))
+	})
+
+	t.Run("values above the page size cannot raise the cap", func(t *testing.T) {
+		t.Setenv("SUPERPLANE_AGENT_MAX_NODE_EVENTS", "1000")
+		assert.EqualValues(t, canvasRepository.MaxLimit, nodeEventsLimit(500))
+	})
+}
+
 func TestReadRuntimeAction_ReadsRunnerLogsByExecutionID(t *testing.T) {
 	r := support.Setup(t)
 	defer r.Close()
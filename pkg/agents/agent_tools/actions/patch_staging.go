@@ -78,7 +78,7 @@ func (a patchStagingAction) Execute(ctx context.Context, session agents.AgentSes
 		return updateResult{}, err
 	}
 
-	return newPatchStagingResult(session, target.draft, canvas, patched), nil
+	return newPatchStagingResult(session, target, canvas, stagedCanvas, patched), nil
 }
 
 func resolvePatchStagingTarget(session agents.AgentSessionContext, input Input) (patchStagingTarget, error) {
@@ -212,15 +212,31 @@ func stagePatchedDraftFiles(ctx context.Context, session agents.AgentSessionCont
 	return nil
 }
 
-func newPatchStagingResult(session agents.AgentSessionContext, draft *models.CanvasVersion, canvas *models.Canvas, patched *models.CanvasVersion) updateResult {
-	return updateResult{
+/*
+ * The changes are described against the staged canvas
+ * the patch was applied to, so removed nodes keep their names.
+ */
+func newPatchStagingResult(
+	session agents.AgentSessionContext,
+	target patchStagingTarget,
+	canvas *models.Canvas,
+	stagedCanvas stagedDraftCanvas,
+	patched *models.CanvasVersion,
+) updateResult {
+	result := updateResult{
 		Action:     patchStagingActionName,
 		CanvasID:   session.CanvasID,
-		VersionID:  draft.ID.String(),
-		Draft:      draftResult{VersionID: draft.ID.String()},
+		VersionID:  target.draft.ID.String(),
+		Draft:      draftResult{VersionID: target.draft.ID.String()},
 		NodeIssues: collectNodeIssues(patched.Nodes),
 		Summary:    summarizeParsedCanvas(canvas.Name, patched.Nodes, patched.Edges),
 	}
+
+	if target.changeset != nil {
+		result.Changes = changesets.DescribeChangeset(target.changeset, stagedCanvas.nodes)
+	}
+
+	return result
 }
 
 func buildDraftChangeset(operations []PatchOperation) (*changesets.CanvasChangeset, error) {
@@ -256,6 +272,13 @@ func buildDraftChange(operation PatchOperation) (*changesets.Change, error) {
 		}
 		return &changesets.Change{Type: changesets.ChangeTypeUpdateNode, Node: node}, nil
 
+	case "move_node":
+		node, err := patchMoveNode(operation)
+		if err != nil {
+			return nil, err
+		}
+		return &changesets.Change{Type: changesets.ChangeTypeUpdateNode, Node: node}, nil
+
 	case "delete_node":
 		nodeID := patchNodeID(operation)
 		if nodeID == "" {
@@ -292,6 +315,8 @@ func normalizePatchOp(op string) string {
 		return "delete_node"
 	case "remove_edge":
 		return "delete_edge"
+	case "set_position":
+		return "move_node"
 	default:
 		return strings.TrimSpace(op)
 	}
@@ -330,6 +355,29 @@ func patchChangeNode(operation PatchOperation) (*changesets.ChangeNode, error) {
 	return node, nil
 }
 
+/*
+ * move_node only changes the node position.
+ * The node ID is checked against the canvas when the changeset is applied.
+ */
+func patchMoveNode(operation PatchOperation) (*changesets.ChangeNode, error) {
+	nodeID := patchNodeID(operation)
+	if nodeID == "" {
+		return nil, fmt.Errorf("node_id is required")
+	}
+
+	if operation.Node == nil || operation.Node.Position == nil {
+		return nil, fmt.Errorf("node.position is required")
+	}
+
+	return &changesets.ChangeNode{
+		ID: nodeID,
+		Position: &componentpb.Position{
+			X: int32(operation.Node.Position.X),
+			Y: int32(operation.Node.Position.Y),
+		},
+	}, nil
+}
+
 func patchNodeID(operation PatchOperation) string {
 	if operation.NodeID != "" {
 		return strings.TrimSpace(operation.NodeID)
@@ -433,10 +481,18 @@ func defaultPatchStagingAutoLayoutNodeIDs(
 		}
 
 		switch change.Type {
-		case changesets.ChangeTypeAddNode, changesets.ChangeTypeUpdateNode:
+		case changesets.ChangeTypeAddNode:
 			if change.Node != nil {
 				addSeed(change.Node.ID)
 			}
+		case changesets.ChangeTypeUpdateNode:
+			//
+			// Explicit moves keep their coordinates,
+			// so they do not trigger the default auto-layout.
+			//
+			if change.Node != nil && !isPositionOnlyNodeChange(change.Node) {
+				addSeed(change.Node.ID)
+			}
 		case changesets.ChangeTypeDeleteNode:
 			if change.Node != nil && strings.TrimSpace(change.Node.ID) != "" {
 				deletedNodeIDs[strings.TrimSpace(change.Node.ID)] = struct{}{}
@@ -468,6 +524,15 @@ func defaultPatchStagingAutoLayoutNodeIDs(
 	return nodeIDs
 }
 
+func isPositionOnlyNodeChange(node *changesets.ChangeNode) bool {
+	return node.Position != nil &&
+		node.Name == "" &&
+		node.Block == "" &&
+		node.IntegrationID == "" &&
+		node.Configuration == nil &&
+		node.IsCollapsed == nil
+}
+
 func resolveToolAutoLayoutInput(input *AutoLayoutInput) *pb.CanvasAutoLayout {
 	if input == nil {
 		return nil
@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/superplanehq/superplane/pkg/agents"
 	runneraction "github.com/superplanehq/superplane/pkg/components/runner"
+	"github.com/superplanehq/superplane/pkg/config"
 	"github.com/superplanehq/superplane/pkg/database"
 	canvasactions "github.com/superplanehq/superplane/pkg/grpc/actions/canvases"
 	"github.com/superplanehq/superplane/pkg/models"
@@ -178,7 +179,7 @@ func (a readRuntimeAction) read(ctx context.Context, session agents.AgentSession
 		if strings.TrimSpace(input.NodeID) == "" {
 			return nil, fmt.Errorf("node_id is required for node_events")
 		}
-		return protoPayload(canvasactions.ListNodeEvents(ctx, a.registry, canvasID, input.NodeID, input.Limit, before))
+		return protoPayload(canvasactions.ListNodeEvents(ctx, a.registry, canvasID, input.NodeID, strings.TrimSpace(input.Channel), nodeEventsLimit(input.Limit), before, runtimeSince(input.SinceSeconds)))
 	case "runner_logs":
 		return a.readRunnerLogs(ctx, session, canvasID, input)
 	default:
@@ -186,6 +187,30 @@ func (a readRuntimeAction) read(ctx context.Context, session agents.AgentSession
 	}
 }
 
+// nodeEventsLimit applies the per-read SUPERPLANE_AGENT_MAX_NODE_EVENTS cap.
+// ListNodeEvents never returns more than a page, so the cap can only lower it.
+// An omitted limit keeps the backend default as long as it fits under the cap.
+func nodeEventsLimit(requested uint32) uint32 {
+	maxEvents := uint32(min(config.MaxAgentNodeEvents(), canvasactions.MaxLimit))
+	if requested == 0 {
+		requested = canvasactions.DefaultLimit
+	}
+	if requested > maxEvents {
+		return maxEvents
+	}
+	return requested
+}
+
+// runtimeSince turns a since_seconds window into a lower time bound; zero
+// keeps the count-based behavior of returning the latest events of any age.
+func runtimeSince(seconds uint32) *time.Time {
+	if seconds == 0 {
+		return nil
+	}
+	since := time.Now().Add(-time.Duration(seconds) * time.Second)
+	return &since
+}
+
 func (a readRuntimeAction) readRunnerLogs(ctx context.Context, session agents.AgentSessionContext, canvasID uuid.UUID, input Input) (any, error) {
 	organizationID, err := uuid.Parse(session.OrganizationID)
 	if err != nil {
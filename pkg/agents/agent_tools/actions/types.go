@@ -19,10 +19,12 @@ type Input struct {
 	Namespace           string            `json:"namespace,omitempty"`
 	NodeID              string            `json:"node_id,omitempty"`
 	EventID             string            `json:"event_id,omitempty"`
+	Channel             string            `json:"channel,omitempty"`
 	ExecutionID         string            `json:"execution_id,omitempty"`
 	RunID               string            `json:"run_id,omitempty"`
 	Limit               uint32            `json:"limit,omitempty"`
 	Before              string            `json:"before,omitempty"`
+	SinceSeconds        uint32            `json:"since_seconds,omitempty"`
 	States              []string          `json:"states,omitempty"`
 	Results             []string          `json:"results,omitempty"`
 	Path                string            `json:"path,omitempty"`
@@ -87,6 +89,7 @@ type updateResult struct {
 	Draft      draftResult `json:"draft"`
 	Summary    summary     `json:"summary"`
 	NodeIssues []nodeIssue `json:"node_issues,omitempty"`
+	Changes    string      `json:"changes,omitempty"`
 }
 
 type integrationsResult struct {
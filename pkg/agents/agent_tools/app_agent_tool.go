@@ -164,6 +164,10 @@ func (t *AppAgentTool) InputSchema() agents.CustomToolInputSchema {
 				Type:        "string",
 				Description: "For read_runtime resource event_executions.",
 			},
+			"channel": {
+				Type:        "string",
+				Description: "For read_runtime resource node_events. Only return the latest events on this output channel; all channels when omitted.",
+			},
 			"execution_id": {
 				Type:        "string",
 				Description: "For read_runtime resource runner_logs. Fetch logs for a specific node execution.",
@@ -174,7 +178,11 @@ func (t *AppAgentTool) InputSchema() agents.CustomToolInputSchema {
 			},
 			"limit": {
 				Type:        "integer",
-				Description: "For read_runtime paginated resources, runner_logs, and list_resources. Backend defaults apply when omitted; list_resources and runner_logs cap results to keep responses concise.",
+				Description: "For read_runtime paginated resources, runner_logs, and list_resources. Backend defaults apply when omitted; list_resources, runner_logs, and node_events cap results to keep responses concise.",
+			},
+			"since_seconds": {
+				Type:        "integer",
+				Description: "For read_runtime resource node_events. Only return events emitted within this many seconds; events of any age when omitted.",
 			},
 			"before": {
 				Type:        "string",
@@ -198,18 +206,18 @@ func (t *AppAgentTool) InputSchema() agents.CustomToolInputSchema {
 func patchOperationsSchema() agents.CustomToolInputSchema {
 	return agents.CustomToolInputSchema{
 		Type:        "array",
-		Description: "For patch_staging. Ordered graph edits applied without sending full canvas YAML. Supported op values: add_node, update_node, delete_node, add_edge, delete_edge. Aliases replace_node/remove_node/remove_edge are accepted. update_node can change name, configuration, position, and is_collapsed; it can assign the first component to a placeholder node that has no component yet. Use delete_node plus add_node for all other component/integration replacements.",
+		Description: "For patch_staging. Ordered graph edits applied without sending full canvas YAML. Supported op values: add_node, update_node, move_node, delete_node, add_edge, delete_edge. Aliases replace_node/remove_node/remove_edge/set_position are accepted. move_node only changes node.position of an existing node and does not trigger auto-layout. update_node can change name, configuration, position, and is_collapsed; it can assign the first component to a placeholder node that has no component yet. Use delete_node plus add_node for all other component/integration replacements.",
 		Items: &agents.CustomToolInputSchema{
 			Type: "object",
 			Properties: map[string]agents.CustomToolInputSchema{
 				"op": {
 					Type:        "string",
-					Enum:        []string{"add_node", "update_node", "delete_node", "add_edge", "delete_edge", "replace_node", "remove_node", "remove_edge"},
+					Enum:        []string{"add_node", "update_node", "move_node", "delete_node", "add_edge", "delete_edge", "replace_node", "remove_node", "remove_edge", "set_position"},
 					Description: "Patch operation to apply.",
 				},
 				"node_id": {
 					Type:        "string",
-					Description: "For delete_node, or as an ID fallback for update_node.",
+					Description: "For delete_node and move_node, or as an ID fallback for update_node.",
 				},
 				"node": patchNodeSchema(),
 				"edge": patchEdgeSchema(),
@@ -222,7 +230,7 @@ func patchOperationsSchema() agents.CustomToolInputSchema {
 func patchNodeSchema() agents.CustomToolInputSchema {
 	return agents.CustomToolInputSchema{
 		Type:        "object",
-		Description: "Node payload for add_node or update_node.",
+		Description: "Node payload for add_node or update_node. move_node only reads position.",
 		Properties: map[string]agents.CustomToolInputSchema{
 			"id": {
 				Type:        "string",
@@ -245,7 +253,8 @@ func patchNodeSchema() agents.CustomToolInputSchema {
 				Description: "Connected integration ID required for non-core blocks on add_node. update_node ignores integration_id.",
 			},
 			"position": {
-				Type: "object",
+				Type:        "object",
+				Description: "Canvas coordinates of the node. Required for move_node.",
 				Properties: map[string]agents.CustomToolInputSchema{
 					"x": {Type: "integer"},
 					"y": {Type: "integer"},
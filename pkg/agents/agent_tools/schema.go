@@ -63,6 +63,28 @@ func (t *ComponentSchemaAgentTool) InputSchema() agents.CustomToolInputSchema {
 				Type:        "string",
 				Description: "Search term used against component keys, labels, descriptions, kind, and required integration vendor.",
 			},
+			"nodes": {
+				Type:        "array",
+				Description: "Existing canvas nodes to resolve output channels for. Output channels that depend on configuration, like branch names, are resolved per node and returned in nodes.",
+				Items: &agents.CustomToolInputSchema{
+					Type: "object",
+					Properties: map[string]agents.CustomToolInputSchema{
+						"node_id": {
+							Type:        "string",
+							Description: "Canvas node ID.",
+						},
+						"component_key": {
+							Type:        "string",
+							Description: "Component key of the node, for example http.",
+						},
+						"configuration": {
+							Type:        "object",
+							Description: "Current configuration of the node.",
+						},
+					},
+					Required: []string{"node_id", "component_key"},
+				},
+			},
 			"include_examples": {
 				Type:        "boolean",
 				Description: "Include compact example input/output payloads when available. Honored only for exact component_keys lookups; broad vendor and query lookups stay compact.",
@@ -133,6 +155,18 @@ func (t *ComponentSchemaAgentTool) lookup(input superPlaneComponentSchemaInput)
 		}
 	}
 
+	nodes := []superPlaneNodeOutputChannels{}
+	for _, node := range input.Nodes {
+		resolved, err := t.lookupNodeOutputChannels(node)
+		if err != nil {
+			if !slices.Contains(missing, strings.TrimSpace(node.ComponentKey)) {
+				missing = append(missing, strings.TrimSpace(node.ComponentKey))
+			}
+			continue
+		}
+		nodes = append(nodes, resolved)
+	}
+
 	sort.Slice(components, func(i, j int) bool { return components[i].Key < components[j].Key })
 	notes := []string{
 		"Use output_channels.name exactly in edge channel values; labels are display-only.",
@@ -147,6 +181,7 @@ func (t *ComponentSchemaAgentTool) lookup(input superPlaneComponentSchemaInput)
 	return superPlaneComponentSchemaResult{
 		Action:     "lookup",
 		Components: components,
+		Nodes:      nodes,
 		Missing:    missing,
 		Omitted:    omitted,
 		Truncated:  truncated,
@@ -167,6 +202,39 @@ func (t *ComponentSchemaAgentTool) lookupComponent(key string, includeExamples b
 	return superPlaneComponentSchema{}, fmt.Errorf("component %s not found", key)
 }
 
+/*
+ * Output channels may depend on the node configuration,
+ * so they are resolved per node, falling back to the
+ * channels the component reports without a configuration.
+ */
+func (t *ComponentSchemaAgentTool) lookupNodeOutputChannels(node superPlaneComponentSchemaNode) (superPlaneNodeOutputChannels, error) {
+	key := strings.TrimSpace(node.ComponentKey)
+	result := superPlaneNodeOutputChannels{
+		NodeID:       strings.TrimSpace(node.NodeID),
+		ComponentKey: key,
+	}
+
+	if action, err := t.registry.GetAction(key); err == nil {
+		var channels []core.OutputChannel
+		if node.Configuration != nil {
+			channels = safeActionOutputChannels(action, node.Configuration)
+		}
+		if len(channels) == 0 {
+			channels = safeActionOutputChannels(action, nil)
+		}
+
+		result.OutputChannels = actionOutputChannelSchemas(channels)
+		return result, nil
+	}
+
+	if _, err := t.registry.GetTrigger(key); err == nil {
+		result.OutputChannels = outputChannelSchemas([]core.OutputChannel{core.DefaultOutputChannel})
+		return result, nil
+	}
+
+	return superPlaneNodeOutputChannels{}, fmt.Errorf("component %s not found", key)
+}
+
 func (t *ComponentSchemaAgentTool) vendorComponents(vendor string, includeExamples bool) []superPlaneComponentSchema {
 	integration, err := t.registry.GetIntegration(vendor)
 	if err != nil {
@@ -203,11 +271,6 @@ func (t *ComponentSchemaAgentTool) allComponents(includeExamples bool) []superPl
 }
 
 func actionSchema(action core.Action, vendor string, includeExamples bool) superPlaneComponentSchema {
-	outputChannels := outputChannelSchemas(safeActionOutputChannels(action))
-	if len(outputChannels) == 0 {
-		outputChannels = outputChannelSchemas([]core.OutputChannel{core.DefaultOutputChannel})
-	}
-
 	schema := superPlaneComponentSchema{
 		Key:                 action.Name(),
 		Kind:                "action",
@@ -215,7 +278,7 @@ func actionSchema(action core.Action, vendor string, includeExamples bool) super
 		Description:         action.Description(),
 		RequiresIntegration: vendor,
 		Configuration:       fieldSchemas(safeActionConfiguration(action)),
-		OutputChannels:      outputChannels,
+		OutputChannels:      actionOutputChannelSchemas(safeActionOutputChannels(action, nil)),
 	}
 	if includeExamples {
 		schema.ExampleOutput = compactJSON(safeActionExampleOutput(action), componentSchemaExampleLimit)
@@ -270,6 +333,13 @@ func fieldSchemas(fields []configuration.Field) []superPlaneComponentField {
 	return result
 }
 
+func actionOutputChannelSchemas(channels []core.OutputChannel) []superPlaneOutputChannel {
+	if len(channels) == 0 {
+		return outputChannelSchemas([]core.OutputChannel{core.DefaultOutputChannel})
+	}
+	return outputChannelSchemas(channels)
+}
+
 func outputChannelSchemas(channels []core.OutputChannel) []superPlaneOutputChannel {
 	result := make([]superPlaneOutputChannel, 0, len(channels))
 	for _, channel := range channels {
@@ -308,13 +378,13 @@ func visibilityConditionSchemas(conditions []configuration.VisibilityCondition)
 	return result
 }
 
-func safeActionOutputChannels(action core.Action) (channels []core.OutputChannel) {
+func safeActionOutputChannels(action core.Action, config any) (channels []core.OutputChannel) {
 	defer func() {
 		if recover() != nil {
 			channels = nil
 		}
 	}()
-	return action.OutputChannels(nil)
+	return action.OutputChannels(config)
 }
 
 func safeActionConfiguration(action core.Action) (fields []configuration.Field) {
@@ -467,20 +537,28 @@ func truncateString(value string, limit int) string {
 }
 
 type superPlaneComponentSchemaInput struct {
-	ComponentKeys   []string `json:"component_keys,omitempty"`
-	Vendors         []string `json:"vendors,omitempty"`
-	Query           string   `json:"query,omitempty"`
-	IncludeExamples bool     `json:"include_examples,omitempty"`
-	Limit           int      `json:"limit,omitempty"`
+	ComponentKeys   []string                        `json:"component_keys,omitempty"`
+	Vendors         []string                        `json:"vendors,omitempty"`
+	Query           string                          `json:"query,omitempty"`
+	IncludeExamples bool                            `json:"include_examples,omitempty"`
+	Limit           int                             `json:"limit,omitempty"`
+	Nodes           []superPlaneComponentSchemaNode `json:"nodes,omitempty"`
+}
+
+type superPlaneComponentSchemaNode struct {
+	NodeID        string         `json:"node_id"`
+	ComponentKey  string         `json:"component_key"`
+	Configuration map[string]any `json:"configuration,omitempty"`
 }
 
 type superPlaneComponentSchemaResult struct {
-	Action     string                      `json:"action"`
-	Components []superPlaneComponentSchema `json:"components"`
-	Missing    []string                    `json:"missing,omitempty"`
-	Omitted    []string                    `json:"omitted,omitempty"`
-	Truncated  bool                        `json:"truncated,omitempty"`
-	Notes      []string                    `json:"notes,omitempty"`
+	Action     string                         `json:"action"`
+	Components []superPlaneComponentSchema    `json:"components"`
+	Nodes      []superPlaneNodeOutputChannels `json:"nodes,omitempty"`
+	Missing    []string                       `json:"missing,omitempty"`
+	Omitted    []string                       `json:"omitted,omitempty"`
+	Truncated  bool                           `json:"truncated,omitempty"`
+	Notes      []string                       `json:"notes,omitempty"`
 }
 
 type superPlaneComponentSchema struct {
@@ -520,6 +598,12 @@ type superPlaneFieldCondition struct {
 	Values []string `json:"values"`
 }
 
+type superPlaneNodeOutputChannels struct {
+	NodeID         string                    `json:"node_id"`
+	ComponentKey   string                    `json:"component_key"`
+	OutputChannels []superPlaneOutputChannel `json:"output_channels"`
+}
+
 type superPlaneOutputChannel struct {
 	Name        string `json:"name"`
 	Label       string `json:"label,omitempty"`
@@ -7,6 +7,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/superplanehq/superplane/pkg/agents"
+	"github.com/superplanehq/superplane/pkg/components/noop"
+	"github.com/superplanehq/superplane/pkg/core"
 	"github.com/superplanehq/superplane/pkg/crypto"
 	"github.com/superplanehq/superplane/pkg/registry"
 	"github.com/superplanehq/superplane/pkg/registryimports"
@@ -60,6 +62,7 @@ func TestAppAgentToolSchemaIncludesRuntimeReadAction(t *testing.T) {
 	assert.Contains(t, schema.Properties, "namespace")
 	assert.Contains(t, schema.Properties, "node_id")
 	assert.Contains(t, schema.Properties, "event_id")
+	assert.Contains(t, schema.Properties, "channel")
 	assert.Contains(t, schema.Properties, "execution_id")
 	assert.Contains(t, schema.Properties, "run_id")
 	assert.Contains(t, schema.Properties, "path")
@@ -197,6 +200,68 @@ func TestComponentSchemaAgentTool_ReportsOmittedQueryMatchesWhenLimited(t *testi
 	}
 }
 
+func TestComponentSchemaAgentTool_ResolvesOutputChannelsPerNode(t *testing.T) {
+	tool := newComponentSchemaTool(t)
+	tool.registry.Actions["branch"] = &configDependentChannelsAction{Action: &noop.NoOp{}}
+
+	result := executeComponentSchemaTool(t, tool, superPlaneComponentSchemaInput{
+		ComponentKeys: []string{"branch"},
+		Nodes: []superPlaneComponentSchemaNode{
+			{NodeID: "deploy-branch", ComponentKey: "branch", Configuration: map[string]any{"branches": []any{"staging", "production"}}},
+			{NodeID: "review-branch", ComponentKey: "branch", Configuration: map[string]any{"branches": []any{"approved"}}},
+		},
+	})
+
+	require.Len(t, result.Components, 1)
+	assert.Equal(t, []string{"else"}, outputChannelNames(result.Components[0].OutputChannels))
+
+	require.Len(t, result.Nodes, 2)
+	assert.Equal(t, "deploy-branch", result.Nodes[0].NodeID)
+	assert.Equal(t, "branch", result.Nodes[0].ComponentKey)
+	assert.Equal(t, []string{"staging", "production", "else"}, outputChannelNames(result.Nodes[0].OutputChannels))
+	assert.Equal(t, "review-branch", result.Nodes[1].NodeID)
+	assert.Equal(t, []string{"approved", "else"}, outputChannelNames(result.Nodes[1].OutputChannels))
+}
+
+func TestComponentSchemaAgentTool_FallsBackToNilConfigurationOutputChannels(t *testing.T) {
+	tool := newComponentSchemaTool(t)
+	tool.registry.Actions["branch"] = &configDependentChannelsAction{Action: &noop.NoOp{}}
+
+	t.Run("without node configuration", func(t *testing.T) {
+		result := executeComponentSchemaTool(t, tool, superPlaneComponentSchemaInput{
+			Nodes: []superPlaneComponentSchemaNode{{NodeID: "branch-1", ComponentKey: "branch"}},
+		})
+
+		require.Len(t, result.Nodes, 1)
+		assert.Equal(t, []string{"else"}, outputChannelNames(result.Nodes[0].OutputChannels))
+	})
+
+	t.Run("when node configuration panics", func(t *testing.T) {
+		result := executeComponentSchemaTool(t, tool, superPlaneComponentSchemaInput{
+			Nodes: []superPlaneComponentSchemaNode{
+				{NodeID: "branch-1", ComponentKey: "branch", Configuration: map[string]any{"branches": "not-a-list"}},
+			},
+		})
+
+		require.Len(t, result.Nodes, 1)
+		assert.Equal(t, []string{"else"}, outputChannelNames(result.Nodes[0].OutputChannels))
+	})
+}
+
+func TestComponentSchemaAgentTool_ReportsNodesWithUnknownComponents(t *testing.T) {
+	tool := newComponentSchemaTool(t)
+
+	result := executeComponentSchemaTool(t, tool, superPlaneComponentSchemaInput{
+		Nodes: []superPlaneComponentSchemaNode{
+			{NodeID: "node-1", ComponentKey: "does-not-exist"},
+			{NodeID: "node-2", ComponentKey: "does-not-exist"},
+		},
+	})
+
+	assert.Empty(t, result.Nodes)
+	assert.Equal(t, []string{"does-not-exist"}, result.Missing)
+}
+
 func newComponentSchemaTool(t *testing.T) *ComponentSchemaAgentTool {
 	t.Helper()
 
@@ -230,3 +295,26 @@ func outputChannelNames(channels []superPlaneOutputChannel) []string {
 	}
 	return names
 }
+
+// configDependentChannelsAction has one output channel per configured branch, plus "else".
+type configDependentChannelsAction struct {
+	core.Action
+}
+
+func (a *configDependentChannelsAction) Name() string {
+	return "branch"
+}
+
+func (a *configDependentChannelsAction) Description() string {
+	return "Test action with config-dependent output channels"
+}
+
+func (a *configDependentChannelsAction) OutputChannels(config any) []core.OutputChannel {
+	channels := []core.OutputChannel{}
+	if config != nil {
+		for _, branch := range config.(map[string]any)["branches"].([]any) {
+			channels = append(channels, core.OutputChannel{Name: branch.(string)})
+		}
+	}
+	return append(channels, core.OutputChannel{Name: "else"})
+}
@@ -1,13 +1,19 @@
 package agents
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/config"
 	"github.com/superplanehq/superplane/pkg/models"
 )
 
+// ErrCanvasContextTooLarge is returned when the canvas has more nodes
+// than the agent accepts as context.
+var ErrCanvasContextTooLarge = errors.New("canvas context is too large")
+
 type canvasNodeSummary struct {
 	ID        string
 	Name      string
@@ -50,7 +56,7 @@ func buildCanvasSnapshot(session *models.AgentSession) string {
 	builder.WriteString(fmt.Sprintf("node_count: %d\n", len(version.Nodes)))
 	builder.WriteString(fmt.Sprintf("edge_count: %d\n", len(version.Edges)))
 
-	nodes := summarizeSnapshotNodes(version.Nodes, 12)
+	nodes := summarizeSnapshotNodes(version.Nodes, config.MaxAgentSnapshotNodes())
 	if len(nodes) == 0 {
 		builder.WriteString("node_summaries: []\n")
 		return strings.TrimRight(builder.String(), "\n")
@@ -80,6 +86,22 @@ func buildCanvasSnapshot(session *models.AgentSession) string {
 	return strings.TrimRight(builder.String(), "\n")
 }
 
+// checkCanvasContextSize rejects canvases with more nodes than the agent
+// accepts as context. A missing live version is left to the snapshot,
+// which reports it as unavailable.
+func checkCanvasContextSize(session *models.AgentSession) error {
+	version, err := models.FindLiveCanvasVersion(session.CanvasID)
+	if err != nil || version == nil {
+		return nil
+	}
+
+	if maxNodes := config.MaxAgentCanvasNodes(); len(version.Nodes) > maxNodes {
+		return fmt.Errorf("%w: canvas has %d nodes, the limit is %d", ErrCanvasContextTooLarge, len(version.Nodes), maxNodes)
+	}
+
+	return nil
+}
+
 func summarizeSnapshotNodes(nodes []models.Node, limit int) []canvasNodeSummary {
 	count := len(nodes)
 	if count > limit {
@@ -0,0 +1,27 @@
+package agents
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+func TestSummarizeSnapshotNodes_CapsAtLimit(t *testing.T) {
+	nodes := make([]models.Node, 0, 5)
+	for i := range 5 {
+		nodes = append(nodes, models.Node{ID: fmt.Sprintf("node-%d", i), Name: fmt.Sprintf("Node %d", i)})
+	}
+
+	summaries := summarizeSnapshotNodes(nodes, 3)
+
+	assert.Len(t, summaries, 3)
+	assert.Equal(t, "node-2", summaries[2].ID)
+}
+
+func TestSummarizeSnapshotNodes_KeepsAllNodesUnderLimit(t *testing.T) {
+	nodes := []models.Node{{ID: "node-1"}, {ID: "node-2"}}
+
+	assert.Len(t, summarizeSnapshotNodes(nodes, 12), 2)
+}
@@ -1,7 +1,10 @@
 package agents
 
 import (
+	"fmt"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/superplanehq/superplane/pkg/jwt"
 )
@@ -69,6 +72,40 @@ func NormalizeMode(raw string) Mode {
 	}
 }
 
+// DefaultResponseLanguage is the language the agent writes in when a
+// request does not ask for another one.
+const DefaultResponseLanguage = "English"
+
+const maxResponseLanguageLength = 64
+
+// NormalizeResponseLanguage trims the requested language and drops values
+// that are too long or span lines, so it cannot smuggle extra instructions
+// into the preamble.
+func NormalizeResponseLanguage(raw string) string {
+	language := strings.TrimSpace(raw)
+	if language == "" || utf8.RuneCountInString(language) > maxResponseLanguageLength {
+		return ""
+	}
+	if strings.ContainsFunc(language, unicode.IsControl) {
+		return ""
+	}
+	return language
+}
+
+func responseLanguageInstructions(language string) string {
+	language = NormalizeResponseLanguage(language)
+	if language == "" || strings.EqualFold(language, DefaultResponseLanguage) {
+		return ""
+	}
+
+	return fmt.Sprintf(responseLanguageInstructionsTemplate, language)
+}
+
+const responseLanguageInstructionsTemplate = `[Response Language]
+Write every assistant message to the user in %s.
+Keep tool calls, patch operations, node names, component names, field keys,
+expressions, and YAML in their canonical form; do not translate them.`
+
 func modeInstructions(mode Mode) string {
 	switch mode {
 	case ModeBuilder:
@@ -94,6 +131,7 @@ Rules:
   The message field is the commit message: describe what changed in the app. Do not prefix it with "Staging ready" or similar status text.
 
 - You can add, remove, or modify nodes and edges with 'patch_staging' patch_operations. Graph patches auto-layout affected connected components by default.
+- To rearrange nodes (for example "arrange these vertically"), use move_node patch operations with node_id and node.position. Moves keep the given coordinates and do not trigger auto-layout, so stage them separately from graph edits that would re-layout the same nodes.
 - Do not change an existing node's implementation with update_node. update_node may rename a node, update configuration, move it, or collapse/expand it. The only implementation exception is a placeholder node that has no component/trigger/widget yet; assigning its first implementation is allowed. All other component/trigger/widget/integration replacements must be delete_node plus add_node followed by reconnecting the required edges.
 - You can update the app Console when the task asks for status views, runbooks, tables, charts, or KPI panels. Read it with 'superplane_app' include_console and save it with action 'patch_staging' using console_yaml.
 - You can configure integration references and set up expressions. Secrets are managed by the user; reference them in YAML and ask the user to create any that do not exist.
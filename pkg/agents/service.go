@@ -345,12 +345,20 @@ func (s *Service) defineOutcomeOnProvider(ctx context.Context, session *models.A
 		Description:     description,
 		Rubric:          rubric,
 		MaxIterations:   maxIterations,
-		ContextPreamble: s.buildPreamble(session, ModeBuilder),
+		ContextPreamble: s.buildPreamble(session, ModeBuilder, ""),
 	})
 	return contextReplayed, err
 }
 
-func (s *Service) SendMessage(ctx context.Context, organizationID, userID, sessionID uuid.UUID, content string, images []MessageImage, mode ...string) (*models.AgentSessionMessage, error) {
+// SendMessageSettings carries the per-request options of a chat message.
+// ResponseLanguage, when set, asks the agent to write its replies in that
+// language; tool operations stay in their canonical form.
+type SendMessageSettings struct {
+	Mode             string
+	ResponseLanguage string
+}
+
+func (s *Service) SendMessage(ctx context.Context, organizationID, userID, sessionID uuid.UUID, content string, images []MessageImage, settings ...SendMessageSettings) (*models.AgentSessionMessage, error) {
 	if content == "" && len(images) == 0 {
 		return nil, fmt.Errorf("message content is required")
 	}
@@ -360,9 +368,15 @@ func (s *Service) SendMessage(ctx context.Context, organizationID, userID, sessi
 		return nil, err
 	}
 
+	if err := checkCanvasContextSize(session); err != nil {
+		return nil, err
+	}
+
 	agentMode := ModeOperator
-	if len(mode) > 0 {
-		agentMode = NormalizeMode(mode[0])
+	responseLanguage := ""
+	if len(settings) > 0 {
+		agentMode = NormalizeMode(settings[0].Mode)
+		responseLanguage = NormalizeResponseLanguage(settings[0].ResponseLanguage)
 	}
 
 	session, err = s.refreshStaleProviderSession(ctx, session)
@@ -373,7 +387,7 @@ func (s *Service) SendMessage(ctx context.Context, organizationID, userID, sessi
 		return nil, err
 	}
 
-	contextReplayed, err := s.sendMessageToProvider(ctx, session, content, images, agentMode)
+	contextReplayed, err := s.sendMessageToProvider(ctx, session, content, images, agentMode, responseLanguage)
 	if err != nil {
 		if errors.Is(err, ErrSessionBusy) {
 			return nil, s.handleBusySession(sessionID, organizationID, userID)
@@ -386,7 +400,7 @@ func (s *Service) SendMessage(ctx context.Context, organizationID, userID, sessi
 				}
 				return nil, recoverErr
 			}
-			contextReplayed, err = s.sendMessageToProvider(ctx, recovered, content, images, agentMode)
+			contextReplayed, err = s.sendMessageToProvider(ctx, recovered, content, images, agentMode, responseLanguage)
 			if err != nil {
 				if errors.Is(err, ErrSessionBusy) {
 					return nil, s.handleBusySession(sessionID, organizationID, userID)
@@ -437,14 +451,14 @@ func toSessionImages(images []MessageImage) datatypes.JSONSlice[models.AgentSess
 	return out
 }
 
-func (s *Service) sendMessageToProvider(ctx context.Context, session *models.AgentSession, content string, images []MessageImage, mode Mode) (bool, error) {
+func (s *Service) sendMessageToProvider(ctx context.Context, session *models.AgentSession, content string, images []MessageImage, mode Mode, responseLanguage string) (bool, error) {
 	message, contextReplayed, err := s.messageWithRewind(session, content)
 	if err != nil {
 		return false, err
 	}
 
 	err = s.provider.SendMessage(ctx, session.ProviderSessionID, message, SendMessageOptions{
-		ContextPreamble: s.buildPreamble(session, mode),
+		ContextPreamble: s.buildPreamble(session, mode, responseLanguage),
 		Images:          images,
 	})
 	return contextReplayed, err
@@ -635,7 +649,7 @@ func (s *Service) archiveProviderSession(ctx context.Context, providerSessionID
 	}
 }
 
-func (s *Service) buildPreamble(session *models.AgentSession, mode Mode) string {
+func (s *Service) buildPreamble(session *models.AgentSession, mode Mode, responseLanguage string) string {
 	base := fmt.Sprintf(
 		preambleTemplate,
 		session.CanvasID.String(),
@@ -644,7 +658,11 @@ func (s *Service) buildPreamble(session *models.AgentSession, mode Mode) string
 		session.CanvasID.String(),
 	)
 	canvasSnapshot := buildCanvasSnapshot(session)
-	return base + "\n\n" + canvasSnapshot + "\n\n" + modeInstructions(mode)
+	preamble := base + "\n\n" + canvasSnapshot + "\n\n" + modeInstructions(mode)
+	if instructions := responseLanguageInstructions(responseLanguage); instructions != "" {
+		preamble += "\n\n" + instructions
+	}
+	return preamble
 }
 
 func (s *Service) enqueueStream(sessionID, organizationID, userID uuid.UUID) error {
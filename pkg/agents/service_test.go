@@ -15,6 +15,7 @@ import (
 	"github.com/superplanehq/superplane/pkg/database"
 	"github.com/superplanehq/superplane/pkg/models"
 	"github.com/superplanehq/superplane/test/support"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -424,6 +425,35 @@ func TestService_SendMessage_ReturnsPersistedUserMessage(t *testing.T) {
 	assert.Equal(t, "hello", persisted.Content)
 }
 
+func TestService_SendMessage_RejectsCanvasOverNodeLimit(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+	t.Setenv("SUPERPLANE_AGENT_MAX_CANVAS_NODES", "1")
+
+	nodes := []models.CanvasNode{}
+	for _, id := range []string{"node-1", "node-2"} {
+		nodes = append(nodes, models.CanvasNode{
+			NodeID: id,
+			Name:   id,
+			Type:   models.NodeTypeComponent,
+			Ref: datatypes.NewJSONType(models.NodeRef{
+				Component: &models.ComponentRef{Name: "noop"},
+			}),
+		})
+	}
+	canvas, _ := support.CreateCanvas(t, r.Organization.ID, r.User, nodes, []models.Edge{})
+	provider := &fakeProvider{}
+	svc := newService(t, r, provider)
+
+	session, err := svc.EnsureSession(context.Background(), r.Organization.ID, r.User, canvas.ID)
+	require.NoError(t, err)
+
+	_, err = svc.SendMessage(context.Background(), r.Organization.ID, r.User, session.ID, "hello", nil)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, agents.ErrCanvasContextTooLarge))
+	assert.Equal(t, 0, provider.sendCalled)
+}
+
 func TestService_SendMessage_ForwardsAndPersistsImages(t *testing.T) {
 	r := support.Setup(t)
 	defer r.Close()
@@ -708,6 +738,30 @@ func TestService_SendMessage_RecoversFailedSession(t *testing.T) {
 	assert.Equal(t, models.AgentSessionStatusStreaming, refreshed.Status)
 }
 
+func TestService_SendMessage_IncludesResponseLanguage(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+
+	canvas := setupCanvasForUser(t, r)
+	provider := &fakeProvider{}
+	svc := newService(t, r, provider)
+
+	session, err := svc.EnsureSession(context.Background(), r.Organization.ID, r.User, canvas.ID)
+	require.NoError(t, err)
+
+	_, err = svc.SendMessage(context.Background(), r.Organization.ID, r.User, session.ID, "hallo", nil, agents.SendMessageSettings{
+		Mode:             string(agents.ModeBuilder),
+		ResponseLanguage: "German",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, provider.lastPreamble, "[Response Language]")
+	assert.Contains(t, provider.lastPreamble, "Write every assistant message to the user in German.")
+
+	_, err = svc.SendMessage(context.Background(), r.Organization.ID, r.User, session.ID, "hello", nil)
+	require.NoError(t, err)
+	assert.NotContains(t, provider.lastPreamble, "[Response Language]")
+}
+
 func TestService_SendMessage_RefreshesPreambleEveryTurn(t *testing.T) {
 	r := support.Setup(t)
 	defer r.Close()
@@ -28,6 +28,45 @@ func MaxPayloadSize() int {
 	return intFromEnv("SUPERPLANE_MAX_PAYLOAD_SIZE", 512*1024)
 }
 
+// MaxHTTPResponseBytes is the maximum size of an HTTP response body
+// read by components, triggers and integrations. It keeps them from using
+// too much memory and from emitting large events.
+func MaxHTTPResponseBytes() int64 {
+	return int64(intFromEnv("SUPERPLANE_MAX_HTTP_RESPONSE_BYTES", 8*1024*1024))
+}
+
+// ValidateEmittedPayloads enables a development mode in which every emitted
+// payload is compared against the emitting component's example output,
+// and mismatches are logged.
+func ValidateEmittedPayloads() bool {
+	return os.Getenv("SUPERPLANE_VALIDATE_EMITTED_PAYLOADS") == "yes"
+}
+
+// MaxAgentMessageLength is the maximum number of characters
+// accepted in a single agent chat message.
+func MaxAgentMessageLength() int {
+	return intFromEnv("SUPERPLANE_AGENT_MAX_MESSAGE_LENGTH", 20_000)
+}
+
+// MaxAgentSnapshotNodes is the maximum number of canvas nodes
+// summarized in the canvas snapshot sent to the agent on every turn.
+func MaxAgentSnapshotNodes() int {
+	return intFromEnv("SUPERPLANE_AGENT_MAX_SNAPSHOT_NODES", 12)
+}
+
+// MaxAgentNodeEvents caps the node events returned to the agent by a single
+// read_runtime node_events request. It can only lower the ListNodeEvents
+// page size; larger values have no effect.
+func MaxAgentNodeEvents() int {
+	return intFromEnv("SUPERPLANE_AGENT_MAX_NODE_EVENTS", 20)
+}
+
+// MaxAgentCanvasNodes is the maximum number of nodes a canvas may have
+// for agent chat messages to be accepted on it.
+func MaxAgentCanvasNodes() int {
+	return intFromEnv("SUPERPLANE_AGENT_MAX_CANVAS_NODES", 500)
+}
+
 // AnthropicAgentConfig holds the credentials and identifiers needed to talk
 // to a single Anthropic managed agent. Empty values mean managed agents are
 // disabled on this installation.
@@ -39,3 +39,85 @@ func TestMaxPayloadSize(t *testing.T) {
 		assert.Equal(t, 512*1024, MaxPayloadSize())
 	})
 }
+
+func TestMaxHTTPResponseBytes(t *testing.T) {
+	t.Run("defaults to 8 MiB", func(t *testing.T) {
+		t.Setenv("SUPERPLANE_MAX_HTTP_RESPONSE_BYTES", "")
+		assert.Equal(t, int64(8*1024*1024), MaxHTTPResponseBytes())
+	})
+
+	t.Run("reads SUPERPLANE_MAX_HTTP_RESPONSE_BYTES", func(t *testing.T) {
+		t.Setenv("SUPERPLANE_MAX_HTTP_RESPONSE_BYTES", "1048576")
+		assert.Equal(t, int64(1024*1024), MaxHTTPResponseBytes())
+	})
+
+	t.Run("ignores invalid env values", func(t *testing.T) {
+		t.Setenv("SUPERPLANE_MAX_HTTP_RESPONSE_BYTES", "not-a-number")
+		assert.Equal(t, int64(8*1024*1024), MaxHTTPResponseBytes())
+	})
+}
+
+func TestValidateEmittedPayloads(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		t.Setenv("SUPERPLANE_VALIDATE_EMITTED_PAYLOADS", "")
+		assert.False(t, ValidateEmittedPayloads())
+	})
+
+	t.Run("enabled with yes", func(t *testing.T) {
+		t.Setenv("SUPERPLANE_VALIDATE_EMITTED_PAYLOADS", "yes")
+		assert.True(t, ValidateEmittedPayloads())
+	})
+}
+
+func TestMaxAgentMessageLength(t *testing.T) {
+	t.Run("defaults to 20000", func(t *testing.T) {
+		t.Setenv("SUPERPLANE_AGENT_MAX_MESSAGE_LENGTH", "")
+		assert.Equal(t, 20_000, MaxAgentMessageLength())
+	})
+
+	t.Run("reads SUPERPLANE_AGENT_MAX_MESSAGE_LENGTH", func(t *testing.T) {
+		t.Setenv("SUPERPLANE_AGENT_MAX_MESSAGE_LENGTH", "500")
+		assert.Equal(t, 500, MaxAgentMessageLength())
+	})
+
+	t.Run("ignores invalid env values", func(t *testing.T) {
+		t.Setenv("SUPERPLANE_AGENT_MAX_MESSAGE_LENGTH", "-1")
+		assert.Equal(t, 20_000, MaxAgentMessageLength())
+	})
+}
+
+func TestMaxAgentSnapshotNodes(t *testing.T) {
+	t.Run("defaults to 12", func(t *testing.T) {
+		t.Setenv("SUPERPLANE_AGENT_MAX_SNAPSHOT_NODES", "")
+		assert.Equal(t, 12, MaxAgentSnapshotNodes())
+	})
+
+	t.Run("reads SUPERPLANE_AGENT_MAX_SNAPSHOT_NODES", func(t *testing.T) {
+		t.Setenv("SUPERPLANE_AGENT_MAX_SNAPSHOT_NODES", "50")
+		assert.Equal(t, 50, MaxAgentSnapshotNodes())
+	})
+}
+
+func TestMaxAgentNodeEvents(t *testing.T) {
+	t.Run("defaults to 20", func(t *testing.T) {
+		t.Setenv("SUPERPLANE_AGENT_MAX_NODE_EVENTS", "")
+		assert.Equal(t, 20, MaxAgentNodeEvents())
+	})
+
+	t.Run("reads SUPERPLANE_AGENT_MAX_NODE_EVENTS", func(t *testing.T) {
+		t.Setenv("SUPERPLANE_AGENT_MAX_NODE_EVENTS", "5")
+		assert.Equal(t, 5, MaxAgentNodeEvents())
+	})
+}
+
+func TestMaxAgentCanvasNodes(t *testing.T) {
+	t.Run("defaults to 500", func(t *testing.T) {
+		t.Setenv("SUPERPLANE_AGENT_MAX_CANVAS_NODES", "")
+		assert.Equal(t, 500, MaxAgentCanvasNodes())
+	})
+
+	t.Run("reads SUPERPLANE_AGENT_MAX_CANVAS_NODES", func(t *testing.T) {
+		t.Setenv("SUPERPLANE_AGENT_MAX_CANVAS_NODES", "50")
+		assert.Equal(t, 50, MaxAgentCanvasNodes())
+	})
+}
@@ -0,0 +1,84 @@
+package configuration
+
+import "maps"
+
+/*
+ * RedactedValue replaces the value of sensitive fields
+ * whenever configuration is echoed back in responses or logs.
+ */
+const RedactedValue = "<redacted>"
+
+/*
+ * RedactSensitiveValues returns a copy of config where the values of
+ * fields marked as Sensitive are replaced with RedactedValue.
+ * Object fields and lists of objects are redacted using their schemas.
+ * Keys not described by fields are kept as they are, and config is never modified.
+ */
+func RedactSensitiveValues(fields []Field, config map[string]any) map[string]any {
+	if config == nil {
+		return nil
+	}
+
+	redacted := maps.Clone(config)
+	for _, field := range fields {
+		value, exists := config[field.Name]
+		if !exists {
+			continue
+		}
+
+		if field.Sensitive {
+			redacted[field.Name] = RedactedValue
+			continue
+		}
+
+		redacted[field.Name] = redactNestedValue(field, value)
+	}
+
+	return redacted
+}
+
+func redactNestedValue(field Field, value any) any {
+	if field.TypeOptions == nil {
+		return value
+	}
+
+	switch field.Type {
+	case FieldTypeObject:
+		if field.TypeOptions.Object == nil {
+			return value
+		}
+
+		object, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+
+		return RedactSensitiveValues(field.TypeOptions.Object.Schema, object)
+
+	case FieldTypeList:
+		list := field.TypeOptions.List
+		if list == nil || list.ItemDefinition == nil || list.ItemDefinition.Type != FieldTypeObject {
+			return value
+		}
+
+		items, ok := value.([]any)
+		if !ok {
+			return value
+		}
+
+		redactedItems := make([]any, len(items))
+		for i, item := range items {
+			object, ok := item.(map[string]any)
+			if !ok {
+				redactedItems[i] = item
+				continue
+			}
+
+			redactedItems[i] = RedactSensitiveValues(list.ItemDefinition.Schema, object)
+		}
+
+		return redactedItems
+	}
+
+	return value
+}
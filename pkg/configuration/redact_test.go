@@ -0,0 +1,79 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test__RedactSensitiveValues(t *testing.T) {
+	fields := []Field{
+		{Name: "projectId", Type: FieldTypeString},
+		{Name: "serviceAccountKey", Type: FieldTypeText, Sensitive: true},
+		{
+			Name: "auth",
+			Type: FieldTypeObject,
+			TypeOptions: &TypeOptions{
+				Object: &ObjectTypeOptions{
+					Schema: []Field{
+						{Name: "username", Type: FieldTypeString},
+						{Name: "password", Type: FieldTypeString, Sensitive: true},
+					},
+				},
+			},
+		},
+		{
+			Name: "headers",
+			Type: FieldTypeList,
+			TypeOptions: &TypeOptions{
+				List: &ListTypeOptions{
+					ItemDefinition: &ListItemDefinition{
+						Type: FieldTypeObject,
+						Schema: []Field{
+							{Name: "name", Type: FieldTypeString},
+							{Name: "value", Type: FieldTypeString, Sensitive: true},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("sensitive field is masked and normal field is not", func(t *testing.T) {
+		config := map[string]any{
+			"projectId":         "my-project",
+			"serviceAccountKey": "{\"private_key\": \"secret\"}",
+		}
+
+		redacted := RedactSensitiveValues(fields, config)
+
+		assert.Equal(t, "my-project", redacted["projectId"])
+		assert.Equal(t, RedactedValue, redacted["serviceAccountKey"])
+		assert.Equal(t, "{\"private_key\": \"secret\"}", config["serviceAccountKey"])
+	})
+
+	t.Run("nested object and list fields are masked", func(t *testing.T) {
+		config := map[string]any{
+			"auth": map[string]any{"username": "admin", "password": "hunter2"},
+			"headers": []any{
+				map[string]any{"name": "Authorization", "value": "Bearer token"},
+			},
+		}
+
+		redacted := RedactSensitiveValues(fields, config)
+
+		assert.Equal(t, map[string]any{"username": "admin", "password": RedactedValue}, redacted["auth"])
+		assert.Equal(t, []any{map[string]any{"name": "Authorization", "value": RedactedValue}}, redacted["headers"])
+		assert.Equal(t, "hunter2", config["auth"].(map[string]any)["password"])
+	})
+
+	t.Run("missing sensitive field and unknown keys are left alone", func(t *testing.T) {
+		redacted := RedactSensitiveValues(fields, map[string]any{"extra": "value"})
+
+		assert.Equal(t, map[string]any{"extra": "value"}, redacted)
+	})
+
+	t.Run("nil config stays nil", func(t *testing.T) {
+		assert.Nil(t, RedactSensitiveValues(fields, nil))
+	})
+}
@@ -16,3 +16,14 @@ type MetadataWriter interface {
 	MetadataReader
 	Set(any) error
 }
+
+/*
+ * MetadataClaimer is implemented by metadata writers that can update
+ * metadata only while one of its top-level fields still has the expected value.
+ * Components use it to claim work that more than one path,
+ * e.g. a poll hook and a webhook, may try to start at the same time.
+ * It reports whether the metadata was updated.
+ */
+type MetadataClaimer interface {
+	SetIfField(field, expected string, value any) (bool, error)
+}
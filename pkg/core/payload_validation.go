@@ -0,0 +1,128 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+/*
+ * PayloadMismatch describes a difference between an emitted payload
+ * and the example output declared by the component that emitted it.
+ */
+type PayloadMismatch struct {
+
+	/*
+	 * Dotted path of the key that differs, e.g. "bootstrap.exitCode".
+	 * Empty when the payload as a whole does not match.
+	 */
+	Path string
+
+	/*
+	 * Human-readable description of the mismatch.
+	 */
+	Message string
+}
+
+func (m PayloadMismatch) String() string {
+	if m.Path == "" {
+		return m.Message
+	}
+	return fmt.Sprintf("%s: %s", m.Path, m.Message)
+}
+
+/*
+ * ValidatePayloadAgainstExample compares an emitted payload with the
+ * component's ExampleOutput(), which has the shape {"type": ..., "data": {...}}.
+ * Keys present in the example data but missing from the payload are reported,
+ * recursing into nested objects. Extra keys in the payload are not reported,
+ * since examples are allowed to show a subset of the fields.
+ *
+ * Payloads whose type differs from the example's type are not compared,
+ * and an empty example yields no mismatches.
+ */
+func ValidatePayloadAgainstExample(example map[string]any, payloadType string, payload any) ([]PayloadMismatch, error) {
+	if len(example) == 0 {
+		return nil, nil
+	}
+
+	// Components with several output channels often emit payload types the
+	// example does not describe; there is nothing to compare those against.
+	if exampleType, ok := example["type"].(string); ok && exampleType != "" && exampleType != payloadType {
+		return nil, nil
+	}
+
+	exampleData, ok := example["data"].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+
+	data, err := normalizePayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	dataMap, ok := data.(map[string]any)
+	if !ok {
+		return []PayloadMismatch{{
+			Message: fmt.Sprintf("payload is a %T but example data is an object", data),
+		}}, nil
+	}
+
+	return missingKeys("", exampleData, dataMap), nil
+}
+
+func missingKeys(prefix string, example, payload map[string]any) []PayloadMismatch {
+	keys := make([]string, 0, len(example))
+	for key := range example {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	mismatches := []PayloadMismatch{}
+	for _, key := range keys {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		value, ok := payload[key]
+		if !ok {
+			mismatches = append(mismatches, PayloadMismatch{Path: path, Message: "missing key present in example output"})
+			continue
+		}
+
+		exampleChild, ok := example[key].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		payloadChild, ok := value.(map[string]any)
+		if !ok {
+			if value != nil {
+				mismatches = append(mismatches, PayloadMismatch{Path: path, Message: fmt.Sprintf("expected an object, got %T", value)})
+			}
+			continue
+		}
+
+		mismatches = append(mismatches, missingKeys(path, exampleChild, payloadChild)...)
+	}
+
+	return mismatches
+}
+
+// normalizePayload round-trips the payload through JSON so structs are
+// compared by their serialized keys, exactly as they are stored.
+func normalizePayload(payload any) (any, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	var data any
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	return data, nil
+}
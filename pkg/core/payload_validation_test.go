@@ -0,0 +1,85 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test__ValidatePayloadAgainstExample(t *testing.T) {
+	example := map[string]any{
+		"type": "daytona.repository.sandbox",
+		"data": map[string]any{
+			"sandboxId": "sandbox-123",
+			"bootstrap": map[string]any{
+				"exitCode": 0,
+				"result":   "ok",
+			},
+		},
+	}
+
+	type bootstrap struct {
+		ExitCode int    `json:"exitCode"`
+		Result   string `json:"result,omitempty"`
+	}
+
+	type output struct {
+		SandboxID string     `json:"sandboxId,omitempty"`
+		Bootstrap *bootstrap `json:"bootstrap,omitempty"`
+	}
+
+	t.Run("matching payload has no mismatches", func(t *testing.T) {
+		mismatches, err := ValidatePayloadAgainstExample(example, "daytona.repository.sandbox", output{
+			SandboxID: "sandbox-1",
+			Bootstrap: &bootstrap{ExitCode: 0, Result: "done"},
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, mismatches)
+	})
+
+	t.Run("payload missing an example key is flagged", func(t *testing.T) {
+		mismatches, err := ValidatePayloadAgainstExample(example, "daytona.repository.sandbox", output{
+			Bootstrap: &bootstrap{ExitCode: 1},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, mismatches, 2)
+		assert.Equal(t, "bootstrap.result", mismatches[0].Path)
+		assert.Equal(t, "sandboxId", mismatches[1].Path)
+	})
+
+	t.Run("map payloads are compared too", func(t *testing.T) {
+		mismatches, err := ValidatePayloadAgainstExample(example, "daytona.repository.sandbox", map[string]any{
+			"bootstrap": map[string]any{"exitCode": 0, "result": "ok"},
+			"extra":     true,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, mismatches, 1)
+		assert.Equal(t, "sandboxId: missing key present in example output", mismatches[0].String())
+	})
+
+	t.Run("payload of another type is not compared", func(t *testing.T) {
+		mismatches, err := ValidatePayloadAgainstExample(example, "daytona.sandbox.failed", map[string]any{"error": "boom"})
+
+		require.NoError(t, err)
+		assert.Empty(t, mismatches)
+	})
+
+	t.Run("non-object payload is flagged", func(t *testing.T) {
+		mismatches, err := ValidatePayloadAgainstExample(example, "daytona.repository.sandbox", "just a string")
+
+		require.NoError(t, err)
+		require.Len(t, mismatches, 1)
+		assert.Contains(t, mismatches[0].Message, "example data is an object")
+	})
+
+	t.Run("empty example is never flagged", func(t *testing.T) {
+		mismatches, err := ValidatePayloadAgainstExample(nil, "anything", map[string]any{})
+
+		require.NoError(t, err)
+		assert.Empty(t, mismatches)
+	})
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+/*
+ * RetryPolicy controls how Retry re-runs a failing operation.
+ * Zero values fall back to a single attempt with no delay.
+ */
+type RetryPolicy struct {
+
+	/*
+	 * Total number of attempts, including the first one.
+	 */
+	MaxAttempts int
+
+	/*
+	 * Delay before the second attempt.
+	 * Each subsequent delay doubles, up to MaxDelay.
+	 */
+	BaseDelay time.Duration
+
+	/*
+	 * Upper bound for a single delay. Zero means no bound.
+	 */
+	MaxDelay time.Duration
+
+	/*
+	 * Fraction of each delay, between 0 and 1, that is randomized.
+	 * A jitter of 0.2 turns a 1s delay into something between 0.8s and 1.2s.
+	 */
+	Jitter float64
+
+	/*
+	 * Decides whether an error is worth retrying.
+	 * If nil, every error is retried.
+	 */
+	Retryable func(error) bool
+}
+
+/*
+ * Retry calls fn until it succeeds, returns a non-retryable error,
+ * the policy runs out of attempts, or ctx is done.
+ * The last error returned by fn is returned.
+ */
+func Retry(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	attempts := max(policy.MaxAttempts, 1)
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			if err != nil {
+				return err
+			}
+			return ctxErr
+		}
+
+		err = fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if policy.Retryable != nil && !policy.Retryable(err) {
+			return err
+		}
+
+		if attempt == attempts-1 {
+			break
+		}
+
+		timer := time.NewTimer(policy.delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+
+	return err
+}
+
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := 0; i < attempt && delay < math.MaxInt64/2; i++ {
+		delay *= 2
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 {
+		jitter := min(p.Jitter, 1)
+		delta := float64(delay) * jitter
+		delay = time.Duration(float64(delay) - delta + rand.Float64()*2*delta)
+	}
+
+	return delay
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test__Retry(t *testing.T) {
+	errTemporary := errors.New("temporary")
+	errPermanent := errors.New("permanent")
+
+	t.Run("returns immediately on success", func(t *testing.T) {
+		calls := 0
+		err := Retry(context.Background(), RetryPolicy{MaxAttempts: 3}, func(ctx context.Context) error {
+			calls++
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("retries until success", func(t *testing.T) {
+		calls := 0
+		err := Retry(context.Background(), RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}, func(ctx context.Context) error {
+			calls++
+			if calls < 3 {
+				return errTemporary
+			}
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("stops after max attempts and returns last error", func(t *testing.T) {
+		calls := 0
+		err := Retry(context.Background(), RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond}, func(ctx context.Context) error {
+			calls++
+			return errTemporary
+		})
+
+		require.ErrorIs(t, err, errTemporary)
+		assert.Equal(t, 4, calls)
+	})
+
+	t.Run("zero max attempts runs once", func(t *testing.T) {
+		calls := 0
+		err := Retry(context.Background(), RetryPolicy{}, func(ctx context.Context) error {
+			calls++
+			return errTemporary
+		})
+
+		require.ErrorIs(t, err, errTemporary)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("does not retry non-retryable errors", func(t *testing.T) {
+		calls := 0
+		policy := RetryPolicy{
+			MaxAttempts: 5,
+			BaseDelay:   time.Millisecond,
+			Retryable: func(err error) bool {
+				return errors.Is(err, errTemporary)
+			},
+		}
+
+		err := Retry(context.Background(), policy, func(ctx context.Context) error {
+			calls++
+			return errPermanent
+		})
+
+		require.ErrorIs(t, err, errPermanent)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("stops waiting when context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		start := time.Now()
+		err := Retry(ctx, RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour}, func(ctx context.Context) error {
+			calls++
+			cancel()
+			return errTemporary
+		})
+
+		require.ErrorIs(t, err, errTemporary)
+		assert.Equal(t, 1, calls)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("does not call fn with an already cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		calls := 0
+		err := Retry(ctx, RetryPolicy{MaxAttempts: 3}, func(ctx context.Context) error {
+			calls++
+			return nil
+		})
+
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 0, calls)
+	})
+}
+
+func Test__RetryPolicy__delay(t *testing.T) {
+	t.Run("doubles up to max delay", func(t *testing.T) {
+		policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+		assert.Equal(t, 100*time.Millisecond, policy.delay(0))
+		assert.Equal(t, 200*time.Millisecond, policy.delay(1))
+		assert.Equal(t, 300*time.Millisecond, policy.delay(2))
+		assert.Equal(t, 300*time.Millisecond, policy.delay(10))
+	})
+
+	t.Run("jitter stays within bounds", func(t *testing.T) {
+		policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, Jitter: 0.5}
+		for i := 0; i < 50; i++ {
+			d := policy.delay(0)
+			assert.GreaterOrEqual(t, d, 50*time.Millisecond)
+			assert.LessOrEqual(t, d, 150*time.Millisecond)
+		}
+	})
+}
@@ -33,10 +33,10 @@ func (s *stubService) GetSession(o, u, id uuid.UUID) (*models.AgentSession, erro
 func (s *stubService) ListMessages(id, before uuid.UUID, limit int) ([]models.AgentSessionMessage, error) {
 	return s.listMessages(id, before, limit)
 }
-func (s *stubService) SendMessage(ctx context.Context, o, u, id uuid.UUID, content string, images []agentservice.MessageImage, mode ...string) (*models.AgentSessionMessage, error) {
+func (s *stubService) SendMessage(ctx context.Context, o, u, id uuid.UUID, content string, images []agentservice.MessageImage, settings ...agentservice.SendMessageSettings) (*models.AgentSessionMessage, error) {
 	selectedMode := ""
-	if len(mode) > 0 {
-		selectedMode = mode[0]
+	if len(settings) > 0 {
+		selectedMode = settings[0].Mode
 	}
 	return s.sendMessage(ctx, o, u, id, content, images, selectedMode)
 }
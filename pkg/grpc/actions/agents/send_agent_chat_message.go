@@ -5,9 +5,13 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"strings"
+	"unicode/utf8"
+
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 	agentservice "github.com/superplanehq/superplane/pkg/agents"
+	"github.com/superplanehq/superplane/pkg/config"
 	"github.com/superplanehq/superplane/pkg/grpc/errors"
 	pb "github.com/superplanehq/superplane/pkg/protos/agents"
 	"gorm.io/gorm"
@@ -34,8 +38,18 @@ func SendAgentChatMessage(ctx context.Context, svc AgentsService, orgID, userID
 	if req.Content == "" && len(images) == 0 {
 		return nil, grpcerrors.InvalidArgument(nil, "content or an image is required")
 	}
+	if maxLength := config.MaxAgentMessageLength(); utf8.RuneCountInString(req.Content) > maxLength {
+		return nil, grpcerrors.InvalidArgument(nil, fmt.Sprintf("message content exceeds the %d character limit", maxLength))
+	}
+	responseLanguage := strings.TrimSpace(req.ResponseLanguage)
+	if responseLanguage != "" && agentservice.NormalizeResponseLanguage(responseLanguage) == "" {
+		return nil, grpcerrors.InvalidArgument(nil, "invalid response language")
+	}
 
-	persisted, err := svc.SendMessage(ctx, org, user, chatID, req.Content, images, agentModeFromProto(req.Mode))
+	persisted, err := svc.SendMessage(ctx, org, user, chatID, req.Content, images, agentservice.SendMessageSettings{
+		Mode:             agentModeFromProto(req.Mode),
+		ResponseLanguage: responseLanguage,
+	})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, grpcerrors.NotFound(err, "agent chat not found")
@@ -43,6 +57,9 @@ func SendAgentChatMessage(ctx context.Context, svc AgentsService, orgID, userID
 		if errors.Is(err, agentservice.ErrSessionBusy) {
 			return nil, grpcerrors.FailedPrecondition(nil, "agent is still processing the previous turn")
 		}
+		if errors.Is(err, agentservice.ErrCanvasContextTooLarge) {
+			return nil, grpcerrors.InvalidArgument(nil, err.Error())
+		}
 		log.WithError(err).WithField("chat_id", chatID).Error("failed to send agent chat message")
 		return nil, grpcerrors.Internal(err, "failed to send agent chat message")
 	}
@@ -3,6 +3,7 @@ package agents_test
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/google/uuid"
@@ -30,6 +31,44 @@ func TestSendAgentChatMessage_RequiresContent(t *testing.T) {
 	assert.Equal(t, codes.InvalidArgument, grpcerrors.Code(err))
 }
 
+func TestSendAgentChatMessage_RejectsOverLongContent(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+	t.Setenv("SUPERPLANE_AGENT_MAX_MESSAGE_LENGTH", "10")
+
+	svc := &stubService{
+		sendMessage: func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID, string, []agentservice.MessageImage, string) (*models.AgentSessionMessage, error) {
+			t.Fatal("message over the length limit should not be forwarded")
+			return nil, nil
+		},
+	}
+	_, err := actionsagents.SendAgentChatMessage(context.Background(), svc, r.Organization.ID.String(), r.User.String(), &pb.SendAgentChatMessageRequest{
+		ChatId:  uuid.NewString(),
+		Content: "this message is too long",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, grpcerrors.Code(err))
+	assert.Contains(t, err.Error(), "exceeds the 10 character limit")
+}
+
+func TestSendAgentChatMessage_RejectsOverLargeCanvasContext(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+
+	svc := &stubService{
+		sendMessage: func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID, string, []agentservice.MessageImage, string) (*models.AgentSessionMessage, error) {
+			return nil, fmt.Errorf("%w: canvas has 600 nodes, the limit is 500", agentservice.ErrCanvasContextTooLarge)
+		},
+	}
+	_, err := actionsagents.SendAgentChatMessage(context.Background(), svc, r.Organization.ID.String(), r.User.String(), &pb.SendAgentChatMessageRequest{
+		ChatId:  uuid.NewString(),
+		Content: "hello",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, grpcerrors.Code(err))
+	assert.Contains(t, err.Error(), "canvas has 600 nodes, the limit is 500")
+}
+
 func TestSendAgentChatMessage_ProjectsSuccess(t *testing.T) {
 	r := support.Setup(t)
 	defer r.Close()
@@ -0,0 +1,171 @@
+package changesets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+/*
+ * DescribeChangeset explains in plain English what applying the changeset
+ * to a canvas with the given nodes will do. It makes no external calls,
+ * and the same changeset and nodes always produce the same description,
+ * so it can be recomputed whenever a fresh summary is needed.
+ */
+func DescribeChangeset(changeset *CanvasChangeset, currentNodes []models.Node) string {
+	if changeset == nil || len(changeset.Changes) == 0 {
+		return "No changes."
+	}
+
+	names := describeNodeNames(changeset, currentNodes)
+	lines := []string{}
+	for _, changeType := range []ChangeType{
+		ChangeTypeDeleteNode,
+		ChangeTypeAddNode,
+		ChangeTypeUpdateNode,
+		ChangeTypeDeleteEdge,
+		ChangeTypeAddEdge,
+	} {
+		group := []string{}
+		for _, change := range changeset.Changes {
+			if change.Type != changeType {
+				continue
+			}
+
+			if line := describeChange(change, names); line != "" {
+				group = append(group, line)
+			}
+		}
+
+		sort.Strings(group)
+		lines = append(lines, group...)
+	}
+
+	if len(lines) == 0 {
+		return "No changes."
+	}
+
+	return fmt.Sprintf("%s:\n- %s", describeChangeCount(len(lines)), strings.Join(lines, "\n- "))
+}
+
+func describeChangeCount(count int) string {
+	if count == 1 {
+		return "1 change"
+	}
+
+	return fmt.Sprintf("%d changes", count)
+}
+
+func describeChange(change *Change, names map[string]string) string {
+	switch change.Type {
+	case ChangeTypeAddNode:
+		if change.Node == nil {
+			return ""
+		}
+
+		if change.Node.Block == "" {
+			return fmt.Sprintf("Add node %s", describeNode(change.Node.ID, names))
+		}
+
+		return fmt.Sprintf("Add node %s (%s)", describeNode(change.Node.ID, names), change.Node.Block)
+
+	case ChangeTypeDeleteNode:
+		if change.Node == nil {
+			return ""
+		}
+
+		return fmt.Sprintf("Delete node %s", describeNode(change.Node.ID, names))
+
+	case ChangeTypeUpdateNode:
+		if change.Node == nil {
+			return ""
+		}
+
+		updated := describeNodeUpdates(change.Node)
+		if len(updated) == 0 {
+			return fmt.Sprintf("Update node %s", describeNode(change.Node.ID, names))
+		}
+
+		return fmt.Sprintf("Update the %s of node %s", strings.Join(updated, " and "), describeNode(change.Node.ID, names))
+
+	case ChangeTypeAddEdge:
+		if change.Edge == nil {
+			return ""
+		}
+
+		return fmt.Sprintf("Connect %s to %s", describeNode(change.Edge.SourceID, names), describeEdgeTarget(change.Edge, names))
+
+	case ChangeTypeDeleteEdge:
+		if change.Edge == nil {
+			return ""
+		}
+
+		return fmt.Sprintf("Disconnect %s from %s", describeNode(change.Edge.SourceID, names), describeEdgeTarget(change.Edge, names))
+
+	default:
+		return ""
+	}
+}
+
+/*
+ * Update changes only carry the fields that changed,
+ * so the fields that are set are the ones being updated.
+ */
+func describeNodeUpdates(node *ChangeNode) []string {
+	updated := []string{}
+	if node.Configuration != nil {
+		updated = append(updated, "configuration")
+	}
+
+	if node.Position != nil {
+		updated = append(updated, "position")
+	}
+
+	if node.IsCollapsed != nil {
+		updated = append(updated, "collapsed state")
+	}
+
+	return updated
+}
+
+func describeEdgeTarget(edge *ChangeEdge, names map[string]string) string {
+	if edge.Channel == "" {
+		return describeNode(edge.TargetID, names)
+	}
+
+	return fmt.Sprintf("%s on the %q channel", describeNode(edge.TargetID, names), edge.Channel)
+}
+
+func describeNode(nodeID string, names map[string]string) string {
+	name := names[nodeID]
+	if name == "" || name == nodeID {
+		return fmt.Sprintf("%q", nodeID)
+	}
+
+	return fmt.Sprintf("%q", name)
+}
+
+/*
+ * Names from the changeset take precedence over the current ones,
+ * so renamed and newly added nodes are described by their new name.
+ */
+func describeNodeNames(changeset *CanvasChangeset, currentNodes []models.Node) map[string]string {
+	names := make(map[string]string, len(currentNodes))
+	for _, node := range currentNodes {
+		names[node.ID] = node.Name
+	}
+
+	for _, change := range changeset.Changes {
+		if change.Node == nil || change.Node.Name == "" {
+			continue
+		}
+
+		if change.Type == ChangeTypeAddNode || change.Type == ChangeTypeUpdateNode {
+			names[change.Node.ID] = change.Node.Name
+		}
+	}
+
+	return names
+}
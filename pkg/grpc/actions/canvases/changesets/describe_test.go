@@ -0,0 +1,75 @@
+package changesets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/superplanehq/superplane/pkg/models"
+	componentpb "github.com/superplanehq/superplane/pkg/protos/components"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func Test__DescribeChangeset(t *testing.T) {
+	currentNodes := []models.Node{
+		{ID: "trigger", Name: "On Push"},
+		{ID: "build", Name: "Build"},
+		{ID: "old-deploy", Name: "Old Deploy"},
+	}
+
+	t.Run("no changes", func(t *testing.T) {
+		assert.Equal(t, "No changes.", DescribeChangeset(nil, currentNodes))
+		assert.Equal(t, "No changes.", DescribeChangeset(&CanvasChangeset{}, currentNodes))
+	})
+
+	t.Run("known operation set", func(t *testing.T) {
+		configuration, err := structpb.NewStruct(map[string]any{"image": "app:1.0.0"})
+		assert.NoError(t, err)
+
+		changeset := &CanvasChangeset{
+			Changes: []*Change{
+				{Type: ChangeTypeAddEdge, Edge: &ChangeEdge{SourceID: "build", TargetID: "deploy", Channel: "default"}},
+				{Type: ChangeTypeAddNode, Node: &ChangeNode{ID: "deploy", Name: "Deploy", Block: "gcp.cloudrun.deployService"}},
+				{Type: ChangeTypeDeleteEdge, Edge: &ChangeEdge{SourceID: "build", TargetID: "old-deploy", Channel: "default"}},
+				{Type: ChangeTypeUpdateNode, Node: &ChangeNode{
+					ID:            "build",
+					Name:          "Build Image",
+					Configuration: configuration,
+					Position:      &componentpb.Position{X: 10, Y: 20},
+				}},
+				{Type: ChangeTypeDeleteNode, Node: &ChangeNode{ID: "old-deploy"}},
+				{Type: ChangeTypeUpdateNode, Node: &ChangeNode{ID: "trigger", IsCollapsed: proto.Bool(true)}},
+			},
+		}
+
+		assert.Equal(t,
+			"6 changes:\n"+
+				"- Delete node \"Old Deploy\"\n"+
+				"- Add node \"Deploy\" (gcp.cloudrun.deployService)\n"+
+				"- Update the collapsed state of node \"On Push\"\n"+
+				"- Update the configuration and position of node \"Build Image\"\n"+
+				"- Disconnect \"Build Image\" from \"Old Deploy\" on the \"default\" channel\n"+
+				"- Connect \"Build Image\" to \"Deploy\" on the \"default\" channel",
+			DescribeChangeset(changeset, currentNodes),
+		)
+	})
+
+	t.Run("same changeset in a different order produces the same description", func(t *testing.T) {
+		first := &CanvasChangeset{Changes: []*Change{
+			{Type: ChangeTypeAddNode, Node: &ChangeNode{ID: "a", Name: "A"}},
+			{Type: ChangeTypeAddNode, Node: &ChangeNode{ID: "b", Name: "B"}},
+		}}
+		second := &CanvasChangeset{Changes: []*Change{first.Changes[1], first.Changes[0]}}
+
+		assert.Equal(t, "2 changes:\n- Add node \"A\"\n- Add node \"B\"", DescribeChangeset(first, nil))
+		assert.Equal(t, DescribeChangeset(first, nil), DescribeChangeset(second, nil))
+	})
+
+	t.Run("unknown nodes are described by ID", func(t *testing.T) {
+		changeset := &CanvasChangeset{Changes: []*Change{
+			{Type: ChangeTypeDeleteNode, Node: &ChangeNode{ID: "missing"}},
+		}}
+
+		assert.Equal(t, "1 change:\n- Delete node \"missing\"", DescribeChangeset(changeset, nil))
+	})
+}
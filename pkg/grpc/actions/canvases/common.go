@@ -9,6 +9,7 @@ import (
 	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
 	"github.com/superplanehq/superplane/pkg/models"
 	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"github.com/superplanehq/superplane/pkg/registry"
 	"github.com/superplanehq/superplane/pkg/telemetry"
 	"gorm.io/gorm"
 )
@@ -44,7 +45,7 @@ func loadLiveCanvasVersion(ctx context.Context, db *gorm.DB, canvas *models.Canv
 	return models.FindLiveCanvasVersionByCanvasInTransaction(db.WithContext(ctx), canvas)
 }
 
-func loadCanvasStatus(ctx context.Context, db *gorm.DB, canvasID uuid.UUID) (canvasStatus *pb.Canvas_Status, err error) {
+func loadCanvasStatus(ctx context.Context, db *gorm.DB, registry *registry.Registry, canvasID uuid.UUID) (canvasStatus *pb.Canvas_Status, err error) {
 	ctx, done := telemetry.Span(ctx, "canvases.load_status")
 	defer done(&err)
 
@@ -53,7 +54,7 @@ func loadCanvasStatus(ctx context.Context, db *gorm.DB, canvasID uuid.UUID) (can
 		return nil, err
 	}
 
-	executionResources, err := LoadNodeExecutionResources(db.WithContext(ctx), lastExecutions)
+	executionResources, err := LoadNodeExecutionResources(db.WithContext(ctx), registry, lastExecutions)
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +48,7 @@ func DescribeCanvas(ctx context.Context, registry *registry.Registry, organizati
 		return nil, grpcerrors.Internal(err, "failed to load canvas spec")
 	}
 
-	canvasStatus, err := loadCanvasStatus(ctx, db, canvas.ID)
+	canvasStatus, err := loadCanvasStatus(ctx, db, registry, canvas.ID)
 	if err != nil {
 		return nil, grpcerrors.Internal(err, "failed to load canvas status")
 	}
@@ -86,7 +86,7 @@ func InvokeNodeExecutionHook(
 		Configuration:  node.Configuration.Data(),
 		HTTP:           registry.HTTPContext(),
 		Metadata:       contexts.NewExecutionMetadataContext(tx, execution),
-		ExecutionState: contexts.NewExecutionStateContext(tx, execution, onNewEvents),
+		ExecutionState: contexts.NewExecutionStateContext(tx, execution, onNewEvents).WithExampleOutput(hookProvider),
 		Auth:           contexts.NewAuthReader(tx, orgID, authService, user),
 		Requests:       contexts.NewExecutionRequestContext(tx, execution),
 	}
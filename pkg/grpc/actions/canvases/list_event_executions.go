@@ -34,7 +34,7 @@ func ListEventExecutions(ctx context.Context, registry *registry.Registry, workf
 
 	db := database.DB(ctx)
 
-	resources, err := LoadNodeExecutionResources(db, executions)
+	resources, err := LoadNodeExecutionResources(db, registry, executions)
 	if err != nil {
 		return nil, err
 	}
@@ -2,6 +2,7 @@ package canvases
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/superplanehq/superplane/pkg/models"
@@ -10,19 +11,27 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func ListNodeEvents(ctx context.Context, registry *registry.Registry, workflowID uuid.UUID, nodeID string, limit uint32, before *timestamppb.Timestamp) (*pb.ListNodeEventsResponse, error) {
+// ListNodeEvents lists the node's latest events. An empty channel lists events on every channel,
+// and a nil since lists events of any age.
+func ListNodeEvents(ctx context.Context, registry *registry.Registry, workflowID uuid.UUID, nodeID string, channel string, limit uint32, before *timestamppb.Timestamp, since *time.Time) (*pb.ListNodeEventsResponse, error) {
 	limit = getLimit(limit)
 	beforeTime := getBefore(before)
 
 	//
 	// List and count events
 	//
-	events, err := models.ListCanvasEvents(workflowID, nodeID, int(limit), beforeTime)
+	options := models.ListCanvasEventsOptions{
+		Limit:   int(limit),
+		Before:  beforeTime,
+		Since:   since,
+		Channel: channel,
+	}
+	events, err := models.ListCanvasEventsWithOptions(workflowID, nodeID, options)
 	if err != nil {
 		return nil, err
 	}
 
-	totalCount, err := models.CountCanvasEvents(workflowID, nodeID)
+	totalCount, err := models.CountCanvasEventsWithOptions(workflowID, nodeID, options)
 	if err != nil {
 		return nil, err
 	}
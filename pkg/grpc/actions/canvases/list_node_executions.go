@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/database"
 	"github.com/superplanehq/superplane/pkg/grpc/errors"
 	"github.com/superplanehq/superplane/pkg/models"
@@ -13,6 +14,7 @@ import (
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/gorm"
+	"slices"
 	"sync"
 	"time"
 )
@@ -65,7 +67,7 @@ func ListNodeExecutions(ctx context.Context, registry *registry.Registry, workfl
 
 	db := database.DB(ctx)
 
-	resources, err := LoadNodeExecutionResources(db, executions)
+	resources, err := LoadNodeExecutionResources(db, registry, executions)
 	if err != nil {
 		return nil, err
 	}
@@ -87,16 +89,24 @@ type NodeExecutionResources struct {
 	rootEventsByID            map[string]models.CanvasEvent
 	outputEventsByExecutionID map[string][]models.CanvasEvent
 	cancelledByUsersByID      map[uuid.UUID]models.User
+
+	//
+	// Configuration fields of the component each node runs,
+	// used to redact sensitive values in the execution configuration.
+	//
+	configurationFieldsByNode map[string][]configuration.Field
 }
 
-func LoadNodeExecutionResources(db *gorm.DB, executions []models.CanvasNodeExecution) (*NodeExecutionResources, error) {
+func LoadNodeExecutionResources(db *gorm.DB, registry *registry.Registry, executions []models.CanvasNodeExecution) (*NodeExecutionResources, error) {
 	var rootEvents, outputEvents []models.CanvasEvent
 	var rootEventsErr, outputEventsErr error
 	var cancelledByUsers []models.User
 	var cancelledByUsersErr error
+	var nodes []models.CanvasNode
+	var nodesErr error
 	var wg sync.WaitGroup
 
-	wg.Add(3)
+	wg.Add(4)
 
 	go func() {
 		defer wg.Done()
@@ -113,6 +123,11 @@ func LoadNodeExecutionResources(db *gorm.DB, executions []models.CanvasNodeExecu
 		cancelledByUsers, cancelledByUsersErr = models.FindMaybeDeletedUsersByIDs(db, cancelledByIDs(executions))
 	}()
 
+	go func() {
+		defer wg.Done()
+		nodes, nodesErr = findExecutionNodes(db, executions)
+	}()
+
 	wg.Wait()
 
 	if rootEventsErr != nil {
@@ -124,6 +139,9 @@ func LoadNodeExecutionResources(db *gorm.DB, executions []models.CanvasNodeExecu
 	if cancelledByUsersErr != nil {
 		return nil, fmt.Errorf("error finding cancelled-by users: %w", cancelledByUsersErr)
 	}
+	if nodesErr != nil {
+		return nil, fmt.Errorf("error finding execution nodes: %w", nodesErr)
+	}
 
 	cancelledByUsersByID := make(map[uuid.UUID]models.User, len(cancelledByUsers))
 	for _, user := range cancelledByUsers {
@@ -148,9 +166,64 @@ func LoadNodeExecutionResources(db *gorm.DB, executions []models.CanvasNodeExecu
 		rootEventsByID:            rootEventsByID,
 		outputEventsByExecutionID: outputEventsByExecutionID,
 		cancelledByUsersByID:      cancelledByUsersByID,
+		configurationFieldsByNode: configurationFieldsByNode(registry, nodes),
 	}, nil
 }
 
+/*
+ * findExecutionNodes includes deleted nodes,
+ * since their executions are still listed.
+ */
+func findExecutionNodes(db *gorm.DB, executions []models.CanvasNodeExecution) ([]models.CanvasNode, error) {
+	nodeIDsByCanvas := map[uuid.UUID][]string{}
+	for _, execution := range executions {
+		if slices.Contains(nodeIDsByCanvas[execution.WorkflowID], execution.NodeID) {
+			continue
+		}
+
+		nodeIDsByCanvas[execution.WorkflowID] = append(nodeIDsByCanvas[execution.WorkflowID], execution.NodeID)
+	}
+
+	nodes := []models.CanvasNode{}
+	for canvasID, nodeIDs := range nodeIDsByCanvas {
+		canvasNodes, err := models.FindCanvasNodesByIDs(db.Unscoped(), canvasID, nodeIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, canvasNodes...)
+	}
+
+	return nodes, nil
+}
+
+func configurationFieldsByNode(registry *registry.Registry, nodes []models.CanvasNode) map[string][]configuration.Field {
+	fields := make(map[string][]configuration.Field, len(nodes))
+	if registry == nil {
+		return fields
+	}
+
+	for _, node := range nodes {
+		ref := node.Ref.Data()
+		if ref.Component == nil {
+			continue
+		}
+
+		action, err := registry.GetAction(ref.Component.Name)
+		if err != nil {
+			continue
+		}
+
+		fields[executionNodeKey(node.WorkflowID, node.NodeID)] = action.Configuration()
+	}
+
+	return fields
+}
+
+func executionNodeKey(canvasID uuid.UUID, nodeID string) string {
+	return canvasID.String() + "/" + nodeID
+}
+
 func SerializeNodeExecutions(executions []models.CanvasNodeExecution, resources *NodeExecutionResources) ([]*pb.CanvasNodeExecution, error) {
 	result := make([]*pb.CanvasNodeExecution, 0, len(executions))
 	for _, execution := range executions {
@@ -170,7 +243,8 @@ func SerializeNodeExecutions(executions []models.CanvasNodeExecution, resources
 			return nil, err
 		}
 
-		configuration, err := newStructpbStruct(execution.Configuration.Data())
+		fields := resources.configurationFieldsByNode[executionNodeKey(execution.WorkflowID, execution.NodeID)]
+		executionConfiguration, err := newStructpbStruct(configuration.RedactSensitiveValues(fields, execution.Configuration.Data()))
 		if err != nil {
 			return nil, err
 		}
@@ -187,7 +261,7 @@ func SerializeNodeExecutions(executions []models.CanvasNodeExecution, resources
 			CreatedAt:           timestamppb.New(*execution.CreatedAt),
 			UpdatedAt:           timestamppb.New(*execution.UpdatedAt),
 			Metadata:            metadata,
-			Configuration:       configuration,
+			Configuration:       executionConfiguration,
 			Outputs:             outputs,
 			RootEvent:           rootEvent,
 			CancelledBy:         cancelledByRef(execution.CancelledBy, resources.cancelledByUsersByID),
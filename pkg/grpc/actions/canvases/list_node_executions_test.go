@@ -160,6 +160,50 @@ func Test__ListNodeExecutions(t *testing.T) {
 		require.NotNil(t, response.Executions[0].RootEvent)
 		assert.Equal(t, customName, response.Executions[0].RootEvent.CustomName)
 	})
+
+	t.Run("sensitive configuration values are redacted", func(t *testing.T) {
+		canvas, _ := support.CreateCanvas(
+			t,
+			r.Organization.ID,
+			r.User,
+			[]models.CanvasNode{
+				{
+					NodeID: "node-1",
+					Name:   "Update env var",
+					Type:   models.NodeTypeComponent,
+					Ref: datatypes.NewJSONType(models.NodeRef{
+						Component: &models.ComponentRef{Name: "render.updateEnvVar"},
+					}),
+				},
+			},
+			[]models.Edge{},
+		)
+
+		event := support.EmitCanvasEventForNode(t, canvas.ID, "node-1", "default", nil)
+		execution := support.CreateCanvasNodeExecution(t, canvas.ID, "node-1", event.ID, event.ID)
+		execution.Configuration = datatypes.NewJSONType(map[string]any{
+			"valueStrategy": "set",
+			"value":         "super-secret",
+		})
+		require.NoError(t, database.Conn().Save(execution).Error)
+
+		response, err := ListNodeExecutions(
+			context.Background(),
+			r.Registry,
+			canvas.ID.String(),
+			"node-1",
+			[]pb.CanvasNodeExecution_State{},
+			[]pb.CanvasNodeExecution_Result{},
+			0,
+			nil,
+		)
+
+		require.NoError(t, err)
+		require.Len(t, response.Executions, 1)
+		configuration := response.Executions[0].Configuration.AsMap()
+		assert.Equal(t, "set", configuration["valueStrategy"])
+		assert.Equal(t, "<redacted>", configuration["value"])
+	})
 }
 
 func SerializeThosandNodeExecutions(b *testing.B) {
@@ -200,7 +244,7 @@ func SerializeThosandNodeExecutions(b *testing.B) {
 	executions, err := models.ListNodeExecutions(canvas.ID, "node-1", []string{}, []string{}, 1000, nil)
 	require.NoError(b, err)
 
-	resources, err := LoadNodeExecutionResources(database.Conn(), executions)
+	resources, err := LoadNodeExecutionResources(database.Conn(), r.Registry, executions)
 	require.NoError(b, err)
 
 	b.ResetTimer()
@@ -9,6 +9,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
 	"github.com/superplanehq/superplane/pkg/database"
 	"github.com/superplanehq/superplane/pkg/grpc/actions"
@@ -481,7 +482,7 @@ func encryptConfigurationIfNeeded(ctx context.Context, registry *registry.Regist
 		//
 		// If the value is not <redacted>, encrypt it, since it's new.
 		//
-		if s != "<redacted>" {
+		if s != configuration.RedactedValue {
 			encrypted, err := registry.Encryptor.Encrypt(ctx, []byte(s), []byte(installationID.String()))
 			if err != nil {
 				return nil, fmt.Errorf("failed to encrypt field %s: %v", field.Name, err)
@@ -507,6 +508,10 @@ func encryptConfigurationIfNeeded(ctx context.Context, registry *registry.Regist
 	return result, nil
 }
 
+/*
+ * Only top-level sensitive fields are redacted, since those are the only ones
+ * encryptConfigurationIfNeeded restores when <redacted> comes back on update.
+ */
 func sanitizeConfigurationIfNeeded(integration core.Integration, config map[string]any) map[string]any {
 	sanitized := maps.Clone(config)
 
@@ -520,7 +525,7 @@ func sanitizeConfigurationIfNeeded(integration core.Integration, config map[stri
 			continue
 		}
 
-		sanitized[field.Name] = "<redacted>"
+		sanitized[field.Name] = configuration.RedactedValue
 	}
 
 	return sanitized
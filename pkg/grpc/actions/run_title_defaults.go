@@ -47,6 +47,7 @@ var defaultRunTitleExpressions = map[string]string{
 	"gcp.artifactregistry.onArtifactPush":     "{{ root().data.tag }}",
 	"gcp.cloudbuild.onBuildComplete":          "{{ root().data.status }} - {{ root().data.id }}",
 	"gcp.compute.onVMInstance":                "{{ root().data.resourceName }}",
+	"gcp.compute.onVMStatusChanged":           "{{ root().data.status }} - {{ root().data.instanceName }}",
 	"gcp.monitoring.onAlert":                  "{{ root().data.conditionName }} {{ root().data.state }} - {{ root().data.resourceName }}",
 	"gcp.pubsub.onMessage":                    "{{ root().data.messageId }}",
 
@@ -131,7 +131,7 @@ func (s *CanvasService) ListNodeEvents(ctx context.Context, req *pb.ListNodeEven
 		return nil, status.Error(codes.InvalidArgument, "node_id is required")
 	}
 
-	return canvases.ListNodeEvents(ctx, s.registry, canvasID, req.NodeId, req.Limit, req.Before)
+	return canvases.ListNodeEvents(ctx, s.registry, canvasID, req.NodeId, "", req.Limit, req.Before, nil)
 }
 
 func (s *CanvasService) ReemitTriggerEvent(ctx context.Context, req *pb.ReemitTriggerEventRequest) (*pb.ReemitTriggerEventResponse, error) {
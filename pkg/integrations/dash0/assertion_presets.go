@@ -0,0 +1,71 @@
+package dash0
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+)
+
+const (
+	AssertionPresetAPIHealthy = "api-healthy"
+	AssertionPresetFastSSL    = "fast-ssl"
+)
+
+// assertionPresets are named sets of assertions for common check patterns.
+var assertionPresets = map[string][]AssertionSpec{
+	AssertionPresetAPIHealthy: {
+		{Kind: "status_code", Severity: "critical", Operator: "is", Value: "200"},
+		{Kind: "timing", Severity: "critical", Type: "response", Operator: "lte", Value: "5000ms"},
+		{Kind: "timing", Severity: "degraded", Type: "response", Operator: "lte", Value: "2000ms"},
+	},
+	AssertionPresetFastSSL: {
+		{Kind: "ssl_certificate_validity", Severity: "critical", Operator: "gte", Value: "7d"},
+		{Kind: "ssl_certificate_validity", Severity: "degraded", Operator: "gte", Value: "30d"},
+		{Kind: "timing", Severity: "degraded", Type: "ssl", Operator: "lte", Value: "500ms"},
+	},
+}
+
+// assertionPresetsField returns the preset selector for Create and Update HTTP synthetic check components.
+func assertionPresetsField() configuration.Field {
+	return configuration.Field{
+		Name:        "assertionPresets",
+		Label:       "Assertion Presets",
+		Type:        configuration.FieldTypeMultiSelect,
+		Required:    false,
+		Togglable:   true,
+		Description: "Predefined assertions added before the assertions below",
+		TypeOptions: &configuration.TypeOptions{
+			MultiSelect: &configuration.MultiSelectTypeOptions{
+				Options: []configuration.FieldOption{
+					{Label: "API healthy (200 status, response under 2s/5s)", Value: AssertionPresetAPIHealthy},
+					{Label: "Fast SSL (certificate valid 7d/30d, SSL handshake under 500ms)", Value: AssertionPresetFastSSL},
+				},
+			},
+		},
+	}
+}
+
+func validateAssertionPresets(presets []string) error {
+	for _, preset := range presets {
+		if _, ok := assertionPresets[strings.TrimSpace(preset)]; !ok {
+			return fmt.Errorf("unknown assertion preset: %s", preset)
+		}
+	}
+
+	return nil
+}
+
+// expandAssertionPresets returns the preset assertions followed by the user-supplied ones.
+func expandAssertionPresets(presets []string, assertions *[]AssertionSpec) []AssertionSpec {
+	result := []AssertionSpec{}
+	for _, preset := range presets {
+		result = append(result, assertionPresets[strings.TrimSpace(preset)]...)
+	}
+
+	if assertions != nil {
+		result = append(result, *assertions...)
+	}
+
+	return result
+}
@@ -0,0 +1,221 @@
+package dash0
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__BuildSyntheticCheckAssertions__Presets(t *testing.T) {
+	t.Run("no presets and no assertions -> empty lists", func(t *testing.T) {
+		assertions := BuildSyntheticCheckAssertions(nil, nil)
+		assert.Empty(t, assertions.CriticalAssertions)
+		assert.Empty(t, assertions.DegradedAssertions)
+	})
+
+	t.Run("api-healthy preset expands to status and timing assertions", func(t *testing.T) {
+		assertions := BuildSyntheticCheckAssertions([]string{AssertionPresetAPIHealthy}, nil)
+
+		assert.Equal(t, []SyntheticCheckAssertion{
+			{Kind: "status_code", Spec: map[string]any{"operator": "is", "value": "200"}},
+			{Kind: "timing", Spec: map[string]any{"operator": "lte", "value": "5000ms", "type": "response"}},
+		}, assertions.CriticalAssertions)
+		assert.Equal(t, []SyntheticCheckAssertion{
+			{Kind: "timing", Spec: map[string]any{"operator": "lte", "value": "2000ms", "type": "response"}},
+		}, assertions.DegradedAssertions)
+	})
+
+	t.Run("fast-ssl preset expands to certificate and handshake assertions", func(t *testing.T) {
+		assertions := BuildSyntheticCheckAssertions([]string{AssertionPresetFastSSL}, nil)
+
+		assert.Equal(t, []SyntheticCheckAssertion{
+			{Kind: "ssl_certificate_validity", Spec: map[string]any{"operator": "gte", "value": "7d"}},
+		}, assertions.CriticalAssertions)
+		assert.Equal(t, []SyntheticCheckAssertion{
+			{Kind: "ssl_certificate_validity", Spec: map[string]any{"operator": "gte", "value": "30d"}},
+			{Kind: "timing", Spec: map[string]any{"operator": "lte", "value": "500ms", "type": "ssl"}},
+		}, assertions.DegradedAssertions)
+	})
+
+	t.Run("user assertions are added after preset assertions", func(t *testing.T) {
+		user := []AssertionSpec{
+			{Kind: "json_body", Severity: "critical", Expression: "$.status", Operator: "is", Value: "ok"},
+			{Kind: "status_code", Severity: "critical", Operator: "is", Value: "204"},
+		}
+
+		assertions := BuildSyntheticCheckAssertions([]string{AssertionPresetAPIHealthy}, &user)
+
+		require.Len(t, assertions.CriticalAssertions, 4)
+		assert.Equal(t, "status_code", assertions.CriticalAssertions[0].Kind)
+		assert.Equal(t, "timing", assertions.CriticalAssertions[1].Kind)
+		assert.Equal(t, SyntheticCheckAssertion{
+			Kind: "json_body",
+			Spec: map[string]any{"expression": "$.status", "operator": "is", "value": "ok"},
+		}, assertions.CriticalAssertions[2])
+		assert.Equal(t, SyntheticCheckAssertion{
+			Kind: "status_code",
+			Spec: map[string]any{"operator": "is", "value": "204"},
+		}, assertions.CriticalAssertions[3])
+		require.Len(t, assertions.DegradedAssertions, 1)
+	})
+
+	t.Run("presets combine in the order they are listed", func(t *testing.T) {
+		assertions := BuildSyntheticCheckAssertions([]string{AssertionPresetFastSSL, AssertionPresetAPIHealthy}, nil)
+
+		require.Len(t, assertions.CriticalAssertions, 3)
+		assert.Equal(t, "ssl_certificate_validity", assertions.CriticalAssertions[0].Kind)
+		assert.Equal(t, "status_code", assertions.CriticalAssertions[1].Kind)
+		require.Len(t, assertions.DegradedAssertions, 3)
+	})
+}
+
+func Test__BuildSyntheticCheckAssertions__SameKind(t *testing.T) {
+	t.Run("multiple timing assertions keep their own operators and values", func(t *testing.T) {
+		user := []AssertionSpec{
+			{Kind: "timing", Severity: "critical", Type: "total", Operator: "lte", Value: "10000ms"},
+			{Kind: "timing", Severity: "critical", Type: "dns", Operator: "lt", Value: "200ms"},
+			{Kind: "timing", Severity: "degraded", Type: "response", Operator: "gt", Value: "1500ms"},
+		}
+
+		assertions := BuildSyntheticCheckAssertions(nil, &user)
+
+		assert.Equal(t, []SyntheticCheckAssertion{
+			{Kind: "timing", Spec: map[string]any{"type": "total", "operator": "lte", "value": "10000ms"}},
+			{Kind: "timing", Spec: map[string]any{"type": "dns", "operator": "lt", "value": "200ms"}},
+		}, assertions.CriticalAssertions)
+		assert.Equal(t, []SyntheticCheckAssertion{
+			{Kind: "timing", Spec: map[string]any{"type": "response", "operator": "gt", "value": "1500ms"}},
+		}, assertions.DegradedAssertions)
+	})
+
+	t.Run("presence and value checks on response headers are separate entries", func(t *testing.T) {
+		user := []AssertionSpec{
+			{Kind: "response_header", Severity: "critical", Name: "x-request-id", Operator: "is_not", Value: ""},
+			{Kind: "response_header", Severity: "critical", Name: "content-type", Operator: "contains", Value: "application/json"},
+		}
+
+		assertions := BuildSyntheticCheckAssertions(nil, &user)
+
+		assert.Equal(t, []SyntheticCheckAssertion{
+			{Kind: "response_header", Spec: map[string]any{"name": "x-request-id", "operator": "is_not"}},
+			{Kind: "response_header", Spec: map[string]any{"name": "content-type", "operator": "contains", "value": "application/json"}},
+		}, assertions.CriticalAssertions)
+		assert.Empty(t, assertions.DegradedAssertions)
+	})
+
+	t.Run("fields hidden for a kind are not sent", func(t *testing.T) {
+		user := []AssertionSpec{
+			{Kind: "response_header", Severity: "critical", Type: "response", Name: "etag", Operator: "contains", Value: "W/"},
+			{Kind: "error_type", Severity: "critical", Type: "response", Operator: "is", Value: "dns"},
+			{Kind: "status_code", Severity: "critical", Type: "response", Name: "stale", Expression: "$.stale", Operator: "is", Value: "200"},
+		}
+
+		assertions := BuildSyntheticCheckAssertions(nil, &user)
+
+		assert.Equal(t, []SyntheticCheckAssertion{
+			{Kind: "response_header", Spec: map[string]any{"name": "etag", "operator": "contains", "value": "W/"}},
+			{Kind: "error_type", Spec: map[string]any{"value": "dns"}},
+			{Kind: "status_code", Spec: map[string]any{"operator": "is", "value": "200"}},
+		}, assertions.CriticalAssertions)
+	})
+}
+
+func Test__CreateHTTPSyntheticCheck__Setup__AssertionPresets(t *testing.T) {
+	component := CreateHTTPSyntheticCheck{}
+	configuration := func(presets []string) map[string]any {
+		return map[string]any{
+			"name":             "Login API",
+			"request":          map[string]any{"url": "https://example.com/health"},
+			"schedule":         map[string]any{"locations": []string{"de-frankfurt"}},
+			"assertionPresets": presets,
+		}
+	}
+
+	t.Run("known presets -> no error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: configuration([]string{AssertionPresetAPIHealthy, AssertionPresetFastSSL}),
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown preset -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: configuration([]string{"always-green"}),
+		})
+
+		require.ErrorContains(t, err, "unknown assertion preset: always-green")
+	})
+}
+
+func Test__BuildSyntheticCheckAssertions__SSLIssuer(t *testing.T) {
+	user := []AssertionSpec{
+		{Kind: AssertionKindSSLIssuer, Severity: "critical", Type: "response", Operator: "contains", Value: " Let's Encrypt "},
+		{Kind: AssertionKindSSLIssuer, Severity: "degraded", Operator: "is", Value: "R11"},
+	}
+
+	assertions := BuildSyntheticCheckAssertions(nil, &user)
+
+	assert.Equal(t, []SyntheticCheckAssertion{
+		{Kind: "ssl_issuer", Spec: map[string]any{"operator": "contains", "value": "Let's Encrypt"}},
+	}, assertions.CriticalAssertions)
+	assert.Equal(t, []SyntheticCheckAssertion{
+		{Kind: "ssl_issuer", Spec: map[string]any{"operator": "is", "value": "R11"}},
+	}, assertions.DegradedAssertions)
+}
+
+func Test__AssertionFieldSchema__SSLIssuer(t *testing.T) {
+	fields := map[string]configuration.Field{}
+	for _, field := range AssertionFieldSchema() {
+		fields[field.Name] = field
+	}
+
+	kindValues := []string{}
+	for _, option := range fields["kind"].TypeOptions.Select.Options {
+		kindValues = append(kindValues, option.Value)
+	}
+	assert.Contains(t, kindValues, AssertionKindSSLIssuer)
+
+	assert.Contains(t, fields["operator"].VisibilityConditions[0].Values, AssertionKindSSLIssuer)
+	assert.Contains(t, fields["value"].VisibilityConditions[0].Values, AssertionKindSSLIssuer)
+	assert.NotContains(t, fields["type"].VisibilityConditions[0].Values, AssertionKindSSLIssuer)
+}
+
+func Test__CreateHTTPSyntheticCheck__Setup__SSLIssuer(t *testing.T) {
+	component := CreateHTTPSyntheticCheck{}
+	setup := func(operator, value string) error {
+		return component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"name":     "Login API",
+				"request":  map[string]any{"url": "https://example.com/health"},
+				"schedule": map[string]any{"locations": []string{"de-frankfurt"}},
+				"assertions": []map[string]any{
+					{"kind": "status_code", "severity": "critical", "operator": "is", "value": "200"},
+					{"kind": "ssl_issuer", "severity": "critical", "operator": operator, "value": value},
+				},
+			},
+		})
+	}
+
+	t.Run("issuer contains value -> no error", func(t *testing.T) {
+		require.NoError(t, setup("contains", "Let's Encrypt"))
+	})
+
+	t.Run("empty issuer value -> error", func(t *testing.T) {
+		require.ErrorContains(t, setup("is", "  "), "assertions[1]: issuer value is required for ssl_issuer")
+	})
+
+	t.Run("unsupported operator -> error", func(t *testing.T) {
+		require.ErrorContains(t, setup("gte", "R11"), `operator "gte" is not supported for ssl_issuer`)
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
 )
 
 const (
@@ -47,10 +48,15 @@ func NewClient(http core.HTTPContext, ctx core.IntegrationContext) (*Client, err
 	// Strip /api/prometheus if user included it in the base URL
 	baseURL = strings.TrimSuffix(baseURL, "/api/prometheus")
 
+	http, err = registry.WithIntegrationTLS(http, registry.IntegrationTLSOptionsFromConfig(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error configuring TLS: %v", err)
+	}
+
 	return &Client{
 		Token:   string(apiToken),
 		BaseURL: baseURL,
-		http:    http,
+		http:    registry.InstrumentIntegrationHTTP("dash0", http),
 	}, nil
 }
 
@@ -72,15 +78,16 @@ func (c *Client) execRequest(method, url string, body io.Reader, contentType str
 	}
 	defer res.Body.Close()
 
-	// Limit response size to prevent excessive memory usage
-	limitedReader := io.LimitReader(res.Body, MaxResponseSize)
+	// Limit response size to prevent excessive memory usage.
+	// Read one extra byte so a body of exactly MaxResponseSize is accepted.
+	limitedReader := io.LimitReader(res.Body, MaxResponseSize+1)
 	responseBody, err := io.ReadAll(limitedReader)
 	if err != nil {
 		return nil, fmt.Errorf("error reading body: %v", err)
 	}
 
 	// Check if we hit the limit (response was truncated)
-	if len(responseBody) >= MaxResponseSize {
+	if len(responseBody) > MaxResponseSize {
 		return nil, fmt.Errorf("response too large: exceeds maximum size of %d bytes", MaxResponseSize)
 	}
 
@@ -501,6 +508,44 @@ func (c *Client) GetSyntheticCheck(checkID string, dataset string) (*SyntheticCh
 	return &response, nil
 }
 
+// PauseSyntheticCheck disables a synthetic check without deleting it (PATCH).
+func (c *Client) PauseSyntheticCheck(checkID string, dataset string) (*SyntheticCheckResponse, error) {
+	return c.setSyntheticCheckEnabled(checkID, dataset, false)
+}
+
+// ResumeSyntheticCheck re-enables a paused synthetic check (PATCH).
+func (c *Client) ResumeSyntheticCheck(checkID string, dataset string) (*SyntheticCheckResponse, error) {
+	return c.setSyntheticCheckEnabled(checkID, dataset, true)
+}
+
+func (c *Client) setSyntheticCheckEnabled(checkID string, dataset string, enabled bool) (*SyntheticCheckResponse, error) {
+	apiURL := fmt.Sprintf("%s/api/synthetic-checks/%s?dataset=%s", c.BaseURL, url.PathEscape(checkID), url.QueryEscape(dataset))
+
+	body, err := json.Marshal(map[string]any{
+		"spec": map[string]any{"enabled": enabled},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling request: %v", err)
+	}
+
+	responseBody, err := c.execRequest(http.MethodPatch, apiURL, bytes.NewReader(body), "application/json")
+	if err != nil {
+		return nil, err
+	}
+
+	// PATCH may return 204 No Content with empty body
+	if len(responseBody) == 0 {
+		return &SyntheticCheckResponse{Spec: SyntheticCheckResponseSpec{Enabled: enabled}}, nil
+	}
+
+	var response SyntheticCheckResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("error parsing response: %v", err)
+	}
+
+	return &response, nil
+}
+
 // LogRecord represents a structured log record to be sent to Dash0 via OTLP HTTP ingestion.
 type LogRecord struct {
 	SeverityText string            `json:"severityText"`
@@ -543,13 +588,13 @@ func (c *Client) SendLogRecord(dataset string, record LogRecord) (map[string]any
 	}
 	defer res.Body.Close()
 
-	limitedReader := io.LimitReader(res.Body, MaxResponseSize)
+	limitedReader := io.LimitReader(res.Body, MaxResponseSize+1)
 	responseBody, err := io.ReadAll(limitedReader)
 	if err != nil {
 		return nil, fmt.Errorf("error reading body: %v", err)
 	}
 
-	if len(responseBody) >= MaxResponseSize {
+	if len(responseBody) > MaxResponseSize {
 		return nil, fmt.Errorf("response too large: exceeds maximum size of %d bytes", MaxResponseSize)
 	}
 
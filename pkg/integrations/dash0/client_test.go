@@ -210,3 +210,74 @@ func Test__Client__ExecutePrometheusRangeQuery(t *testing.T) {
 		assert.Contains(t, httpContext.Requests[0].URL.String(), "/api/prometheus/api/v1/query_range")
 	})
 }
+
+func Test__Client__PauseResumeSyntheticCheck(t *testing.T) {
+	integrationCtx := &contexts.IntegrationContext{
+		Configuration: map[string]any{
+			"apiToken": "token123",
+			"baseURL":  "https://api.us-west-2.aws.dash0.com",
+		},
+	}
+
+	t.Run("pause sends enabled=false", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"metadata":{"name":"Checkout"},"spec":{"display":{"name":"Checkout"},"enabled":false}}`)),
+				},
+			},
+		}
+
+		client, err := NewClient(httpContext, integrationCtx)
+		require.NoError(t, err)
+
+		check, err := client.PauseSyntheticCheck("check-123", "production")
+		require.NoError(t, err)
+		assert.False(t, check.Spec.Enabled)
+		assert.Equal(t, "Checkout", check.Spec.Display.Name)
+
+		require.Len(t, httpContext.Requests, 1)
+		request := httpContext.Requests[0]
+		assert.Equal(t, http.MethodPatch, request.Method)
+		assert.Equal(t, "https://api.us-west-2.aws.dash0.com/api/synthetic-checks/check-123?dataset=production", request.URL.String())
+		assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(request.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"spec":{"enabled":false}}`, string(body))
+	})
+
+	t.Run("resume sends enabled=true and handles empty responses", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusNoContent, Body: io.NopCloser(strings.NewReader(""))},
+			},
+		}
+
+		client, err := NewClient(httpContext, integrationCtx)
+		require.NoError(t, err)
+
+		check, err := client.ResumeSyntheticCheck("check-123", "default")
+		require.NoError(t, err)
+		assert.True(t, check.Spec.Enabled)
+
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"spec":{"enabled":true}}`, string(body))
+	})
+
+	t.Run("request failure -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{"error":"not found"}`))},
+			},
+		}
+
+		client, err := NewClient(httpContext, integrationCtx)
+		require.NoError(t, err)
+
+		_, err = client.PauseSyntheticCheck("missing", "default")
+		require.ErrorContains(t, err, "request got 404 code")
+	})
+}
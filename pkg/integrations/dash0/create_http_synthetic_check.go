@@ -12,6 +12,9 @@ import (
 	"github.com/superplanehq/superplane/pkg/core"
 )
 
+// AssertionKindSSLIssuer asserts on the common name (CN) of the certificate issuer.
+const AssertionKindSSLIssuer = "ssl_issuer"
+
 type CreateHTTPSyntheticCheck struct{}
 
 type CreateHTTPSyntheticCheckSpec struct {
@@ -19,6 +22,7 @@ type CreateHTTPSyntheticCheckSpec struct {
 	Dataset    string           `mapstructure:"dataset"`
 	Request    RequestSpec      `mapstructure:"request"`
 	Schedule   ScheduleSpec     `mapstructure:"schedule"`
+	Presets    []string         `mapstructure:"assertionPresets"`
 	Assertions *[]AssertionSpec `mapstructure:"assertions"`
 	Retries    *RetrySpec       `mapstructure:"retries"`
 }
@@ -53,8 +57,8 @@ type AssertionSpec struct {
 	Severity string `mapstructure:"severity"`
 
 	// Shared fields reused across assertion kinds
-	Operator   string `mapstructure:"operator"`   // status_code, timing, ssl, response_header, json_body, text_body
-	Value      string `mapstructure:"value"`      // status_code, timing, error_type, ssl, response_header, json_body, text_body
+	Operator   string `mapstructure:"operator"`   // status_code, timing, ssl, ssl_issuer, response_header, json_body, text_body
+	Value      string `mapstructure:"value"`      // status_code, timing, error_type, ssl, ssl_issuer, response_header, json_body, text_body
 	Type       string `mapstructure:"type"`       // timing (phase: response, request, ssl, connection, dns, total)
 	Name       string `mapstructure:"name"`       // response_header (header name)
 	Expression string `mapstructure:"expression"` // json_body (JSONPath expression)
@@ -101,12 +105,18 @@ func (c *CreateHTTPSyntheticCheck) Documentation() string {
 - **Locations**: Probe locations (Frankfurt, Oregon, North Virginia, London, Brussels, Melbourne)
 - **Strategy**: Execution strategy (all locations or round-robin)
 
+### Assertion Presets
+Predefined assertion sets that are added before your own assertions:
+- **API healthy**: Status code is 200, response time under 5s (critical) and 2s (degraded)
+- **Fast SSL**: Certificate valid for at least 7 days (critical) and 30 days (degraded), SSL handshake under 500ms (degraded)
+
 ### Assertions
 Each assertion has a kind, severity (critical or degraded), and kind-specific parameters:
 - **Status Code**: Validate the HTTP response status code
 - **Timing**: Set thresholds for response, request, SSL, connection, DNS, or total time
 - **Error Type**: Detect specific error types (DNS, connection, SSL, timeout)
 - **SSL Certificate Validity**: Enforce minimum days until certificate expiration
+- **SSL Certificate Issuer**: Check that the issuer common name (CN) is or contains a value, e.g. "Let's Encrypt"
 - **Response Header**: Validate presence or value of a specific response header
 - **JSON Body**: Validate JSON response fields using JSONPath expressions
 - **Text Body**: Match plain-text response content
@@ -180,6 +190,7 @@ func (c *CreateHTTPSyntheticCheck) Configuration() []configuration.Field {
 				},
 			},
 		},
+		assertionPresetsField(),
 		{
 			Name:        "assertions",
 			Label:       "Assertions",
@@ -242,7 +253,11 @@ func (c *CreateHTTPSyntheticCheck) Setup(ctx core.SetupContext) error {
 		return errors.New("at least one location is required")
 	}
 
-	return nil
+	if err := validateAssertionPresets(spec.Presets); err != nil {
+		return err
+	}
+
+	return validateAssertions(spec.Assertions)
 }
 
 func (c *CreateHTTPSyntheticCheck) Execute(ctx core.ExecutionContext) error {
@@ -277,7 +292,7 @@ func (c *CreateHTTPSyntheticCheck) Execute(ctx core.ExecutionContext) error {
 }
 
 func (c *CreateHTTPSyntheticCheck) buildRequest(spec CreateHTTPSyntheticCheckSpec) SyntheticCheckRequest {
-	return BuildSyntheticCheckRequest(spec.Name, spec.Request, spec.Schedule, BuildSyntheticCheckAssertions(spec.Assertions), spec.Retries)
+	return BuildSyntheticCheckRequest(spec.Name, spec.Request, spec.Schedule, BuildSyntheticCheckAssertions(spec.Presets, spec.Assertions), spec.Retries)
 }
 
 // BuildSyntheticCheckRequest builds the API request payload from spec fields (shared by create and update components).
@@ -370,19 +385,13 @@ func BuildSyntheticCheckRequest(name string, req RequestSpec, sched ScheduleSpec
 	}
 }
 
-// BuildSyntheticCheckAssertions builds the API assertions payload from spec (shared by create and update components).
-func BuildSyntheticCheckAssertions(assertions *[]AssertionSpec) SyntheticCheckAssertions {
+// BuildSyntheticCheckAssertions builds the API assertions payload from presets and spec (shared by create and update components).
+// Preset assertions come first, followed by the user-supplied ones.
+func BuildSyntheticCheckAssertions(presets []string, assertions *[]AssertionSpec) SyntheticCheckAssertions {
 	criticalAssertions := make([]SyntheticCheckAssertion, 0)
 	degradedAssertions := make([]SyntheticCheckAssertion, 0)
 
-	if assertions == nil {
-		return SyntheticCheckAssertions{
-			CriticalAssertions: criticalAssertions,
-			DegradedAssertions: degradedAssertions,
-		}
-	}
-
-	for _, a := range *assertions {
+	for _, a := range expandAssertionPresets(presets, assertions) {
 		assertion := buildSingleAssertion(a)
 		if assertion == nil {
 			continue
@@ -402,24 +411,32 @@ func BuildSyntheticCheckAssertions(assertions *[]AssertionSpec) SyntheticCheckAs
 	}
 }
 
+// buildSingleAssertion builds one assertion entry. Each row is independent, so
+// several rows of the same kind produce separate entries. Fields only apply to
+// the kinds that show them in AssertionFieldSchema, so a hidden default (e.g. the
+// timing phase) never leaks into another kind's spec.
 func buildSingleAssertion(a AssertionSpec) *SyntheticCheckAssertion {
 	spec := map[string]any{}
 
-	if a.Operator != "" {
+	if a.Operator != "" && a.Kind != "error_type" {
 		spec["operator"] = a.Operator
 	}
 	if a.Value != "" {
 		spec["value"] = a.Value
 	}
-	if a.Type != "" {
+	if a.Type != "" && a.Kind == "timing" {
 		spec["type"] = a.Type
 	}
-	if a.Name != "" {
+	if a.Name != "" && a.Kind == "response_header" {
 		spec["name"] = a.Name
 	}
-	if a.Expression != "" {
+	if a.Expression != "" && a.Kind == "json_body" {
 		spec["expression"] = a.Expression
 	}
+	if a.Kind == AssertionKindSSLIssuer {
+		// Issuer names are matched literally, so stray whitespace would never match.
+		spec["value"] = strings.TrimSpace(a.Value)
+	}
 
 	return &SyntheticCheckAssertion{
 		Kind: a.Kind,
@@ -427,6 +444,28 @@ func buildSingleAssertion(a AssertionSpec) *SyntheticCheckAssertion {
 	}
 }
 
+// validateAssertions checks the kind-specific parameters of user-supplied assertions.
+func validateAssertions(assertions *[]AssertionSpec) error {
+	if assertions == nil {
+		return nil
+	}
+
+	for i, a := range *assertions {
+		if a.Kind != AssertionKindSSLIssuer {
+			continue
+		}
+
+		if strings.TrimSpace(a.Value) == "" {
+			return fmt.Errorf("assertions[%d]: issuer value is required for %s", i, AssertionKindSSLIssuer)
+		}
+		if a.Operator != "is" && a.Operator != "contains" {
+			return fmt.Errorf("assertions[%d]: operator %q is not supported for %s, use is or contains", i, a.Operator, AssertionKindSSLIssuer)
+		}
+	}
+
+	return nil
+}
+
 // AssertionFieldSchema returns the configuration fields for a single assertion (used by create and update components).
 func AssertionFieldSchema() []configuration.Field {
 	return []configuration.Field{
@@ -443,6 +482,7 @@ func AssertionFieldSchema() []configuration.Field {
 						{Label: "Timing", Value: "timing"},
 						{Label: "Error Type", Value: "error_type"},
 						{Label: "SSL Certificate Validity", Value: "ssl_certificate_validity"},
+						{Label: "SSL Certificate Issuer", Value: AssertionKindSSLIssuer},
 						{Label: "Response Header", Value: "response_header"},
 						{Label: "JSON Body", Value: "json_body"},
 						{Label: "Text Body", Value: "text_body"},
@@ -532,7 +572,7 @@ func AssertionFieldSchema() []configuration.Field {
 				},
 			},
 			VisibilityConditions: []configuration.VisibilityCondition{
-				{Field: "kind", Values: []string{"status_code", "timing", "ssl_certificate_validity", "response_header", "json_body", "text_body"}},
+				{Field: "kind", Values: []string{"status_code", "timing", "ssl_certificate_validity", AssertionKindSSLIssuer, "response_header", "json_body", "text_body"}},
 			},
 		},
 
@@ -543,7 +583,7 @@ func AssertionFieldSchema() []configuration.Field {
 			Type:        configuration.FieldTypeString,
 			Placeholder: "200",
 			VisibilityConditions: []configuration.VisibilityCondition{
-				{Field: "kind", Values: []string{"status_code", "timing", "error_type", "ssl_certificate_validity", "response_header", "json_body", "text_body"}},
+				{Field: "kind", Values: []string{"status_code", "timing", "error_type", "ssl_certificate_validity", AssertionKindSSLIssuer, "response_header", "json_body", "text_body"}},
 			},
 		},
 	}
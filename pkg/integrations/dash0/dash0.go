@@ -20,8 +20,10 @@ func init() {
 type Dash0 struct{}
 
 type Configuration struct {
-	APIToken string `json:"apiToken"`
-	BaseURL  string `json:"baseURL"`
+	APIToken        string `json:"apiToken"`
+	BaseURL         string `json:"baseURL"`
+	CACertificate   string `json:"caCertificate"`
+	TLSVerification string `json:"tlsVerification"`
 }
 
 type Metadata struct {
@@ -72,6 +74,29 @@ func (d *Dash0) Configuration() []configuration.Field {
 			Required:    true,
 			Placeholder: "https://api.us-west-2.aws.dash0.com",
 		},
+		{
+			Name:        "caCertificate",
+			Label:       "CA Certificate",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "PEM-encoded CA certificate bundle for a self-hosted endpoint signed by a private CA",
+		},
+		{
+			Name:        "tlsVerification",
+			Label:       "TLS Verification",
+			Type:        configuration.FieldTypeSelect,
+			Required:    false,
+			Default:     registry.IntegrationTLSVerify,
+			Description: "Skipping verification is insecure and only works if the server allows it",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Verify", Value: registry.IntegrationTLSVerify},
+						{Label: "Skip verification (insecure)", Value: registry.IntegrationTLSSkip},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -83,6 +108,8 @@ func (d *Dash0) Actions() []core.Action {
 		&UpdateHTTPSyntheticCheck{},
 		&DeleteHTTPSyntheticCheck{},
 		&GetHTTPSyntheticCheck{},
+		&PauseResumeSyntheticCheck{},
+		&WaitForCheckResult{},
 		&CreateCheckRule{},
 		&GetCheckRule{},
 		&UpdateCheckRule{},
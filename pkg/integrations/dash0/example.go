@@ -37,6 +37,18 @@ var exampleOutputDeleteHTTPSyntheticCheckBytes []byte
 var exampleOutputDeleteHTTPSyntheticCheckOnce sync.Once
 var exampleOutputDeleteHTTPSyntheticCheck map[string]any
 
+//go:embed example_output_pause_resume_synthetic_check.json
+var exampleOutputPauseResumeSyntheticCheckBytes []byte
+
+var exampleOutputPauseResumeSyntheticCheckOnce sync.Once
+var exampleOutputPauseResumeSyntheticCheck map[string]any
+
+//go:embed example_output_wait_for_check_result.json
+var exampleOutputWaitForCheckResultBytes []byte
+
+var exampleOutputWaitForCheckResultOnce sync.Once
+var exampleOutputWaitForCheckResult map[string]any
+
 //go:embed example_output_send_log_event.json
 var exampleOutputSendLogEventBytes []byte
 
@@ -105,6 +117,14 @@ func (c *DeleteHTTPSyntheticCheck) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputDeleteHTTPSyntheticCheckOnce, exampleOutputDeleteHTTPSyntheticCheckBytes, &exampleOutputDeleteHTTPSyntheticCheck)
 }
 
+func (c *PauseResumeSyntheticCheck) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputPauseResumeSyntheticCheckOnce, exampleOutputPauseResumeSyntheticCheckBytes, &exampleOutputPauseResumeSyntheticCheck)
+}
+
+func (c *WaitForCheckResult) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputWaitForCheckResultOnce, exampleOutputWaitForCheckResultBytes, &exampleOutputWaitForCheckResult)
+}
+
 func (c *SendLogEvent) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputSendLogEventOnce, exampleOutputSendLogEventBytes, &exampleOutputSendLogEvent)
 }
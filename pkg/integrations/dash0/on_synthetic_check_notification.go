@@ -1,9 +1,12 @@
 package dash0
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"slices"
+	"sort"
+	"strings"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/configuration"
@@ -17,9 +20,20 @@ type OnSyntheticCheckNotificationMetadata struct {
 }
 
 type OnSyntheticCheckNotificationConfiguration struct {
-	Statuses []string `json:"statuses" mapstructure:"statuses"`
+	Statuses   []string `json:"statuses" mapstructure:"statuses"`
+	CheckNames []string `json:"checkNames" mapstructure:"checkNames"`
+	Dataset    string   `json:"dataset" mapstructure:"dataset"`
 }
 
+const (
+	syntheticCheckLabelID             = "dash0.synthetic_check.id"
+	syntheticCheckLabelName           = "dash0.synthetic_check.name"
+	syntheticCheckLabelFailedCritical = "dash0.synthetic_check.failed_critical_assertions"
+	syntheticCheckLabelFailedDegraded = "dash0.synthetic_check.failed_degraded_assertions"
+	syntheticCheckSeverityCritical    = "critical"
+	syntheticCheckSeverityDegraded    = "degraded"
+)
+
 func (t *OnSyntheticCheckNotification) Name() string {
 	return "dash0.onSyntheticCheckNotification"
 }
@@ -39,9 +53,16 @@ func (t *OnSyntheticCheckNotification) Documentation() string {
 
 Connect the Dash0 integration in SuperPlane. A webhook notification channel is created automatically in Dash0 with routing for all synthetic check notifications. Add this trigger to a workflow and publish the canvas to start receiving events.
 
+## Configuration
+
+- **Statuses**: Only emit notifications for issues with these statuses. Use **Critical** and **Degraded** to react to failing checks; **Closed** fires when a check recovers.
+- **Check Names**: Optional list of synthetic check names or IDs. When set, only notifications for these checks are emitted.
+- **Dataset**: Optional Dash0 dataset. When set, only notifications from this dataset are emitted.
+
 ## Event Data
 
 The trigger emits the full JSON payload received from Dash0 as ` + "`dash0.syntheticCheckNotification`" + `.
+It also adds a ` + "`check`" + ` object with the check ` + "`id`" + `, ` + "`name`" + `, and the ` + "`failedAssertions`" + ` reported by Dash0, one entry per location and assertion, with the assertion ` + "`kind`" + `, ` + "`severity`" + `, ` + "`actualValue`" + `, ` + "`explanation`" + ` and ` + "`spec`" + `.
 
 ## Labels Format
 
@@ -79,6 +100,29 @@ func (t *OnSyntheticCheckNotification) Configuration() []configuration.Field {
 				},
 			},
 		},
+		{
+			Name:     "checkNames",
+			Label:    "Check Names",
+			Type:     configuration.FieldTypeList,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Check Name",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+			Description: "Optional synthetic check names or IDs to listen to",
+		},
+		{
+			Name:        "dataset",
+			Label:       "Dataset",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Optional Dash0 dataset to listen to",
+			Placeholder: "default",
+		},
 	}
 }
 
@@ -126,6 +170,24 @@ type SyntheticCheckNotificationEvent struct {
 
 type SyntheticCheckNotificationData struct {
 	Issue *SyntheticCheckNotificationIssue `json:"issue"`
+	Check *SyntheticCheckDetails           `json:"check,omitempty" mapstructure:"-"`
+}
+
+// SyntheticCheckDetails is extracted from the issue labels so workflows
+// don't have to parse Dash0's tuple-based label format themselves.
+type SyntheticCheckDetails struct {
+	ID               string                          `json:"id,omitempty"`
+	Name             string                          `json:"name,omitempty"`
+	FailedAssertions []SyntheticCheckFailedAssertion `json:"failedAssertions"`
+}
+
+type SyntheticCheckFailedAssertion struct {
+	Location    string         `json:"location"`
+	Severity    string         `json:"severity"`
+	Kind        string         `json:"kind"`
+	ActualValue string         `json:"actualValue,omitempty"`
+	Explanation string         `json:"explanation,omitempty"`
+	Spec        map[string]any `json:"spec,omitempty"`
 }
 
 // SyntheticCheckNotificationIssue represents the issue in a synthetic check notification.
@@ -190,9 +252,127 @@ func (t *OnSyntheticCheckNotification) OnIntegrationMessage(ctx core.Integration
 		return nil
 	}
 
+	dataset := strings.TrimSpace(config.Dataset)
+	if dataset != "" && issue.Dataset != dataset {
+		ctx.Logger.Infof("Ignoring synthetic check notification event for dataset %s", issue.Dataset)
+		return nil
+	}
+
+	check := syntheticCheckDetails(issue)
+	if !matchesSyntheticCheckNames(config.CheckNames, check) {
+		ctx.Logger.Infof("Ignoring synthetic check notification event for check %s", check.Name)
+		return nil
+	}
+
+	event.Data.Check = check
 	return ctx.Events.Emit("dash0.syntheticCheckNotification", event.Data)
 }
 
+func matchesSyntheticCheckNames(names []string, check *SyntheticCheckDetails) bool {
+	filtered := []string{}
+	for _, name := range names {
+		if trimmed := strings.TrimSpace(name); trimmed != "" {
+			filtered = append(filtered, trimmed)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return true
+	}
+
+	return slices.Contains(filtered, check.Name) || (check.ID != "" && slices.Contains(filtered, check.ID))
+}
+
+func syntheticCheckDetails(issue *SyntheticCheckNotificationIssue) *SyntheticCheckDetails {
+	labels := syntheticCheckLabels(issue.Labels)
+	check := &SyntheticCheckDetails{
+		ID:               labels[syntheticCheckLabelID],
+		Name:             labels[syntheticCheckLabelName],
+		FailedAssertions: []SyntheticCheckFailedAssertion{},
+	}
+
+	if check.Name == "" && len(issue.CheckRules) > 0 {
+		check.Name = issue.CheckRules[0].Name
+	}
+
+	check.FailedAssertions = append(check.FailedAssertions, parseFailedAssertions(labels[syntheticCheckLabelFailedCritical], syntheticCheckSeverityCritical)...)
+	check.FailedAssertions = append(check.FailedAssertions, parseFailedAssertions(labels[syntheticCheckLabelFailedDegraded], syntheticCheckSeverityDegraded)...)
+	return check
+}
+
+// syntheticCheckLabels flattens Dash0's [index, {key, value: {stringValue}}]
+// label tuples into a key -> string value map.
+func syntheticCheckLabels(labels []any) map[string]string {
+	out := map[string]string{}
+	for _, label := range labels {
+		tuple, ok := label.([]any)
+		if !ok || len(tuple) < 2 {
+			continue
+		}
+
+		entry, ok := tuple[1].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		key, _ := entry["key"].(string)
+		value, _ := entry["value"].(map[string]any)
+		if key == "" || value == nil {
+			continue
+		}
+
+		if stringValue, ok := value["stringValue"].(string); ok {
+			out[key] = stringValue
+		}
+	}
+
+	return out
+}
+
+type syntheticCheckAssertionResult struct {
+	ActualValue string `json:"actualValue"`
+	Explanation string `json:"explanation"`
+	Assertion   struct {
+		Kind string         `json:"kind"`
+		Spec map[string]any `json:"spec"`
+	} `json:"assertion"`
+}
+
+// parseFailedAssertions parses the JSON-encoded {location: [result]} map
+// Dash0 attaches to synthetic check issues. Invalid values are ignored.
+func parseFailedAssertions(raw, severity string) []SyntheticCheckFailedAssertion {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+
+	byLocation := map[string][]syntheticCheckAssertionResult{}
+	if err := json.Unmarshal([]byte(raw), &byLocation); err != nil {
+		return nil
+	}
+
+	locations := make([]string, 0, len(byLocation))
+	for location := range byLocation {
+		locations = append(locations, location)
+	}
+	sort.Strings(locations)
+
+	assertions := []SyntheticCheckFailedAssertion{}
+	for _, location := range locations {
+		for _, result := range byLocation[location] {
+			assertions = append(assertions, SyntheticCheckFailedAssertion{
+				Location:    location,
+				Severity:    severity,
+				Kind:        result.Assertion.Kind,
+				ActualValue: result.ActualValue,
+				Explanation: result.Explanation,
+				Spec:        result.Assertion.Spec,
+			})
+		}
+	}
+
+	return assertions
+}
+
 func (t *OnSyntheticCheckNotification) Cleanup(ctx core.TriggerContext) error {
 	return nil
 }
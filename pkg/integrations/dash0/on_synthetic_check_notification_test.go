@@ -227,3 +227,122 @@ func Test__OnSyntheticCheckNotification__OnIntegrationMessage(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to decode synthetic check notification event")
 	})
 }
+
+func syntheticCheckLabel(index, key, value string) []any {
+	return []any{index, map[string]any{"key": key, "value": map[string]any{"stringValue": value}}}
+}
+
+func syntheticCheckFailureMessage(status, dataset string) map[string]any {
+	return map[string]any{
+		"type": "synthetic.alert.ongoing",
+		"data": map[string]any{
+			"issue": map[string]any{
+				"id":      "issue-789",
+				"status":  status,
+				"dataset": dataset,
+				"summary": "Synthetic check failed: API Health Check",
+				"labels": []any{
+					syntheticCheckLabel("0", "dash0.synthetic_check.id", "api-health-check"),
+					syntheticCheckLabel("1", "dash0.synthetic_check.name", "API Health Check"),
+					syntheticCheckLabel("2", "dash0.synthetic_check.failed_critical_assertions",
+						`{"be-brussels":[{"actualValue":"503","assertion":{"kind":"status_code","spec":{"operator":"is","value":"200"}},"explanation":"Expected value to be 200, but got 503"}]}`),
+				},
+			},
+		},
+	}
+}
+
+func Test__OnSyntheticCheckNotification__CheckFailures(t *testing.T) {
+	trigger := &OnSyntheticCheckNotification{}
+
+	t.Run("critical alert -> emits failing check and assertion details", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+			Message:       syntheticCheckFailureMessage("critical", "default"),
+			Configuration: map[string]any{"statuses": []string{"critical", "degraded"}},
+			Logger:        logrus.NewEntry(logrus.New()),
+			Events:        events,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, events.Payloads, 1)
+
+		payload, ok := events.Payloads[0].Data.(SyntheticCheckNotificationData)
+		require.True(t, ok)
+		require.NotNil(t, payload.Check)
+		assert.Equal(t, "api-health-check", payload.Check.ID)
+		assert.Equal(t, "API Health Check", payload.Check.Name)
+		require.Len(t, payload.Check.FailedAssertions, 1)
+
+		assertion := payload.Check.FailedAssertions[0]
+		assert.Equal(t, "be-brussels", assertion.Location)
+		assert.Equal(t, "critical", assertion.Severity)
+		assert.Equal(t, "status_code", assertion.Kind)
+		assert.Equal(t, "503", assertion.ActualValue)
+		assert.Equal(t, "Expected value to be 200, but got 503", assertion.Explanation)
+		assert.Equal(t, map[string]any{"operator": "is", "value": "200"}, assertion.Spec)
+	})
+
+	t.Run("recovery alert -> not emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+			Message:       syntheticCheckFailureMessage("closed", "default"),
+			Configuration: map[string]any{"statuses": []string{"critical", "degraded"}},
+			Logger:        logrus.NewEntry(logrus.New()),
+			Events:        events,
+		})
+
+		require.NoError(t, err)
+		require.Empty(t, events.Payloads)
+	})
+
+	t.Run("check name filter matches name or ID", func(t *testing.T) {
+		for _, name := range []string{"API Health Check", "api-health-check"} {
+			events := &contexts.EventContext{}
+			err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+				Message: syntheticCheckFailureMessage("critical", "default"),
+				Configuration: map[string]any{
+					"statuses":   []string{"critical"},
+					"checkNames": []string{name},
+				},
+				Logger: logrus.NewEntry(logrus.New()),
+				Events: events,
+			})
+
+			require.NoError(t, err)
+			require.Len(t, events.Payloads, 1)
+		}
+	})
+
+	t.Run("other check -> not emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+			Message: syntheticCheckFailureMessage("critical", "default"),
+			Configuration: map[string]any{
+				"statuses":   []string{"critical"},
+				"checkNames": []string{"Checkout Flow"},
+			},
+			Logger: logrus.NewEntry(logrus.New()),
+			Events: events,
+		})
+
+		require.NoError(t, err)
+		require.Empty(t, events.Payloads)
+	})
+
+	t.Run("other dataset -> not emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+			Message: syntheticCheckFailureMessage("critical", "staging"),
+			Configuration: map[string]any{
+				"statuses": []string{"critical"},
+				"dataset":  "default",
+			},
+			Logger: logrus.NewEntry(logrus.New()),
+			Events: events,
+		})
+
+		require.NoError(t, err)
+		require.Empty(t, events.Payloads)
+	})
+}
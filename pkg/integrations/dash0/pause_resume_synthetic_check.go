@@ -0,0 +1,232 @@
+package dash0
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	SyntheticCheckActionPause  = "pause"
+	SyntheticCheckActionResume = "resume"
+)
+
+type PauseResumeSyntheticCheck struct{}
+
+type PauseResumeSyntheticCheckSpec struct {
+	SyntheticCheck string `mapstructure:"syntheticCheck"`
+	Dataset        string `mapstructure:"dataset"`
+	Action         string `mapstructure:"action"`
+}
+
+func (c *PauseResumeSyntheticCheck) Name() string {
+	return "dash0.pauseResumeSyntheticCheck"
+}
+
+func (c *PauseResumeSyntheticCheck) Label() string {
+	return "Pause/Resume Synthetic Check"
+}
+
+func (c *PauseResumeSyntheticCheck) Description() string {
+	return "Pause or resume a Dash0 synthetic check without deleting it"
+}
+
+func (c *PauseResumeSyntheticCheck) Documentation() string {
+	return `The Pause/Resume Synthetic Check component enables or disables an existing Dash0 synthetic check. The check keeps its configuration and history while paused.
+
+## Use Cases
+
+- **Maintenance windows**: Pause checks before planned downtime and resume them afterwards
+- **Deployments**: Silence checks for a service while it is being redeployed
+
+## Configuration
+
+- **Synthetic Check**: The synthetic check to pause or resume
+- **Dataset**: The dataset the check belongs to (defaults to "default")
+- **Action**: Pause disables the check, Resume enables it again
+
+## Output
+
+Returns the check **id**, **name**, **dataset**, and the new **enabled** state of the check.`
+}
+
+func (c *PauseResumeSyntheticCheck) Icon() string {
+	return "activity"
+}
+
+func (c *PauseResumeSyntheticCheck) Color() string {
+	return "blue"
+}
+
+func (c *PauseResumeSyntheticCheck) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *PauseResumeSyntheticCheck) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "syntheticCheck",
+			Label:       "Synthetic Check",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The synthetic check to pause or resume",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "synthetic-check",
+				},
+			},
+		},
+		{
+			Name:        "dataset",
+			Label:       "Dataset",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Default:     "default",
+			Description: "The dataset the synthetic check belongs to",
+		},
+		{
+			Name:     "action",
+			Label:    "Action",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  SyntheticCheckActionPause,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Pause", Value: SyntheticCheckActionPause},
+						{Label: "Resume", Value: SyntheticCheckActionResume},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *PauseResumeSyntheticCheck) Setup(ctx core.SetupContext) error {
+	spec := PauseResumeSyntheticCheckSpec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	// Expressions are only resolved at execution time, so only plain check IDs are validated here.
+	if !strings.Contains(spec.SyntheticCheck, "{{") {
+		if err := validateSyntheticCheckID(spec.SyntheticCheck); err != nil {
+			return err
+		}
+	}
+	if strings.TrimSpace(spec.Dataset) == "" {
+		return errors.New("dataset is required")
+	}
+
+	return validateSyntheticCheckAction(spec.Action)
+}
+
+func (c *PauseResumeSyntheticCheck) Execute(ctx core.ExecutionContext) error {
+	spec := PauseResumeSyntheticCheckSpec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	checkID := strings.TrimSpace(spec.SyntheticCheck)
+	if err := validateSyntheticCheckID(checkID); err != nil {
+		return err
+	}
+	if err := validateSyntheticCheckAction(spec.Action); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	dataset := spec.Dataset
+	if dataset == "" {
+		dataset = "default"
+	}
+
+	var check *SyntheticCheckResponse
+	eventType := "dash0.syntheticCheck.paused"
+	if spec.Action == SyntheticCheckActionResume {
+		eventType = "dash0.syntheticCheck.resumed"
+		check, err = client.ResumeSyntheticCheck(checkID, dataset)
+	} else {
+		check, err = client.PauseSyntheticCheck(checkID, dataset)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to %s synthetic check: %v", spec.Action, err)
+	}
+
+	name := check.Spec.Display.Name
+	if name == "" {
+		name = check.Metadata.Name
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		eventType,
+		[]any{map[string]any{
+			"id":      checkID,
+			"name":    name,
+			"dataset": dataset,
+			"enabled": check.Spec.Enabled,
+		}},
+	)
+}
+
+// validateSyntheticCheckID rejects IDs that would change the API path.
+func validateSyntheticCheckID(checkID string) error {
+	checkID = strings.TrimSpace(checkID)
+	if checkID == "" {
+		return errors.New("syntheticCheck is required")
+	}
+
+	if strings.ContainsAny(checkID, "/?# \t\n") || checkID == "." || checkID == ".." {
+		return fmt.Errorf("invalid syntheticCheck %q", checkID)
+	}
+
+	return nil
+}
+
+func validateSyntheticCheckAction(action string) error {
+	switch action {
+	case SyntheticCheckActionPause, SyntheticCheckActionResume:
+		return nil
+	case "":
+		return errors.New("action is required")
+	default:
+		return fmt.Errorf("invalid action %q: must be %s or %s", action, SyntheticCheckActionPause, SyntheticCheckActionResume)
+	}
+}
+
+func (c *PauseResumeSyntheticCheck) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *PauseResumeSyntheticCheck) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *PauseResumeSyntheticCheck) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *PauseResumeSyntheticCheck) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *PauseResumeSyntheticCheck) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *PauseResumeSyntheticCheck) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
@@ -0,0 +1,128 @@
+package dash0
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__PauseResumeSyntheticCheck__Setup(t *testing.T) {
+	component := PauseResumeSyntheticCheck{}
+
+	t.Run("syntheticCheck is required", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"syntheticCheck": "  ", "dataset": "default", "action": "pause"},
+		})
+
+		require.ErrorContains(t, err, "syntheticCheck is required")
+	})
+
+	t.Run("invalid syntheticCheck -> error", func(t *testing.T) {
+		for _, checkID := range []string{"../other", "check?dataset=x", "check 123", ".."} {
+			err := component.Setup(core.SetupContext{
+				Integration:   &contexts.IntegrationContext{},
+				Metadata:      &contexts.MetadataContext{},
+				Configuration: map[string]any{"syntheticCheck": checkID, "dataset": "default", "action": "pause"},
+			})
+
+			require.ErrorContains(t, err, "invalid syntheticCheck", checkID)
+		}
+	})
+
+	t.Run("invalid action -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"syntheticCheck": "check-123", "dataset": "default", "action": "delete"},
+		})
+
+		require.ErrorContains(t, err, `invalid action "delete"`)
+	})
+
+	t.Run("expression syntheticCheck is not validated", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"syntheticCheck": `{{ root().data.check.metadata.labels["dash0.com/id"] }}`,
+				"dataset":        "default",
+				"action":         "resume",
+			},
+		})
+
+		require.NoError(t, err)
+	})
+}
+
+func Test__PauseResumeSyntheticCheck__Execute(t *testing.T) {
+	component := PauseResumeSyntheticCheck{}
+
+	execute := func(t *testing.T, action string, response string) (*contexts.HTTPContext, *contexts.ExecutionStateContext) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(response))},
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"syntheticCheck": "check-123",
+				"dataset":        "production",
+				"action":         action,
+			},
+			HTTP: httpContext,
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{
+					"apiToken": "token123",
+					"baseURL":  "https://api.us-west-2.aws.dash0.com",
+				},
+			},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		return httpContext, executionState
+	}
+
+	t.Run("pause disables the check and emits the new state", func(t *testing.T) {
+		httpContext, executionState := execute(t, "pause", `{"metadata":{"name":"checkout"},"spec":{"display":{"name":"Checkout API"},"enabled":false}}`)
+
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, http.MethodPatch, httpContext.Requests[0].Method)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"spec":{"enabled":false}}`, string(body))
+
+		assert.True(t, executionState.Passed)
+		assert.Equal(t, "dash0.syntheticCheck.paused", executionState.Type)
+		require.Len(t, executionState.Payloads, 1)
+		assert.Equal(t, map[string]any{
+			"id":      "check-123",
+			"name":    "Checkout API",
+			"dataset": "production",
+			"enabled": false,
+		}, executionState.Payloads[0].(map[string]any)["data"])
+	})
+
+	t.Run("resume enables the check and emits the new state", func(t *testing.T) {
+		httpContext, executionState := execute(t, "resume", `{"metadata":{"name":"checkout"},"spec":{"enabled":true}}`)
+
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"spec":{"enabled":true}}`, string(body))
+
+		assert.Equal(t, "dash0.syntheticCheck.resumed", executionState.Type)
+		data := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, true, data["enabled"])
+		assert.Equal(t, "checkout", data["name"])
+	})
+}
@@ -97,6 +97,24 @@ func FetchSyntheticCheckMetrics(ctx core.ExecutionContext, client *Client, datas
 }
 
 func fetchLastSyntheticCheckOutcome(client *Client, dataset, checkID string) string {
+	result, err := fetchLatestSyntheticCheckResult(client, dataset, checkID)
+	if err != nil || result == nil {
+		return ""
+	}
+
+	return result.Outcome
+}
+
+// SyntheticCheckResult is the outcome of the most recent synthetic check run.
+type SyntheticCheckResult struct {
+	Outcome string
+	// Timestamp is the Unix time (in seconds) of the sample that reported the run.
+	Timestamp float64
+}
+
+// fetchLatestSyntheticCheckResult returns the most recent run outcome of a synthetic check
+// and when it was reported. It returns nil when the check has no recent runs.
+func fetchLatestSyntheticCheckResult(client *Client, dataset, checkID string) (*SyntheticCheckResult, error) {
 	query := fmt.Sprintf(
 		`topk(1, max by (dash0_synthetic_check_outcome) (timestamp({otel_metric_name="dash0.synthetic_check.runs", dash0_check_id="%s"})))`,
 		checkID,
@@ -104,19 +122,28 @@ func fetchLastSyntheticCheckOutcome(client *Client, dataset, checkID string) str
 
 	result, err := client.ExecutePrometheusInstantQuery(query, dataset)
 	if err != nil {
-		return ""
+		return nil, err
 	}
 
 	data, ok := result["data"].(PrometheusResponseData)
 	if !ok || len(data.Result) == 0 {
-		return ""
+		return nil, nil
 	}
 	outcome := data.Result[0].Metric["dash0_synthetic_check_outcome"]
 	if outcome != "Healthy" && outcome != "Degraded" && outcome != "Critical" {
-		return ""
+		return nil, nil
+	}
+
+	latest := &SyntheticCheckResult{Outcome: outcome}
+	if len(data.Result[0].Value) >= 2 {
+		if valStr, ok := data.Result[0].Value[1].(string); ok {
+			if ts, err := strconv.ParseFloat(valStr, 64); err == nil {
+				latest.Timestamp = ts
+			}
+		}
 	}
 
-	return outcome
+	return latest, nil
 }
 
 // queryInstantScalar executes a Prometheus instant query and returns the scalar result value.
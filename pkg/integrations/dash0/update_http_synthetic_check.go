@@ -21,6 +21,7 @@ type UpdateHTTPSyntheticCheckSpec struct {
 	Dataset    string           `mapstructure:"dataset"`
 	Request    RequestSpec      `mapstructure:"request"`
 	Schedule   ScheduleSpec     `mapstructure:"schedule"`
+	Presets    []string         `mapstructure:"assertionPresets"`
 	Assertions *[]AssertionSpec `mapstructure:"assertions"`
 	Retries    *RetrySpec       `mapstructure:"retries"`
 }
@@ -44,7 +45,7 @@ func (c *UpdateHTTPSyntheticCheck) Documentation() string {
 
 - **Check ID**: The Dash0 synthetic check ID to update (required).
 - **Dataset**: The dataset the check belongs to (defaults to "default").
-- **Name**, **Request**, **Schedule**, **Assertions**, **Retries**: Same as Create HTTP Synthetic Check; the full spec is sent to replace the existing check.`
+- **Name**, **Request**, **Schedule**, **Assertion Presets**, **Assertions**, **Retries**: Same as Create HTTP Synthetic Check; the full spec is sent to replace the existing check.`
 }
 
 func (c *UpdateHTTPSyntheticCheck) Icon() string {
@@ -119,6 +120,7 @@ func (c *UpdateHTTPSyntheticCheck) Configuration() []configuration.Field {
 				},
 			},
 		},
+		assertionPresetsField(),
 		{
 			Name:        "assertions",
 			Label:       "Assertions",
@@ -183,7 +185,11 @@ func (c *UpdateHTTPSyntheticCheck) Setup(ctx core.SetupContext) error {
 		return errors.New("at least one location is required")
 	}
 
-	return nil
+	if err := validateAssertionPresets(spec.Presets); err != nil {
+		return err
+	}
+
+	return validateAssertions(spec.Assertions)
 }
 
 func (c *UpdateHTTPSyntheticCheck) Execute(ctx core.ExecutionContext) error {
@@ -207,7 +213,7 @@ func (c *UpdateHTTPSyntheticCheck) Execute(ctx core.ExecutionContext) error {
 		spec.Name,
 		spec.Request,
 		spec.Schedule,
-		BuildSyntheticCheckAssertions(spec.Assertions),
+		BuildSyntheticCheckAssertions(spec.Presets, spec.Assertions),
 		spec.Retries,
 	)
 
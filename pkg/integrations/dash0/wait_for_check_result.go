@@ -0,0 +1,285 @@
+package dash0
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	ChannelNamePassed = "passed"
+	ChannelNameFailed = "failed"
+
+	WaitForCheckResultPayloadType    = "dash0.syntheticCheck.result"
+	WaitForCheckResultPollInterval   = 15 * time.Second
+	WaitForCheckResultDefaultTimeout = 600
+	WaitForCheckResultMinTimeout     = 60
+	WaitForCheckResultMaxTimeout     = 3600
+)
+
+type WaitForCheckResult struct{}
+
+type WaitForCheckResultSpec struct {
+	SyntheticCheck string `mapstructure:"syntheticCheck"`
+	Dataset        string `mapstructure:"dataset"`
+	Timeout        int    `mapstructure:"timeout"`
+}
+
+type WaitForCheckResultMetadata struct {
+	SyntheticCheck string `json:"syntheticCheck" mapstructure:"syntheticCheck"`
+	Dataset        string `json:"dataset" mapstructure:"dataset"`
+	StartedAt      int64  `json:"startedAt" mapstructure:"startedAt"`
+	Timeout        int    `json:"timeout" mapstructure:"timeout"`
+}
+
+func (c *WaitForCheckResult) Name() string {
+	return "dash0.waitForCheckResult"
+}
+
+func (c *WaitForCheckResult) Label() string {
+	return "Wait for Synthetic Check Result"
+}
+
+func (c *WaitForCheckResult) Description() string {
+	return "Wait for the next run of a Dash0 synthetic check and route on its outcome"
+}
+
+func (c *WaitForCheckResult) Documentation() string {
+	return `The Wait for Synthetic Check Result component waits until a Dash0 synthetic check reports a run that happened after the component started, then routes on its outcome.
+
+## Use Cases
+
+- **Deployment verification**: Create or resume a check after a deploy and gate the workflow on its first probe
+- **Smoke tests**: Block a rollout until the check confirms the new endpoint responds as expected
+
+## Configuration
+
+- **Synthetic Check**: The synthetic check to wait for
+- **Dataset**: The dataset the check belongs to (defaults to "default")
+- **Timeout**: How long to wait for a result, in seconds (60–3600, defaults to 600)
+
+## Output Channels
+
+- **Passed**: The run outcome is "Healthy"
+- **Failed**: The run outcome is "Degraded" or "Critical"
+
+## Output
+
+Returns the check **id**, **dataset**, the run **outcome**, when the run was reported (**reportedAt**) and how long the component waited (**waitedSeconds**).
+
+## Notes
+
+- Results are read from the Dash0 Prometheus API every 15 seconds, so a run can take a little while to show up after it completes
+- Runs reported before the component started are ignored
+- The execution fails if no result arrives before the timeout`
+}
+
+func (c *WaitForCheckResult) Icon() string {
+	return "activity"
+}
+
+func (c *WaitForCheckResult) Color() string {
+	return "blue"
+}
+
+func (c *WaitForCheckResult) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: ChannelNamePassed, Label: "Passed", Description: "The check run was healthy"},
+		{Name: ChannelNameFailed, Label: "Failed", Description: "The check run was degraded or critical"},
+	}
+}
+
+func (c *WaitForCheckResult) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "syntheticCheck",
+			Label:       "Synthetic Check",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The synthetic check to wait for",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "synthetic-check",
+				},
+			},
+		},
+		{
+			Name:        "dataset",
+			Label:       "Dataset",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Default:     "default",
+			Description: "The dataset the synthetic check belongs to",
+		},
+		{
+			Name:        "timeout",
+			Label:       "Timeout",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     WaitForCheckResultDefaultTimeout,
+			Description: "How long to wait for a result, in seconds",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := WaitForCheckResultMinTimeout; return &min }(),
+					Max: func() *int { max := WaitForCheckResultMaxTimeout; return &max }(),
+				},
+			},
+		},
+	}
+}
+
+func (c *WaitForCheckResult) Setup(ctx core.SetupContext) error {
+	spec := WaitForCheckResultSpec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	// Expressions are only resolved at execution time, so only plain check IDs are validated here.
+	if !strings.Contains(spec.SyntheticCheck, "{{") {
+		if err := validateSyntheticCheckID(spec.SyntheticCheck); err != nil {
+			return err
+		}
+	}
+	if strings.TrimSpace(spec.Dataset) == "" {
+		return errors.New("dataset is required")
+	}
+
+	return validateWaitForCheckResultTimeout(spec.Timeout)
+}
+
+func validateWaitForCheckResultTimeout(timeout int) error {
+	if timeout == 0 {
+		return nil
+	}
+
+	if timeout < WaitForCheckResultMinTimeout || timeout > WaitForCheckResultMaxTimeout {
+		return fmt.Errorf("timeout must be between %d and %d seconds", WaitForCheckResultMinTimeout, WaitForCheckResultMaxTimeout)
+	}
+
+	return nil
+}
+
+func (c *WaitForCheckResult) Execute(ctx core.ExecutionContext) error {
+	spec := WaitForCheckResultSpec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	checkID := strings.TrimSpace(spec.SyntheticCheck)
+	if err := validateSyntheticCheckID(checkID); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+	if err := validateWaitForCheckResultTimeout(spec.Timeout); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	dataset := spec.Dataset
+	if dataset == "" {
+		dataset = "default"
+	}
+
+	timeout := spec.Timeout
+	if timeout == 0 {
+		timeout = WaitForCheckResultDefaultTimeout
+	}
+
+	err = ctx.Metadata.Set(WaitForCheckResultMetadata{
+		SyntheticCheck: checkID,
+		Dataset:        dataset,
+		StartedAt:      time.Now().Unix(),
+		Timeout:        timeout,
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, WaitForCheckResultPollInterval)
+}
+
+func (c *WaitForCheckResult) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *WaitForCheckResult) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *WaitForCheckResult) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *WaitForCheckResult) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *WaitForCheckResult) Hooks() []core.Hook {
+	return []core.Hook{
+		{Name: "poll", Type: core.HookTypeInternal},
+	}
+}
+
+func (c *WaitForCheckResult) HandleHook(ctx core.ActionHookContext) error {
+	if ctx.Name == "poll" {
+		return c.poll(ctx)
+	}
+	return fmt.Errorf("unknown hook: %s", ctx.Name)
+}
+
+func (c *WaitForCheckResult) poll(ctx core.ActionHookContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	var metadata WaitForCheckResultMetadata
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %v", err)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	// Query errors are treated like a missing result: the next poll retries until the timeout.
+	result, err := fetchLatestSyntheticCheckResult(client, metadata.Dataset, metadata.SyntheticCheck)
+	if err == nil && result != nil && int64(math.Floor(result.Timestamp)) >= metadata.StartedAt {
+		return c.emitResult(ctx, metadata, result)
+	}
+
+	if time.Now().Unix()-metadata.StartedAt >= int64(metadata.Timeout) {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("timed out after %d seconds waiting for a result of synthetic check %s", metadata.Timeout, metadata.SyntheticCheck))
+	}
+
+	return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, WaitForCheckResultPollInterval)
+}
+
+func (c *WaitForCheckResult) emitResult(ctx core.ActionHookContext, metadata WaitForCheckResultMetadata, result *SyntheticCheckResult) error {
+	channel := ChannelNameFailed
+	if result.Outcome == "Healthy" {
+		channel = ChannelNamePassed
+	}
+
+	reportedAt := time.Unix(int64(math.Floor(result.Timestamp)), 0).UTC()
+
+	return ctx.ExecutionState.Emit(
+		channel,
+		WaitForCheckResultPayloadType,
+		[]any{map[string]any{
+			"id":            metadata.SyntheticCheck,
+			"dataset":       metadata.Dataset,
+			"outcome":       result.Outcome,
+			"reportedAt":    reportedAt.Format(time.RFC3339),
+			"waitedSeconds": reportedAt.Unix() - metadata.StartedAt,
+		}},
+	)
+}
@@ -0,0 +1,224 @@
+package dash0
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__WaitForCheckResult__Setup(t *testing.T) {
+	component := WaitForCheckResult{}
+
+	setup := func(configuration map[string]any) error {
+		return component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: configuration,
+		})
+	}
+
+	t.Run("syntheticCheck is required", func(t *testing.T) {
+		err := setup(map[string]any{"syntheticCheck": "  ", "dataset": "default"})
+		require.ErrorContains(t, err, "syntheticCheck is required")
+	})
+
+	t.Run("syntheticCheck cannot change the API path", func(t *testing.T) {
+		err := setup(map[string]any{"syntheticCheck": "../checks", "dataset": "default"})
+		require.ErrorContains(t, err, "invalid syntheticCheck")
+	})
+
+	t.Run("timeout below minimum", func(t *testing.T) {
+		err := setup(map[string]any{"syntheticCheck": "check-123", "dataset": "default", "timeout": 30})
+		require.ErrorContains(t, err, "timeout must be between 60 and 3600 seconds")
+	})
+
+	t.Run("timeout above maximum", func(t *testing.T) {
+		err := setup(map[string]any{"syntheticCheck": "check-123", "dataset": "default", "timeout": 7200})
+		require.ErrorContains(t, err, "timeout must be between 60 and 3600 seconds")
+	})
+
+	t.Run("expression syntheticCheck is accepted", func(t *testing.T) {
+		err := setup(map[string]any{"syntheticCheck": "{{ $['Create Check'].data.id }}", "dataset": "default"})
+		require.NoError(t, err)
+	})
+
+	t.Run("valid setup", func(t *testing.T) {
+		err := setup(map[string]any{"syntheticCheck": "check-123", "dataset": "default", "timeout": 300})
+		require.NoError(t, err)
+	})
+}
+
+func Test__WaitForCheckResult__Execute(t *testing.T) {
+	component := WaitForCheckResult{}
+
+	metadataCtx := &contexts.MetadataContext{}
+	requestCtx := &contexts.RequestContext{}
+	httpContext := &contexts.HTTPContext{}
+	err := component.Execute(core.ExecutionContext{
+		Configuration: map[string]any{
+			"syntheticCheck": " check-123 ",
+			"dataset":        "production",
+		},
+		HTTP:           httpContext,
+		Integration:    &contexts.IntegrationContext{},
+		ExecutionState: &contexts.ExecutionStateContext{},
+		Metadata:       metadataCtx,
+		Requests:       requestCtx,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "poll", requestCtx.Action)
+	assert.Equal(t, WaitForCheckResultPollInterval, requestCtx.Duration)
+	assert.Empty(t, httpContext.Requests)
+
+	metadata, ok := metadataCtx.Metadata.(WaitForCheckResultMetadata)
+	require.True(t, ok)
+	assert.Equal(t, "check-123", metadata.SyntheticCheck)
+	assert.Equal(t, "production", metadata.Dataset)
+	assert.Equal(t, WaitForCheckResultDefaultTimeout, metadata.Timeout)
+	assert.NotZero(t, metadata.StartedAt)
+}
+
+func Test__WaitForCheckResult__HandleHook(t *testing.T) {
+	component := WaitForCheckResult{}
+	integration := &contexts.IntegrationContext{
+		Configuration: map[string]any{
+			"apiToken": "token123",
+			"baseURL":  "https://api.us-west-2.aws.dash0.com",
+		},
+	}
+
+	metadata := func(startedAt int64) *contexts.MetadataContext {
+		return &contexts.MetadataContext{
+			Metadata: map[string]any{
+				"syntheticCheck": "check-123",
+				"dataset":        "default",
+				"startedAt":      startedAt,
+				"timeout":        600,
+			},
+		}
+	}
+
+	latestRun := func(outcome string, timestamp int64) *contexts.HTTPContext {
+		return &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(fmt.Sprintf(`{
+						"status": "success",
+						"data": {
+							"resultType": "vector",
+							"result": [
+								{
+									"metric": {"dash0_synthetic_check_outcome": "%s"},
+									"value": [%d, "%d.123"]
+								}
+							]
+						}
+					}`, outcome, timestamp, timestamp))),
+				},
+			},
+		}
+	}
+
+	t.Run("healthy result arrives -> emits on passed channel", func(t *testing.T) {
+		startedAt := time.Now().Add(-time.Minute).Unix()
+		reportedAt := startedAt + 42
+		httpContext := latestRun("Healthy", reportedAt)
+		execCtx := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           "poll",
+			HTTP:           httpContext,
+			Integration:    integration,
+			Metadata:       metadata(startedAt),
+			ExecutionState: execCtx,
+			Requests:       &contexts.RequestContext{},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, execCtx.Passed)
+		assert.Equal(t, ChannelNamePassed, execCtx.Channel)
+		assert.Equal(t, WaitForCheckResultPayloadType, execCtx.Type)
+
+		require.Len(t, httpContext.Requests, 1)
+		body, _ := io.ReadAll(httpContext.Requests[0].Body)
+		assert.Contains(t, string(body), "check-123")
+
+		require.Len(t, execCtx.Payloads, 1)
+		payload := execCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "check-123", payload["id"])
+		assert.Equal(t, "Healthy", payload["outcome"])
+		assert.Equal(t, time.Unix(reportedAt, 0).UTC().Format(time.RFC3339), payload["reportedAt"])
+		assert.Equal(t, int64(42), payload["waitedSeconds"])
+	})
+
+	t.Run("critical result arrives -> emits on failed channel", func(t *testing.T) {
+		startedAt := time.Now().Add(-time.Minute).Unix()
+		execCtx := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           "poll",
+			HTTP:           latestRun("Critical", startedAt+10),
+			Integration:    integration,
+			Metadata:       metadata(startedAt),
+			ExecutionState: execCtx,
+			Requests:       &contexts.RequestContext{},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, execCtx.Passed)
+		assert.Equal(t, ChannelNameFailed, execCtx.Channel)
+	})
+
+	t.Run("only a result from before the start -> polls again", func(t *testing.T) {
+		startedAt := time.Now().Add(-time.Minute).Unix()
+		requestCtx := &contexts.RequestContext{}
+		execCtx := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           "poll",
+			HTTP:           latestRun("Healthy", startedAt-300),
+			Integration:    integration,
+			Metadata:       metadata(startedAt),
+			ExecutionState: execCtx,
+			Requests:       requestCtx,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, execCtx.Finished)
+		assert.Equal(t, "poll", requestCtx.Action)
+	})
+
+	t.Run("no result before the timeout -> fails execution", func(t *testing.T) {
+		startedAt := time.Now().Add(-11 * time.Minute).Unix()
+		requestCtx := &contexts.RequestContext{}
+		execCtx := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name: "poll",
+			HTTP: &contexts.HTTPContext{
+				Responses: []*http.Response{
+					{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(`{"status": "success", "data": {"resultType": "vector", "result": []}}`)),
+					},
+				},
+			},
+			Integration:    integration,
+			Metadata:       metadata(startedAt),
+			ExecutionState: execCtx,
+			Requests:       requestCtx,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, execCtx.Finished)
+		assert.False(t, execCtx.Passed)
+		assert.Equal(t, "timed out after 600 seconds waiting for a result of synthetic check check-123", execCtx.FailureMessage)
+		assert.Empty(t, requestCtx.Action)
+	})
+}
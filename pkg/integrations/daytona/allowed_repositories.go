@@ -0,0 +1,128 @@
+package daytona
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const ConfigNameAllowedRepositories = "allowedRepositories"
+
+// AllowedRepositories returns the repository patterns allowed by the
+// integration's repository allowlist. An empty result means no restriction.
+func AllowedRepositories(integration core.IntegrationContext) []string {
+	if integration == nil {
+		return nil
+	}
+
+	raw, err := integration.GetConfig(ConfigNameAllowedRepositories)
+	if err != nil {
+		return nil
+	}
+
+	return parseAllowedRepositories(string(raw))
+}
+
+func parseAllowedRepositories(raw string) []string {
+	var patterns []string
+	for _, part := range strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == '\n' || r == ' ' || r == '\t'
+	}) {
+		if pattern := normalizeRepositoryPattern(part); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	return patterns
+}
+
+/*
+ * Repositories are compared as host/owner/name, so HTTPS and SSH URLs
+ * of the same repository match the same patterns.
+ * SCP-style URLs are split the way git does: the host is everything
+ * before the first ":", without the "user@" prefix.
+ */
+func normalizeRepository(repository string) string {
+	repository = strings.TrimSpace(repository)
+
+	if isURIStyleRepository(repository) {
+		if parsed, err := url.Parse(repository); err == nil {
+			repository = parsed.Hostname() + parsed.Path
+		}
+	} else if isSCPStyleRepository(repository) {
+		userAndHost, repositoryPath, _ := strings.Cut(repository, ":")
+		host := userAndHost
+		if at := strings.LastIndex(userAndHost, "@"); at >= 0 {
+			host = userAndHost[at+1:]
+		}
+		repository = host + "/" + strings.TrimPrefix(repositoryPath, "/")
+	}
+
+	repository = strings.TrimSuffix(strings.TrimSuffix(repository, "/"), ".git")
+	return strings.ToLower(repository)
+}
+
+func normalizeRepositoryPattern(pattern string) string {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return ""
+	}
+
+	// A trailing slash marks a prefix pattern, e.g. github.com/acme/.
+	if strings.HasSuffix(pattern, "/") {
+		return normalizeRepository(pattern) + "/"
+	}
+
+	return normalizeRepository(pattern)
+}
+
+// isRepositoryAllowed matches a repository against exact, prefix ("github.com/acme/")
+// and glob ("github.com/acme/service-*") patterns.
+func isRepositoryAllowed(patterns []string, repository string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	repository = normalizeRepository(repository)
+
+	//
+	// Dot segments are resolved by git and curl after the check,
+	// e.g. github.com/acme/../evil/repo fetches github.com/evil/repo.
+	//
+	if hasDotSegments(repository) {
+		return false
+	}
+
+	for _, pattern := range patterns {
+		if strings.HasSuffix(pattern, "/") && strings.HasPrefix(repository, pattern) {
+			return true
+		}
+
+		if matched, err := path.Match(pattern, repository); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasDotSegments(repository string) bool {
+	for _, segment := range strings.Split(repository, "/") {
+		if segment == "." || segment == ".." {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkRepositoryAllowed(integration core.IntegrationContext, repository string) error {
+	if isRepositoryAllowed(AllowedRepositories(integration), repository) {
+		return nil
+	}
+
+	return fmt.Errorf("repository %q is not in the integration's allowed repositories", strings.TrimSpace(repository))
+}
@@ -0,0 +1,60 @@
+package daytona
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__AllowedRepositories(t *testing.T) {
+	t.Run("missing or empty configuration allows everything", func(t *testing.T) {
+		assert.Empty(t, AllowedRepositories(nil))
+		assert.Empty(t, AllowedRepositories(&contexts.IntegrationContext{Configuration: map[string]any{}}))
+		assert.Empty(t, AllowedRepositories(&contexts.IntegrationContext{Configuration: map[string]any{"allowedRepositories": " \n "}}))
+		assert.True(t, isRepositoryAllowed(nil, "https://github.com/anyone/anything.git"))
+	})
+
+	t.Run("patterns are normalized", func(t *testing.T) {
+		integration := &contexts.IntegrationContext{Configuration: map[string]any{
+			"allowedRepositories": "https://GitHub.com/acme/\ngit@github.com:partner/service-*.git, github.com/tools/cli",
+		}}
+
+		assert.Equal(t, []string{
+			"github.com/acme/",
+			"github.com/partner/service-*",
+			"github.com/tools/cli",
+		}, AllowedRepositories(integration))
+	})
+}
+
+func Test__IsRepositoryAllowed(t *testing.T) {
+	patterns := parseAllowedRepositories("github.com/acme/\ngithub.com/partner/service-*\ngithub.com/tools/cli")
+
+	cases := map[string]bool{
+		"https://github.com/acme/api.git":             true,
+		"git@github.com:acme/api.git":                 true,
+		"ssh://git@github.com/acme/web":               true,
+		"https://github.com/partner/service-billing":  true,
+		"https://github.com/tools/cli.git":            true,
+		"https://github.com/tools/cli-extras.git":     false,
+		"https://github.com/partner/website.git":      false,
+		"https://github.com/acme-evil/api.git":        false,
+		"https://gitlab.com/acme/api.git":             false,
+		"https://github.com/partner/service-a/nested": false,
+
+		// Dot segments cannot escape a prefix pattern.
+		"https://github.com/acme/../evil/repo.git": false,
+		"https://github.com/acme/%2e%2e/evil/repo": false,
+		"git@github.com:acme/../evil/repo.git":     false,
+		"https://github.com/acme/./api.git":        false,
+
+		// The SCP host is the text before the first ":", as git parses it.
+		"evil.com:x/@github.com/acme/repo":         false,
+		"git@evil.com:x/@github.com/acme/repo.git": false,
+	}
+
+	for repository, expected := range cases {
+		assert.Equal(t, expected, isRepositoryAllowed(patterns, repository), repository)
+	}
+}
@@ -2,15 +2,19 @@ package daytona
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"path"
+	"time"
 
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
 )
 
 const defaultBaseURL = "https://app.daytona.io/api"
@@ -36,17 +40,23 @@ func NewClient(httpClient core.HTTPContext, ctx core.IntegrationContext) (*Clien
 		baseURL = string(customURL)
 	}
 
+	httpClient, err = registry.WithIntegrationTLS(httpClient, registry.IntegrationTLSOptionsFromConfig(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure TLS: %v", err)
+	}
+
 	return &Client{
 		APIKey:  string(apiKey),
 		BaseURL: baseURL,
-		http:    httpClient,
+		http:    registry.InstrumentIntegrationHTTP("daytona", httpClient),
 	}, nil
 }
 
 // Sandbox represents a Daytona sandbox environment
 type Sandbox struct {
-	ID    string `json:"id"`
-	State string `json:"state"`
+	ID     string            `json:"id"`
+	State  string            `json:"state"`
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // CreateSandboxRequest represents the request to create a sandbox
@@ -55,7 +65,7 @@ type CreateSandboxRequest struct {
 	Target           string            `json:"target,omitempty"`
 	Env              map[string]string `json:"env,omitempty"`
 	Labels           map[string]string `json:"labels,omitempty"`
-	AutoStopInterval int               `json:"autoStopInterval,omitempty"`
+	AutoStopInterval *int              `json:"autoStopInterval,omitempty"`
 }
 
 // ExecuteCodeRequest represents the request to execute code in a sandbox
@@ -69,6 +79,7 @@ type ExecuteCodeRequest struct {
 type ExecuteCodeResponse struct {
 	ExitCode int    `json:"exitCode"`
 	Result   string `json:"result"`
+	Stage    string `json:"stage,omitempty"`
 }
 
 // ExecuteCommandRequest represents the request to execute a command in a sandbox
@@ -101,22 +112,34 @@ type Snapshot struct {
 
 // PaginatedSnapshots represents a paginated list of snapshots
 type PaginatedSnapshots struct {
-	Items []Snapshot `json:"items"`
+	Items      []Snapshot `json:"items"`
+	Page       int        `json:"page"`
+	TotalPages int        `json:"totalPages"`
 }
 
-// ListSnapshots lists available snapshots
+// snapshotsPageSize is the largest page the snapshots endpoint accepts.
+const snapshotsPageSize = 100
+
+// ListSnapshots lists available snapshots, following pagination.
 func (c *Client) ListSnapshots() ([]Snapshot, error) {
-	responseBody, err := c.execRequest(http.MethodGet, c.BaseURL+"/snapshots", nil)
-	if err != nil {
-		return nil, err
-	}
+	var snapshots []Snapshot
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/snapshots?page=%d&limit=%d", c.BaseURL, page, snapshotsPageSize)
+		responseBody, err := c.execRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
 
-	var result PaginatedSnapshots
-	if err := json.Unmarshal(responseBody, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal snapshots response: %v", err)
-	}
+		var result PaginatedSnapshots
+		if err := json.Unmarshal(responseBody, &result); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal snapshots response: %v", err)
+		}
 
-	return result.Items, nil
+		snapshots = append(snapshots, result.Items...)
+		if len(result.Items) == 0 || page >= result.TotalPages {
+			return snapshots, nil
+		}
+	}
 }
 
 type PaginatedSandboxes struct {
@@ -188,9 +211,24 @@ type SignedPreviewURL struct {
 	URL       string `json:"url"`
 }
 
+// fetchConfigRetryPolicy retries transient failures when fetching /api/config,
+// which every toolbox call depends on.
+var fetchConfigRetryPolicy = core.RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+	Jitter:      0.2,
+	Retryable:   isRetryableRequestError,
+}
+
 // FetchConfig fetches the API configuration from the /api/config endpoint
 func (c *Client) FetchConfig() (*APIConfig, error) {
-	responseBody, err := c.execRequest(http.MethodGet, c.BaseURL+"/config", nil)
+	var responseBody []byte
+	err := core.Retry(context.Background(), fetchConfigRetryPolicy, func(ctx context.Context) error {
+		var err error
+		responseBody, err = c.execRequest(http.MethodGet, c.BaseURL+"/config", nil)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch config: %v", err)
 	}
@@ -462,6 +500,29 @@ func (c *Client) UploadFile(sandboxID, filePath string, content []byte) error {
 	return err
 }
 
+// DownloadFile downloads a file from the sandbox filesystem.
+func (c *Client) DownloadFile(sandboxID, filePath string) ([]byte, error) {
+	baseURL, err := c.toolboxBaseURL(sandboxID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve toolbox URL: %v", err)
+	}
+
+	url := fmt.Sprintf("%s/files/download?path=%s", baseURL, url.QueryEscape(filePath))
+	return c.execRequest(http.MethodGet, url, nil)
+}
+
+// DeleteFile deletes a file from the sandbox filesystem.
+func (c *Client) DeleteFile(sandboxID, filePath string) error {
+	baseURL, err := c.toolboxBaseURL(sandboxID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve toolbox URL: %v", err)
+	}
+
+	url := fmt.Sprintf("%s/files?path=%s", baseURL, url.QueryEscape(filePath))
+	_, err = c.execRequest(http.MethodDelete, url, nil)
+	return err
+}
+
 // GetSandbox retrieves the current state of a sandbox
 func (c *Client) GetSandbox(sandboxID string) (*Sandbox, error) {
 	url := fmt.Sprintf("%s/sandbox/%s", c.BaseURL, sandboxID)
@@ -516,6 +577,36 @@ func (c *Client) GetSignedPreviewURL(sandboxID string, port int, expiresInSecond
 	return &preview, nil
 }
 
+// SandboxLabelsRequest represents the request to replace the labels of a sandbox
+type SandboxLabelsRequest struct {
+	Labels map[string]string `json:"labels"`
+}
+
+// ReplaceLabels replaces all labels of a sandbox and returns the labels it now has
+func (c *Client) ReplaceLabels(sandboxID string, labels map[string]string) (map[string]string, error) {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	body, err := json.Marshal(&SandboxLabelsRequest{Labels: labels})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %v", err)
+	}
+
+	url := fmt.Sprintf("%s/sandbox/%s/labels", c.BaseURL, sandboxID)
+	responseBody, err := c.execRequest(http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	var response SandboxLabelsRequest
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal labels response: %v", err)
+	}
+
+	return response.Labels, nil
+}
+
 // DeleteSandbox deletes a sandbox
 func (c *Client) DeleteSandbox(sandboxID string, force bool) error {
 	url := fmt.Sprintf("%s/sandbox/%s?force=%t", c.BaseURL, sandboxID, force)
@@ -529,6 +620,29 @@ type APIError struct {
 	Message string `json:"message"`
 }
 
+// RequestError is returned when the Daytona API responds with a non-2xx status.
+type RequestError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *RequestError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("API error (%d)", e.StatusCode)
+}
+
+// isRetryableRequestError reports whether a request error is transient:
+// rate limiting, server-side failures, or transport errors.
+func isRetryableRequestError(err error) bool {
+	var requestErr *RequestError
+	if errors.As(err, &requestErr) {
+		return requestErr.StatusCode == http.StatusTooManyRequests || requestErr.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
 func (c *Client) execRequest(method, url string, body io.Reader) ([]byte, error) {
 	return c.execRequestWithContentType(method, url, body, "application/json")
 }
@@ -564,9 +678,9 @@ func (c *Client) execRequestWithContentType(method, url string, body io.Reader,
 		// Try to parse error response for a cleaner message
 		var apiErr APIError
 		if json.Unmarshal(responseBody, &apiErr) == nil && apiErr.Message != "" {
-			return nil, fmt.Errorf("API error (%d): %s", res.StatusCode, apiErr.Message)
+			return nil, &RequestError{StatusCode: res.StatusCode, Message: apiErr.Message}
 		}
-		return nil, fmt.Errorf("API error (%d)", res.StatusCode)
+		return nil, &RequestError{StatusCode: res.StatusCode}
 	}
 
 	return responseBody, nil
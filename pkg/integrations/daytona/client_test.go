@@ -330,6 +330,103 @@ func Test__Client__ListSandboxes(t *testing.T) {
 	})
 }
 
+func Test__Client__ListSnapshots(t *testing.T) {
+	t.Run("single page", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(
+						`{"items":[{"id":"snap-1","name":"default"},{"id":"snap-2","name":"python"}],"page":1,"totalPages":1}`,
+					)),
+				},
+			},
+		}
+
+		appCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"apiKey": "test-api-key",
+			},
+		}
+
+		client, err := NewClient(httpContext, appCtx)
+		require.NoError(t, err)
+
+		snapshots, err := client.ListSnapshots()
+
+		require.NoError(t, err)
+		require.Len(t, snapshots, 2)
+		assert.Equal(t, "default", snapshots[0].Name)
+		assert.Equal(t, "python", snapshots[1].Name)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, http.MethodGet, httpContext.Requests[0].Method)
+		assert.Equal(t, "/api/snapshots", httpContext.Requests[0].URL.Path)
+		assert.Equal(t, "1", httpContext.Requests[0].URL.Query().Get("page"))
+	})
+
+	t.Run("multiple pages -> follows pagination", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(
+						`{"items":[{"id":"snap-1","name":"default"}],"page":1,"totalPages":2}`,
+					)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(
+						`{"items":[{"id":"snap-2","name":"python"}],"page":2,"totalPages":2}`,
+					)),
+				},
+			},
+		}
+
+		appCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"apiKey": "test-api-key",
+			},
+		}
+
+		client, err := NewClient(httpContext, appCtx)
+		require.NoError(t, err)
+
+		snapshots, err := client.ListSnapshots()
+
+		require.NoError(t, err)
+		require.Len(t, snapshots, 2)
+		assert.Equal(t, "snap-1", snapshots[0].ID)
+		assert.Equal(t, "snap-2", snapshots[1].ID)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "1", httpContext.Requests[0].URL.Query().Get("page"))
+		assert.Equal(t, "2", httpContext.Requests[1].URL.Query().Get("page"))
+	})
+
+	t.Run("list snapshots failure -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusUnauthorized,
+					Body:       io.NopCloser(strings.NewReader(`{"message":"unauthorized"}`)),
+				},
+			},
+		}
+
+		appCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"apiKey": "test-api-key",
+			},
+		}
+
+		client, err := NewClient(httpContext, appCtx)
+		require.NoError(t, err)
+
+		_, err = client.ListSnapshots()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "401")
+	})
+}
+
 func Test__Client__ExecuteCommand(t *testing.T) {
 	t.Run("successful command execution", func(t *testing.T) {
 		httpContext := &contexts.HTTPContext{
@@ -368,6 +465,10 @@ func Test__Client__ExecuteCommand(t *testing.T) {
 	t.Run("command execution failure -> error", func(t *testing.T) {
 		httpContext := &contexts.HTTPContext{
 			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"proxyToolboxUrl":"https://app.daytona.io/api/toolbox"}`)),
+				},
 				{
 					StatusCode: http.StatusInternalServerError,
 					Body:       io.NopCloser(strings.NewReader(`{"message":"execution failed"}`)),
@@ -392,6 +493,55 @@ func Test__Client__ExecuteCommand(t *testing.T) {
 	})
 }
 
+func Test__Client__FetchConfig(t *testing.T) {
+	appCtx := &contexts.IntegrationContext{
+		Configuration: map[string]any{
+			"apiKey": "test-api-key",
+		},
+	}
+
+	t.Run("transient failure is retried", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusServiceUnavailable,
+					Body:       io.NopCloser(strings.NewReader(`{"message":"unavailable"}`)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"proxyToolboxUrl":"https://app.daytona.io/api/toolbox"}`)),
+				},
+			},
+		}
+
+		client, err := NewClient(httpContext, appCtx)
+		require.NoError(t, err)
+
+		config, err := client.FetchConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "https://app.daytona.io/api/toolbox", config.ProxyToolboxURL)
+		require.Len(t, httpContext.Requests, 2)
+	})
+
+	t.Run("client error is not retried", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusUnauthorized,
+					Body:       io.NopCloser(strings.NewReader(`{"message":"unauthorized"}`)),
+				},
+			},
+		}
+
+		client, err := NewClient(httpContext, appCtx)
+		require.NoError(t, err)
+
+		_, err = client.FetchConfig()
+		require.ErrorContains(t, err, "API error (401): unauthorized")
+		require.Len(t, httpContext.Requests, 1)
+	})
+}
+
 func Test__Client__ExecuteCode(t *testing.T) {
 	t.Run("successful python code execution", func(t *testing.T) {
 		httpContext := &contexts.HTTPContext{
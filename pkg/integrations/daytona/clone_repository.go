@@ -0,0 +1,315 @@
+package daytona
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const CloneRepositoryPayloadType = "daytona.repository.cloned"
+
+type CloneRepository struct{}
+
+type CloneRepositorySpec struct {
+	Sandbox    string                     `json:"sandbox"`
+	Repository string                     `json:"repository"`
+	Directory  string                     `json:"directory,omitempty"`
+	Token      configuration.SecretKeyRef `json:"token,omitempty"`
+}
+
+type CloneRepositoryPayload struct {
+	SandboxID     string `json:"sandboxId"`
+	Repository    string `json:"repository"`
+	Directory     string `json:"directory"`
+	Authenticated bool   `json:"authenticated"`
+	StartedAt     string `json:"startedAt"`
+	FinishedAt    string `json:"finishedAt"`
+}
+
+func (c *CloneRepository) Name() string {
+	return "daytona.cloneRepository"
+}
+
+func (c *CloneRepository) Label() string {
+	return "Clone Repository"
+}
+
+func (c *CloneRepository) Description() string {
+	return "Clone a Git repository into an existing sandbox"
+}
+
+func (c *CloneRepository) Documentation() string {
+	return `The Clone Repository component clones a Git repository into an existing Daytona sandbox.
+
+## Use Cases
+
+- **Multiple repositories**: Add more repositories to a sandbox created by **Create Sandbox** or **Create Repository Sandbox**
+- **Reusing sandboxes**: Clone a fresh copy of a repository into a long-lived sandbox
+
+## Configuration
+
+- **Sandbox**: The ID or name of the sandbox to clone the repository into
+- **Repository**: HTTPS URL of the repository (e.g. ` + "`https://github.com/owner/repository.git`" + `)
+- **Directory**: Where to clone the repository. Relative paths are resolved from ` + "`" + SandboxHomeDir + "`" + `. Defaults to the repository name in that directory
+- **Token**: Optional secret key holding an access token for private repositories
+
+## Output
+
+Returns:
+- **sandboxId**: The sandbox the repository was cloned into
+- **repository**: The cloned repository URL
+- **directory**: The absolute path of the clone in the sandbox
+- **authenticated**: Whether a token was used for the clone
+- **startedAt** / **finishedAt**: When the clone started and finished
+
+## Notes
+
+- The clone uses the Daytona toolbox Git API, which only supports HTTPS. Use **Create Repository Sandbox** with an SSH deploy key for SSH repositories
+- When the integration has **Allowed Repositories** set, repositories that match none of its patterns are rejected
+- The token is sent as the password with the ` + "`x-access-token`" + ` username, which works for GitHub tokens
+- The execution fails if the clone fails, for example when the token is missing or invalid for a private repository, or the directory already exists`
+}
+
+func (c *CloneRepository) Icon() string {
+	return "daytona"
+}
+
+func (c *CloneRepository) Color() string {
+	return "orange"
+}
+
+func (c *CloneRepository) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CloneRepository) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "sandbox",
+			Label:       "Sandbox",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "Sandbox to clone the repository into",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "sandbox",
+				},
+			},
+		},
+		{
+			Name:        "repository",
+			Label:       "Repository",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "HTTPS URL of the repository to clone",
+			Placeholder: "https://github.com/owner/repository.git",
+		},
+		{
+			Name:        "directory",
+			Label:       "Directory",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Directory to clone into. Relative paths are resolved from " + SandboxHomeDir + ". Defaults to the repository name",
+			Placeholder: "repository",
+		},
+		{
+			Name:        "token",
+			Label:       "Token",
+			Type:        configuration.FieldTypeSecretKey,
+			Required:    false,
+			Description: "Secret key holding an access token used to clone private repositories",
+		},
+	}
+}
+
+func (c *CloneRepository) Setup(ctx core.SetupContext) error {
+	spec := CloneRepositorySpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %v", err)
+	}
+
+	if spec.Sandbox == "" {
+		return fmt.Errorf("sandbox is required")
+	}
+
+	if spec.Repository == "" {
+		return fmt.Errorf("repository is required")
+	}
+
+	//
+	// Repository and directory may be expressions,
+	// so they are only validated here when they are plain values.
+	//
+	if !strings.Contains(spec.Repository, "{{") {
+		if err := validateCloneRepositoryURL(spec.Repository); err != nil {
+			return err
+		}
+
+		if err := checkRepositoryAllowed(ctx.Integration, spec.Repository); err != nil {
+			return err
+		}
+	}
+
+	if spec.Directory != "" && !strings.Contains(spec.Directory, "{{") {
+		if _, err := cloneRepositoryDirectory(spec.Repository, spec.Directory); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *CloneRepository) Execute(ctx core.ExecutionContext) error {
+	spec := CloneRepositorySpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %v", err)
+	}
+
+	repository := strings.TrimSpace(spec.Repository)
+	if err := validateCloneRepositoryURL(repository); err != nil {
+		return err
+	}
+
+	if err := checkRepositoryAllowed(ctx.Integration, repository); err != nil {
+		return err
+	}
+
+	directory, err := cloneRepositoryDirectory(repository, spec.Directory)
+	if err != nil {
+		return err
+	}
+
+	token := ""
+	if spec.Token.IsSet() {
+		value, err := ctx.Secrets.GetKey(spec.Token.Secret, spec.Token.Key)
+		if err != nil {
+			return fmt.Errorf("failed to resolve secret %s/%s: %w", spec.Token.Secret, spec.Token.Key, err)
+		}
+
+		token = strings.TrimSpace(string(value))
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %v", err)
+	}
+
+	startedAt := time.Now().Format(time.RFC3339)
+	if err := client.CloneRepository(spec.Sandbox, newCloneRepositoryRequest(repository, directory, token)); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to clone %s into %s: %v", repository, directory, err))
+	}
+
+	payload := CloneRepositoryPayload{
+		SandboxID:     spec.Sandbox,
+		Repository:    repository,
+		Directory:     directory,
+		Authenticated: token != "",
+		StartedAt:     startedAt,
+		FinishedAt:    time.Now().Format(time.RFC3339),
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		CloneRepositoryPayloadType,
+		[]any{payload},
+	)
+}
+
+func (c *CloneRepository) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CloneRepository) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CloneRepository) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CloneRepository) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *CloneRepository) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *CloneRepository) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+/*
+ * The toolbox Git API only supports username/password authentication,
+ * so only HTTP(S) repository URLs can be cloned with it.
+ */
+func validateCloneRepositoryURL(repository string) error {
+	repository = strings.TrimSpace(repository)
+	if repository == "" {
+		return fmt.Errorf("repository is required")
+	}
+
+	if isSSHRepository(repository) {
+		return fmt.Errorf("repository %q is an SSH URL: use an HTTPS URL", repository)
+	}
+
+	parsed, err := url.Parse(repository)
+	if err != nil {
+		return fmt.Errorf("invalid repository URL %q: %v", repository, err)
+	}
+
+	if parsed.Scheme != "https" && parsed.Scheme != "http" {
+		return fmt.Errorf("repository must be an HTTPS URL, got %q", repository)
+	}
+
+	if parsed.Host == "" {
+		return fmt.Errorf("repository URL %q has no host", repository)
+	}
+
+	if parsed.User != nil {
+		return fmt.Errorf("repository URL must not contain credentials: use the token field instead")
+	}
+
+	if _, err := directoryFromPath(parsed.Path, repository); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// cloneRepositoryDirectory resolves the absolute clone directory in the sandbox.
+func cloneRepositoryDirectory(repository, directory string) (string, error) {
+	directory = strings.TrimSpace(directory)
+	if directory == "" {
+		parsed, err := url.Parse(strings.TrimSpace(repository))
+		if err != nil {
+			return "", fmt.Errorf("failed to parse repository URL: %v", err)
+		}
+
+		name, err := directoryFromPath(parsed.Path, repository)
+		if err != nil {
+			return "", err
+		}
+
+		return path.Join(SandboxHomeDir, name), nil
+	}
+
+	if !path.IsAbs(directory) {
+		directory = path.Join(SandboxHomeDir, directory)
+	}
+
+	directory = path.Clean(directory)
+	if directory == "/" || directory == SandboxHomeDir {
+		return "", fmt.Errorf("directory %q must be a subdirectory", directory)
+	}
+
+	return directory, nil
+}
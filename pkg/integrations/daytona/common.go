@@ -1,6 +1,156 @@
 package daytona
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+)
+
 const (
 	SandboxHomeDir = "/home/daytona"
 	SandboxBaseDir = "/home/daytona/.superplane"
 )
+
+const (
+	SandboxTargetUS    = "us"
+	SandboxTargetEU    = "eu"
+	SandboxTargetLocal = "local"
+	SandboxTargetOther = "other"
+)
+
+var sandboxTargets = []string{SandboxTargetUS, SandboxTargetEU, SandboxTargetLocal}
+
+func keepAliveConfigurationField() configuration.Field {
+	return configuration.Field{
+		Name:        "keepAlive",
+		Label:       "Keep Alive",
+		Type:        configuration.FieldTypeBool,
+		Required:    false,
+		Default:     false,
+		Description: "Disable auto-stop so the sandbox keeps running until it is deleted",
+	}
+}
+
+func autoStopIntervalConfigurationField() configuration.Field {
+	return configuration.Field{
+		Name:        "autoStopInterval",
+		Label:       "Auto Stop Interval",
+		Type:        configuration.FieldTypeNumber,
+		Required:    false,
+		Description: "Time in minutes before the sandbox auto-stops",
+		Default:     15,
+		VisibilityConditions: []configuration.VisibilityCondition{
+			{Field: "keepAlive", Values: []string{"false"}},
+		},
+	}
+}
+
+func validateAutoStop(keepAlive bool, autoStopInterval int) error {
+	if autoStopInterval < 0 {
+		return fmt.Errorf("autoStopInterval cannot be negative")
+	}
+
+	if keepAlive && autoStopInterval > 0 {
+		return fmt.Errorf("autoStopInterval cannot be set when keepAlive is enabled")
+	}
+
+	return nil
+}
+
+/*
+ * Daytona disables auto-stop when autoStopInterval is explicitly 0,
+ * and uses its own default when the field is omitted.
+ */
+func sandboxAutoStopInterval(keepAlive bool, autoStopInterval int) *int {
+	if keepAlive {
+		disabled := 0
+		return &disabled
+	}
+
+	if autoStopInterval <= 0 {
+		return nil
+	}
+
+	return &autoStopInterval
+}
+
+func targetConfigurationField(defaultTarget string) configuration.Field {
+	field := configuration.Field{
+		Name:        "target",
+		Label:       "Target Region",
+		Type:        configuration.FieldTypeSelect,
+		Required:    false,
+		Description: "Target region for the sandbox",
+		TypeOptions: &configuration.TypeOptions{
+			Select: &configuration.SelectTypeOptions{
+				Options: []configuration.FieldOption{
+					{Label: "US", Value: SandboxTargetUS},
+					{Label: "EU", Value: SandboxTargetEU},
+					{Label: "Local", Value: SandboxTargetLocal},
+					{Label: "Other", Value: SandboxTargetOther},
+				},
+			},
+		},
+	}
+
+	if defaultTarget != "" {
+		field.Default = defaultTarget
+	}
+
+	return field
+}
+
+func customTargetConfigurationField() configuration.Field {
+	return configuration.Field{
+		Name:        "customTarget",
+		Label:       "Custom Target",
+		Type:        configuration.FieldTypeString,
+		Required:    false,
+		Placeholder: "e.g. asia",
+		Description: "Target name for regions not listed above",
+		VisibilityConditions: []configuration.VisibilityCondition{
+			{Field: "target", Values: []string{SandboxTargetOther}},
+		},
+		RequiredConditions: []configuration.RequiredCondition{
+			{Field: "target", Values: []string{SandboxTargetOther}},
+		},
+	}
+}
+
+/*
+ * validateTarget only rejects "other" without a custom target.
+ * Targets are matched case-insensitively, and unknown values saved
+ * before the target list existed are kept and sent as custom targets.
+ */
+func validateTarget(target, customTarget string) error {
+	if !strings.EqualFold(strings.TrimSpace(target), SandboxTargetOther) {
+		return nil
+	}
+
+	if strings.TrimSpace(customTarget) == "" {
+		return fmt.Errorf("custom target is required when target is %q", SandboxTargetOther)
+	}
+
+	return nil
+}
+
+/*
+ * sandboxTarget returns the target sent to Daytona.
+ * Known targets are normalized to lowercase, "other" is replaced
+ * with the custom target name, and any other value is sent as is.
+ */
+func sandboxTarget(target, customTarget string) string {
+	target = strings.TrimSpace(target)
+	if strings.EqualFold(target, SandboxTargetOther) {
+		return strings.TrimSpace(customTarget)
+	}
+
+	for _, known := range sandboxTargets {
+		if strings.EqualFold(target, known) {
+			return known
+		}
+	}
+
+	return target
+}
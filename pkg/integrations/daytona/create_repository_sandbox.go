@@ -1,10 +1,13 @@
 package daytona
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"path"
+	"regexp"
 	"strings"
 	"time"
 
@@ -12,6 +15,7 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/models"
 )
 
 const (
@@ -21,44 +25,102 @@ const (
 
 	SandboxBootstrapFromInline = "inline"
 	SandboxBootstrapFromFile   = "file"
+	SandboxBootstrapFromSteps  = "steps"
 
 	repositorySandboxStagePreparingSandbox = "preparingSandbox"
+	repositorySandboxStageCloning          = "cloning"
 	repositorySandboxStageBootstrapping    = "bootstrapping"
 	repositorySandboxStageDone             = "done"
 
 	repositorySandboxInlineBootstrapPath = SandboxBaseDir + "/bootstrap.sh"
+	repositorySandboxBootstrapStepPath   = SandboxBaseDir + "/bootstrap-step-%d.sh"
+
+	/*
+	 * Failure classifications, stored as failureReason in the metadata
+	 * so it is clear which stage of the setup failed. The execution
+	 * itself always fails with the "error" reason.
+	 */
+	CreateRepositorySandboxFailureSandboxFailed   = "sandbox_failed"
+	CreateRepositorySandboxFailureSandboxTimeout  = "sandbox_timeout"
+	CreateRepositorySandboxFailureSetupFailed     = "setup_failed"
+	CreateRepositorySandboxFailureCloneFailed     = "clone_failed"
+	CreateRepositorySandboxFailureBootstrapFailed = "bootstrap_failed"
+
+	RepositoryGitAuthHTTPS  = "https"
+	RepositoryGitAuthSSHKey = "sshKey"
+
+	repositorySandboxDeployKeyPath     = SandboxBaseDir + "/deploy_key"
+	repositorySandboxSSHCloneTimeout   = 300
+	repositorySandboxExecutionKey      = "sandbox_id"
+	repositorySandboxStateUpdatedEvent = "sandbox.state.updated"
+	repositorySandboxStateStarted      = "started"
+	repositorySandboxStateError        = "error"
+
+	// repositorySandboxMaxStartRetries caps how many times a sandbox that
+	// fails to start is re-created.
+	repositorySandboxMaxStartRetries = 5
 )
 
+var bootstrapTemplateVariable = regexp.MustCompile(`\$?\$\{(REPO_DIR|SANDBOX_ID)\}`)
+
 type CreateRepositorySandbox struct{}
 
 type CreateRepositorySandboxSpec struct {
 	Snapshot         string                                `json:"snapshot,omitempty"`
 	Target           string                                `json:"target,omitempty"`
+	CustomTarget     string                                `json:"customTarget,omitempty"`
 	AutoStopInterval int                                   `json:"autoStopInterval,omitempty"`
+	KeepAlive        bool                                  `json:"keepAlive,omitempty"`
 	Env              []EnvVariable                         `json:"env,omitempty"`
 	Secrets          []SandboxSecret                       `json:"secrets,omitempty"`
+	Labels           []SandboxLabel                        `json:"labels,omitempty"`
 	Repository       string                                `json:"repository"`
+	GitAuth          string                                `json:"gitAuth,omitempty"`
+	DeployKey        configuration.SecretKeyRef            `json:"deployKey,omitempty"`
 	Bootstrap        *CreateRepositorySandboxBootstrapSpec `json:"bootstrap"`
+	DeleteOnFailure  bool                                  `json:"deleteOnFailure,omitempty"`
+	StartRetries     int                                   `json:"startRetries,omitempty"`
 }
 
 type CreateRepositorySandboxBootstrapSpec struct {
-	From   string `json:"from,omitempty"`
-	Script string `json:"script,omitempty"`
-	Path   string `json:"path,omitempty"`
-	URL    string `json:"url,omitempty"`
+	From       string              `json:"from,omitempty"`
+	Script     string              `json:"script,omitempty"`
+	Path       string              `json:"path,omitempty"`
+	URL        string              `json:"url,omitempty"`
+	Steps      []BootstrapStepSpec `json:"steps,omitempty"`
+	WorkingDir string              `json:"workingDir,omitempty"`
+}
+
+type BootstrapStepSpec struct {
+	Name   string `json:"name"`
+	Script string `json:"script"`
 }
 
 type CreateRepositorySandboxMetadata struct {
-	Stage            string             `json:"stage" mapstructure:"stage"`
-	SandboxID        string             `json:"sandboxId" mapstructure:"sandboxId"`
-	SandboxStartedAt string             `json:"sandboxStartedAt" mapstructure:"sandboxStartedAt"`
-	SessionID        string             `json:"sessionId" mapstructure:"sessionId"`
-	Timeout          int                `json:"timeout" mapstructure:"timeout"`
-	Repository       string             `json:"repository" mapstructure:"repository"`
-	Directory        string             `json:"directory" mapstructure:"directory"`
-	Secrets          []SandboxSecret    `json:"secrets,omitempty" mapstructure:"secrets,omitempty"`
-	Clone            *CloneMetadata     `json:"clone,omitempty" mapstructure:"clone,omitempty"`
-	Bootstrap        *BootstrapMetadata `json:"bootstrap,omitempty" mapstructure:"bootstrap,omitempty"`
+	Stage            string                      `json:"stage" mapstructure:"stage"`
+	SandboxID        string                      `json:"sandboxId" mapstructure:"sandboxId"`
+	SandboxStartedAt string                      `json:"sandboxStartedAt" mapstructure:"sandboxStartedAt"`
+	SessionID        string                      `json:"sessionId" mapstructure:"sessionId"`
+	Timeout          int                         `json:"timeout" mapstructure:"timeout"`
+	Repository       string                      `json:"repository" mapstructure:"repository"`
+	GitAuth          string                      `json:"gitAuth,omitempty" mapstructure:"gitAuth,omitempty"`
+	DeployKey        *configuration.SecretKeyRef `json:"deployKey,omitempty" mapstructure:"deployKey,omitempty"`
+	Directory        string                      `json:"directory" mapstructure:"directory"`
+	DeleteOnFailure  bool                        `json:"deleteOnFailure,omitempty" mapstructure:"deleteOnFailure,omitempty"`
+	StartRetries     int                         `json:"startRetries,omitempty" mapstructure:"startRetries,omitempty"`
+	StartAttempts    int                         `json:"startAttempts,omitempty" mapstructure:"startAttempts,omitempty"`
+	Secrets          []SandboxSecret             `json:"secrets,omitempty" mapstructure:"secrets,omitempty"`
+	Labels           map[string]string           `json:"labels,omitempty" mapstructure:"labels,omitempty"`
+	Clone            *CloneMetadata              `json:"clone,omitempty" mapstructure:"clone,omitempty"`
+	Bootstrap        *BootstrapMetadata          `json:"bootstrap,omitempty" mapstructure:"bootstrap,omitempty"`
+	FailureReason    string                      `json:"failureReason,omitempty" mapstructure:"failureReason,omitempty"`
+}
+
+type SandboxStateWebhookPayload struct {
+	Event    string `json:"event"`
+	ID       string `json:"id"`
+	OldState string `json:"oldState"`
+	NewState string `json:"newState"`
 }
 
 type CloneMetadata struct {
@@ -68,15 +130,32 @@ type CloneMetadata struct {
 }
 
 type BootstrapMetadata struct {
-	CmdID      string  `json:"cmdId" mapstructure:"cmdId"`
-	StartedAt  string  `json:"startedAt" mapstructure:"startedAt"`
-	FinishedAt string  `json:"finishedAt" mapstructure:"finishedAt"`
-	ExitCode   int     `json:"exitCode" mapstructure:"exitCode"`
-	Result     string  `json:"result" mapstructure:"result"`
-	From       string  `json:"from" mapstructure:"from"`
-	Script     *string `json:"script,omitempty" mapstructure:"script,omitempty"`
-	Path       *string `json:"path,omitempty" mapstructure:"path,omitempty"`
-	URL        *string `json:"url,omitempty" mapstructure:"url,omitempty"`
+	CmdID       string                  `json:"cmdId" mapstructure:"cmdId"`
+	StartedAt   string                  `json:"startedAt" mapstructure:"startedAt"`
+	FinishedAt  string                  `json:"finishedAt" mapstructure:"finishedAt"`
+	ExitCode    int                     `json:"exitCode" mapstructure:"exitCode"`
+	Result      string                  `json:"result" mapstructure:"result"`
+	From        string                  `json:"from" mapstructure:"from"`
+	Script      *string                 `json:"script,omitempty" mapstructure:"script,omitempty"`
+	Path        *string                 `json:"path,omitempty" mapstructure:"path,omitempty"`
+	URL         *string                 `json:"url,omitempty" mapstructure:"url,omitempty"`
+	Steps       []BootstrapStepMetadata `json:"steps,omitempty" mapstructure:"steps,omitempty"`
+	CurrentStep int                     `json:"currentStep,omitempty" mapstructure:"currentStep,omitempty"`
+	WorkingDir  string                  `json:"workingDir,omitempty" mapstructure:"workingDir,omitempty"`
+
+	// LogsFetchedAt is set when the logs were refreshed through the getLogs action.
+	LogsFetchedAt string `json:"logsFetchedAt,omitempty" mapstructure:"logsFetchedAt,omitempty"`
+}
+
+type BootstrapStepMetadata struct {
+	Name       string `json:"name" mapstructure:"name"`
+	Script     string `json:"script" mapstructure:"script"`
+	Path       string `json:"path,omitempty" mapstructure:"path,omitempty"`
+	CmdID      string `json:"cmdId,omitempty" mapstructure:"cmdId,omitempty"`
+	StartedAt  string `json:"startedAt,omitempty" mapstructure:"startedAt,omitempty"`
+	FinishedAt string `json:"finishedAt,omitempty" mapstructure:"finishedAt,omitempty"`
+	ExitCode   int    `json:"exitCode" mapstructure:"exitCode"`
+	Result     string `json:"result,omitempty" mapstructure:"result,omitempty"`
 }
 
 func (c *CreateRepositorySandbox) Name() string {
@@ -103,8 +182,18 @@ func (c *CreateRepositorySandbox) Documentation() string {
 ## Notes
 
 - The component waits for the sandbox to reach the "started" state
+- Sandbox state webhooks sent to the node webhook URL advance the execution immediately; polling remains as a fallback
 - Clone and bootstrap run sequentially in the same session
-- If clone or bootstrap fails, the component returns an error`
+- Bootstrap can run a single script, or an ordered list of named steps. Steps run one after another in the same session, and the first failing step fails the component
+- Bootstrap runs from the repository root by default. Set **Working Directory** to a path relative to the repository root (e.g. a package in a monorepo) to run it from there instead
+- Inline bootstrap scripts and bootstrap steps can use ` + "`${REPO_DIR}`" + ` (the directory the repository was cloned into) and ` + "`${SANDBOX_ID}`" + ` (the sandbox ID). They are replaced before the script is uploaded. Write ` + "`$${REPO_DIR}`" + ` to keep the text as is
+- If the sandbox, clone, or bootstrap fails, the execution fails and the ` + "`failureReason`" + ` in its metadata tells them apart: ` + "`sandbox_failed`" + `, ` + "`sandbox_timeout`" + `, ` + "`setup_failed`" + `, ` + "`clone_failed`" + ` or ` + "`bootstrap_failed`" + `
+- **Labels** are set on the sandbox when it is created and included in the output, which makes long-lived sandboxes easier to find later
+- Repositories are cloned over HTTPS by default, using a ` + "`GITHUB_TOKEN`" + ` env-var secret when one is set. Set **Git Authentication** to **SSH deploy key** to clone an SSH URL (e.g. ` + "`git@github.com:owner/repository.git`" + `) with a private key from a secret
+- When the integration has **Allowed Repositories** set, repositories that match none of its patterns are rejected before the sandbox is created
+- Use the **getLogs** action to refresh the bootstrap logs of a finished execution. Logs are fetched again from the sandbox session if it still exists; otherwise the stored logs are kept
+- Set **Start retries** to re-create the sandbox when it fails to start, which is often a transient error. The failed sandbox is deleted before a new one is created, and the execution fails with the ` + "`sandbox_failed`" + ` failure reason once all retries are used
+- Enable **Delete on failure** to delete the sandbox when the component fails, so it does not count against your quota. It is off by default to keep failed sandboxes around for debugging`
 }
 
 func (c *CreateRepositorySandbox) Icon() string {
@@ -135,23 +224,10 @@ func (c *CreateRepositorySandbox) Configuration() []configuration.Field {
 			Description: "Base environment snapshot for the sandbox",
 			Default:     "default",
 		},
-		{
-			Name:        "target",
-			Label:       "Target Region",
-			Type:        configuration.FieldTypeString,
-			Required:    false,
-			Placeholder: "e.g. us, eu, local",
-			Description: "Target region for the sandbox",
-			Default:     "us",
-		},
-		{
-			Name:        "autoStopInterval",
-			Label:       "Auto Stop Interval",
-			Type:        configuration.FieldTypeNumber,
-			Required:    false,
-			Description: "Time in minutes before the sandbox auto-stops",
-			Default:     15,
-		},
+		targetConfigurationField(SandboxTargetUS),
+		customTargetConfigurationField(),
+		keepAliveConfigurationField(),
+		autoStopIntervalConfigurationField(),
 		{
 			Name:        "repository",
 			Label:       "Repository",
@@ -160,6 +236,31 @@ func (c *CreateRepositorySandbox) Configuration() []configuration.Field {
 			Description: "Repository URL to clone",
 			Placeholder: "https://github.com/owner/repository.git",
 		},
+		{
+			Name:        "gitAuth",
+			Label:       "Git Authentication",
+			Type:        configuration.FieldTypeSelect,
+			Required:    false,
+			Default:     RepositoryGitAuthHTTPS,
+			Description: "HTTPS uses a GITHUB_TOKEN env-var secret if one is set. SSH uses a deploy key and needs an SSH repository URL",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "HTTPS", Value: RepositoryGitAuthHTTPS},
+						{Label: "SSH deploy key", Value: RepositoryGitAuthSSHKey},
+					},
+				},
+			},
+		},
+		{
+			Name:                 "deployKey",
+			Label:                "Deploy Key",
+			Type:                 configuration.FieldTypeSecretKey,
+			Required:             false,
+			Description:          "Secret key holding the SSH private key used to clone the repository",
+			VisibilityConditions: []configuration.VisibilityCondition{{Field: "gitAuth", Values: []string{RepositoryGitAuthSSHKey}}},
+			RequiredConditions:   []configuration.RequiredCondition{{Field: "gitAuth", Values: []string{RepositoryGitAuthSSHKey}}},
+		},
 		{
 			Name:  "env",
 			Label: "Environment Variables",
@@ -190,6 +291,23 @@ func (c *CreateRepositorySandbox) Configuration() []configuration.Field {
 			Description: "Environment variables to set in the sandbox",
 		},
 		sandboxSecretsConfigurationField(),
+		sandboxLabelsConfigurationField(),
+		{
+			Name:        "deleteOnFailure",
+			Label:       "Delete on failure",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Delete the sandbox if clone or bootstrap fails",
+		},
+		{
+			Name:        "startRetries",
+			Label:       "Start retries",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     0,
+			Description: "How many times to re-create the sandbox if it fails to start (up to 5)",
+		},
 		{
 			Name:        "bootstrap",
 			Label:       "Bootstrap",
@@ -211,6 +329,7 @@ func (c *CreateRepositorySandbox) Configuration() []configuration.Field {
 									Options: []configuration.FieldOption{
 										{Label: "Inline Script", Value: SandboxBootstrapFromInline},
 										{Label: "Repository File", Value: SandboxBootstrapFromFile},
+										{Label: "Steps", Value: SandboxBootstrapFromSteps},
 									},
 								},
 							},
@@ -220,6 +339,7 @@ func (c *CreateRepositorySandbox) Configuration() []configuration.Field {
 							Label:       "Script",
 							Type:        configuration.FieldTypeText,
 							Required:    false,
+							Description: "Use ${REPO_DIR} and ${SANDBOX_ID} to reference the repository directory and the sandbox ID",
 							Placeholder: "npm ci && npm test",
 							VisibilityConditions: []configuration.VisibilityCondition{
 								{Field: "from", Values: []string{SandboxBootstrapFromInline}},
@@ -235,6 +355,49 @@ func (c *CreateRepositorySandbox) Configuration() []configuration.Field {
 								{Field: "from", Values: []string{SandboxBootstrapFromFile}},
 							},
 						},
+						{
+							Name:        "steps",
+							Label:       "Steps",
+							Type:        configuration.FieldTypeList,
+							Required:    false,
+							Description: "Scripts to run in order. The first failing step stops the bootstrap",
+							VisibilityConditions: []configuration.VisibilityCondition{
+								{Field: "from", Values: []string{SandboxBootstrapFromSteps}},
+							},
+							TypeOptions: &configuration.TypeOptions{
+								List: &configuration.ListTypeOptions{
+									ItemLabel: "Step",
+									ItemDefinition: &configuration.ListItemDefinition{
+										Type: configuration.FieldTypeObject,
+										Schema: []configuration.Field{
+											{
+												Name:        "name",
+												Label:       "Name",
+												Type:        configuration.FieldTypeString,
+												Required:    true,
+												Placeholder: "install",
+											},
+											{
+												Name:        "script",
+												Label:       "Script",
+												Type:        configuration.FieldTypeText,
+												Required:    true,
+												Placeholder: "npm ci",
+												Description: "Use ${REPO_DIR} and ${SANDBOX_ID} to reference the repository directory and the sandbox ID",
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Name:        "workingDir",
+							Label:       "Working Directory",
+							Type:        configuration.FieldTypeString,
+							Required:    false,
+							Description: "Directory to run the bootstrap from, relative to the repository root. Defaults to the repository root",
+							Placeholder: "packages/api",
+						},
 					},
 				},
 			},
@@ -252,14 +415,32 @@ func (c *CreateRepositorySandbox) Setup(ctx core.SetupContext) error {
 		return fmt.Errorf("snapshot must not be empty if provided")
 	}
 
-	if spec.AutoStopInterval < 0 {
-		return fmt.Errorf("autoStopInterval cannot be negative")
+	if err := validateTarget(spec.Target, spec.CustomTarget); err != nil {
+		return err
+	}
+
+	if err := validateAutoStop(spec.KeepAlive, spec.AutoStopInterval); err != nil {
+		return err
+	}
+
+	if spec.StartRetries < 0 || spec.StartRetries > repositorySandboxMaxStartRetries {
+		return fmt.Errorf("startRetries must be between 0 and %d", repositorySandboxMaxStartRetries)
 	}
 
 	if spec.Repository == "" {
 		return fmt.Errorf("repository is required")
 	}
 
+	if err := validateRepositoryGitAuth(spec); err != nil {
+		return err
+	}
+
+	if !strings.Contains(spec.Repository, "{{") {
+		if err := checkRepositoryAllowed(ctx.Integration, spec.Repository); err != nil {
+			return err
+		}
+	}
+
 	for _, env := range spec.Env {
 		name := strings.TrimSpace(env.Name)
 		if name == "" {
@@ -271,10 +452,18 @@ func (c *CreateRepositorySandbox) Setup(ctx core.SetupContext) error {
 		}
 	}
 
+	if err := validateSandboxLabels(spec.Labels); err != nil {
+		return err
+	}
+
 	if err := validateSandboxSecrets(spec.Secrets); err != nil {
 		return err
 	}
 
+	if err := validateSandboxEnvSecretCollisions(spec.Env, spec.Secrets); err != nil {
+		return err
+	}
+
 	_, err := c.bootstrapMetadataFromSpec(spec)
 	if err != nil {
 		return fmt.Errorf("failed to validate bootstrap configuration: %v", err)
@@ -296,8 +485,14 @@ func (c *CreateRepositorySandbox) bootstrapMetadataFromSpec(spec CreateRepositor
 		return nil, fmt.Errorf("bootstrap.from is required")
 	}
 
+	workingDir, err := bootstrapWorkingDir(spec.Bootstrap.WorkingDir)
+	if err != nil {
+		return nil, err
+	}
+
 	metadata := BootstrapMetadata{
-		From: spec.Bootstrap.From,
+		From:       spec.Bootstrap.From,
+		WorkingDir: workingDir,
 	}
 
 	switch spec.Bootstrap.From {
@@ -317,30 +512,76 @@ func (c *CreateRepositorySandbox) bootstrapMetadataFromSpec(spec CreateRepositor
 		metadata.Path = &spec.Bootstrap.Path
 		return &metadata, nil
 
+	case SandboxBootstrapFromSteps:
+		if len(spec.Bootstrap.Steps) == 0 {
+			return nil, fmt.Errorf("bootstrap.steps is required when bootstrap.from is steps")
+		}
+
+		metadata.Steps = make([]BootstrapStepMetadata, 0, len(spec.Bootstrap.Steps))
+		for i, step := range spec.Bootstrap.Steps {
+			name := strings.TrimSpace(step.Name)
+			if name == "" {
+				return nil, fmt.Errorf("bootstrap.steps[%d].name is required", i)
+			}
+
+			if strings.TrimSpace(step.Script) == "" {
+				return nil, fmt.Errorf("bootstrap.steps[%d].script is required", i)
+			}
+
+			metadata.Steps = append(metadata.Steps, BootstrapStepMetadata{
+				Name:   name,
+				Script: step.Script,
+			})
+		}
+
+		return &metadata, nil
+
 	default:
 		return nil, fmt.Errorf("invalid bootstrap.from: %s", spec.Bootstrap.From)
 	}
 }
 
+/*
+ * bootstrapWorkingDir normalizes the bootstrap working directory,
+ * which must stay inside the repository. An empty value means the repository root.
+ */
+func bootstrapWorkingDir(workingDir string) (string, error) {
+	workingDir = strings.TrimSpace(workingDir)
+	if workingDir == "" {
+		return "", nil
+	}
+
+	if path.IsAbs(workingDir) {
+		return "", fmt.Errorf("bootstrap.workingDir must be relative to the repository root: %s", workingDir)
+	}
+
+	cleaned := path.Clean(workingDir)
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("bootstrap.workingDir must not escape the repository: %s", workingDir)
+	}
+
+	if cleaned == "." {
+		return "", nil
+	}
+
+	return cleaned, nil
+}
+
 func (c *CreateRepositorySandbox) Execute(ctx core.ExecutionContext) error {
 	spec := CreateRepositorySandboxSpec{}
 	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
 		return fmt.Errorf("failed to decode configuration: %v", err)
 	}
 
+	if err := checkRepositoryAllowed(ctx.Integration, spec.Repository); err != nil {
+		return err
+	}
+
 	client, err := NewClient(ctx.HTTP, ctx.Integration)
 	if err != nil {
 		return fmt.Errorf("failed to create client: %v", err)
 	}
 
-	var envMap map[string]string
-	if len(spec.Env) > 0 {
-		envMap = make(map[string]string, len(spec.Env))
-		for _, env := range spec.Env {
-			envMap[strings.TrimSpace(env.Name)] = env.Value
-		}
-	}
-
 	repositoryDirectory, err := c.getDirectoryName(spec.Repository)
 	if err != nil {
 		return fmt.Errorf("failed to determine repository directory name: %v", err)
@@ -351,28 +592,36 @@ func (c *CreateRepositorySandbox) Execute(ctx core.ExecutionContext) error {
 		return err
 	}
 
-	sandbox, err := client.CreateSandbox(&CreateSandboxRequest{
-		Snapshot:         spec.Snapshot,
-		Target:           spec.Target,
-		AutoStopInterval: spec.AutoStopInterval,
-		Env:              envMap,
-	})
-
+	sandbox, err := client.CreateSandbox(c.createSandboxRequest(spec))
 	if err != nil {
 		return fmt.Errorf("failed to create sandbox: %v", err)
 	}
 
 	ctx.Logger.Infof("Created sandbox %s", sandbox.ID)
 
+	if err := ctx.ExecutionState.SetKV(repositorySandboxExecutionKey, sandbox.ID); err != nil {
+		return fmt.Errorf("failed to set execution kv: %v", err)
+	}
+
 	metadata := CreateRepositorySandboxMetadata{
 		Stage:            repositorySandboxStagePreparingSandbox,
 		SandboxID:        sandbox.ID,
 		SandboxStartedAt: time.Now().Format(time.RFC3339),
 		Timeout:          int(CreateRepositorySandboxDefaultTimeout.Seconds()),
 		Repository:       strings.TrimSpace(spec.Repository),
+		GitAuth:          repositoryGitAuth(spec.GitAuth),
 		Directory:        path.Join(SandboxHomeDir, repositoryDirectory),
 		Secrets:          spec.Secrets,
+		Labels:           sandboxLabelsMap(spec.Labels),
 		Bootstrap:        bootstrapMetadata,
+		DeleteOnFailure:  spec.DeleteOnFailure,
+		StartRetries:     spec.StartRetries,
+		StartAttempts:    1,
+	}
+
+	if metadata.GitAuth == RepositoryGitAuthSSHKey {
+		deployKey := spec.DeployKey
+		metadata.DeployKey = &deployKey
 	}
 
 	if err := ctx.Metadata.Set(metadata); err != nil {
@@ -382,6 +631,24 @@ func (c *CreateRepositorySandbox) Execute(ctx core.ExecutionContext) error {
 	return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, CreateRepositorySandboxPollInterval)
 }
 
+func (c *CreateRepositorySandbox) createSandboxRequest(spec CreateRepositorySandboxSpec) *CreateSandboxRequest {
+	var envMap map[string]string
+	if len(spec.Env) > 0 {
+		envMap = make(map[string]string, len(spec.Env))
+		for _, env := range spec.Env {
+			envMap[strings.TrimSpace(env.Name)] = env.Value
+		}
+	}
+
+	return &CreateSandboxRequest{
+		Snapshot:         spec.Snapshot,
+		Target:           sandboxTarget(spec.Target, spec.CustomTarget),
+		AutoStopInterval: sandboxAutoStopInterval(spec.KeepAlive, spec.AutoStopInterval),
+		Env:              envMap,
+		Labels:           sandboxLabelsMap(spec.Labels),
+	}
+}
+
 func (c *CreateRepositorySandbox) Cancel(ctx core.ExecutionContext) error {
 	return nil
 }
@@ -393,6 +660,7 @@ func (c *CreateRepositorySandbox) ProcessQueueItem(ctx core.ProcessQueueContext)
 func (c *CreateRepositorySandbox) Hooks() []core.Hook {
 	return []core.Hook{
 		{Name: "poll", Type: core.HookTypeInternal},
+		{Name: "getLogs", Type: core.HookTypeUser},
 	}
 }
 
@@ -400,11 +668,85 @@ func (c *CreateRepositorySandbox) HandleHook(ctx core.ActionHookContext) error {
 	switch ctx.Name {
 	case "poll":
 		return c.poll(ctx)
+	case "getLogs":
+		return c.getLogs(ctx)
 	default:
 		return fmt.Errorf("unknown action: %s", ctx.Name)
 	}
 }
 
+/*
+ * getLogs can run after the execution finished. The stored bootstrap results
+ * are refreshed from the session when it still exists; if it is gone
+ * (e.g. the sandbox was deleted), the stored results are kept as they are.
+ */
+func (c *CreateRepositorySandbox) getLogs(ctx core.ActionHookContext) error {
+	var metadata CreateRepositorySandboxMetadata
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %v", err)
+	}
+
+	if metadata.Bootstrap == nil {
+		return fmt.Errorf("no bootstrap logs: this execution does not run a bootstrap script")
+	}
+
+	if metadata.SessionID == "" || metadata.Bootstrap.CmdID == "" {
+		return fmt.Errorf("no bootstrap logs: bootstrap has not started yet")
+	}
+
+	if err := c.refreshBootstrapLogs(ctx, &metadata); err != nil {
+		ctx.Logger.Warnf("could not fetch live bootstrap logs, keeping stored logs: %v", err)
+		return nil
+	}
+
+	return ctx.Metadata.Set(metadata)
+}
+
+func (c *CreateRepositorySandbox) refreshBootstrapLogs(ctx core.ActionHookContext, metadata *CreateRepositorySandboxMetadata) error {
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	logs := map[string]string{}
+	fetch := func(cmdID string) (string, error) {
+		if result, ok := logs[cmdID]; ok {
+			return result, nil
+		}
+
+		result, err := client.GetSessionCommandLogs(metadata.SandboxID, metadata.SessionID, cmdID)
+		if err != nil {
+			return "", err
+		}
+
+		logs[cmdID] = result
+		return result, nil
+	}
+
+	result, err := fetch(metadata.Bootstrap.CmdID)
+	if err != nil {
+		return err
+	}
+
+	for i := range metadata.Bootstrap.Steps {
+		step := &metadata.Bootstrap.Steps[i]
+		if step.CmdID == "" {
+			continue
+		}
+
+		stepResult, err := fetch(step.CmdID)
+		if err != nil {
+			return err
+		}
+
+		step.Result = stepResult
+	}
+
+	metadata.Bootstrap.Result = result
+	metadata.Bootstrap.LogsFetchedAt = time.Now().Format(time.RFC3339)
+	return nil
+}
+
 func (c *CreateRepositorySandbox) poll(ctx core.ActionHookContext) error {
 	if ctx.ExecutionState.IsFinished() {
 		return nil
@@ -423,13 +765,20 @@ func (c *CreateRepositorySandbox) poll(ctx core.ActionHookContext) error {
 	timeout := time.Duration(metadata.Timeout) * time.Second
 	if time.Since(startedAt) > timeout {
 		ctx.Logger.Errorf("sandbox creation failed on stage %s after %v", metadata.Stage, timeout)
-		return ctx.ExecutionState.Fail("error", fmt.Sprintf("sandbox creation failed on stage %s after %v", metadata.Stage, timeout))
+		return c.fail(ctx, &metadata, CreateRepositorySandboxFailureSandboxTimeout, fmt.Sprintf("sandbox creation failed on stage %s after %v", metadata.Stage, timeout))
 	}
 
 	switch metadata.Stage {
 	case repositorySandboxStagePreparingSandbox:
 		return c.pollWaitingSandbox(ctx, &metadata)
 
+	//
+	// The clone is already running from a webhook,
+	// so we only wait for it to move to the next stage.
+	//
+	case repositorySandboxStageCloning:
+		return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, CreateRepositorySandboxPollInterval)
+
 	case repositorySandboxStageBootstrapping:
 		return c.pollBootstrapping(ctx, &metadata)
 
@@ -451,27 +800,150 @@ func (c *CreateRepositorySandbox) pollWaitingSandbox(ctx core.ActionHookContext,
 	}
 
 	switch sandbox.State {
-	case "started":
-		if err := injectSandboxSecrets(client, metadata.SandboxID, ctx.Secrets, metadata.Secrets); err != nil {
-			return fmt.Errorf("failed to inject sandbox secrets: %v", err)
+	case repositorySandboxStateStarted:
+		if err := c.startRepositorySetup(ctx, client, metadata); err != nil {
+			return err
+		}
+
+		if ctx.ExecutionState.IsFinished() {
+			return nil
 		}
 
-		return c.startClone(ctx, client, metadata)
-	case "error":
-		return fmt.Errorf("sandbox %s failed to start", metadata.SandboxID)
+		return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, CreateRepositorySandboxPollInterval)
+	case repositorySandboxStateError:
+		if c.canRetrySandboxStart(metadata) {
+			if err := c.retrySandboxStart(ctx, client, metadata); err != nil {
+				return err
+			}
+
+			return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, CreateRepositorySandboxPollInterval)
+		}
+
+		return c.failSandboxStart(ctx, metadata)
 	default:
 		return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, CreateRepositorySandboxPollInterval)
 	}
 }
 
+func (c *CreateRepositorySandbox) canRetrySandboxStart(metadata *CreateRepositorySandboxMetadata) bool {
+	return metadata.StartRetries > 0 && metadata.StartAttempts <= metadata.StartRetries
+}
+
+/*
+ * retrySandboxStart replaces a sandbox that failed to start with a new one
+ * created from the same configuration. The failed sandbox is deleted,
+ * and a failed delete is only logged, since it can no longer be used anyway.
+ * The sandbox timeout starts over for the new sandbox.
+ */
+func (c *CreateRepositorySandbox) retrySandboxStart(ctx core.ActionHookContext, client *Client, metadata *CreateRepositorySandboxMetadata) error {
+	ctx.Logger.Warnf("sandbox %s failed to start, re-creating it (attempt %d of %d)", metadata.SandboxID, metadata.StartAttempts+1, metadata.StartRetries+1)
+
+	if err := client.DeleteSandbox(metadata.SandboxID, true); err != nil {
+		ctx.Logger.Errorf("failed to delete sandbox %s that failed to start: %v", metadata.SandboxID, err)
+	}
+
+	spec := CreateRepositorySandboxSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %v", err)
+	}
+
+	sandbox, err := client.CreateSandbox(c.createSandboxRequest(spec))
+	if err != nil {
+		return fmt.Errorf("failed to re-create sandbox: %v", err)
+	}
+
+	ctx.Logger.Infof("Created sandbox %s", sandbox.ID)
+
+	if err := ctx.ExecutionState.SetKV(repositorySandboxExecutionKey, sandbox.ID); err != nil {
+		return fmt.Errorf("failed to set execution kv: %v", err)
+	}
+
+	metadata.SandboxID = sandbox.ID
+	metadata.SandboxStartedAt = time.Now().Format(time.RFC3339)
+	metadata.StartAttempts++
+
+	return ctx.Metadata.Set(*metadata)
+}
+
+func (c *CreateRepositorySandbox) failSandboxStart(ctx core.ActionHookContext, metadata *CreateRepositorySandboxMetadata) error {
+	message := fmt.Sprintf("sandbox %s failed to start", metadata.SandboxID)
+	if metadata.StartAttempts > 1 {
+		message = fmt.Sprintf("%s after %d attempts", message, metadata.StartAttempts)
+	}
+
+	ctx.Logger.Error(message)
+	return c.fail(ctx, metadata, CreateRepositorySandboxFailureSandboxFailed, message)
+}
+
+/*
+ * startRepositorySetup is reached from both the poll loop and the webhook handler.
+ * The stage is moved from preparingSandbox to cloning with a conditional update
+ * before anything runs, so whichever path gets here second does not clone and bootstrap again.
+ * Once the stage is claimed, nothing moves it back, so any error after that fails the execution.
+ */
+func (c *CreateRepositorySandbox) startRepositorySetup(ctx core.ActionHookContext, client *Client, metadata *CreateRepositorySandboxMetadata) error {
+	claimed, err := c.claimRepositorySetup(ctx, metadata)
+	if err != nil {
+		return err
+	}
+
+	if !claimed {
+		ctx.Logger.Infof("repository setup for sandbox %s already started", metadata.SandboxID)
+		return nil
+	}
+
+	err = c.runRepositorySetup(ctx, client, metadata)
+	if err == nil || ctx.ExecutionState.IsFinished() {
+		return err
+	}
+
+	ctx.Logger.Errorf("repository setup failed: %v", err)
+	return c.fail(ctx, metadata, CreateRepositorySandboxFailureSetupFailed, fmt.Sprintf("repository setup failed: %v", err))
+}
+
+func (c *CreateRepositorySandbox) claimRepositorySetup(ctx core.ActionHookContext, metadata *CreateRepositorySandboxMetadata) (bool, error) {
+	claimed := *metadata
+	claimed.Stage = repositorySandboxStageCloning
+
+	claimer, ok := ctx.Metadata.(core.MetadataClaimer)
+	if !ok {
+		if err := ctx.Metadata.Set(claimed); err != nil {
+			return false, err
+		}
+
+		*metadata = claimed
+		return true, nil
+	}
+
+	updated, err := claimer.SetIfField("stage", repositorySandboxStagePreparingSandbox, claimed)
+	if err != nil || !updated {
+		return false, err
+	}
+
+	*metadata = claimed
+	return true, nil
+}
+
+func (c *CreateRepositorySandbox) runRepositorySetup(ctx core.ActionHookContext, client *Client, metadata *CreateRepositorySandboxMetadata) error {
+	if err := injectSandboxSecrets(client, metadata.SandboxID, ctx.Secrets, metadata.Secrets); err != nil {
+		return fmt.Errorf("failed to inject sandbox secrets: %v", err)
+	}
+
+	return c.startClone(ctx, client, metadata)
+}
+
+/*
+ * startClone does not schedule the next poll itself,
+ * since it can be reached from both the poll loop and the webhook handler.
+ */
 func (c *CreateRepositorySandbox) startClone(ctx core.ActionHookContext, client *Client, metadata *CreateRepositorySandboxMetadata) error {
-	cloneRequest, err := c.cloneRepositoryRequest(ctx.Secrets, metadata)
+	clone, err := c.cloneFunc(ctx.Secrets, client, metadata)
 	if err != nil {
 		return err
 	}
 
 	cloneStartedAt := time.Now().Format(time.RFC3339)
-	if err := client.CloneRepository(metadata.SandboxID, cloneRequest); err != nil {
+	if err := clone(); err != nil {
 		errorMessage := err.Error()
 		metadata.Clone = &CloneMetadata{
 			StartedAt:  cloneStartedAt,
@@ -484,8 +956,7 @@ func (c *CreateRepositorySandbox) startClone(ctx core.ActionHookContext, client
 		}
 
 		ctx.Logger.Errorf("repository clone failed: %v", err)
-		ctx.ExecutionState.Fail("error", fmt.Sprintf("repository clone failed: %v", err))
-		return nil
+		return c.fail(ctx, metadata, CreateRepositorySandboxFailureCloneFailed, fmt.Sprintf("repository clone failed: %v", err))
 	}
 
 	metadata.Clone = &CloneMetadata{
@@ -504,11 +975,26 @@ func (c *CreateRepositorySandbox) startClone(ctx core.ActionHookContext, client
 		return err
 	}
 
+	if err := c.prepareBootstrapStepScripts(client, metadata); err != nil {
+		return err
+	}
+
 	sessionID := uuid.New().String()
 	if err := client.CreateSession(metadata.SandboxID, sessionID); err != nil {
 		return fmt.Errorf("failed to create session: %v", err)
 	}
 
+	metadata.Stage = repositorySandboxStageBootstrapping
+	metadata.SessionID = sessionID
+
+	if len(metadata.Bootstrap.Steps) > 0 {
+		if err := c.startBootstrapStep(client, metadata, 0); err != nil {
+			return err
+		}
+
+		return ctx.Metadata.Set(*metadata)
+	}
+
 	bootstrapCommand := c.bootstrapCommand(metadata)
 	bootstrapCommand = wrapCommandWithSandboxSecretEnv(bootstrapCommand)
 	response, err := client.ExecuteSessionCommand(metadata.SandboxID, sessionID, bootstrapCommand)
@@ -516,35 +1002,134 @@ func (c *CreateRepositorySandbox) startClone(ctx core.ActionHookContext, client
 		return fmt.Errorf("failed to execute bootstrap script: %v", err)
 	}
 
-	metadata.Stage = repositorySandboxStageBootstrapping
-	metadata.SessionID = sessionID
 	metadata.Bootstrap.CmdID = response.CmdID
 	metadata.Bootstrap.StartedAt = time.Now().Format(time.RFC3339)
 
-	if err := ctx.Metadata.Set(*metadata); err != nil {
-		return err
+	return ctx.Metadata.Set(*metadata)
+}
+
+/*
+ * cloneFunc resolves the credentials needed for the clone and returns the function that runs it.
+ * Credential errors are returned directly, while clone errors fail the execution.
+ */
+func (c *CreateRepositorySandbox) cloneFunc(secretsContext core.SecretsContext, client *Client, metadata *CreateRepositorySandboxMetadata) (func() error, error) {
+	if metadata.GitAuth == RepositoryGitAuthSSHKey {
+		command, deployKey, err := c.sshCloneSetup(secretsContext, metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		return func() error {
+			return c.cloneWithDeployKey(client, metadata, command, deployKey)
+		}, nil
 	}
 
-	return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, CreateRepositorySandboxPollInterval)
+	cloneRequest, err := c.cloneRepositoryRequest(secretsContext, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		return client.CloneRepository(metadata.SandboxID, cloneRequest)
+	}, nil
 }
 
-func (c *CreateRepositorySandbox) cloneRepositoryRequest(secretsContext core.SecretsContext, metadata *CreateRepositorySandboxMetadata) (*CloneRepositoryRequest, error) {
-	request := &CloneRepositoryRequest{
-		URL:  metadata.Repository,
-		Path: metadata.Directory,
+/*
+ * The toolbox Git API only supports username/password authentication,
+ * so SSH clones run git in the sandbox with the deploy key written to a file.
+ */
+func (c *CreateRepositorySandbox) sshCloneSetup(secretsContext core.SecretsContext, metadata *CreateRepositorySandboxMetadata) (string, []byte, error) {
+	if metadata.DeployKey == nil || !metadata.DeployKey.IsSet() {
+		return "", nil, fmt.Errorf("deploy key is required for SSH clone")
+	}
+
+	deployKey, err := secretsContext.GetKey(metadata.DeployKey.Secret, metadata.DeployKey.Key)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to resolve secret %s/%s: %w", metadata.DeployKey.Secret, metadata.DeployKey.Key, err)
+	}
+
+	//
+	// ssh refuses keys without a trailing newline.
+	//
+	if !bytes.HasSuffix(deployKey, []byte("\n")) {
+		deployKey = append(deployKey, '\n')
+	}
+
+	//
+	// process/execute does not run commands through a shell,
+	// so the clone is wrapped in sh -c. The deploy key is removed
+	// right after the clone, whether it succeeded or not.
+	//
+	sshCommand := fmt.Sprintf("ssh -i %s -o IdentitiesOnly=yes -o StrictHostKeyChecking=accept-new", repositorySandboxDeployKeyPath)
+	script := fmt.Sprintf(
+		"chmod 600 %s && GIT_SSH_COMMAND=%s git clone %s %s; status=$?; rm -f %s; exit $status",
+		shellQuote(repositorySandboxDeployKeyPath),
+		shellQuote(sshCommand),
+		shellQuote(metadata.Repository),
+		shellQuote(metadata.Directory),
+		shellQuote(repositorySandboxDeployKeyPath),
+	)
+
+	return "sh -c " + shellQuote(script), deployKey, nil
+}
+
+func (c *CreateRepositorySandbox) cloneWithDeployKey(client *Client, metadata *CreateRepositorySandboxMetadata, command string, deployKey []byte) error {
+	if err := ensureFolderExists(client, metadata.SandboxID, SandboxBaseDir); err != nil {
+		return err
+	}
+
+	if err := client.UploadFile(metadata.SandboxID, repositorySandboxDeployKeyPath, deployKey); err != nil {
+		return fmt.Errorf("failed to upload deploy key: %v", err)
+	}
+
+	response, err := client.ExecuteCommand(metadata.SandboxID, &ExecuteCommandRequest{
+		Command: command,
+		Timeout: repositorySandboxSSHCloneTimeout,
+	})
+	if err != nil {
+		//
+		// The command removes the deploy key itself,
+		// but we cannot tell whether it ran, so we remove it here too.
+		//
+		if deleteErr := client.DeleteFile(metadata.SandboxID, repositorySandboxDeployKeyPath); deleteErr != nil {
+			return fmt.Errorf("%v (failed to remove deploy key: %v)", err, deleteErr)
+		}
+
+		return err
 	}
 
+	if response.ExitCode != 0 {
+		return fmt.Errorf("git clone exited with code %d: %s", response.ExitCode, response.ShortResult())
+	}
+
+	return nil
+}
+
+func (c *CreateRepositorySandbox) cloneRepositoryRequest(secretsContext core.SecretsContext, metadata *CreateRepositorySandboxMetadata) (*CloneRepositoryRequest, error) {
 	token, err := c.findCloneToken(secretsContext, metadata)
 	if err != nil {
 		return nil, err
 	}
 
+	return newCloneRepositoryRequest(metadata.Repository, metadata.Directory, token), nil
+}
+
+/*
+ * GitHub accepts any username along with a token as password,
+ * so "x-access-token" is used as the username for token clones.
+ */
+func newCloneRepositoryRequest(repository, directory, token string) *CloneRepositoryRequest {
+	request := &CloneRepositoryRequest{
+		URL:  repository,
+		Path: directory,
+	}
+
 	if token != "" {
 		request.Username = "x-access-token"
 		request.Password = token
 	}
 
-	return request, nil
+	return request
 }
 
 /*
@@ -597,7 +1182,7 @@ func (c *CreateRepositorySandbox) prepareInlineBootstrapScript(client *Client, m
 	}
 
 	inlineScriptPath := repositorySandboxInlineBootstrapPath
-	script := *metadata.Bootstrap.Script
+	script := interpolateBootstrapScript(*metadata.Bootstrap.Script, metadata)
 	if !strings.HasSuffix(script, "\n") {
 		script += "\n"
 	}
@@ -610,6 +1195,83 @@ func (c *CreateRepositorySandbox) prepareInlineBootstrapScript(client *Client, m
 	return nil
 }
 
+/*
+ * interpolateBootstrapScript replaces the documented template variables
+ * in an inline bootstrap script or bootstrap step with values resolved at runtime:
+ *
+ *   ${REPO_DIR}   - directory the repository was cloned into
+ *   ${SANDBOX_ID} - ID of the sandbox
+ *
+ * Prefixing a variable with an extra $, e.g. $${REPO_DIR}, keeps it literal.
+ * Any other use of $ is left untouched, so regular shell variables still work.
+ */
+func interpolateBootstrapScript(script string, metadata *CreateRepositorySandboxMetadata) string {
+	values := map[string]string{
+		"REPO_DIR":   metadata.Directory,
+		"SANDBOX_ID": metadata.SandboxID,
+	}
+
+	return bootstrapTemplateVariable.ReplaceAllStringFunc(script, func(match string) string {
+		if strings.HasPrefix(match, "$$") {
+			return match[1:]
+		}
+
+		name := strings.TrimSuffix(strings.TrimPrefix(match, "${"), "}")
+		return values[name]
+	})
+}
+
+/*
+ * Each bootstrap step is uploaded as its own script,
+ * so steps can be executed and reported on individually.
+ */
+func (c *CreateRepositorySandbox) prepareBootstrapStepScripts(client *Client, metadata *CreateRepositorySandboxMetadata) error {
+	if metadata.Bootstrap == nil || len(metadata.Bootstrap.Steps) == 0 {
+		return nil
+	}
+
+	if err := ensureFolderExists(client, metadata.SandboxID, SandboxBaseDir); err != nil {
+		return err
+	}
+
+	for i := range metadata.Bootstrap.Steps {
+		step := &metadata.Bootstrap.Steps[i]
+		script := interpolateBootstrapScript(step.Script, metadata)
+		if !strings.HasSuffix(script, "\n") {
+			script += "\n"
+		}
+
+		stepPath := fmt.Sprintf(repositorySandboxBootstrapStepPath, i+1)
+		if err := client.UploadFile(metadata.SandboxID, stepPath, []byte(script)); err != nil {
+			return fmt.Errorf("failed to upload script for bootstrap step %s: %v", step.Name, err)
+		}
+
+		step.Path = stepPath
+	}
+
+	return nil
+}
+
+func (c *CreateRepositorySandbox) startBootstrapStep(client *Client, metadata *CreateRepositorySandboxMetadata, index int) error {
+	step := &metadata.Bootstrap.Steps[index]
+	command := wrapCommandWithSandboxSecretEnv(c.scriptCommand(bootstrapDirectory(metadata), step.Path))
+	response, err := client.ExecuteSessionCommand(metadata.SandboxID, metadata.SessionID, command)
+	if err != nil {
+		return fmt.Errorf("failed to execute bootstrap step %s: %v", step.Name, err)
+	}
+
+	step.CmdID = response.CmdID
+	step.StartedAt = time.Now().Format(time.RFC3339)
+
+	metadata.Bootstrap.CurrentStep = index
+	metadata.Bootstrap.CmdID = response.CmdID
+	if index == 0 {
+		metadata.Bootstrap.StartedAt = step.StartedAt
+	}
+
+	return nil
+}
+
 func (c *CreateRepositorySandbox) pollBootstrapping(ctx core.ActionHookContext, metadata *CreateRepositorySandboxMetadata) error {
 	result, err := c.getCommandResult(ctx, metadata, metadata.Bootstrap.CmdID)
 	if err != nil {
@@ -621,19 +1283,92 @@ func (c *CreateRepositorySandbox) pollBootstrapping(ctx core.ActionHookContext,
 	metadata.Bootstrap.FinishedAt = time.Now().Format(time.RFC3339)
 	metadata.Bootstrap.ExitCode = result.ExitCode
 
+	if len(metadata.Bootstrap.Steps) > 0 {
+		return c.advanceBootstrapSteps(ctx, metadata, result)
+	}
+
 	if result.ExitCode != 0 {
 		if err := ctx.Metadata.Set(*metadata); err != nil {
 			return err
 		}
 
 		ctx.Logger.Errorf("bootstrap script failed with exit code %d: %s", result.ExitCode, result.ShortResult())
-		ctx.ExecutionState.Fail("error", fmt.Sprintf("bootstrap script failed with exit code %d: %s", result.ExitCode, result.ShortResult()))
-		return nil
+		return c.fail(ctx, metadata, CreateRepositorySandboxFailureBootstrapFailed, fmt.Sprintf("bootstrap script failed with exit code %d: %s", result.ExitCode, result.ShortResult()))
 	}
 
 	return c.finish(ctx, metadata)
 }
 
+func (c *CreateRepositorySandbox) advanceBootstrapSteps(ctx core.ActionHookContext, metadata *CreateRepositorySandboxMetadata, result *ExecuteCommandResponse) error {
+	current := metadata.Bootstrap.CurrentStep
+	step := &metadata.Bootstrap.Steps[current]
+	step.Result = result.Result
+	step.FinishedAt = metadata.Bootstrap.FinishedAt
+	step.ExitCode = result.ExitCode
+
+	if result.ExitCode != 0 {
+		if err := ctx.Metadata.Set(*metadata); err != nil {
+			return err
+		}
+
+		ctx.Logger.Errorf("bootstrap step %s failed with exit code %d: %s", step.Name, result.ExitCode, result.ShortResult())
+		return c.fail(ctx, metadata, CreateRepositorySandboxFailureBootstrapFailed, fmt.Sprintf("bootstrap step %s failed with exit code %d: %s", step.Name, result.ExitCode, result.ShortResult()))
+	}
+
+	if current+1 >= len(metadata.Bootstrap.Steps) {
+		return c.finish(ctx, metadata)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	if err := c.startBootstrapStep(client, metadata, current+1); err != nil {
+		return err
+	}
+
+	if err := ctx.Metadata.Set(*metadata); err != nil {
+		return err
+	}
+
+	return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, CreateRepositorySandboxPollInterval)
+}
+
+/*
+ * The failure reason is recorded in the metadata, and the execution
+ * fails with the regular error reason, so error routing and resolution keep working.
+ * If deleteOnFailure is enabled, the sandbox is deleted before the execution fails.
+ * A failed delete is only logged, since the execution is failing anyway.
+ */
+func (c *CreateRepositorySandbox) fail(ctx core.ActionHookContext, metadata *CreateRepositorySandboxMetadata, reason, message string) error {
+	if metadata.DeleteOnFailure {
+		c.deleteSandbox(ctx, metadata.SandboxID)
+	}
+
+	metadata.FailureReason = reason
+	if err := ctx.Metadata.Set(*metadata); err != nil {
+		return err
+	}
+
+	return ctx.ExecutionState.Fail(models.CanvasNodeExecutionResultReasonError, message)
+}
+
+func (c *CreateRepositorySandbox) deleteSandbox(ctx core.ActionHookContext, sandboxID string) {
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		ctx.Logger.Errorf("failed to create client to delete sandbox %s: %v", sandboxID, err)
+		return
+	}
+
+	if err := client.DeleteSandbox(sandboxID, true); err != nil {
+		ctx.Logger.Errorf("failed to delete sandbox %s after failure: %v", sandboxID, err)
+		return
+	}
+
+	ctx.Logger.Infof("Deleted sandbox %s after failure", sandboxID)
+}
+
 func (c *CreateRepositorySandbox) finish(ctx core.ActionHookContext, metadata *CreateRepositorySandboxMetadata) error {
 	metadata.Stage = repositorySandboxStageDone
 	err := ctx.Metadata.Set(*metadata)
@@ -704,6 +1439,43 @@ func isSCPStyleRepository(repository string) bool {
 	return strings.Contains(repository, "@") && strings.Contains(repository, ":") && !isURIStyleRepository(repository)
 }
 
+func isSSHRepository(repository string) bool {
+	return isSCPStyleRepository(repository) || strings.HasPrefix(repository, "ssh://")
+}
+
+func repositoryGitAuth(gitAuth string) string {
+	if strings.TrimSpace(gitAuth) == "" {
+		return RepositoryGitAuthHTTPS
+	}
+
+	return strings.TrimSpace(gitAuth)
+}
+
+func validateRepositoryGitAuth(spec CreateRepositorySandboxSpec) error {
+	repository := strings.TrimSpace(spec.Repository)
+
+	switch repositoryGitAuth(spec.GitAuth) {
+	case RepositoryGitAuthHTTPS:
+		if isSSHRepository(repository) {
+			return fmt.Errorf("repository %q is an SSH URL: use SSH deploy key authentication or an HTTPS URL", repository)
+		}
+
+	case RepositoryGitAuthSSHKey:
+		if !isSSHRepository(repository) {
+			return fmt.Errorf("SSH deploy key authentication needs an SSH repository URL (e.g. git@github.com:owner/repository.git), got %q", repository)
+		}
+
+		if !spec.DeployKey.IsSet() {
+			return fmt.Errorf("deploy key is required for SSH deploy key authentication")
+		}
+
+	default:
+		return fmt.Errorf("invalid git authentication: %s", spec.GitAuth)
+	}
+
+	return nil
+}
+
 func getDirectoryFromURI(repository string) (string, error) {
 	parsed, err := url.Parse(repository)
 	if err != nil {
@@ -739,17 +1511,144 @@ func directoryFromPath(candidate, original string) (string, error) {
 }
 
 func (c *CreateRepositorySandbox) bootstrapCommand(metadata *CreateRepositorySandboxMetadata) string {
+	return c.scriptCommand(bootstrapDirectory(metadata), *metadata.Bootstrap.Path)
+}
+
+// bootstrapDirectory is the directory bootstrap scripts run from.
+func bootstrapDirectory(metadata *CreateRepositorySandboxMetadata) string {
+	if metadata.Bootstrap == nil || metadata.Bootstrap.WorkingDir == "" {
+		return metadata.Directory
+	}
+
+	return path.Join(metadata.Directory, metadata.Bootstrap.WorkingDir)
+}
+
+func (c *CreateRepositorySandbox) scriptCommand(directory, scriptPath string) string {
 	return strings.Join(
 		[]string{
-			fmt.Sprintf("cd %s", shellQuote(metadata.Directory)),
-			fmt.Sprintf("sh %s", shellQuote(*metadata.Bootstrap.Path)),
+			fmt.Sprintf("cd %s", shellQuote(directory)),
+			fmt.Sprintf("sh %s", shellQuote(scriptPath)),
 		},
 		" && ",
 	)
 }
 
+/*
+ * Daytona sandbox state webhooks let us advance the execution as soon as
+ * the sandbox starts, instead of waiting for the next poll.
+ * The poll loop keeps running as a fallback, so any event we cannot
+ * act on is acknowledged and ignored.
+ */
 func (c *CreateRepositorySandbox) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
-	return http.StatusOK, nil, nil
+	if ctx.FindExecutionByKV == nil {
+		return http.StatusOK, nil, nil
+	}
+
+	payload := SandboxStateWebhookPayload{}
+	if err := json.Unmarshal(ctx.Body, &payload); err != nil {
+		return http.StatusBadRequest, nil, fmt.Errorf("failed to parse webhook payload: %v", err)
+	}
+
+	if payload.Event != repositorySandboxStateUpdatedEvent {
+		return http.StatusOK, nil, nil
+	}
+
+	if payload.NewState != repositorySandboxStateStarted && payload.NewState != repositorySandboxStateError {
+		return http.StatusOK, nil, nil
+	}
+
+	sandboxID := strings.TrimSpace(payload.ID)
+	if sandboxID == "" {
+		return http.StatusOK, nil, nil
+	}
+
+	executionCtx, err := ctx.FindExecutionByKV(repositorySandboxExecutionKey, sandboxID)
+	if err != nil {
+		// Ignore events for sandboxes not created by this node.
+		return http.StatusOK, nil, nil
+	}
+
+	if executionCtx.ExecutionState == nil || executionCtx.ExecutionState.IsFinished() {
+		return http.StatusOK, nil, nil
+	}
+
+	var metadata CreateRepositorySandboxMetadata
+	if err := mapstructure.Decode(executionCtx.Metadata.Get(), &metadata); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to decode metadata: %v", err)
+	}
+
+	//
+	// Only the sandbox preparation stage is driven by state webhooks.
+	// Everything after that is handled by the poll loop.
+	//
+	if metadata.Stage != repositorySandboxStagePreparingSandbox {
+		return http.StatusOK, nil, nil
+	}
+
+	//
+	// Secret values are not available when handling webhooks,
+	// so sandboxes that need them, either for sandbox secrets
+	// or for the SSH deploy key, are left for the poll loop.
+	//
+	if executionCtx.Secrets == nil && (len(metadata.Secrets) > 0 || metadata.GitAuth == RepositoryGitAuthSSHKey) {
+		return http.StatusOK, nil, nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	//
+	// Webhook payloads are not signed, so we confirm
+	// the sandbox state with the API before acting on it.
+	//
+	sandbox, err := client.GetSandbox(sandboxID)
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to get sandbox %s: %v", sandboxID, err)
+	}
+
+	hookCtx := core.ActionHookContext{
+		Configuration:  executionCtx.Configuration,
+		Logger:         executionCtx.Logger,
+		HTTP:           ctx.HTTP,
+		Metadata:       executionCtx.Metadata,
+		ExecutionState: executionCtx.ExecutionState,
+		Requests:       executionCtx.Requests,
+		Integration:    ctx.Integration,
+		Secrets:        executionCtx.Secrets,
+	}
+
+	switch sandbox.State {
+	case repositorySandboxStateStarted:
+		if err := c.startRepositorySetup(hookCtx, client, &metadata); err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+
+		return http.StatusOK, nil, nil
+
+	case repositorySandboxStateError:
+		//
+		// The poll loop is still scheduled, and picks up
+		// the re-created sandbox from the updated metadata.
+		//
+		if c.canRetrySandboxStart(&metadata) {
+			if err := c.retrySandboxStart(hookCtx, client, &metadata); err != nil {
+				return http.StatusInternalServerError, nil, err
+			}
+
+			return http.StatusOK, nil, nil
+		}
+
+		if err := c.failSandboxStart(hookCtx, &metadata); err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+
+		return http.StatusOK, nil, nil
+
+	default:
+		return http.StatusOK, nil, nil
+	}
 }
 
 func (c *CreateRepositorySandbox) Cleanup(ctx core.SetupContext) error {
@@ -23,7 +23,9 @@ type CreateSandbox struct{}
 type CreateSandboxSpec struct {
 	Snapshot         string          `json:"snapshot,omitempty"`
 	Target           string          `json:"target,omitempty"`
+	CustomTarget     string          `json:"customTarget,omitempty"`
 	AutoStopInterval int             `json:"autoStopInterval,omitempty"`
+	KeepAlive        bool            `json:"keepAlive,omitempty"`
 	Env              []EnvVariable   `json:"env,omitempty"`
 	Secrets          []SandboxSecret `json:"secrets,omitempty"`
 }
@@ -105,7 +107,7 @@ Use this pattern when you want to create a sandbox, run one or more commands in
 ## Configuration
 
 - **Snapshot**: Base environment snapshot (optional, uses default if not specified)
-- **Target**: Target region for the sandbox (optional)
+- **Target**: Target region for the sandbox (optional): US, EU, Local, or Other with a custom target name
 - **Auto Stop Interval**: Time in minutes before the sandbox auto-stops
 - **Environment Variables**: Key-value pairs to set as environment variables in the sandbox
 
@@ -150,22 +152,10 @@ func (c *CreateSandbox) Configuration() []configuration.Field {
 			Description: "Base environment snapshot for the sandbox",
 			Default:     "default",
 		},
-		{
-			Name:        "target",
-			Label:       "Target Region",
-			Type:        configuration.FieldTypeString,
-			Required:    false,
-			Placeholder: "e.g. us, eu, local",
-			Description: "Target region for the sandbox",
-		},
-		{
-			Name:        "autoStopInterval",
-			Label:       "Auto Stop Interval",
-			Type:        configuration.FieldTypeNumber,
-			Required:    false,
-			Description: "Time in minutes before the sandbox auto-stops",
-			Default:     15,
-		},
+		targetConfigurationField(""),
+		customTargetConfigurationField(),
+		keepAliveConfigurationField(),
+		autoStopIntervalConfigurationField(),
 		{
 			Name:  "env",
 			Label: "Environment Variables",
@@ -212,8 +202,12 @@ func (c *CreateSandbox) Setup(ctx core.SetupContext) error {
 		}
 	}
 
-	if spec.AutoStopInterval < 0 {
-		return fmt.Errorf("autoStopInterval cannot be negative")
+	if err := validateTarget(spec.Target, spec.CustomTarget); err != nil {
+		return err
+	}
+
+	if err := validateAutoStop(spec.KeepAlive, spec.AutoStopInterval); err != nil {
+		return err
 	}
 
 	return validateSandboxSecrets(spec.Secrets)
@@ -240,8 +234,8 @@ func (c *CreateSandbox) Execute(ctx core.ExecutionContext) error {
 
 	req := &CreateSandboxRequest{
 		Snapshot:         spec.Snapshot,
-		Target:           spec.Target,
-		AutoStopInterval: spec.AutoStopInterval,
+		Target:           sandboxTarget(spec.Target, spec.CustomTarget),
+		AutoStopInterval: sandboxAutoStopInterval(spec.KeepAlive, spec.AutoStopInterval),
 		Env:              envMap,
 	}
 
@@ -55,6 +55,31 @@ func Test__CreateSandbox__Setup(t *testing.T) {
 		require.ErrorContains(t, err, "autoStopInterval cannot be negative")
 	})
 
+	t.Run("keepAlive with autoStopInterval -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"keepAlive":        true,
+				"autoStopInterval": 15,
+			},
+		})
+
+		require.ErrorContains(t, err, "autoStopInterval cannot be set when keepAlive is enabled")
+	})
+
+	t.Run("keepAlive without autoStopInterval -> ok", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"keepAlive": true,
+			},
+		})
+
+		require.NoError(t, err)
+	})
+
 	t.Run("invalid secret env-var name -> error", func(t *testing.T) {
 		appCtx := &contexts.IntegrationContext{}
 		err := component.Setup(core.SetupContext{
@@ -76,6 +101,58 @@ func Test__CreateSandbox__Setup(t *testing.T) {
 
 		require.ErrorContains(t, err, "invalid env variable name")
 	})
+
+	t.Run("known target -> ok", func(t *testing.T) {
+		for _, target := range []string{"us", "eu", "local", "US", " Eu "} {
+			err := component.Setup(core.SetupContext{
+				Integration:   &contexts.IntegrationContext{},
+				Metadata:      &contexts.MetadataContext{},
+				Configuration: map[string]any{"target": target},
+			})
+
+			require.NoError(t, err, target)
+		}
+	})
+
+	t.Run("unknown saved target -> ok", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"target": "usa"},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("other target without custom target -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"target": "other"},
+		})
+
+		require.ErrorContains(t, err, "custom target is required")
+	})
+
+	t.Run("other target in a different case without custom target -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"target": "Other"},
+		})
+
+		require.ErrorContains(t, err, "custom target is required")
+	})
+
+	t.Run("other target with custom target -> ok", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"target": "other", "customTarget": "asia"},
+		})
+
+		require.NoError(t, err)
+	})
 }
 
 func Test__CreateSandbox__Execute(t *testing.T) {
@@ -122,6 +199,97 @@ func Test__CreateSandbox__Execute(t *testing.T) {
 		assert.NotZero(t, metadata.StartedAt)
 	})
 
+	t.Run("keepAlive disables auto-stop", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"id":"sandbox-123","state":"creating"}`)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"keepAlive": true,
+			},
+			HTTP: httpContext,
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{"apiKey": "test-api-key"},
+			},
+			ExecutionState: &contexts.ExecutionStateContext{},
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       &contexts.RequestContext{},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"autoStopInterval":0`)
+	})
+
+	t.Run("custom target is sent in place of other", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"id":"sandbox-123","state":"creating"}`)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"target":       "other",
+				"customTarget": "asia",
+			},
+			HTTP: httpContext,
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{"apiKey": "test-api-key"},
+			},
+			ExecutionState: &contexts.ExecutionStateContext{},
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       &contexts.RequestContext{},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"target":"asia"`)
+	})
+
+	t.Run("autoStopInterval is sent when keepAlive is not set", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"id":"sandbox-123","state":"creating"}`)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"autoStopInterval": 30,
+			},
+			HTTP: httpContext,
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{"apiKey": "test-api-key"},
+			},
+			ExecutionState: &contexts.ExecutionStateContext{},
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       &contexts.RequestContext{},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"autoStopInterval":30`)
+	})
+
 	t.Run("sandbox creation with env variables schedules poll", func(t *testing.T) {
 		httpContext := &contexts.HTTPContext{
 			Responses: []*http.Response{
@@ -395,7 +563,7 @@ func Test__CreateSandbox__Configuration(t *testing.T) {
 	component := CreateSandbox{}
 
 	config := component.Configuration()
-	assert.Len(t, config, 5)
+	assert.Len(t, config, 7)
 
 	fieldNames := make([]string, len(config))
 	for i, f := range config {
@@ -404,6 +572,8 @@ func Test__CreateSandbox__Configuration(t *testing.T) {
 
 	assert.Contains(t, fieldNames, "snapshot")
 	assert.Contains(t, fieldNames, "target")
+	assert.Contains(t, fieldNames, "customTarget")
+	assert.Contains(t, fieldNames, "keepAlive")
 	assert.Contains(t, fieldNames, "autoStopInterval")
 	assert.Contains(t, fieldNames, "env")
 	assert.Contains(t, fieldNames, "secrets")
@@ -420,3 +590,11 @@ func Test__CreateSandbox__OutputChannels(t *testing.T) {
 	require.Len(t, channels, 1)
 	assert.Equal(t, core.DefaultOutputChannel, channels[0])
 }
+
+func Test__sandboxTarget(t *testing.T) {
+	assert.Equal(t, "us", sandboxTarget("US", ""))
+	assert.Equal(t, "eu", sandboxTarget(" eu ", ""))
+	assert.Equal(t, "asia", sandboxTarget("Other", " asia "))
+	assert.Equal(t, "usa", sandboxTarget("usa", ""))
+	assert.Equal(t, "", sandboxTarget("", ""))
+}
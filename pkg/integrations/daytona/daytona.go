@@ -2,6 +2,7 @@ package daytona
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/configuration"
@@ -16,11 +17,29 @@ func init() {
 type Daytona struct{}
 
 type Configuration struct {
-	APIKey  string `json:"apiKey"`
-	BaseURL string `json:"baseURL"`
+	APIKey              string `json:"apiKey"`
+	BaseURL             string `json:"baseURL"`
+	CACertificate       string `json:"caCertificate"`
+	TLSVerification     string `json:"tlsVerification"`
+	AllowedRepositories string `json:"allowedRepositories"`
 }
 
+const (
+	ConnectionStatusConnected   = "connected"
+	ConnectionStatusUnreachable = "unreachable"
+
+	syncInterval = time.Hour
+)
+
 type Metadata struct {
+	Connection *ConnectionStatus `json:"connection,omitempty" mapstructure:"connection,omitempty"`
+}
+
+// ConnectionStatus is the result of the last connectivity check against the Daytona API.
+type ConnectionStatus struct {
+	Status    string `json:"status" mapstructure:"status"`
+	CheckedAt string `json:"checkedAt" mapstructure:"checkedAt"`
+	Error     string `json:"error,omitempty" mapstructure:"error,omitempty"`
 }
 
 func (d *Daytona) Name() string {
@@ -57,6 +76,37 @@ func (d *Daytona) Configuration() []configuration.Field {
 			Default:     "https://app.daytona.io/api",
 			Description: "API base URL",
 		},
+		{
+			Name:        "caCertificate",
+			Label:       "CA Certificate",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "PEM-encoded CA certificate bundle for a self-hosted endpoint signed by a private CA",
+		},
+		{
+			Name:        "tlsVerification",
+			Label:       "TLS Verification",
+			Type:        configuration.FieldTypeSelect,
+			Required:    false,
+			Default:     registry.IntegrationTLSVerify,
+			Description: "Skipping verification is insecure and only works if the server allows it",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Verify", Value: registry.IntegrationTLSVerify},
+						{Label: "Skip verification (insecure)", Value: registry.IntegrationTLSSkip},
+					},
+				},
+			},
+		},
+		{
+			Name:        ConfigNameAllowedRepositories,
+			Label:       "Allowed Repositories",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "Repositories that may be cloned into sandboxes, one per line. Use a trailing slash for prefixes or * for globs. Leave empty to allow all repositories.",
+			Placeholder: "github.com/acme/\ngithub.com/partner/service-*",
+		},
 	}
 }
 
@@ -64,9 +114,15 @@ func (d *Daytona) Actions() []core.Action {
 	return []core.Action{
 		&CreateSandbox{},
 		&CreateRepositorySandbox{},
+		&CloneRepository{},
 		&GetPreviewURLComponent{},
 		&ExecuteCode{},
 		&ExecuteCommand{},
+		&RunCommands{},
+		&RunTests{},
+		&RunMigration{},
+		&RunCommandStreaming{},
+		&TagSandbox{},
 		&DeleteSandbox{},
 	}
 }
@@ -94,11 +150,34 @@ func (d *Daytona) Sync(ctx core.SyncContext) error {
 		return err
 	}
 
+	checkedAt := time.Now().UTC().Format(time.RFC3339)
 	if err := client.Verify(); err != nil {
-		return err
+		ctx.Integration.SetMetadata(Metadata{
+			Connection: &ConnectionStatus{
+				Status:    ConnectionStatusUnreachable,
+				CheckedAt: checkedAt,
+				Error:     err.Error(),
+			},
+		})
+
+		return fmt.Errorf("failed to verify Daytona API access: %w", err)
+	}
+
+	ctx.Integration.SetMetadata(Metadata{
+		Connection: &ConnectionStatus{
+			Status:    ConnectionStatusConnected,
+			CheckedAt: checkedAt,
+		},
+	})
+
+	//
+	// Re-check periodically, so a revoked API key
+	// shows up on the integration instead of only in failed executions.
+	//
+	if err := ctx.Integration.ScheduleResync(syncInterval); err != nil {
+		return fmt.Errorf("failed to schedule resync: %w", err)
 	}
 
-	ctx.Integration.SetMetadata(Metadata{})
 	ctx.Integration.Ready()
 	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -57,9 +58,17 @@ func Test__Daytona__Sync(t *testing.T) {
 		require.Len(t, httpContext.Requests, 1)
 		assert.Contains(t, httpContext.Requests[0].URL.String(), "/sandbox")
 		assert.Equal(t, "Bearer test-api-key", httpContext.Requests[0].Header.Get("Authorization"))
+
+		metadata, ok := appCtx.Metadata.(Metadata)
+		require.True(t, ok)
+		require.NotNil(t, metadata.Connection)
+		assert.Equal(t, ConnectionStatusConnected, metadata.Connection.Status)
+		assert.NotEmpty(t, metadata.Connection.CheckedAt)
+		assert.Empty(t, metadata.Connection.Error)
+		assert.Equal(t, []time.Duration{syncInterval}, appCtx.ResyncRequests)
 	})
 
-	t.Run("connection test failure -> error", func(t *testing.T) {
+	t.Run("401 from connection test -> error", func(t *testing.T) {
 		httpContext := &contexts.HTTPContext{
 			Responses: []*http.Response{
 				{
@@ -81,8 +90,15 @@ func Test__Daytona__Sync(t *testing.T) {
 			Integration:   appCtx,
 		})
 
-		require.Error(t, err)
+		require.ErrorContains(t, err, "failed to verify Daytona API access")
 		assert.NotEqual(t, "ready", appCtx.State)
+		assert.Empty(t, appCtx.ResyncRequests)
+
+		metadata, ok := appCtx.Metadata.(Metadata)
+		require.True(t, ok)
+		require.NotNil(t, metadata.Connection)
+		assert.Equal(t, ConnectionStatusUnreachable, metadata.Connection.Status)
+		assert.Contains(t, metadata.Connection.Error, "401")
 	})
 
 	t.Run("custom baseURL is used", func(t *testing.T) {
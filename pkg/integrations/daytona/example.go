@@ -19,12 +19,30 @@ var exampleOutputExecuteCodeBytes []byte
 //go:embed example_output_execute_command.json
 var exampleOutputExecuteCommandBytes []byte
 
+//go:embed example_output_run_commands.json
+var exampleOutputRunCommandsBytes []byte
+
+//go:embed example_output_run_tests.json
+var exampleOutputRunTestsBytes []byte
+
+//go:embed example_output_run_migration.json
+var exampleOutputRunMigrationBytes []byte
+
+//go:embed example_output_run_command_streaming.json
+var exampleOutputRunCommandStreamingBytes []byte
+
 //go:embed example_output_get_preview_url.json
 var exampleOutputGetPreviewURLBytes []byte
 
 //go:embed example_output_delete_sandbox.json
 var exampleOutputDeleteSandboxBytes []byte
 
+//go:embed example_output_tag_sandbox.json
+var exampleOutputTagSandboxBytes []byte
+
+//go:embed example_output_clone_repository.json
+var exampleOutputCloneRepositoryBytes []byte
+
 var exampleOutputCreateSandboxOnce sync.Once
 var exampleOutputCreateSandbox map[string]any
 
@@ -37,12 +55,30 @@ var exampleOutputExecuteCode map[string]any
 var exampleOutputExecuteCommandOnce sync.Once
 var exampleOutputExecuteCommand map[string]any
 
+var exampleOutputRunCommandsOnce sync.Once
+var exampleOutputRunCommands map[string]any
+
+var exampleOutputRunTestsOnce sync.Once
+var exampleOutputRunTests map[string]any
+
+var exampleOutputRunMigrationOnce sync.Once
+var exampleOutputRunMigration map[string]any
+
+var exampleOutputRunCommandStreamingOnce sync.Once
+var exampleOutputRunCommandStreaming map[string]any
+
 var exampleOutputGetPreviewURLOnce sync.Once
 var exampleOutputGetPreviewURL map[string]any
 
 var exampleOutputDeleteSandboxOnce sync.Once
 var exampleOutputDeleteSandbox map[string]any
 
+var exampleOutputTagSandboxOnce sync.Once
+var exampleOutputTagSandbox map[string]any
+
+var exampleOutputCloneRepositoryOnce sync.Once
+var exampleOutputCloneRepository map[string]any
+
 func (c *CreateSandbox) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputCreateSandboxOnce, exampleOutputCreateSandboxBytes, &exampleOutputCreateSandbox)
 }
@@ -63,6 +99,22 @@ func (e *ExecuteCommand) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputExecuteCommandOnce, exampleOutputExecuteCommandBytes, &exampleOutputExecuteCommand)
 }
 
+func (r *RunCommands) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputRunCommandsOnce, exampleOutputRunCommandsBytes, &exampleOutputRunCommands)
+}
+
+func (r *RunTests) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputRunTestsOnce, exampleOutputRunTestsBytes, &exampleOutputRunTests)
+}
+
+func (r *RunMigration) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputRunMigrationOnce, exampleOutputRunMigrationBytes, &exampleOutputRunMigration)
+}
+
+func (r *RunCommandStreaming) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputRunCommandStreamingOnce, exampleOutputRunCommandStreamingBytes, &exampleOutputRunCommandStreaming)
+}
+
 func (p *GetPreviewURLComponent) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputGetPreviewURLOnce, exampleOutputGetPreviewURLBytes, &exampleOutputGetPreviewURL)
 }
@@ -70,3 +122,11 @@ func (p *GetPreviewURLComponent) ExampleOutput() map[string]any {
 func (d *DeleteSandbox) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputDeleteSandboxOnce, exampleOutputDeleteSandboxBytes, &exampleOutputDeleteSandbox)
 }
+
+func (t *TagSandbox) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputTagSandboxOnce, exampleOutputTagSandboxBytes, &exampleOutputTagSandbox)
+}
+
+func (c *CloneRepository) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputCloneRepositoryOnce, exampleOutputCloneRepositoryBytes, &exampleOutputCloneRepository)
+}
@@ -3,7 +3,10 @@ package daytona
 import (
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 	"github.com/mitchellh/mapstructure"
@@ -16,14 +19,36 @@ const (
 	ExecuteCodePollInterval         = 5 * time.Second
 	ExecuteCodeOutputChannelSuccess = "success"
 	ExecuteCodeOutputChannelFailed  = "failed"
+	ExecuteCodeSetupTimeout         = 600
+
+	executeCodeStageSetup = "setup"
+	executeCodeStageCode  = "code"
 )
 
+/*
+ * Package managers a setup command may use, and the languages they install packages for.
+ * Commands that do not use any of them (e.g. apt-get) are allowed for every language.
+ */
+var executeCodePackageManagers = map[string][]string{
+	"pip":    {"python"},
+	"pip3":   {"python"},
+	"uv":     {"python"},
+	"poetry": {"python"},
+	"conda":  {"python"},
+	"npm":    {"javascript", "typescript"},
+	"npx":    {"javascript", "typescript"},
+	"yarn":   {"javascript", "typescript"},
+	"pnpm":   {"javascript", "typescript"},
+	"bun":    {"javascript", "typescript"},
+}
+
 type ExecuteCode struct{}
 
 type ExecuteCodeSpec struct {
 	Sandbox  string `json:"sandbox"`
 	Code     string `json:"code"`
 	Language string `json:"language"`
+	Setup    string `json:"setup,omitempty"`
 	Timeout  int    `json:"timeout,omitempty"`
 }
 
@@ -33,6 +58,12 @@ type ExecuteCodeMetadata struct {
 	CmdID     string `json:"cmdId" mapstructure:"cmdId"`
 	StartedAt int64  `json:"startedAt" mapstructure:"startedAt"`
 	Timeout   int    `json:"timeout" mapstructure:"timeout"`
+
+	// Set when a setup command runs before the code.
+	// Command holds the code command until setup succeeds.
+	Stage      string `json:"stage,omitempty" mapstructure:"stage"`
+	SetupCmdID string `json:"setupCmdId,omitempty" mapstructure:"setupCmdId"`
+	Command    string `json:"command,omitempty" mapstructure:"command"`
 }
 
 func (e *ExecuteCode) Name() string {
@@ -62,19 +93,24 @@ func (e *ExecuteCode) Documentation() string {
 - **Sandbox**: The sandbox ID to execute code in (from Create Sandbox or Create Repository Sandbox output). Supports expressions, e.g. ` + "`" + `{{ previous().data.id }}` + "`" + ` or ` + "`" + `{{ $["Create Repository Sandbox"].data.sandboxId }}` + "`" + `
 - **Code**: The code to execute (supports expressions)
 - **Language**: The programming language (python, typescript, javascript)
-- **Timeout**: Optional execution timeout in milliseconds
+- **Setup**: Optional shell command that runs before the code in the same session, e.g. ` + "`pip install pandas`" + ` or ` + "`npm install lodash`" + `
+- **Timeout**: Optional execution timeout in seconds
 
 ## Output
 
 Returns the execution result including:
 - **exitCode**: The process exit code (0 for success)
 - **result**: The stdout/output from the code execution
+- **stage**: ` + "`setup`" + ` when the setup command failed, ` + "`code`" + ` otherwise (only set when a setup command is configured)
 
 ## Notes
 
 - The sandbox must be created first using createSandbox
 - Code output is captured from stdout
-- Non-zero exit codes indicate execution errors`
+- Non-zero exit codes indicate execution errors
+- If the setup command fails, the code is not run and the setup output is emitted on the failed channel
+- The setup command has its own timeout of 10 minutes; **Timeout** applies to the code only
+- The setup command must use a package manager for the selected language (e.g. pip for Python, npm for JavaScript)`
 }
 
 func (e *ExecuteCode) Icon() string {
@@ -131,6 +167,15 @@ func (e *ExecuteCode) Configuration() []configuration.Field {
 			},
 			Description: "The programming language of the code",
 		},
+		{
+			Name:        "setup",
+			Label:       "Setup",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Togglable:   true,
+			Description: "Shell command that installs dependencies before the code runs",
+			Placeholder: "pip install pandas requests",
+		},
 		{
 			Name:        "timeout",
 			Label:       "Timeout",
@@ -169,7 +214,7 @@ func (e *ExecuteCode) Setup(ctx core.SetupContext) error {
 		return fmt.Errorf("invalid language: %s (must be python, typescript, or javascript)", spec.Language)
 	}
 
-	return nil
+	return validateExecuteCodeSetup(spec.Language, spec.Setup)
 }
 
 func (e *ExecuteCode) Execute(ctx core.ExecutionContext) error {
@@ -191,11 +236,6 @@ func (e *ExecuteCode) Execute(ctx core.ExecutionContext) error {
 		return fmt.Errorf("failed to create session: %v", err)
 	}
 
-	response, err := client.ExecuteSessionCommand(spec.Sandbox, sessionID, command)
-	if err != nil {
-		return fmt.Errorf("failed to execute code: %v", err)
-	}
-
 	timeout := spec.Timeout
 	if timeout == 0 {
 		timeout = 30
@@ -204,11 +244,30 @@ func (e *ExecuteCode) Execute(ctx core.ExecutionContext) error {
 	metadata := ExecuteCodeMetadata{
 		SandboxID: spec.Sandbox,
 		SessionID: sessionID,
-		CmdID:     response.CmdID,
 		StartedAt: time.Now().Unix(),
 		Timeout:   timeout,
 	}
 
+	setup := strings.TrimSpace(spec.Setup)
+	if setup != "" {
+		response, err := client.ExecuteSessionCommand(spec.Sandbox, sessionID, wrapCommandWithSandboxSecretEnv(setup))
+		if err != nil {
+			return fmt.Errorf("failed to execute setup command: %v", err)
+		}
+
+		metadata.Stage = executeCodeStageSetup
+		metadata.SetupCmdID = response.CmdID
+		metadata.CmdID = response.CmdID
+		metadata.Command = command
+	} else {
+		response, err := client.ExecuteSessionCommand(spec.Sandbox, sessionID, command)
+		if err != nil {
+			return fmt.Errorf("failed to execute code: %v", err)
+		}
+
+		metadata.CmdID = response.CmdID
+	}
+
 	if err := ctx.Metadata.Set(metadata); err != nil {
 		return err
 	}
@@ -247,7 +306,11 @@ func (e *ExecuteCode) poll(ctx core.ActionHookContext) error {
 		return fmt.Errorf("failed to decode metadata: %v", err)
 	}
 
-	if time.Now().Unix()-metadata.StartedAt > int64(metadata.Timeout) {
+	if metadata.Stage == executeCodeStageSetup {
+		if time.Now().Unix()-metadata.StartedAt > ExecuteCodeSetupTimeout {
+			return fmt.Errorf("setup command timed out after %d seconds", ExecuteCodeSetupTimeout)
+		}
+	} else if time.Now().Unix()-metadata.StartedAt > int64(metadata.Timeout) {
 		return fmt.Errorf("code execution timed out after %d seconds", metadata.Timeout)
 	}
 
@@ -266,6 +329,10 @@ func (e *ExecuteCode) poll(ctx core.ActionHookContext) error {
 		return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, ExecuteCodePollInterval)
 	}
 
+	if metadata.Stage == executeCodeStageSetup && *cmd.ExitCode == 0 {
+		return e.startCode(ctx, client, metadata)
+	}
+
 	logs, err := client.GetSessionCommandLogs(metadata.SandboxID, metadata.SessionID, metadata.CmdID)
 	if err != nil {
 		logs = ""
@@ -274,6 +341,7 @@ func (e *ExecuteCode) poll(ctx core.ActionHookContext) error {
 	result := &ExecuteCodeResponse{
 		ExitCode: *cmd.ExitCode,
 		Result:   logs,
+		Stage:    metadata.Stage,
 	}
 
 	channel := ExecuteCodeOutputChannelFailed
@@ -288,6 +356,27 @@ func (e *ExecuteCode) poll(ctx core.ActionHookContext) error {
 	)
 }
 
+/*
+ * Runs the code in the session once the setup command succeeded.
+ * The code timeout starts counting from here.
+ */
+func (e *ExecuteCode) startCode(ctx core.ActionHookContext, client *Client, metadata ExecuteCodeMetadata) error {
+	response, err := client.ExecuteSessionCommand(metadata.SandboxID, metadata.SessionID, metadata.Command)
+	if err != nil {
+		return fmt.Errorf("failed to execute code: %v", err)
+	}
+
+	metadata.Stage = executeCodeStageCode
+	metadata.CmdID = response.CmdID
+	metadata.Command = ""
+	metadata.StartedAt = time.Now().Unix()
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return err
+	}
+
+	return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, ExecuteCodePollInterval)
+}
+
 func (e *ExecuteCode) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
 	return http.StatusOK, nil, nil
 }
@@ -310,3 +399,22 @@ func buildExecuteCodeCommand(language, code string) string {
 		return fmt.Sprintf("python3 -c %s", quotedCode)
 	}
 }
+
+/*
+ * A setup command cannot install packages for a different language than the code,
+ * e.g. pip install before JavaScript code.
+ */
+func validateExecuteCodeSetup(language, setup string) error {
+	for _, word := range strings.FieldsFunc(setup, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(";&|()", r)
+	}) {
+		languages, ok := executeCodePackageManagers[word]
+		if !ok || slices.Contains(languages, language) {
+			continue
+		}
+
+		return fmt.Errorf("setup command uses %s, which does not install packages for %s", word, language)
+	}
+
+	return nil
+}
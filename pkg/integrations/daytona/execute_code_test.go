@@ -79,6 +79,36 @@ func Test__ExecuteCode__Setup(t *testing.T) {
 		require.ErrorContains(t, err, "ruby")
 	})
 
+	t.Run("setup command for another language -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"sandbox":  "sandbox-123",
+				"code":     "console.log('hello')",
+				"language": "javascript",
+				"setup":    "pip install -r requirements.txt",
+			},
+		})
+
+		require.ErrorContains(t, err, "setup command uses pip, which does not install packages for javascript")
+	})
+
+	t.Run("setup command for the same language", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"sandbox":  "sandbox-123",
+				"code":     "import pandas",
+				"language": "python",
+				"setup":    "sudo apt-get install -y libpq-dev && pip install pandas",
+			},
+		})
+
+		require.NoError(t, err)
+	})
+
 	t.Run("valid python setup", func(t *testing.T) {
 		appCtx := &contexts.IntegrationContext{}
 		err := component.Setup(core.SetupContext{
@@ -470,7 +500,7 @@ func Test__ExecuteCode__Configuration(t *testing.T) {
 	component := ExecuteCode{}
 
 	config := component.Configuration()
-	assert.Len(t, config, 4)
+	assert.Len(t, config, 5)
 
 	fieldNames := make([]string, len(config))
 	for i, f := range config {
@@ -480,14 +510,15 @@ func Test__ExecuteCode__Configuration(t *testing.T) {
 	assert.Contains(t, fieldNames, "sandbox")
 	assert.Contains(t, fieldNames, "code")
 	assert.Contains(t, fieldNames, "language")
+	assert.Contains(t, fieldNames, "setup")
 	assert.Contains(t, fieldNames, "timeout")
 
 	for _, f := range config {
 		if f.Name == "sandbox" || f.Name == "code" || f.Name == "language" {
 			assert.True(t, f.Required, "%s should be required", f.Name)
 		}
-		if f.Name == "timeout" {
-			assert.False(t, f.Required, "timeout should be optional")
+		if f.Name == "timeout" || f.Name == "setup" {
+			assert.False(t, f.Required, "%s should be optional", f.Name)
 		}
 	}
 
@@ -509,3 +540,150 @@ func Test__ExecuteCode__OutputChannels(t *testing.T) {
 	assert.Equal(t, ExecuteCodeOutputChannelSuccess, channels[0].Name)
 	assert.Equal(t, ExecuteCodeOutputChannelFailed, channels[1].Name)
 }
+
+func Test__ExecuteCode__SetupCommand(t *testing.T) {
+	component := ExecuteCode{}
+	toolboxConfig := `{"proxyToolboxUrl":"https://app.daytona.io/api/toolbox"}`
+	integration := &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}}
+
+	t.Run("runs setup command before the code", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(toolboxConfig))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(toolboxConfig))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"cmdId":"setup-001"}`))},
+			},
+		}
+
+		metadataCtx := &contexts.MetadataContext{}
+		requestCtx := &contexts.RequestContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"sandbox":  "sandbox-123",
+				"code":     "import pandas",
+				"language": "python",
+				"setup":    "pip install pandas",
+			},
+			HTTP:           httpContext,
+			Integration:    integration,
+			ExecutionState: &contexts.ExecutionStateContext{},
+			Metadata:       metadataCtx,
+			Requests:       requestCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "poll", requestCtx.Action)
+		require.Len(t, httpContext.Requests, 4)
+
+		body, err := io.ReadAll(httpContext.Requests[3].Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), "pip install pandas")
+		assert.NotContains(t, string(body), "python3 -c")
+
+		metadata, ok := metadataCtx.Metadata.(ExecuteCodeMetadata)
+		require.True(t, ok)
+		assert.Equal(t, executeCodeStageSetup, metadata.Stage)
+		assert.Equal(t, "setup-001", metadata.SetupCmdID)
+		assert.Equal(t, "setup-001", metadata.CmdID)
+		assert.Contains(t, metadata.Command, "python3 -c 'import pandas'")
+	})
+
+	t.Run("setup fails -> emits failed channel without running code", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(toolboxConfig))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"sessionId":"session-abc","commands":[{"id":"setup-001","exitCode":1}]}`))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(toolboxConfig))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`ERROR: No matching distribution found for pandsa`))},
+			},
+		}
+
+		execCtx := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name: "poll",
+			HTTP: httpContext,
+			Metadata: &contexts.MetadataContext{
+				Metadata: map[string]any{
+					"sandboxId":  "sandbox-123",
+					"sessionId":  "session-abc",
+					"cmdId":      "setup-001",
+					"setupCmdId": "setup-001",
+					"stage":      executeCodeStageSetup,
+					"command":    "python3 -c 'import pandas'",
+					"startedAt":  time.Now().Unix(),
+					"timeout":    30,
+				},
+			},
+			ExecutionState: execCtx,
+			Requests:       &contexts.RequestContext{},
+			Integration:    integration,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 4)
+		for _, req := range httpContext.Requests {
+			assert.NotContains(t, req.URL.Path, "/exec")
+		}
+
+		assert.True(t, execCtx.Finished)
+		assert.Equal(t, ExecuteCodeOutputChannelFailed, execCtx.Channel)
+		require.Len(t, execCtx.Payloads, 1)
+		result := execCtx.Payloads[0].(map[string]any)["data"].(*ExecuteCodeResponse)
+		assert.Equal(t, 1, result.ExitCode)
+		assert.Equal(t, executeCodeStageSetup, result.Stage)
+		assert.Contains(t, result.Result, "No matching distribution")
+	})
+
+	t.Run("setup succeeds -> starts the code in the same session", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(toolboxConfig))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"sessionId":"session-abc","commands":[{"id":"setup-001","exitCode":0}]}`))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(toolboxConfig))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"cmdId":"cmd-002"}`))},
+			},
+		}
+
+		metadataCtx := &contexts.MetadataContext{
+			Metadata: map[string]any{
+				"sandboxId":  "sandbox-123",
+				"sessionId":  "session-abc",
+				"cmdId":      "setup-001",
+				"setupCmdId": "setup-001",
+				"stage":      executeCodeStageSetup,
+				"command":    "python3 -c 'import pandas'",
+				"startedAt":  time.Now().Add(-5 * time.Minute).Unix(),
+				"timeout":    30,
+			},
+		}
+		execCtx := &contexts.ExecutionStateContext{}
+		requestCtx := &contexts.RequestContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           "poll",
+			HTTP:           httpContext,
+			Metadata:       metadataCtx,
+			ExecutionState: execCtx,
+			Requests:       requestCtx,
+			Integration:    integration,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, execCtx.Finished)
+		assert.Equal(t, "poll", requestCtx.Action)
+
+		require.Len(t, httpContext.Requests, 4)
+		assert.Contains(t, httpContext.Requests[3].URL.Path, "/session/session-abc/exec")
+		body, err := io.ReadAll(httpContext.Requests[3].Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), "python3 -c 'import pandas'")
+
+		metadata, ok := metadataCtx.Metadata.(ExecuteCodeMetadata)
+		require.True(t, ok)
+		assert.Equal(t, executeCodeStageCode, metadata.Stage)
+		assert.Equal(t, "cmd-002", metadata.CmdID)
+		assert.Equal(t, "setup-001", metadata.SetupCmdID)
+		assert.Empty(t, metadata.Command)
+		assert.WithinDuration(t, time.Now(), time.Unix(metadata.StartedAt, 0), 5*time.Second)
+	})
+}
@@ -233,28 +233,7 @@ func (e *ExecuteCommand) Execute(ctx core.ExecutionContext) error {
 		return fmt.Errorf("failed to create session: %v", err)
 	}
 
-	command := spec.Command
-	if spec.Cwd != "" {
-		command = fmt.Sprintf("cd %s && %s", spec.Cwd, spec.Command)
-	}
-
-	if len(spec.Env) > 0 {
-		envExports := make([]string, 0, len(spec.Env))
-		for _, env := range spec.Env {
-			name := strings.TrimSpace(env.Name)
-			if name == "" {
-				continue
-			}
-
-			envExports = append(envExports, fmt.Sprintf("%s=%s", name, shellQuote(env.Value)))
-		}
-
-		if len(envExports) > 0 {
-			command = fmt.Sprintf("export %s && %s", strings.Join(envExports, " "), command)
-		}
-	}
-
-	command = wrapCommandWithSandboxSecretEnv(command)
+	command := buildSessionCommand(spec.Command, spec.Cwd, spec.Env)
 
 	response, err := client.ExecuteSessionCommand(spec.Sandbox, sessionID, command)
 	if err != nil {
@@ -362,6 +341,32 @@ func (e *ExecuteCommand) HandleWebhook(ctx core.WebhookRequestContext) (int, *co
 	return http.StatusOK, nil, nil
 }
 
+// buildSessionCommand prefixes command with the working directory change,
+// the exported environment variables and the sandbox secret env loading.
+func buildSessionCommand(command, cwd string, env []EnvVariable) string {
+	if cwd != "" {
+		command = fmt.Sprintf("cd %s && %s", cwd, command)
+	}
+
+	if len(env) > 0 {
+		envExports := make([]string, 0, len(env))
+		for _, variable := range env {
+			name := strings.TrimSpace(variable.Name)
+			if name == "" {
+				continue
+			}
+
+			envExports = append(envExports, fmt.Sprintf("%s=%s", name, shellQuote(variable.Value)))
+		}
+
+		if len(envExports) > 0 {
+			command = fmt.Sprintf("export %s && %s", strings.Join(envExports, " "), command)
+		}
+	}
+
+	return wrapCommandWithSandboxSecretEnv(command)
+}
+
 func shellQuote(value string) string {
 	if value == "" {
 		return "''"
@@ -0,0 +1,150 @@
+package daytona
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// maxReportedTestFailures caps how many failed test cases are listed in the output.
+const maxReportedTestFailures = 50
+
+// TestSummary is the pass/fail/skipped summary of a JUnit XML report.
+type TestSummary struct {
+	Total    int           `json:"total"`
+	Passed   int           `json:"passed"`
+	Failed   int           `json:"failed"`
+	Errors   int           `json:"errors"`
+	Skipped  int           `json:"skipped"`
+	Duration float64       `json:"duration"`
+	Failures []TestFailure `json:"failures"`
+}
+
+// TestFailure is a failed or errored test case.
+type TestFailure struct {
+	Suite     string `json:"suite,omitempty"`
+	ClassName string `json:"className,omitempty"`
+	Name      string `json:"name"`
+	Message   string `json:"message,omitempty"`
+	Type      string `json:"type,omitempty"`
+}
+
+type junitTestSuites struct {
+	Suites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Suites    []junitTestSuite `xml:"testsuite"`
+	TestCases []junitTestCase  `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure"`
+	Error     *junitProblem `xml:"error"`
+	Skipped   *struct{}     `xml:"skipped"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ParseJUnitReport summarizes a JUnit XML report. Both a <testsuites> root and
+// a single <testsuite> root are accepted, and nested suites are flattened.
+// Counts come from the test cases themselves rather than the suite attributes,
+// which some tools leave out or get wrong.
+func ParseJUnitReport(content []byte) (*TestSummary, error) {
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(content, &root); err != nil {
+		return nil, fmt.Errorf("invalid JUnit XML: %v", err)
+	}
+
+	var suites []junitTestSuite
+	switch root.XMLName.Local {
+	case "testsuites":
+		var parsed junitTestSuites
+		if err := xml.Unmarshal(content, &parsed); err != nil {
+			return nil, fmt.Errorf("invalid JUnit XML: %v", err)
+		}
+		suites = parsed.Suites
+	case "testsuite":
+		var parsed junitTestSuite
+		if err := xml.Unmarshal(content, &parsed); err != nil {
+			return nil, fmt.Errorf("invalid JUnit XML: %v", err)
+		}
+		suites = []junitTestSuite{parsed}
+	default:
+		return nil, fmt.Errorf("invalid JUnit XML: unexpected root element <%s>", root.XMLName.Local)
+	}
+
+	summary := &TestSummary{Failures: []TestFailure{}}
+	for _, suite := range suites {
+		summary.addSuite(suite)
+	}
+
+	return summary, nil
+}
+
+func (s *TestSummary) addSuite(suite junitTestSuite) {
+	for _, nested := range suite.Suites {
+		s.addSuite(nested)
+	}
+
+	for _, testCase := range suite.TestCases {
+		s.Total++
+		s.Duration += parseJUnitTime(testCase.Time)
+
+		switch {
+		case testCase.Failure != nil:
+			s.Failed++
+			s.addFailure(suite.Name, testCase, testCase.Failure)
+		case testCase.Error != nil:
+			s.Errors++
+			s.addFailure(suite.Name, testCase, testCase.Error)
+		case testCase.Skipped != nil:
+			s.Skipped++
+		default:
+			s.Passed++
+		}
+	}
+}
+
+func (s *TestSummary) addFailure(suite string, testCase junitTestCase, problem *junitProblem) {
+	if len(s.Failures) >= maxReportedTestFailures {
+		return
+	}
+
+	message := strings.TrimSpace(problem.Message)
+	if message == "" {
+		message = firstLine(problem.Text)
+	}
+
+	s.Failures = append(s.Failures, TestFailure{
+		Suite:     suite,
+		ClassName: testCase.ClassName,
+		Name:      testCase.Name,
+		Message:   message,
+		Type:      problem.Type,
+	})
+}
+
+func parseJUnitTime(value string) float64 {
+	var seconds float64
+	if _, err := fmt.Sscanf(strings.ReplaceAll(strings.TrimSpace(value), ",", ""), "%g", &seconds); err != nil {
+		return 0
+	}
+
+	return seconds
+}
+
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	return strings.TrimSpace(line)
+}
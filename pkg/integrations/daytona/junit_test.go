@@ -0,0 +1,82 @@
+package daytona
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleJUnitReport = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="api" tests="4" failures="1" errors="1" skipped="1">
+    <testcase classname="api.users" name="test_create" time="0.5"/>
+    <testcase classname="api.users" name="test_delete" time="1.25">
+      <failure message="expected 204, got 500" type="AssertionError">Traceback...</failure>
+    </testcase>
+    <testcase classname="api.users" name="test_update" time="0.25">
+      <error type="TimeoutError">
+        connection timed out
+        at line 12
+      </error>
+    </testcase>
+    <testcase classname="api.users" name="test_legacy">
+      <skipped message="deprecated"/>
+    </testcase>
+  </testsuite>
+  <testsuite name="worker">
+    <testsuite name="worker.jobs">
+      <testcase classname="worker.jobs" name="test_retry" time="2"/>
+    </testsuite>
+  </testsuite>
+</testsuites>`
+
+func Test__ParseJUnitReport(t *testing.T) {
+	t.Run("testsuites root with nested suites", func(t *testing.T) {
+		summary, err := ParseJUnitReport([]byte(sampleJUnitReport))
+		require.NoError(t, err)
+
+		assert.Equal(t, 5, summary.Total)
+		assert.Equal(t, 2, summary.Passed)
+		assert.Equal(t, 1, summary.Failed)
+		assert.Equal(t, 1, summary.Errors)
+		assert.Equal(t, 1, summary.Skipped)
+		assert.InDelta(t, 4.0, summary.Duration, 0.001)
+		assert.Equal(t, []TestFailure{
+			{Suite: "api", ClassName: "api.users", Name: "test_delete", Message: "expected 204, got 500", Type: "AssertionError"},
+			{Suite: "api", ClassName: "api.users", Name: "test_update", Message: "connection timed out", Type: "TimeoutError"},
+		}, summary.Failures)
+	})
+
+	t.Run("single testsuite root", func(t *testing.T) {
+		summary, err := ParseJUnitReport([]byte(`<testsuite name="unit"><testcase name="a"/><testcase name="b"/></testsuite>`))
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, summary.Total)
+		assert.Equal(t, 2, summary.Passed)
+		assert.Empty(t, summary.Failures)
+	})
+
+	t.Run("failures are capped", func(t *testing.T) {
+		report := "<testsuite>"
+		for i := 0; i < maxReportedTestFailures+5; i++ {
+			report += `<testcase name="t"><failure message="boom"/></testcase>`
+		}
+		report += "</testsuite>"
+
+		summary, err := ParseJUnitReport([]byte(report))
+		require.NoError(t, err)
+		assert.Equal(t, maxReportedTestFailures+5, summary.Failed)
+		assert.Len(t, summary.Failures, maxReportedTestFailures)
+	})
+
+	t.Run("not XML -> error", func(t *testing.T) {
+		_, err := ParseJUnitReport([]byte("FAIL ./pkg 0.01s"))
+		require.ErrorContains(t, err, "invalid JUnit XML")
+	})
+
+	t.Run("unexpected root element -> error", func(t *testing.T) {
+		_, err := ParseJUnitReport([]byte("<coverage/>"))
+		require.ErrorContains(t, err, "unexpected root element <coverage>")
+	})
+}
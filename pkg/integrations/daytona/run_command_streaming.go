@@ -0,0 +1,296 @@
+package daytona
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	RunCommandStreamingPayloadType      = "daytona.command.finished"
+	RunCommandStreamingLogsPayloadType  = "daytona.command.logs"
+	RunCommandStreamingOutputChannelLog = "logs"
+	RunCommandStreamingPollInterval     = 5 * time.Second
+
+	//
+	// Upper bound for the logs emitted on the logs channel across the whole execution.
+	// Logs past it are dropped, and the last emitted chunk is marked as truncated.
+	//
+	RunCommandStreamingMaxLogBytes = 256 * 1024
+)
+
+type RunCommandStreaming struct{}
+
+type RunCommandStreamingMetadata struct {
+	SandboxID     string `json:"sandboxId" mapstructure:"sandboxId"`
+	SessionID     string `json:"sessionId" mapstructure:"sessionId"`
+	CmdID         string `json:"cmdId" mapstructure:"cmdId"`
+	StartedAt     int64  `json:"startedAt" mapstructure:"startedAt"`
+	Timeout       int    `json:"timeout" mapstructure:"timeout"`
+	LogsOffset    int    `json:"logsOffset" mapstructure:"logsOffset"`
+	EmittedBytes  int    `json:"emittedBytes" mapstructure:"emittedBytes"`
+	EmittedChunks int    `json:"emittedChunks" mapstructure:"emittedChunks"`
+	LogsTruncated bool   `json:"logsTruncated" mapstructure:"logsTruncated"`
+}
+
+type RunCommandStreamingLogsPayload struct {
+	SandboxID string `json:"sandboxId"`
+	Sequence  int    `json:"sequence"`
+	Logs      string `json:"logs"`
+	Truncated bool   `json:"truncated"`
+}
+
+type RunCommandStreamingPayload struct {
+	SandboxID     string `json:"sandboxId"`
+	ExitCode      *int   `json:"exitCode"`
+	Timeout       bool   `json:"timeout"`
+	LogBytes      int    `json:"logBytes"`
+	LogChunks     int    `json:"logChunks"`
+	LogsTruncated bool   `json:"logsTruncated"`
+}
+
+func (r *RunCommandStreaming) Name() string {
+	return "daytona.runCommandStreaming"
+}
+
+func (r *RunCommandStreaming) Label() string {
+	return "Run Command (Streaming)"
+}
+
+func (r *RunCommandStreaming) Description() string {
+	return "Run a long-running command in a sandbox and stream its logs"
+}
+
+func (r *RunCommandStreaming) Documentation() string {
+	return `The Run Command (Streaming) component runs a shell command in an existing Daytona sandbox and emits its logs while it runs.
+
+## Use Cases
+
+- **Long builds and test suites**: Follow the progress of commands that run for minutes
+- **Log-driven workflows**: React to output lines as soon as they are printed
+
+## Configuration
+
+- **Sandbox**: The sandbox ID to run the command in (from **Create Sandbox** or **Create Repository Sandbox** output)
+- **Command**: The shell command to execute
+- **Working Directory**: Optional working directory for the command
+- **Environment Variables**: Optional key-value pairs exported before command execution
+- **Timeout**: Optional execution timeout in seconds
+
+## Output
+
+- **logs**: Emitted every few seconds while the command runs, with the log lines printed since the previous event. Each event has a **sequence** number starting at 1
+- **default**: Emitted once the command finishes, with its **exitCode**, whether it timed out, and how many log bytes and chunks were emitted
+
+## Notes
+
+- At most 256 KiB of logs are emitted on the logs channel. Once the limit is reached, the last chunk is marked as **truncated** and further logs are dropped
+- Non-zero exit codes are reported on the default channel. Use **Execute Command** to route on success and failure`
+}
+
+func (r *RunCommandStreaming) Icon() string {
+	return "daytona"
+}
+
+func (r *RunCommandStreaming) Color() string {
+	return "orange"
+}
+
+func (r *RunCommandStreaming) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		core.DefaultOutputChannel,
+		{Name: RunCommandStreamingOutputChannelLog, Label: "Logs"},
+	}
+}
+
+func (r *RunCommandStreaming) Configuration() []configuration.Field {
+	return (&ExecuteCommand{}).Configuration()
+}
+
+func (r *RunCommandStreaming) Setup(ctx core.SetupContext) error {
+	return (&ExecuteCommand{}).Setup(ctx)
+}
+
+func (r *RunCommandStreaming) Execute(ctx core.ExecutionContext) error {
+	spec := ExecuteCommandSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %v", err)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %v", err)
+	}
+
+	sessionID := uuid.New().String()
+	if err := client.CreateSession(spec.Sandbox, sessionID); err != nil {
+		return fmt.Errorf("failed to create session: %v", err)
+	}
+
+	response, err := client.ExecuteSessionCommand(spec.Sandbox, sessionID, buildSessionCommand(spec.Command, spec.Cwd, spec.Env))
+	if err != nil {
+		return fmt.Errorf("failed to execute command: %v", err)
+	}
+
+	timeout := spec.Timeout
+	if timeout == 0 {
+		timeout = 60
+	}
+
+	metadata := RunCommandStreamingMetadata{
+		SandboxID: spec.Sandbox,
+		SessionID: sessionID,
+		CmdID:     response.CmdID,
+		StartedAt: time.Now().Unix(),
+		Timeout:   timeout,
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return err
+	}
+
+	return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, RunCommandStreamingPollInterval)
+}
+
+func (r *RunCommandStreaming) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (r *RunCommandStreaming) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (r *RunCommandStreaming) Hooks() []core.Hook {
+	return []core.Hook{
+		{Name: "poll", Type: core.HookTypeInternal},
+	}
+}
+
+func (r *RunCommandStreaming) HandleHook(ctx core.ActionHookContext) error {
+	if ctx.Name == "poll" {
+		return r.poll(ctx)
+	}
+	return fmt.Errorf("unknown hook: %s", ctx.Name)
+}
+
+func (r *RunCommandStreaming) poll(ctx core.ActionHookContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	var metadata RunCommandStreamingMetadata
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %v", err)
+	}
+
+	if time.Now().Unix()-metadata.StartedAt > int64(metadata.Timeout) {
+		return r.finish(ctx, &metadata, nil, true)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	session, err := client.GetSession(metadata.SandboxID, metadata.SessionID)
+	if err != nil {
+		return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, RunCommandStreamingPollInterval)
+	}
+
+	cmd := session.FindCommand(metadata.CmdID)
+
+	//
+	// Logs are fetched before checking if the command finished,
+	// so the output printed since the last poll is emitted before the final event.
+	// Once the log limit is reached, nothing else is emitted, so they are no longer fetched.
+	//
+	if !metadata.LogsTruncated {
+		logs, err := client.GetSessionCommandLogs(metadata.SandboxID, metadata.SessionID, metadata.CmdID)
+		if err != nil {
+			ctx.Logger.Warnf("failed to get command logs for %s: %v", metadata.CmdID, err)
+		} else if err := r.emitNewLogs(ctx, &metadata, logs); err != nil {
+			return err
+		}
+	}
+
+	if cmd == nil || cmd.ExitCode == nil {
+		return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, RunCommandStreamingPollInterval)
+	}
+
+	return r.finish(ctx, &metadata, cmd.ExitCode, false)
+}
+
+/*
+ * The session logs endpoint always returns the full output,
+ * so only the part after the stored offset is emitted.
+ * The offset is only stored after the chunk is emitted,
+ * so a failed emit is retried on the next poll.
+ */
+func (r *RunCommandStreaming) emitNewLogs(ctx core.ActionHookContext, metadata *RunCommandStreamingMetadata, logs string) error {
+	if len(logs) <= metadata.LogsOffset {
+		return nil
+	}
+
+	next := *metadata
+	chunk := logs[next.LogsOffset:]
+	next.LogsOffset = len(logs)
+
+	remaining := RunCommandStreamingMaxLogBytes - next.EmittedBytes
+	if len(chunk) > remaining {
+		for remaining > 0 && !utf8.RuneStart(chunk[remaining]) {
+			remaining--
+		}
+
+		chunk = chunk[:remaining]
+		next.LogsTruncated = true
+	}
+
+	next.EmittedBytes += len(chunk)
+	next.EmittedChunks++
+
+	err := ctx.ExecutionState.EmitAndContinue(
+		RunCommandStreamingOutputChannelLog,
+		RunCommandStreamingLogsPayloadType,
+		[]any{RunCommandStreamingLogsPayload{
+			SandboxID: next.SandboxID,
+			Sequence:  next.EmittedChunks,
+			Logs:      chunk,
+			Truncated: next.LogsTruncated,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+
+	*metadata = next
+	return ctx.Metadata.Set(*metadata)
+}
+
+func (r *RunCommandStreaming) finish(ctx core.ActionHookContext, metadata *RunCommandStreamingMetadata, exitCode *int, timedOut bool) error {
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		RunCommandStreamingPayloadType,
+		[]any{RunCommandStreamingPayload{
+			SandboxID:     metadata.SandboxID,
+			ExitCode:      exitCode,
+			Timeout:       timedOut,
+			LogBytes:      metadata.EmittedBytes,
+			LogChunks:     metadata.EmittedChunks,
+			LogsTruncated: metadata.LogsTruncated,
+		}},
+	)
+}
+
+func (r *RunCommandStreaming) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (r *RunCommandStreaming) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,271 @@
+package daytona
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__RunCommandStreaming__Execute(t *testing.T) {
+	component := RunCommandStreaming{}
+
+	httpContext := &contexts.HTTPContext{
+		Responses: []*http.Response{
+			toolboxConfigResponse(),
+			{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))},
+			toolboxConfigResponse(),
+			{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"cmdId":"cmd-001"}`))},
+		},
+	}
+
+	metadataCtx := &contexts.MetadataContext{}
+	requestCtx := &contexts.RequestContext{}
+	execCtx := &contexts.ExecutionStateContext{}
+	err := component.Execute(core.ExecutionContext{
+		Configuration: map[string]any{
+			"sandbox": "sandbox-123",
+			"command": "make test",
+			"timeout": 600,
+		},
+		HTTP:           httpContext,
+		Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+		ExecutionState: execCtx,
+		Metadata:       metadataCtx,
+		Requests:       requestCtx,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, execCtx.Finished)
+	assert.Equal(t, "poll", requestCtx.Action)
+	assert.Equal(t, RunCommandStreamingPollInterval, requestCtx.Duration)
+
+	metadata, ok := metadataCtx.Metadata.(RunCommandStreamingMetadata)
+	require.True(t, ok)
+	assert.Equal(t, "sandbox-123", metadata.SandboxID)
+	assert.Equal(t, "cmd-001", metadata.CmdID)
+	assert.Equal(t, 600, metadata.Timeout)
+	assert.Zero(t, metadata.LogsOffset)
+}
+
+func Test__RunCommandStreaming__Poll(t *testing.T) {
+	component := RunCommandStreaming{}
+
+	poll := func(metadataCtx *contexts.MetadataContext, execCtx *contexts.ExecutionStateContext, exitCode string, logs string) *contexts.RequestContext {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				toolboxConfigResponse(),
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"sessionId":"session-1","commands":[{"id":"cmd-001","exitCode":` + exitCode + `}]}`))},
+				toolboxConfigResponse(),
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(logs))},
+			},
+		}
+
+		requestCtx := &contexts.RequestContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           "poll",
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			Logger:         log.NewEntry(log.New()),
+			Metadata:       metadataCtx,
+			ExecutionState: execCtx,
+			Requests:       requestCtx,
+		})
+
+		require.NoError(t, err)
+		return requestCtx
+	}
+
+	newMetadata := func() *contexts.MetadataContext {
+		return &contexts.MetadataContext{Metadata: RunCommandStreamingMetadata{
+			SandboxID: "sandbox-123",
+			SessionID: "session-1",
+			CmdID:     "cmd-001",
+			StartedAt: time.Now().Unix(),
+			Timeout:   600,
+		}}
+	}
+
+	t.Run("emits incremental logs before the command finishes", func(t *testing.T) {
+		metadataCtx := newMetadata()
+
+		execCtx := &contexts.ExecutionStateContext{}
+		requestCtx := poll(metadataCtx, execCtx, "null", "building...\n")
+		assert.False(t, execCtx.Finished)
+		assert.Equal(t, RunCommandStreamingOutputChannelLog, execCtx.Channel)
+		assert.Equal(t, RunCommandStreamingLogsPayloadType, execCtx.Type)
+		require.Len(t, execCtx.Payloads, 1)
+		assert.Equal(t, RunCommandStreamingLogsPayload{
+			SandboxID: "sandbox-123",
+			Sequence:  1,
+			Logs:      "building...\n",
+		}, execCtx.Payloads[0].(map[string]any)["data"])
+		assert.Equal(t, "poll", requestCtx.Action)
+
+		execCtx = &contexts.ExecutionStateContext{}
+		poll(metadataCtx, execCtx, "null", "building...\ntesting...\n")
+		assert.False(t, execCtx.Finished)
+		require.Len(t, execCtx.Payloads, 1)
+		chunk := execCtx.Payloads[0].(map[string]any)["data"].(RunCommandStreamingLogsPayload)
+		assert.Equal(t, 2, chunk.Sequence)
+		assert.Equal(t, "testing...\n", chunk.Logs)
+
+		execCtx = &contexts.ExecutionStateContext{}
+		requestCtx = poll(metadataCtx, execCtx, "null", "building...\ntesting...\n")
+		assert.False(t, execCtx.Finished)
+		assert.Empty(t, execCtx.Payloads, "no new logs means no event")
+		assert.Equal(t, "poll", requestCtx.Action)
+
+		execCtx = &contexts.ExecutionStateContext{}
+		requestCtx = poll(metadataCtx, execCtx, "1", "building...\ntesting...\nFAIL\n")
+		assert.True(t, execCtx.Finished)
+		assert.Empty(t, requestCtx.Action)
+		assert.Equal(t, core.DefaultOutputChannel.Name, execCtx.Channel)
+		assert.Equal(t, RunCommandStreamingPayloadType, execCtx.Type)
+
+		result := execCtx.Payloads[0].(map[string]any)["data"].(RunCommandStreamingPayload)
+		require.NotNil(t, result.ExitCode)
+		assert.Equal(t, 1, *result.ExitCode)
+		assert.False(t, result.Timeout)
+		assert.Equal(t, 3, result.LogChunks)
+		assert.Equal(t, len("building...\ntesting...\nFAIL\n"), result.LogBytes)
+		assert.False(t, result.LogsTruncated)
+	})
+
+	t.Run("emitted log volume is bounded", func(t *testing.T) {
+		metadataCtx := newMetadata()
+		firstChunk := strings.Repeat("a", RunCommandStreamingMaxLogBytes-10)
+
+		execCtx := &contexts.ExecutionStateContext{}
+		poll(metadataCtx, execCtx, "null", firstChunk)
+		require.Len(t, execCtx.Payloads, 1)
+
+		execCtx = &contexts.ExecutionStateContext{}
+		poll(metadataCtx, execCtx, "null", firstChunk+strings.Repeat("b", 100))
+		require.Len(t, execCtx.Payloads, 1)
+		chunk := execCtx.Payloads[0].(map[string]any)["data"].(RunCommandStreamingLogsPayload)
+		assert.Equal(t, strings.Repeat("b", 10), chunk.Logs)
+		assert.True(t, chunk.Truncated)
+
+		execCtx = &contexts.ExecutionStateContext{}
+		poll(metadataCtx, execCtx, "null", firstChunk+strings.Repeat("b", 200))
+		assert.Empty(t, execCtx.Payloads, "logs past the limit are dropped")
+
+		execCtx = &contexts.ExecutionStateContext{}
+		poll(metadataCtx, execCtx, "0", firstChunk+strings.Repeat("b", 300))
+		result := execCtx.Payloads[0].(map[string]any)["data"].(RunCommandStreamingPayload)
+		assert.Equal(t, RunCommandStreamingMaxLogBytes, result.LogBytes)
+		assert.True(t, result.LogsTruncated)
+	})
+
+	t.Run("truncated logs are no longer fetched", func(t *testing.T) {
+		metadataCtx := newMetadata()
+		metadata := metadataCtx.Metadata.(RunCommandStreamingMetadata)
+		metadata.LogsTruncated = true
+		metadataCtx.Metadata = metadata
+
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				toolboxConfigResponse(),
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"sessionId":"session-1","commands":[{"id":"cmd-001","exitCode":null}]}`))},
+			},
+		}
+
+		execCtx := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           "poll",
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			Logger:         log.NewEntry(log.New()),
+			Metadata:       metadataCtx,
+			ExecutionState: execCtx,
+			Requests:       &contexts.RequestContext{},
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, httpContext.Requests, 2)
+		assert.Empty(t, execCtx.Payloads)
+	})
+
+	t.Run("failed emit does not advance the logs offset", func(t *testing.T) {
+		metadataCtx := newMetadata()
+
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				toolboxConfigResponse(),
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"sessionId":"session-1","commands":[{"id":"cmd-001","exitCode":null}]}`))},
+				toolboxConfigResponse(),
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("building...\n"))},
+			},
+		}
+
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           "poll",
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			Logger:         log.NewEntry(log.New()),
+			Metadata:       metadataCtx,
+			ExecutionState: &failingEmitExecutionState{ExecutionStateContext: &contexts.ExecutionStateContext{}},
+			Requests:       &contexts.RequestContext{},
+		})
+
+		require.ErrorContains(t, err, "emit failed")
+		metadata := metadataCtx.Metadata.(RunCommandStreamingMetadata)
+		assert.Equal(t, 0, metadata.LogsOffset)
+		assert.Equal(t, 0, metadata.EmittedChunks)
+
+		execCtx := &contexts.ExecutionStateContext{}
+		poll(metadataCtx, execCtx, "null", "building...\n")
+		require.Len(t, execCtx.Payloads, 1)
+		chunk := execCtx.Payloads[0].(map[string]any)["data"].(RunCommandStreamingLogsPayload)
+		assert.Equal(t, 1, chunk.Sequence)
+		assert.Equal(t, "building...\n", chunk.Logs)
+	})
+
+	t.Run("timeout finishes on the default channel", func(t *testing.T) {
+		metadataCtx := &contexts.MetadataContext{Metadata: RunCommandStreamingMetadata{
+			SandboxID: "sandbox-123",
+			SessionID: "session-1",
+			CmdID:     "cmd-001",
+			StartedAt: time.Now().Add(-2 * time.Minute).Unix(),
+			Timeout:   60,
+		}}
+
+		execCtx := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           "poll",
+			Metadata:       metadataCtx,
+			ExecutionState: execCtx,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, execCtx.Finished)
+		assert.Equal(t, core.DefaultOutputChannel.Name, execCtx.Channel)
+		result := execCtx.Payloads[0].(map[string]any)["data"].(RunCommandStreamingPayload)
+		assert.Nil(t, result.ExitCode)
+		assert.True(t, result.Timeout)
+	})
+}
+
+type failingEmitExecutionState struct {
+	*contexts.ExecutionStateContext
+}
+
+func (s *failingEmitExecutionState) EmitAndContinue(channel, payloadType string, payloads []any) error {
+	return fmt.Errorf("emit failed")
+}
+
+func toolboxConfigResponse() *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"proxyToolboxUrl":"https://app.daytona.io/api/toolbox"}`)),
+	}
+}
@@ -0,0 +1,436 @@
+package daytona
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	RunCommandsPayloadType          = "daytona.commands.response"
+	RunCommandsPollInterval         = 5 * time.Second
+	RunCommandsOutputChannelSuccess = "success"
+	RunCommandsOutputChannelFailed  = "failed"
+	RunCommandsDefaultTimeout       = 300
+)
+
+type RunCommands struct{}
+
+type RunCommandsSpec struct {
+	Sandbox  string        `json:"sandbox"`
+	Commands []string      `json:"commands"`
+	Cwd      string        `json:"cwd,omitempty"`
+	Env      []EnvVariable `json:"env,omitempty"`
+	Timeout  int           `json:"timeout,omitempty"`
+}
+
+type RunCommandsMetadata struct {
+	SandboxID    string             `json:"sandboxId" mapstructure:"sandboxId"`
+	SessionID    string             `json:"sessionId" mapstructure:"sessionId"`
+	Commands     []string           `json:"commands" mapstructure:"commands"`
+	CommandIndex int                `json:"commandIndex" mapstructure:"commandIndex"`
+	CmdID        string             `json:"cmdId" mapstructure:"cmdId"`
+	StartedAt    int64              `json:"startedAt" mapstructure:"startedAt"`
+	Timeout      int                `json:"timeout" mapstructure:"timeout"`
+	Results      []RunCommandResult `json:"results" mapstructure:"results"`
+}
+
+// RunCommandResult is the outcome of a single command in a RunCommands batch.
+type RunCommandResult struct {
+	Command  string `json:"command" mapstructure:"command"`
+	ExitCode int    `json:"exitCode" mapstructure:"exitCode"`
+	Result   string `json:"result" mapstructure:"result"`
+}
+
+// RunCommandsResponse is the payload emitted once the batch finishes.
+type RunCommandsResponse struct {
+	ExitCode           *int               `json:"exitCode"`
+	Timeout            bool               `json:"timeout"`
+	FailedCommandIndex *int               `json:"failedCommandIndex"`
+	FailedCommand      string             `json:"failedCommand,omitempty"`
+	Commands           []RunCommandResult `json:"commands"`
+	Result             string             `json:"result"`
+}
+
+func (r *RunCommands) Name() string {
+	return "daytona.runCommands"
+}
+
+func (r *RunCommands) Label() string {
+	return "Run Commands"
+}
+
+func (r *RunCommands) Description() string {
+	return "Run an ordered list of shell commands in one sandbox session"
+}
+
+func (r *RunCommands) Documentation() string {
+	return `The Run Commands component runs several shell commands, in order, in a single session of an existing Daytona sandbox.
+
+## Use Cases
+
+- **Build pipelines**: Install dependencies, build, and test in one step
+- **Setup scripts**: Run a sequence of setup commands without chaining several nodes
+- **Shared shell state**: Later commands see directory changes and exported variables from earlier ones
+
+## Configuration
+
+- **Sandbox**: The sandbox ID to run commands in (from **Create Sandbox** or **Create Repository Sandbox** output). Supports expressions, e.g. ` + "`" + `{{ previous().data.id }}` + "`" + `
+- **Commands**: The shell commands to execute, in order
+- **Working Directory**: Optional working directory the session starts in
+- **Environment Variables**: Optional key-value pairs exported before the first command
+- **Timeout**: Optional timeout in seconds for the whole batch
+
+## Output
+
+Routes to one of two channels:
+- **success**: Every command exited with code 0
+- **failed**: A command exited with a non-zero code, or the batch timed out
+
+The payload includes:
+- **exitCode**: The exit code of the last command that ran
+- **timeout**: Whether the batch timed out
+- **failedCommandIndex**: Zero-based index of the command that failed, or null on success
+- **failedCommand**: The command that failed, if any
+- **commands**: The command, exit code, and output of each command that ran
+- **result**: The output of all commands that ran, each preceded by the command itself
+
+## Notes
+
+- Commands run one at a time; the next command starts only after the previous one exits with code 0
+- Commands after the first failing one are not run
+- All commands share one session, so ` + "`cd`" + ` and ` + "`export`" + ` carry over to later commands`
+}
+
+func (r *RunCommands) Icon() string {
+	return "daytona"
+}
+
+func (r *RunCommands) Color() string {
+	return "orange"
+}
+
+func (r *RunCommands) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: RunCommandsOutputChannelSuccess, Label: "Success"},
+		{Name: RunCommandsOutputChannelFailed, Label: "Failed"},
+	}
+}
+
+func (r *RunCommands) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "sandbox",
+			Label:       "Sandbox",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "Sandbox to run the commands in",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "sandbox",
+				},
+			},
+		},
+		{
+			Name:        "commands",
+			Label:       "Commands",
+			Type:        configuration.FieldTypeList,
+			Required:    true,
+			Description: "Shell commands to execute, in order",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Command",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+		},
+		{
+			Name:        "cwd",
+			Label:       "Working Directory",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Working directory the session starts in",
+			Placeholder: "/home/daytona",
+		},
+		{
+			Name:  "env",
+			Label: "Environment Variables",
+			Type:  configuration.FieldTypeList,
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Variable",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:     "name",
+								Label:    "Name",
+								Type:     configuration.FieldTypeString,
+								Required: true,
+							},
+							{
+								Name:     "value",
+								Label:    "Value",
+								Type:     configuration.FieldTypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+			},
+			Required:    false,
+			Description: "Environment variables to export before running the first command",
+		},
+		{
+			Name:        "timeout",
+			Label:       "Timeout",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Description: "Timeout in seconds for the whole batch",
+			Default:     RunCommandsDefaultTimeout,
+		},
+	}
+}
+
+func (r *RunCommands) Setup(ctx core.SetupContext) error {
+	spec := RunCommandsSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %v", err)
+	}
+
+	if spec.Sandbox == "" {
+		return fmt.Errorf("sandbox is required")
+	}
+
+	if len(spec.Commands) == 0 {
+		return fmt.Errorf("at least one command is required")
+	}
+
+	for i, command := range spec.Commands {
+		if strings.TrimSpace(command) == "" {
+			return fmt.Errorf("command %d is empty", i+1)
+		}
+	}
+
+	for _, env := range spec.Env {
+		name := strings.TrimSpace(env.Name)
+		if name == "" {
+			return fmt.Errorf("env variable name is required")
+		}
+
+		if !envVariableNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid env variable name: %s", env.Name)
+		}
+	}
+
+	return nil
+}
+
+func (r *RunCommands) Execute(ctx core.ExecutionContext) error {
+	spec := RunCommandsSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %v", err)
+	}
+
+	if len(spec.Commands) == 0 {
+		return fmt.Errorf("at least one command is required")
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %v", err)
+	}
+
+	sessionID := uuid.New().String()
+	if err := client.CreateSession(spec.Sandbox, sessionID); err != nil {
+		return fmt.Errorf("failed to create session: %v", err)
+	}
+
+	//
+	// The working directory, environment variables and secrets are only
+	// applied to the first command. The session keeps that shell state
+	// for the commands that follow.
+	//
+	command := buildSessionCommand(spec.Commands[0], spec.Cwd, spec.Env)
+	response, err := client.ExecuteSessionCommand(spec.Sandbox, sessionID, command)
+	if err != nil {
+		return fmt.Errorf("failed to execute command 1: %v", err)
+	}
+
+	timeout := spec.Timeout
+	if timeout == 0 {
+		timeout = RunCommandsDefaultTimeout
+	}
+
+	metadata := RunCommandsMetadata{
+		SandboxID:    spec.Sandbox,
+		SessionID:    sessionID,
+		Commands:     spec.Commands,
+		CommandIndex: 0,
+		CmdID:        response.CmdID,
+		StartedAt:    time.Now().Unix(),
+		Timeout:      timeout,
+		Results:      []RunCommandResult{},
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return err
+	}
+
+	return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, RunCommandsPollInterval)
+}
+
+func (r *RunCommands) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (r *RunCommands) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (r *RunCommands) Hooks() []core.Hook {
+	return []core.Hook{
+		{Name: "poll", Type: core.HookTypeInternal},
+	}
+}
+
+func (r *RunCommands) HandleHook(ctx core.ActionHookContext) error {
+	if ctx.Name == "poll" {
+		return r.poll(ctx)
+	}
+	return fmt.Errorf("unknown hook: %s", ctx.Name)
+}
+
+func (r *RunCommands) poll(ctx core.ActionHookContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	var metadata RunCommandsMetadata
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %v", err)
+	}
+
+	if time.Now().Unix()-metadata.StartedAt > int64(metadata.Timeout) {
+		index := metadata.CommandIndex
+		response := newRunCommandsResponse(metadata.Results)
+		response.Timeout = true
+		response.FailedCommandIndex = &index
+		response.FailedCommand = metadata.currentCommand()
+		response.Result = appendCommandOutput(
+			response.Result,
+			metadata.currentCommand(),
+			fmt.Sprintf("commands timed out after %d seconds", metadata.Timeout),
+		)
+
+		return ctx.ExecutionState.Emit(RunCommandsOutputChannelFailed, RunCommandsPayloadType, []any{response})
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	session, err := client.GetSession(metadata.SandboxID, metadata.SessionID)
+	if err != nil {
+		return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, RunCommandsPollInterval)
+	}
+
+	cmd := session.FindCommand(metadata.CmdID)
+	if cmd == nil || cmd.ExitCode == nil {
+		return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, RunCommandsPollInterval)
+	}
+
+	logs, err := client.GetSessionCommandLogs(metadata.SandboxID, metadata.SessionID, metadata.CmdID)
+	if err != nil {
+		logs = ""
+	}
+
+	metadata.Results = append(metadata.Results, RunCommandResult{
+		Command:  metadata.currentCommand(),
+		ExitCode: *cmd.ExitCode,
+		Result:   logs,
+	})
+
+	if *cmd.ExitCode != 0 {
+		index := metadata.CommandIndex
+		response := newRunCommandsResponse(metadata.Results)
+		response.FailedCommandIndex = &index
+		response.FailedCommand = metadata.currentCommand()
+		return ctx.ExecutionState.Emit(RunCommandsOutputChannelFailed, RunCommandsPayloadType, []any{response})
+	}
+
+	if metadata.CommandIndex == len(metadata.Commands)-1 {
+		response := newRunCommandsResponse(metadata.Results)
+		return ctx.ExecutionState.Emit(RunCommandsOutputChannelSuccess, RunCommandsPayloadType, []any{response})
+	}
+
+	metadata.CommandIndex++
+	next, err := client.ExecuteSessionCommand(metadata.SandboxID, metadata.SessionID, metadata.currentCommand())
+	if err != nil {
+		return fmt.Errorf("failed to execute command %d: %v", metadata.CommandIndex+1, err)
+	}
+
+	metadata.CmdID = next.CmdID
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return err
+	}
+
+	return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, RunCommandsPollInterval)
+}
+
+func (m *RunCommandsMetadata) currentCommand() string {
+	if m.CommandIndex < 0 || m.CommandIndex >= len(m.Commands) {
+		return ""
+	}
+
+	return m.Commands[m.CommandIndex]
+}
+
+func newRunCommandsResponse(results []RunCommandResult) *RunCommandsResponse {
+	response := &RunCommandsResponse{Commands: results}
+	if response.Commands == nil {
+		response.Commands = []RunCommandResult{}
+	}
+
+	for _, result := range results {
+		response.Result = appendCommandOutput(response.Result, result.Command, result.Result)
+	}
+
+	if len(results) > 0 {
+		exitCode := results[len(results)-1].ExitCode
+		response.ExitCode = &exitCode
+	}
+
+	return response
+}
+
+func appendCommandOutput(output, command, logs string) string {
+	var builder strings.Builder
+	builder.WriteString(output)
+	builder.WriteString("$ ")
+	builder.WriteString(command)
+	builder.WriteString("\n")
+	builder.WriteString(logs)
+	if logs != "" && !strings.HasSuffix(logs, "\n") {
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
+
+func (r *RunCommands) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (r *RunCommands) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,310 @@
+package daytona
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const runCommandsToolboxConfig = `{"proxyToolboxUrl":"https://app.daytona.io/api/toolbox"}`
+
+func runCommandsResponse(status int, body string) *http.Response {
+	return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func Test__RunCommands__Setup(t *testing.T) {
+	component := RunCommands{}
+
+	t.Run("sandbox is required", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"commands": []string{"echo hello"},
+			},
+		})
+
+		require.ErrorContains(t, err, "sandbox is required")
+	})
+
+	t.Run("empty command list -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"sandbox":  "sandbox-123",
+				"commands": []string{},
+			},
+		})
+
+		require.ErrorContains(t, err, "at least one command is required")
+	})
+
+	t.Run("blank command -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"sandbox":  "sandbox-123",
+				"commands": []string{"npm ci", "  "},
+			},
+		})
+
+		require.ErrorContains(t, err, "command 2 is empty")
+	})
+
+	t.Run("valid setup", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"sandbox":  "sandbox-123",
+				"commands": []string{"npm ci", "npm test"},
+				"env":      []map[string]any{{"name": "CI", "value": "true"}},
+			},
+		})
+
+		require.NoError(t, err)
+	})
+}
+
+func Test__RunCommands__Execute(t *testing.T) {
+	component := RunCommands{}
+
+	t.Run("creates one session and starts the first command", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				runCommandsResponse(http.StatusOK, runCommandsToolboxConfig),
+				runCommandsResponse(http.StatusOK, `{}`),
+				runCommandsResponse(http.StatusOK, runCommandsToolboxConfig),
+				runCommandsResponse(http.StatusOK, `{"cmdId":"cmd-001"}`),
+			},
+		}
+
+		metadataCtx := &contexts.MetadataContext{}
+		requestCtx := &contexts.RequestContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"sandbox":  "sandbox-123",
+				"commands": []string{"npm ci", "npm test"},
+				"cwd":      "/home/daytona/app",
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: &contexts.ExecutionStateContext{},
+			Metadata:       metadataCtx,
+			Requests:       requestCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "poll", requestCtx.Action)
+
+		require.Len(t, httpContext.Requests, 4)
+		body, _ := io.ReadAll(httpContext.Requests[3].Body)
+		req := SessionExecuteRequest{}
+		require.NoError(t, json.Unmarshal(body, &req))
+		assert.Contains(t, req.Command, "cd /home/daytona/app && npm ci")
+
+		metadata, ok := metadataCtx.Metadata.(RunCommandsMetadata)
+		require.True(t, ok)
+		assert.Equal(t, "cmd-001", metadata.CmdID)
+		assert.Equal(t, 0, metadata.CommandIndex)
+		assert.Equal(t, []string{"npm ci", "npm test"}, metadata.Commands)
+		assert.Equal(t, RunCommandsDefaultTimeout, metadata.Timeout)
+		assert.NotEmpty(t, metadata.SessionID)
+	})
+}
+
+func Test__RunCommands__HandleHook(t *testing.T) {
+	component := RunCommands{}
+	integration := &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}}
+
+	t.Run("successful command starts the next one in the same session", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				runCommandsResponse(http.StatusOK, runCommandsToolboxConfig),
+				runCommandsResponse(http.StatusOK, `{"sessionId":"session-abc","commands":[{"id":"cmd-001","exitCode":0}]}`),
+				runCommandsResponse(http.StatusOK, runCommandsToolboxConfig),
+				runCommandsResponse(http.StatusOK, `installed`),
+				runCommandsResponse(http.StatusOK, runCommandsToolboxConfig),
+				runCommandsResponse(http.StatusOK, `{"cmdId":"cmd-002"}`),
+			},
+		}
+
+		metadataCtx := &contexts.MetadataContext{
+			Metadata: map[string]any{
+				"sandboxId":    "sandbox-123",
+				"sessionId":    "session-abc",
+				"commands":     []any{"npm ci", "npm run build", "npm test"},
+				"commandIndex": 0,
+				"cmdId":        "cmd-001",
+				"startedAt":    time.Now().Unix(),
+				"timeout":      300,
+			},
+		}
+
+		execCtx := &contexts.ExecutionStateContext{}
+		requestCtx := &contexts.RequestContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           "poll",
+			HTTP:           httpContext,
+			Integration:    integration,
+			Metadata:       metadataCtx,
+			ExecutionState: execCtx,
+			Requests:       requestCtx,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, execCtx.Finished)
+		assert.Equal(t, "poll", requestCtx.Action)
+
+		require.Len(t, httpContext.Requests, 6)
+		assert.Contains(t, httpContext.Requests[5].URL.Path, "/process/session/session-abc/exec")
+
+		metadata, ok := metadataCtx.Metadata.(RunCommandsMetadata)
+		require.True(t, ok)
+		assert.Equal(t, 1, metadata.CommandIndex)
+		assert.Equal(t, "cmd-002", metadata.CmdID)
+		require.Len(t, metadata.Results, 1)
+		assert.Equal(t, RunCommandResult{Command: "npm ci", ExitCode: 0, Result: "installed"}, metadata.Results[0])
+	})
+
+	t.Run("middle command fails -> stops and reports it", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				runCommandsResponse(http.StatusOK, runCommandsToolboxConfig),
+				runCommandsResponse(http.StatusOK, `{"sessionId":"session-abc","commands":[{"id":"cmd-001","exitCode":0},{"id":"cmd-002","exitCode":2}]}`),
+				runCommandsResponse(http.StatusOK, runCommandsToolboxConfig),
+				runCommandsResponse(http.StatusOK, "build failed\n"),
+			},
+		}
+
+		execCtx := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:        "poll",
+			HTTP:        httpContext,
+			Integration: integration,
+			Metadata: &contexts.MetadataContext{
+				Metadata: map[string]any{
+					"sandboxId":    "sandbox-123",
+					"sessionId":    "session-abc",
+					"commands":     []any{"npm ci", "npm run build", "npm test"},
+					"commandIndex": 1,
+					"cmdId":        "cmd-002",
+					"startedAt":    time.Now().Unix(),
+					"timeout":      300,
+					"results": []any{
+						map[string]any{"command": "npm ci", "exitCode": 0, "result": "installed"},
+					},
+				},
+			},
+			ExecutionState: execCtx,
+			Requests:       &contexts.RequestContext{},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, execCtx.Finished)
+		assert.Equal(t, RunCommandsOutputChannelFailed, execCtx.Channel)
+		assert.Equal(t, RunCommandsPayloadType, execCtx.Type)
+
+		// The third command is never started.
+		require.Len(t, httpContext.Requests, 4)
+
+		require.Len(t, execCtx.Payloads, 1)
+		data, ok := execCtx.Payloads[0].(map[string]any)["data"].(*RunCommandsResponse)
+		require.True(t, ok)
+		require.NotNil(t, data.FailedCommandIndex)
+		assert.Equal(t, 1, *data.FailedCommandIndex)
+		assert.Equal(t, "npm run build", data.FailedCommand)
+		require.NotNil(t, data.ExitCode)
+		assert.Equal(t, 2, *data.ExitCode)
+		assert.False(t, data.Timeout)
+		assert.Equal(t, []RunCommandResult{
+			{Command: "npm ci", ExitCode: 0, Result: "installed"},
+			{Command: "npm run build", ExitCode: 2, Result: "build failed\n"},
+		}, data.Commands)
+		assert.Equal(t, "$ npm ci\ninstalled\n$ npm run build\nbuild failed\n", data.Result)
+	})
+
+	t.Run("last command succeeds -> emits success", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				runCommandsResponse(http.StatusOK, runCommandsToolboxConfig),
+				runCommandsResponse(http.StatusOK, `{"sessionId":"session-abc","commands":[{"id":"cmd-002","exitCode":0}]}`),
+				runCommandsResponse(http.StatusOK, runCommandsToolboxConfig),
+				runCommandsResponse(http.StatusOK, "ok"),
+			},
+		}
+
+		execCtx := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:        "poll",
+			HTTP:        httpContext,
+			Integration: integration,
+			Metadata: &contexts.MetadataContext{
+				Metadata: map[string]any{
+					"sandboxId":    "sandbox-123",
+					"sessionId":    "session-abc",
+					"commands":     []any{"npm ci", "npm test"},
+					"commandIndex": 1,
+					"cmdId":        "cmd-002",
+					"startedAt":    time.Now().Unix(),
+					"timeout":      300,
+					"results": []any{
+						map[string]any{"command": "npm ci", "exitCode": 0, "result": "installed"},
+					},
+				},
+			},
+			ExecutionState: execCtx,
+			Requests:       &contexts.RequestContext{},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, execCtx.Passed)
+		assert.Equal(t, RunCommandsOutputChannelSuccess, execCtx.Channel)
+
+		data := execCtx.Payloads[0].(map[string]any)["data"].(*RunCommandsResponse)
+		assert.Nil(t, data.FailedCommandIndex)
+		assert.Len(t, data.Commands, 2)
+	})
+
+	t.Run("timeout -> emits failed with the running command", func(t *testing.T) {
+		execCtx := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:        "poll",
+			HTTP:        &contexts.HTTPContext{},
+			Integration: integration,
+			Metadata: &contexts.MetadataContext{
+				Metadata: map[string]any{
+					"sandboxId":    "sandbox-123",
+					"sessionId":    "session-abc",
+					"commands":     []any{"npm ci", "npm test"},
+					"commandIndex": 1,
+					"cmdId":        "cmd-002",
+					"startedAt":    time.Now().Add(-10 * time.Minute).Unix(),
+					"timeout":      60,
+				},
+			},
+			ExecutionState: execCtx,
+			Requests:       &contexts.RequestContext{},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, RunCommandsOutputChannelFailed, execCtx.Channel)
+
+		data := execCtx.Payloads[0].(map[string]any)["data"].(*RunCommandsResponse)
+		assert.True(t, data.Timeout)
+		require.NotNil(t, data.FailedCommandIndex)
+		assert.Equal(t, 1, *data.FailedCommandIndex)
+		assert.Equal(t, "npm test", data.FailedCommand)
+		assert.Nil(t, data.ExitCode)
+	})
+}
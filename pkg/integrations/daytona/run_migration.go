@@ -0,0 +1,386 @@
+package daytona
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	RunMigrationPayloadType       = "daytona.migration.result"
+	RunMigrationPollInterval      = 5 * time.Second
+	RunMigrationDefaultTimeout    = 600
+	RunMigrationDefaultEnvVarName = "DATABASE_URL"
+
+	// runMigrationLogTail is how much of the end of the migration output is
+	// included in the failure message. Migration tools print the error last.
+	runMigrationLogTail = 2048
+)
+
+type RunMigration struct{}
+
+type RunMigrationSpec struct {
+	Sandbox     string                     `json:"sandbox"`
+	Command     string                     `json:"command"`
+	DatabaseURL configuration.SecretKeyRef `json:"databaseUrl" mapstructure:"databaseUrl"`
+	EnvVarName  string                     `json:"envVarName,omitempty" mapstructure:"envVarName"`
+	Cwd         string                     `json:"cwd,omitempty"`
+	Timeout     int                        `json:"timeout,omitempty"`
+}
+
+type RunMigrationMetadata struct {
+	SandboxID string `json:"sandboxId" mapstructure:"sandboxId"`
+	SessionID string `json:"sessionId" mapstructure:"sessionId"`
+	CmdID     string `json:"cmdId" mapstructure:"cmdId"`
+	StartedAt int64  `json:"startedAt" mapstructure:"startedAt"`
+	Timeout   int    `json:"timeout" mapstructure:"timeout"`
+}
+
+// RunMigrationResponse is the payload emitted when the migration succeeds.
+type RunMigrationResponse struct {
+	ExitCode int    `json:"exitCode"`
+	Output   string `json:"output"`
+}
+
+func (r *RunMigration) Name() string {
+	return "daytona.runMigration"
+}
+
+func (r *RunMigration) Label() string {
+	return "Run Migration"
+}
+
+func (r *RunMigration) Description() string {
+	return "Run a database migration in a sandbox with the database URL injected from a secret"
+}
+
+func (r *RunMigration) Documentation() string {
+	return `The Run Migration component runs a database migration command in an existing Daytona sandbox. The database URL is read from an organization secret and exposed to the command as an environment variable, so it never appears in the workflow configuration or in the command itself.
+
+## Use Cases
+
+- **Release pipelines**: Migrate the staging or production database before deploying a new version
+- **Preview environments**: Prepare the database of a preview environment after cloning the repository with **Create Repository Sandbox**
+
+## Configuration
+
+- **Sandbox**: The sandbox ID to run the migration in (from **Create Sandbox** or **Create Repository Sandbox** output). Supports expressions, e.g. ` + "`" + `{{ previous().data.sandboxId }}` + "`" + `
+- **Migration Command**: The command that runs the migrations, e.g. ` + "`" + `npx prisma migrate deploy` + "`" + ` or ` + "`" + `migrate -path db/migrations -database "$DATABASE_URL" up` + "`" + `
+- **Database URL**: The secret and key holding the database connection URL
+- **Environment Variable**: The name the database URL is exported as (defaults to ` + "`DATABASE_URL`" + `)
+- **Working Directory**: Optional working directory for the command
+- **Timeout**: Optional timeout in seconds (defaults to 600)
+
+## Output
+
+Emits on the default channel when the migration command exits with code 0. The payload includes:
+- **exitCode**: The exit code of the migration command
+- **output**: The output of the migration command
+
+The execution fails when the command exits with a non-zero code or times out. The failure message includes the end of the command output.
+
+## Notes
+
+- The database URL is written to a file readable only by the sandbox user, loaded into the command's environment and removed before the migration starts
+- Secrets injected when the sandbox was created are also available to the command`
+}
+
+func (r *RunMigration) Icon() string {
+	return "daytona"
+}
+
+func (r *RunMigration) Color() string {
+	return "orange"
+}
+
+func (r *RunMigration) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (r *RunMigration) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "sandbox",
+			Label:       "Sandbox",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "Sandbox to run the migration in",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "sandbox",
+				},
+			},
+		},
+		{
+			Name:        "command",
+			Label:       "Migration Command",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Command that runs the database migrations",
+			Placeholder: "npx prisma migrate deploy",
+		},
+		{
+			Name:        "databaseUrl",
+			Label:       "Database URL",
+			Type:        configuration.FieldTypeSecretKey,
+			Required:    true,
+			Description: "Secret and key holding the database connection URL",
+		},
+		{
+			Name:        "envVarName",
+			Label:       "Environment Variable",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Name of the environment variable the database URL is exported as",
+			Default:     RunMigrationDefaultEnvVarName,
+			Placeholder: RunMigrationDefaultEnvVarName,
+		},
+		{
+			Name:        "cwd",
+			Label:       "Working Directory",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Working directory for the command",
+			Placeholder: "/home/daytona/repo",
+		},
+		{
+			Name:        "timeout",
+			Label:       "Timeout",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Description: "Timeout in seconds",
+			Default:     RunMigrationDefaultTimeout,
+		},
+	}
+}
+
+func (r *RunMigration) Setup(ctx core.SetupContext) error {
+	spec := RunMigrationSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %v", err)
+	}
+
+	if spec.Sandbox == "" {
+		return fmt.Errorf("sandbox is required")
+	}
+
+	if strings.TrimSpace(spec.Command) == "" {
+		return fmt.Errorf("command is required")
+	}
+
+	if !spec.DatabaseURL.IsSet() {
+		return fmt.Errorf("databaseUrl.secret and databaseUrl.key are required")
+	}
+
+	name := strings.TrimSpace(spec.EnvVarName)
+	if name != "" && !envVariableNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid env variable name: %s", spec.EnvVarName)
+	}
+
+	if spec.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative")
+	}
+
+	return nil
+}
+
+func (r *RunMigration) Execute(ctx core.ExecutionContext) error {
+	spec := RunMigrationSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %v", err)
+	}
+
+	databaseURL, err := ctx.Secrets.GetKey(spec.DatabaseURL.Secret, spec.DatabaseURL.Key)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to resolve secret %s/%s: %v", spec.DatabaseURL.Secret, spec.DatabaseURL.Key, err))
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %v", err)
+	}
+
+	sessionID := uuid.New().String()
+	if err := client.CreateSession(spec.Sandbox, sessionID); err != nil {
+		return fmt.Errorf("failed to create session: %v", err)
+	}
+
+	envVarName := strings.TrimSpace(spec.EnvVarName)
+	if envVarName == "" {
+		envVarName = RunMigrationDefaultEnvVarName
+	}
+
+	envFile := migrationEnvFile(sessionID)
+	if err := uploadMigrationEnvFile(client, spec.Sandbox, envFile, sandboxEnvBinding{Name: envVarName, Value: databaseURL}); err != nil {
+		return err
+	}
+
+	response, err := client.ExecuteSessionCommand(spec.Sandbox, sessionID, buildMigrationCommand(envFile, spec.Command, spec.Cwd))
+	if err != nil {
+		//
+		// The command removes the env file itself,
+		// but we cannot tell whether it ran, so we remove it here too.
+		//
+		if deleteErr := client.DeleteFile(spec.Sandbox, envFile); deleteErr != nil {
+			return fmt.Errorf("failed to execute command: %v (failed to remove migration env file: %v)", err, deleteErr)
+		}
+
+		return fmt.Errorf("failed to execute command: %v", err)
+	}
+
+	timeout := spec.Timeout
+	if timeout == 0 {
+		timeout = RunMigrationDefaultTimeout
+	}
+
+	metadata := RunMigrationMetadata{
+		SandboxID: spec.Sandbox,
+		SessionID: sessionID,
+		CmdID:     response.CmdID,
+		StartedAt: time.Now().Unix(),
+		Timeout:   timeout,
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return err
+	}
+
+	return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, RunMigrationPollInterval)
+}
+
+func (r *RunMigration) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (r *RunMigration) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (r *RunMigration) Hooks() []core.Hook {
+	return []core.Hook{
+		{Name: "poll", Type: core.HookTypeInternal},
+	}
+}
+
+func (r *RunMigration) HandleHook(ctx core.ActionHookContext) error {
+	if ctx.Name == "poll" {
+		return r.poll(ctx)
+	}
+	return fmt.Errorf("unknown hook: %s", ctx.Name)
+}
+
+func (r *RunMigration) poll(ctx core.ActionHookContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	var metadata RunMigrationMetadata
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %v", err)
+	}
+
+	if time.Now().Unix()-metadata.StartedAt > int64(metadata.Timeout) {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("migration timed out after %d seconds", metadata.Timeout))
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	session, err := client.GetSession(metadata.SandboxID, metadata.SessionID)
+	if err != nil {
+		return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, RunMigrationPollInterval)
+	}
+
+	cmd := session.FindCommand(metadata.CmdID)
+	if cmd == nil || cmd.ExitCode == nil {
+		return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, RunMigrationPollInterval)
+	}
+
+	logs, err := client.GetSessionCommandLogs(metadata.SandboxID, metadata.SessionID, metadata.CmdID)
+	if err != nil {
+		logs = ""
+	}
+
+	if *cmd.ExitCode != 0 {
+		message := fmt.Sprintf("migration exited with code %d", *cmd.ExitCode)
+		if tail := migrationLogTail(logs); tail != "" {
+			message += ": " + tail
+		}
+
+		return ctx.ExecutionState.Fail("error", message)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		RunMigrationPayloadType,
+		[]any{RunMigrationResponse{
+			ExitCode: *cmd.ExitCode,
+			Output:   logs,
+		}},
+	)
+}
+
+func (r *RunMigration) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (r *RunMigration) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+// migrationEnvFile is a per-execution env file, so the shared sandbox
+// secrets file written by Create Sandbox is left untouched.
+func migrationEnvFile(sessionID string) string {
+	return fmt.Sprintf("%s/migration-%s.env.sh", SandboxBaseDir, sessionID)
+}
+
+func uploadMigrationEnvFile(client *Client, sandboxID, envFile string, binding sandboxEnvBinding) error {
+	if err := ensureFolderExists(client, sandboxID, SandboxBaseDir); err != nil {
+		return err
+	}
+
+	if err := client.UploadFile(sandboxID, envFile, []byte(buildSandboxSecretsEnvScript([]sandboxEnvBinding{binding}))); err != nil {
+		return fmt.Errorf("failed to upload migration env file: %w", err)
+	}
+
+	if err := setSecretFilePermissions(client, sandboxID, []string{envFile}); err != nil {
+		if deleteErr := client.DeleteFile(sandboxID, envFile); deleteErr != nil {
+			return fmt.Errorf("%v (failed to remove migration env file: %v)", err, deleteErr)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// buildMigrationCommand loads and removes the env file before anything else
+// runs, so the database URL does not stay on disk if the migration fails.
+// The file is removed even when sourcing it fails, and the migration only
+// runs if it was sourced.
+func buildMigrationCommand(envFile, command, cwd string) string {
+	quoted := shellQuote(envFile)
+	return fmt.Sprintf(
+		". %s; status=$?; rm -f %s; [ \"$status\" -eq 0 ] && %s",
+		quoted,
+		quoted,
+		buildSessionCommand(command, cwd, nil),
+	)
+}
+
+func migrationLogTail(logs string) string {
+	logs = strings.TrimSpace(logs)
+	if len(logs) <= runMigrationLogTail {
+		return logs
+	}
+
+	return "..." + logs[len(logs)-runMigrationLogTail:]
+}
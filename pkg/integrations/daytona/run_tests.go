@@ -0,0 +1,396 @@
+package daytona
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	RunTestsPayloadType    = "daytona.tests.result"
+	RunTestsPollInterval   = 5 * time.Second
+	RunTestsDefaultTimeout = 600
+)
+
+type RunTests struct{}
+
+type RunTestsSpec struct {
+	Sandbox    string        `json:"sandbox"`
+	Command    string        `json:"command"`
+	ReportPath string        `json:"reportPath"`
+	Cwd        string        `json:"cwd,omitempty"`
+	Env        []EnvVariable `json:"env,omitempty"`
+	Timeout    int           `json:"timeout,omitempty"`
+}
+
+type RunTestsMetadata struct {
+	SandboxID  string `json:"sandboxId" mapstructure:"sandboxId"`
+	SessionID  string `json:"sessionId" mapstructure:"sessionId"`
+	CmdID      string `json:"cmdId" mapstructure:"cmdId"`
+	ReportPath string `json:"reportPath" mapstructure:"reportPath"`
+	StartedAt  int64  `json:"startedAt" mapstructure:"startedAt"`
+	Timeout    int    `json:"timeout" mapstructure:"timeout"`
+}
+
+// RunTestsResponse is the payload emitted when every test passes.
+type RunTestsResponse struct {
+	ExitCode   int    `json:"exitCode"`
+	ReportPath string `json:"reportPath"`
+	TestSummary
+	Output string `json:"output"`
+}
+
+func (r *RunTests) Name() string {
+	return "daytona.runTests"
+}
+
+func (r *RunTests) Label() string {
+	return "Run Tests"
+}
+
+func (r *RunTests) Description() string {
+	return "Run a test suite in a sandbox and parse its JUnit XML report"
+}
+
+func (r *RunTests) Documentation() string {
+	return `The Run Tests component runs a repository's test suite in an existing Daytona sandbox and reads the JUnit XML report it writes, so later steps get a structured pass/fail/skipped summary instead of just an exit code.
+
+## Use Cases
+
+- **CI checks**: Run the test suite of a repository cloned with **Create Repository Sandbox**
+- **Test reporting**: Post the number of failed tests and their names to Slack or a pull request
+- **Gating deployments**: Continue a workflow only when every test passes
+
+## Configuration
+
+- **Sandbox**: The sandbox ID to run the tests in (from **Create Sandbox** or **Create Repository Sandbox** output). Supports expressions, e.g. ` + "`" + `{{ previous().data.sandboxId }}` + "`" + `
+- **Test Command**: The command that runs the tests and writes a JUnit XML report, e.g. ` + "`" + `go test -v ./... 2>&1 | go-junit-report > report.xml` + "`" + ` or ` + "`" + `pytest --junitxml=report.xml` + "`" + `
+- **JUnit Report Path**: Where the command writes the report. Relative paths are resolved against the working directory
+- **Working Directory**: Optional working directory for the command
+- **Environment Variables**: Optional key-value pairs exported before the command runs
+- **Timeout**: Optional timeout in seconds (defaults to 600)
+
+## Output
+
+Emits on the default channel when every test passes. The payload includes:
+- **exitCode**: The exit code of the test command
+- **reportPath**: The path of the JUnit report that was read
+- **total**, **passed**, **failed**, **errors**, **skipped**: Test case counts
+- **duration**: Total test time in seconds, as reported by the test cases
+- **failures**: Failed and errored test cases (suite, class name, name, message), capped at 50
+- **output**: The output of the test command
+
+The execution fails when any test fails or errors, when the command exits with a non-zero code, when it times out, or when the report cannot be read or parsed.
+
+## Notes
+
+- Any existing file at the report path is removed before the command runs, so a stale report is never read
+- Suites nested inside other suites are flattened into one summary`
+}
+
+func (r *RunTests) Icon() string {
+	return "daytona"
+}
+
+func (r *RunTests) Color() string {
+	return "orange"
+}
+
+func (r *RunTests) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (r *RunTests) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "sandbox",
+			Label:       "Sandbox",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "Sandbox to run the tests in",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "sandbox",
+				},
+			},
+		},
+		{
+			Name:        "command",
+			Label:       "Test Command",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Command that runs the tests and writes a JUnit XML report",
+			Placeholder: "pytest --junitxml=report.xml",
+		},
+		{
+			Name:        "reportPath",
+			Label:       "JUnit Report Path",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Path of the JUnit XML report written by the command, relative to the working directory or absolute",
+			Placeholder: "report.xml",
+		},
+		{
+			Name:        "cwd",
+			Label:       "Working Directory",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Working directory for the command",
+			Placeholder: "/home/daytona/repo",
+		},
+		{
+			Name:  "env",
+			Label: "Environment Variables",
+			Type:  configuration.FieldTypeList,
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Variable",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:     "name",
+								Label:    "Name",
+								Type:     configuration.FieldTypeString,
+								Required: true,
+							},
+							{
+								Name:     "value",
+								Label:    "Value",
+								Type:     configuration.FieldTypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+			},
+			Required:    false,
+			Description: "Environment variables to export before running the command",
+		},
+		{
+			Name:        "timeout",
+			Label:       "Timeout",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Description: "Timeout in seconds",
+			Default:     RunTestsDefaultTimeout,
+		},
+	}
+}
+
+func (r *RunTests) Setup(ctx core.SetupContext) error {
+	spec := RunTestsSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %v", err)
+	}
+
+	if spec.Sandbox == "" {
+		return fmt.Errorf("sandbox is required")
+	}
+
+	if strings.TrimSpace(spec.Command) == "" {
+		return fmt.Errorf("command is required")
+	}
+
+	if strings.TrimSpace(spec.ReportPath) == "" {
+		return fmt.Errorf("report path is required")
+	}
+
+	for _, env := range spec.Env {
+		name := strings.TrimSpace(env.Name)
+		if name == "" {
+			return fmt.Errorf("env variable name is required")
+		}
+
+		if !envVariableNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid env variable name: %s", env.Name)
+		}
+	}
+
+	return nil
+}
+
+func (r *RunTests) Execute(ctx core.ExecutionContext) error {
+	spec := RunTestsSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %v", err)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %v", err)
+	}
+
+	sessionID := uuid.New().String()
+	if err := client.CreateSession(spec.Sandbox, sessionID); err != nil {
+		return fmt.Errorf("failed to create session: %v", err)
+	}
+
+	reportPath := strings.TrimSpace(spec.ReportPath)
+	command := fmt.Sprintf("rm -f %s && %s", shellQuote(reportPath), spec.Command)
+
+	response, err := client.ExecuteSessionCommand(spec.Sandbox, sessionID, buildSessionCommand(command, spec.Cwd, spec.Env))
+	if err != nil {
+		return fmt.Errorf("failed to execute command: %v", err)
+	}
+
+	timeout := spec.Timeout
+	if timeout == 0 {
+		timeout = RunTestsDefaultTimeout
+	}
+
+	metadata := RunTestsMetadata{
+		SandboxID:  spec.Sandbox,
+		SessionID:  sessionID,
+		CmdID:      response.CmdID,
+		ReportPath: resolveReportPath(reportPath, spec.Cwd),
+		StartedAt:  time.Now().Unix(),
+		Timeout:    timeout,
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return err
+	}
+
+	return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, RunTestsPollInterval)
+}
+
+func (r *RunTests) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (r *RunTests) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (r *RunTests) Hooks() []core.Hook {
+	return []core.Hook{
+		{Name: "poll", Type: core.HookTypeInternal},
+	}
+}
+
+func (r *RunTests) HandleHook(ctx core.ActionHookContext) error {
+	if ctx.Name == "poll" {
+		return r.poll(ctx)
+	}
+	return fmt.Errorf("unknown hook: %s", ctx.Name)
+}
+
+func (r *RunTests) poll(ctx core.ActionHookContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	var metadata RunTestsMetadata
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %v", err)
+	}
+
+	if time.Now().Unix()-metadata.StartedAt > int64(metadata.Timeout) {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("tests timed out after %d seconds", metadata.Timeout))
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	session, err := client.GetSession(metadata.SandboxID, metadata.SessionID)
+	if err != nil {
+		return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, RunTestsPollInterval)
+	}
+
+	cmd := session.FindCommand(metadata.CmdID)
+	if cmd == nil || cmd.ExitCode == nil {
+		return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, RunTestsPollInterval)
+	}
+
+	logs, err := client.GetSessionCommandLogs(metadata.SandboxID, metadata.SessionID, metadata.CmdID)
+	if err != nil {
+		logs = ""
+	}
+
+	report, err := client.DownloadFile(metadata.SandboxID, metadata.ReportPath)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("test command exited with code %d and the JUnit report %s could not be read: %v", *cmd.ExitCode, metadata.ReportPath, err))
+	}
+
+	summary, err := ParseJUnitReport(report)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to parse JUnit report %s: %v", metadata.ReportPath, err))
+	}
+
+	if summary.Failed > 0 || summary.Errors > 0 {
+		return ctx.ExecutionState.Fail("error", testFailureMessage(summary))
+	}
+
+	if *cmd.ExitCode != 0 {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("test command exited with code %d although the JUnit report has no failures", *cmd.ExitCode))
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		RunTestsPayloadType,
+		[]any{RunTestsResponse{
+			ExitCode:    *cmd.ExitCode,
+			ReportPath:  metadata.ReportPath,
+			TestSummary: *summary,
+			Output:      logs,
+		}},
+	)
+}
+
+func (r *RunTests) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (r *RunTests) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+// resolveReportPath resolves a relative report path against the working directory.
+func resolveReportPath(reportPath, cwd string) string {
+	if path.IsAbs(reportPath) || strings.TrimSpace(cwd) == "" {
+		return reportPath
+	}
+
+	return path.Join(cwd, reportPath)
+}
+
+// testFailureMessage describes the failed tests, listing the first few by name.
+func testFailureMessage(summary *TestSummary) string {
+	failed := summary.Failed + summary.Errors
+	message := fmt.Sprintf("%d of %d tests failed", failed, summary.Total)
+
+	const listed = 5
+	names := make([]string, 0, listed)
+	for _, failure := range summary.Failures {
+		if len(names) == listed {
+			break
+		}
+
+		name := failure.Name
+		if failure.ClassName != "" {
+			name = failure.ClassName + "." + failure.Name
+		}
+		names = append(names, name)
+	}
+
+	if len(names) == 0 {
+		return message
+	}
+
+	message += ": " + strings.Join(names, ", ")
+	if failed > len(names) {
+		message += fmt.Sprintf(" and %d more", failed-len(names))
+	}
+
+	return message
+}
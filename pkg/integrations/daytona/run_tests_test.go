@@ -0,0 +1,235 @@
+package daytona
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__RunTests__Setup(t *testing.T) {
+	component := RunTests{}
+
+	t.Run("report path is required", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"sandbox": "sandbox-123",
+				"command": "pytest --junitxml=report.xml",
+			},
+		})
+
+		require.ErrorContains(t, err, "report path is required")
+	})
+
+	t.Run("valid setup", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"sandbox":    "sandbox-123",
+				"command":    "pytest --junitxml=report.xml",
+				"reportPath": "report.xml",
+			},
+		})
+
+		require.NoError(t, err)
+	})
+}
+
+func Test__RunTests__Execute(t *testing.T) {
+	component := RunTests{}
+
+	httpContext := &contexts.HTTPContext{
+		Responses: []*http.Response{
+			runCommandsResponse(http.StatusOK, runCommandsToolboxConfig),
+			runCommandsResponse(http.StatusOK, `{}`),
+			runCommandsResponse(http.StatusOK, runCommandsToolboxConfig),
+			runCommandsResponse(http.StatusOK, `{"cmdId":"cmd-001"}`),
+		},
+	}
+
+	metadataCtx := &contexts.MetadataContext{}
+	requestCtx := &contexts.RequestContext{}
+	err := component.Execute(core.ExecutionContext{
+		Configuration: map[string]any{
+			"sandbox":    "sandbox-123",
+			"command":    "pytest --junitxml=report.xml",
+			"reportPath": "report.xml",
+			"cwd":        "/home/daytona/repo",
+		},
+		HTTP:           httpContext,
+		Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+		ExecutionState: &contexts.ExecutionStateContext{},
+		Metadata:       metadataCtx,
+		Requests:       requestCtx,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "poll", requestCtx.Action)
+
+	require.Len(t, httpContext.Requests, 4)
+	body, _ := io.ReadAll(httpContext.Requests[3].Body)
+	req := SessionExecuteRequest{}
+	require.NoError(t, json.Unmarshal(body, &req))
+	assert.Contains(t, req.Command, "cd /home/daytona/repo && rm -f 'report.xml' && pytest --junitxml=report.xml")
+
+	metadata, ok := metadataCtx.Metadata.(RunTestsMetadata)
+	require.True(t, ok)
+	assert.Equal(t, "cmd-001", metadata.CmdID)
+	assert.Equal(t, "/home/daytona/repo/report.xml", metadata.ReportPath)
+	assert.Equal(t, RunTestsDefaultTimeout, metadata.Timeout)
+}
+
+func Test__RunTests__HandleHook(t *testing.T) {
+	component := RunTests{}
+	integration := &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}}
+
+	metadata := func() *contexts.MetadataContext {
+		return &contexts.MetadataContext{
+			Metadata: map[string]any{
+				"sandboxId":  "sandbox-123",
+				"sessionId":  "session-abc",
+				"cmdId":      "cmd-001",
+				"reportPath": "/home/daytona/repo/report.xml",
+				"startedAt":  time.Now().Unix(),
+				"timeout":    600,
+			},
+		}
+	}
+
+	finishedCommand := func(exitCode int, report *http.Response) *contexts.HTTPContext {
+		return &contexts.HTTPContext{
+			Responses: []*http.Response{
+				runCommandsResponse(http.StatusOK, runCommandsToolboxConfig),
+				runCommandsResponse(http.StatusOK, `{"sessionId":"session-abc","commands":[{"id":"cmd-001","exitCode":`+strconv.Itoa(exitCode)+`}]}`),
+				runCommandsResponse(http.StatusOK, runCommandsToolboxConfig),
+				runCommandsResponse(http.StatusOK, "collected 3 items\n"),
+				runCommandsResponse(http.StatusOK, runCommandsToolboxConfig),
+				report,
+			},
+		}
+	}
+
+	t.Run("all tests pass -> emits summary on default channel", func(t *testing.T) {
+		httpContext := finishedCommand(0, runCommandsResponse(http.StatusOK,
+			`<testsuite name="unit"><testcase name="a" time="1"/><testcase name="b" time="0.5"/><testcase name="c"><skipped/></testcase></testsuite>`,
+		))
+
+		execCtx := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           "poll",
+			HTTP:           httpContext,
+			Integration:    integration,
+			Metadata:       metadata(),
+			ExecutionState: execCtx,
+			Requests:       &contexts.RequestContext{},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, execCtx.Passed)
+		assert.Equal(t, core.DefaultOutputChannel.Name, execCtx.Channel)
+		assert.Equal(t, RunTestsPayloadType, execCtx.Type)
+
+		require.Len(t, httpContext.Requests, 6)
+		assert.Equal(t, "/api/toolbox/sandbox-123/files/download", httpContext.Requests[5].URL.Path)
+		assert.Equal(t, "/home/daytona/repo/report.xml", httpContext.Requests[5].URL.Query().Get("path"))
+
+		require.Len(t, execCtx.Payloads, 1)
+		response, ok := execCtx.Payloads[0].(map[string]any)["data"].(RunTestsResponse)
+		require.True(t, ok)
+		assert.Equal(t, 3, response.Total)
+		assert.Equal(t, 2, response.Passed)
+		assert.Equal(t, 1, response.Skipped)
+		assert.Equal(t, 0, response.Failed)
+		assert.InDelta(t, 1.5, response.Duration, 0.001)
+		assert.Equal(t, "collected 3 items\n", response.Output)
+	})
+
+	t.Run("failing tests -> execution fails", func(t *testing.T) {
+		httpContext := finishedCommand(1, runCommandsResponse(http.StatusOK, sampleJUnitReport))
+
+		execCtx := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           "poll",
+			HTTP:           httpContext,
+			Integration:    integration,
+			Metadata:       metadata(),
+			ExecutionState: execCtx,
+			Requests:       &contexts.RequestContext{},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, execCtx.Finished)
+		assert.False(t, execCtx.Passed)
+		assert.Equal(t, "2 of 5 tests failed: api.users.test_delete, api.users.test_update", execCtx.FailureMessage)
+	})
+
+	t.Run("non-zero exit code with a clean report -> execution fails", func(t *testing.T) {
+		httpContext := finishedCommand(2, runCommandsResponse(http.StatusOK, `<testsuite><testcase name="a"/></testsuite>`))
+
+		execCtx := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           "poll",
+			HTTP:           httpContext,
+			Integration:    integration,
+			Metadata:       metadata(),
+			ExecutionState: execCtx,
+			Requests:       &contexts.RequestContext{},
+		})
+
+		require.NoError(t, err)
+		assert.False(t, execCtx.Passed)
+		assert.Contains(t, execCtx.FailureMessage, "exited with code 2")
+	})
+
+	t.Run("missing report -> execution fails", func(t *testing.T) {
+		httpContext := finishedCommand(1, runCommandsResponse(http.StatusNotFound, `{"message":"file not found"}`))
+
+		execCtx := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           "poll",
+			HTTP:           httpContext,
+			Integration:    integration,
+			Metadata:       metadata(),
+			ExecutionState: execCtx,
+			Requests:       &contexts.RequestContext{},
+		})
+
+		require.NoError(t, err)
+		assert.False(t, execCtx.Passed)
+		assert.Contains(t, execCtx.FailureMessage, "JUnit report /home/daytona/repo/report.xml could not be read")
+	})
+
+	t.Run("command still running -> polls again", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				runCommandsResponse(http.StatusOK, runCommandsToolboxConfig),
+				runCommandsResponse(http.StatusOK, `{"sessionId":"session-abc","commands":[{"id":"cmd-001"}]}`),
+			},
+		}
+
+		execCtx := &contexts.ExecutionStateContext{}
+		requestCtx := &contexts.RequestContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           "poll",
+			HTTP:           httpContext,
+			Integration:    integration,
+			Metadata:       metadata(),
+			ExecutionState: execCtx,
+			Requests:       requestCtx,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, execCtx.Finished)
+		assert.Equal(t, "poll", requestCtx.Action)
+	})
+}
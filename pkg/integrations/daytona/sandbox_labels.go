@@ -0,0 +1,100 @@
+package daytona
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+)
+
+const (
+	maxSandboxLabelKeyLength   = 63
+	maxSandboxLabelValueLength = 63
+)
+
+var sandboxLabelKeyPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+var sandboxLabelValuePattern = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?)?$`)
+
+type SandboxLabel struct {
+	Key   string `json:"key" mapstructure:"key"`
+	Value string `json:"value" mapstructure:"value"`
+}
+
+func sandboxLabelsConfigurationField() configuration.Field {
+	return configuration.Field{
+		Name:        "labels",
+		Label:       "Labels",
+		Type:        configuration.FieldTypeList,
+		Required:    false,
+		Description: "Labels to set on the sandbox, so it can be found later",
+		TypeOptions: &configuration.TypeOptions{
+			List: &configuration.ListTypeOptions{
+				ItemLabel: "Label",
+				ItemDefinition: &configuration.ListItemDefinition{
+					Type: configuration.FieldTypeObject,
+					Schema: []configuration.Field{
+						{
+							Name:        "key",
+							Label:       "Key",
+							Type:        configuration.FieldTypeString,
+							Required:    true,
+							Placeholder: "e.g. team",
+						},
+						{
+							Name:        "value",
+							Label:       "Value",
+							Type:        configuration.FieldTypeString,
+							Required:    false,
+							Placeholder: "e.g. platform",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+/*
+ * Label keys and values follow the usual label rules:
+ * up to 63 characters, alphanumeric at both ends,
+ * with '-', '_' and '.' in between. Keys may also contain '/'.
+ */
+func validateSandboxLabels(labels []SandboxLabel) error {
+	seen := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		key := strings.TrimSpace(label.Key)
+		if key == "" {
+			return fmt.Errorf("label key is required")
+		}
+
+		if len(key) > maxSandboxLabelKeyLength || !sandboxLabelKeyPattern.MatchString(key) {
+			return fmt.Errorf("invalid label key %q: use up to %d letters, digits, '-', '_', '.' or '/', starting and ending with a letter or digit", label.Key, maxSandboxLabelKeyLength)
+		}
+
+		if seen[key] {
+			return fmt.Errorf("duplicate label key %q", key)
+		}
+		seen[key] = true
+
+		value := strings.TrimSpace(label.Value)
+		if len(value) > maxSandboxLabelValueLength || !sandboxLabelValuePattern.MatchString(value) {
+			return fmt.Errorf("invalid value for label %q: use up to %d letters, digits, '-', '_' or '.', starting and ending with a letter or digit", key, maxSandboxLabelValueLength)
+		}
+	}
+
+	return nil
+}
+
+func sandboxLabelsMap(labels []SandboxLabel) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(labels))
+	for _, label := range labels {
+		result[strings.TrimSpace(label.Key)] = strings.TrimSpace(label.Value)
+	}
+
+	return result
+}
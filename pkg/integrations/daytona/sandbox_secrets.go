@@ -124,6 +124,28 @@ func validateSandboxSecrets(secrets []SandboxSecret) error {
 	return nil
 }
 
+// validateSandboxEnvSecretCollisions rejects env variables that are also
+// injected by an env-var secret, since it is not defined which value wins.
+func validateSandboxEnvSecretCollisions(env []EnvVariable, secrets []SandboxSecret) error {
+	envNames := make(map[string]struct{}, len(env))
+	for _, variable := range env {
+		envNames[strings.TrimSpace(variable.Name)] = struct{}{}
+	}
+
+	for i, secret := range secrets {
+		if strings.TrimSpace(secret.Type) != SandboxSecretTypeEnvVar {
+			continue
+		}
+
+		name := strings.TrimSpace(secret.Name)
+		if _, ok := envNames[name]; ok {
+			return fmt.Errorf("secrets[%d].name %s collides with env variable %s", i, name, name)
+		}
+	}
+
+	return nil
+}
+
 func injectSandboxSecrets(client *Client, sandboxID string, secretsContext core.SecretsContext, secrets []SandboxSecret) error {
 	if len(secrets) == 0 {
 		return nil
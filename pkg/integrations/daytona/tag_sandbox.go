@@ -0,0 +1,168 @@
+package daytona
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const TagSandboxPayloadType = "daytona.sandbox.labels"
+
+type TagSandbox struct{}
+
+type TagSandboxSpec struct {
+	Sandbox string         `json:"sandbox"`
+	Labels  []SandboxLabel `json:"labels"`
+}
+
+type TagSandboxPayload struct {
+	ID     string            `json:"id"`
+	Labels map[string]string `json:"labels"`
+}
+
+func (t *TagSandbox) Name() string {
+	return "daytona.tagSandbox"
+}
+
+func (t *TagSandbox) Label() string {
+	return "Tag Sandbox"
+}
+
+func (t *TagSandbox) Description() string {
+	return "Set labels on a sandbox"
+}
+
+func (t *TagSandbox) Documentation() string {
+	return `The Tag Sandbox component sets labels on an existing Daytona sandbox.
+
+## Use Cases
+
+- **Discoverability**: Mark long-lived sandboxes with an owner, team, or purpose so they are easy to find later
+- **Lifecycle tracking**: Record the workflow or pull request a sandbox belongs to
+
+## Configuration
+
+- **Sandbox**: The ID or name of the sandbox to label
+- **Labels**: Key-value pairs to set on the sandbox
+
+## Output
+
+Returns:
+- **id**: The sandbox that was labeled
+- **labels**: The labels the sandbox has after the update
+
+## Notes
+
+- The configured labels replace all labels currently set on the sandbox
+- Label keys and values can have up to 63 characters: letters, digits, '-', '_' and '.', starting and ending with a letter or digit. Keys may also contain '/'`
+}
+
+func (t *TagSandbox) Icon() string {
+	return "daytona"
+}
+
+func (t *TagSandbox) Color() string {
+	return "orange"
+}
+
+func (t *TagSandbox) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (t *TagSandbox) Configuration() []configuration.Field {
+	labels := sandboxLabelsConfigurationField()
+	labels.Required = true
+
+	return []configuration.Field{
+		{
+			Name:        "sandbox",
+			Label:       "Sandbox",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The ID or name of the sandbox to label",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "sandbox",
+				},
+			},
+		},
+		labels,
+	}
+}
+
+func (t *TagSandbox) Setup(ctx core.SetupContext) error {
+	spec := TagSandboxSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %v", err)
+	}
+
+	if spec.Sandbox == "" {
+		return fmt.Errorf("sandbox is required")
+	}
+
+	if len(spec.Labels) == 0 {
+		return fmt.Errorf("at least one label is required")
+	}
+
+	return validateSandboxLabels(spec.Labels)
+}
+
+func (t *TagSandbox) Execute(ctx core.ExecutionContext) error {
+	spec := TagSandboxSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %v", err)
+	}
+
+	if err := validateSandboxLabels(spec.Labels); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %v", err)
+	}
+
+	labels, err := client.ReplaceLabels(spec.Sandbox, sandboxLabelsMap(spec.Labels))
+	if err != nil {
+		return fmt.Errorf("failed to set sandbox labels: %v", err)
+	}
+
+	payload := TagSandboxPayload{
+		ID:     spec.Sandbox,
+		Labels: labels,
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		TagSandboxPayloadType,
+		[]any{payload},
+	)
+}
+
+func (t *TagSandbox) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (t *TagSandbox) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (t *TagSandbox) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (t *TagSandbox) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (t *TagSandbox) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (t *TagSandbox) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
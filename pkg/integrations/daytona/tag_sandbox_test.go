@@ -0,0 +1,139 @@
+package daytona
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__TagSandbox__Setup(t *testing.T) {
+	component := TagSandbox{}
+
+	t.Run("sandbox is required", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"labels": []map[string]any{{"key": "team", "value": "platform"}},
+			},
+		})
+
+		require.ErrorContains(t, err, "sandbox is required")
+	})
+
+	t.Run("labels are required", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"sandbox": "sandbox-123"},
+		})
+
+		require.ErrorContains(t, err, "at least one label is required")
+	})
+
+	t.Run("label value too long -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"sandbox": "sandbox-123",
+				"labels":  []map[string]any{{"key": "team", "value": strings.Repeat("a", 64)}},
+			},
+		})
+
+		require.ErrorContains(t, err, `invalid value for label "team"`)
+	})
+
+	t.Run("valid setup", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"sandbox": "sandbox-123",
+				"labels": []map[string]any{
+					{"key": "superplane.io/owner", "value": "platform-team"},
+					{"key": "pinned"},
+				},
+			},
+		})
+
+		require.NoError(t, err)
+	})
+}
+
+func Test__TagSandbox__Execute(t *testing.T) {
+	component := TagSandbox{}
+
+	t.Run("replaces labels and emits them", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"labels":{"team":"platform","purpose":"preview"}}`)),
+				},
+			},
+		}
+
+		execCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"sandbox": "sandbox-123",
+				"labels": []map[string]any{
+					{"key": "team", "value": "platform"},
+					{"key": "purpose", "value": "preview"},
+				},
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, http.MethodPut, httpContext.Requests[0].Method)
+		assert.Equal(t, "https://app.daytona.io/api/sandbox/sandbox-123/labels", httpContext.Requests[0].URL.String())
+
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		req := SandboxLabelsRequest{}
+		require.NoError(t, json.Unmarshal(body, &req))
+		assert.Equal(t, map[string]string{"team": "platform", "purpose": "preview"}, req.Labels)
+
+		assert.True(t, execCtx.Passed)
+		assert.Equal(t, TagSandboxPayloadType, execCtx.Type)
+		require.Len(t, execCtx.Payloads, 1)
+		payload := execCtx.Payloads[0].(map[string]any)["data"].(TagSandboxPayload)
+		assert.Equal(t, "sandbox-123", payload.ID)
+		assert.Equal(t, map[string]string{"team": "platform", "purpose": "preview"}, payload.Labels)
+	})
+
+	t.Run("API error -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(strings.NewReader(`{"message":"sandbox not found"}`)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"sandbox": "missing",
+				"labels":  []map[string]any{{"key": "team", "value": "platform"}},
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "failed to set sandbox labels")
+	})
+}
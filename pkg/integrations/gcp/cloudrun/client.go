@@ -0,0 +1,136 @@
+package cloudrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const cloudRunBaseURL = "https://run.googleapis.com/v2"
+
+// Client is the interface used by Cloud Run components to call the API.
+type Client interface {
+	GetURL(ctx context.Context, fullURL string) ([]byte, error)
+	PostURL(ctx context.Context, fullURL string, body any) ([]byte, error)
+	PatchURL(ctx context.Context, fullURL string, body any) ([]byte, error)
+	ProjectID() string
+}
+
+var (
+	clientFactoryMu sync.RWMutex
+	clientFactory   func(httpCtx core.HTTPContext, integration core.IntegrationContext) (Client, error)
+)
+
+func SetClientFactory(fn func(httpCtx core.HTTPContext, integration core.IntegrationContext) (Client, error)) {
+	clientFactoryMu.Lock()
+	defer clientFactoryMu.Unlock()
+	clientFactory = fn
+}
+
+func getClient(httpCtx core.HTTPContext, integration core.IntegrationContext) (Client, error) {
+	clientFactoryMu.RLock()
+	fn := clientFactory
+	clientFactoryMu.RUnlock()
+	if fn == nil {
+		panic("gcp cloudrun: SetClientFactory was not called by the gcp integration")
+	}
+	return fn(httpCtx, integration)
+}
+
+// Operation is a Cloud Run long-running operation.
+type Operation struct {
+	Name  string `json:"name"`
+	Done  bool   `json:"done"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// Condition is a Cloud Run resource condition, e.g. the service's terminal condition.
+type Condition struct {
+	Type    string `json:"type"`
+	State   string `json:"state"`
+	Message string `json:"message"`
+}
+
+// Service is the subset of a Cloud Run v2 service read by the components.
+type Service struct {
+	Name                string     `json:"name"`
+	URI                 string     `json:"uri"`
+	LatestReadyRevision string     `json:"latestReadyRevision"`
+	TerminalCondition   *Condition `json:"terminalCondition,omitempty"`
+}
+
+const conditionSucceeded = "CONDITION_SUCCEEDED"
+
+func locationPath(project, region string) string {
+	return fmt.Sprintf("projects/%s/locations/%s", project, region)
+}
+
+func servicePath(project, region, service string) string {
+	return fmt.Sprintf("%s/services/%s", locationPath(project, region), service)
+}
+
+func serviceURL(project, region, service string) string {
+	return fmt.Sprintf("%s/%s", cloudRunBaseURL, servicePath(project, region, service))
+}
+
+func createServiceURL(project, region, service string) string {
+	return fmt.Sprintf("%s/%s/services?serviceId=%s", cloudRunBaseURL, locationPath(project, region), url.QueryEscape(service))
+}
+
+func operationURL(name string) string {
+	return fmt.Sprintf("%s/%s", cloudRunBaseURL, name)
+}
+
+// getServiceRaw returns the service as a generic map, so it can be sent back
+// in an update without dropping fields this package does not model.
+func getServiceRaw(ctx context.Context, client Client, project, region, service string) (map[string]any, error) {
+	data, err := client.GetURL(ctx, serviceURL(project, region, service))
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse service: %w", err)
+	}
+	return raw, nil
+}
+
+func getService(ctx context.Context, client Client, project, region, service string) (*Service, error) {
+	data, err := client.GetURL(ctx, serviceURL(project, region, service))
+	if err != nil {
+		return nil, err
+	}
+
+	var s Service
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parse service: %w", err)
+	}
+	return &s, nil
+}
+
+func getOperation(ctx context.Context, client Client, name string) (*Operation, error) {
+	data, err := client.GetURL(ctx, operationURL(name))
+	if err != nil {
+		return nil, err
+	}
+	return parseOperation(data)
+}
+
+func parseOperation(data []byte) (*Operation, error) {
+	var op Operation
+	if err := json.Unmarshal(data, &op); err != nil {
+		return nil, fmt.Errorf("parse operation: %w", err)
+	}
+	if op.Name == "" {
+		return nil, fmt.Errorf("operation response is missing the operation name")
+	}
+	return &op, nil
+}
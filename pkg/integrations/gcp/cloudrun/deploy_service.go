@@ -0,0 +1,507 @@
+package cloudrun
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+)
+
+const (
+	deployServicePayloadType = "gcp.cloudrun.service"
+	pollOperationActionName  = "pollOperation"
+	pollInterval             = 10 * time.Second
+	deployTimeout            = 20 * time.Minute
+
+	// ResourceTypeRegion is the GCP region resource listed by the integration.
+	ResourceTypeRegion = "region"
+)
+
+var (
+	// serviceNamePattern follows the Cloud Run service ID rules: lowercase
+	// letters, digits and hyphens, starting with a letter, at most 49 characters.
+	serviceNamePattern = regexp.MustCompile(`^[a-z]([a-z0-9-]{0,47}[a-z0-9])?$`)
+
+	// imageReferencePattern matches a container image reference:
+	// [host[:port]/]path[:tag][@sha256:digest].
+	imageReferencePattern = regexp.MustCompile(
+		`^(?:[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)*(?::[0-9]+)?/)?` +
+			`[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*` +
+			`(?::[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127})?` +
+			`(?:@sha256:[a-f0-9]{64})?$`,
+	)
+
+	envNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// reservedEnvNames are set by Cloud Run and cannot be overridden.
+var reservedEnvNames = map[string]bool{
+	"PORT":            true,
+	"K_SERVICE":       true,
+	"K_REVISION":      true,
+	"K_CONFIGURATION": true,
+}
+
+type DeployService struct{}
+
+type EnvVar struct {
+	Name  string `json:"name" mapstructure:"name"`
+	Value string `json:"value" mapstructure:"value"`
+}
+
+type DeployServiceConfiguration struct {
+	Region  string   `json:"region" mapstructure:"region"`
+	Service string   `json:"service" mapstructure:"service"`
+	Image   string   `json:"image" mapstructure:"image"`
+	Env     []EnvVar `json:"env" mapstructure:"env"`
+}
+
+// DeployServicePollMetadata is stored while the deploy operation runs.
+type DeployServicePollMetadata struct {
+	Operation string `json:"operation" mapstructure:"operation"`
+	Region    string `json:"region" mapstructure:"region"`
+	Service   string `json:"service" mapstructure:"service"`
+	Image     string `json:"image" mapstructure:"image"`
+	Created   bool   `json:"created" mapstructure:"created"`
+	StartedAt string `json:"startedAt" mapstructure:"startedAt"`
+}
+
+func (c *DeployService) Name() string {
+	return "gcp.cloudrun.deployService"
+}
+
+func (c *DeployService) Label() string {
+	return "Cloud Run • Deploy Service"
+}
+
+func (c *DeployService) Description() string {
+	return "Deploy a container image to a Google Cloud Run service"
+}
+
+func (c *DeployService) Documentation() string {
+	return `The Deploy Service component deploys a container image to a Cloud Run service and waits until the new revision is ready.
+
+## Use Cases
+
+- **Continuous delivery**: Deploy the image built by a previous step
+- **Promotions**: Roll the same image out to another region or service
+- **Configuration changes**: Update the environment variables of a running service
+
+## Configuration
+
+- **Region** (required): The region the service runs in (e.g. ` + "`us-central1`" + `).
+- **Service** (required): The Cloud Run service name. The service is created when it does not exist yet.
+- **Image** (required): The container image to deploy (e.g. ` + "`us-docker.pkg.dev/project/repo/app:1.2.3`" + `).
+- **Environment Variables** (optional): Variables set on the container. They are merged into the variables already set on the service.
+
+## Required IAM roles
+
+The service account must have ` + "`roles/run.developer`" + ` on the project, and ` + "`roles/iam.serviceAccountUser`" + ` on the runtime service account of the service.
+
+## Output
+
+- ` + "`service`" + `: The service name.
+- ` + "`region`" + `: The service region.
+- ` + "`url`" + `: The URL the service is reachable at.
+- ` + "`revision`" + `: The latest ready revision.
+- ` + "`image`" + `: The deployed image.
+- ` + "`created`" + `: Whether the service was created by this deploy.`
+}
+
+func (c *DeployService) Icon() string  { return "gcp" }
+func (c *DeployService) Color() string { return "gray" }
+
+func (c *DeployService) OutputChannels(_ any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *DeployService) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "region",
+			Label:       "Region",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The region to deploy the service to.",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:       ResourceTypeRegion,
+					Parameters: []configuration.ParameterRef{},
+				},
+			},
+		},
+		{
+			Name:        "service",
+			Label:       "Service",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "my-service",
+			Description: "The Cloud Run service name. It is created if it does not exist.",
+		},
+		{
+			Name:        "image",
+			Label:       "Image",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "us-docker.pkg.dev/my-project/my-repo/app:1.0.0",
+			Description: "The container image to deploy.",
+		},
+		{
+			Name:        "env",
+			Label:       "Environment Variables",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Description: "Environment variables to set on the container.",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Variable",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:     "name",
+								Label:    "Name",
+								Type:     configuration.FieldTypeString,
+								Required: true,
+							},
+							{
+								Name:     "value",
+								Label:    "Value",
+								Type:     configuration.FieldTypeString,
+								Required: false,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func decodeDeployServiceConfiguration(raw any) (DeployServiceConfiguration, error) {
+	var config DeployServiceConfiguration
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return DeployServiceConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+	config.Region = strings.TrimSpace(config.Region)
+	config.Service = strings.TrimSpace(config.Service)
+	config.Image = strings.TrimSpace(config.Image)
+	for i := range config.Env {
+		config.Env[i].Name = strings.TrimSpace(config.Env[i].Name)
+	}
+	return config, nil
+}
+
+func validateDeployServiceConfiguration(config DeployServiceConfiguration, allowedRegions []string) error {
+	if config.Region == "" {
+		return fmt.Errorf("region is required")
+	}
+	if !gcpcommon.IsRegionAllowed(allowedRegions, config.Region) {
+		return fmt.Errorf("region %q is not allowed by the integration's region allowlist (%s)", config.Region, strings.Join(allowedRegions, ", "))
+	}
+	if config.Service == "" {
+		return fmt.Errorf("service is required")
+	}
+	if !serviceNamePattern.MatchString(config.Service) {
+		return fmt.Errorf("invalid service name %q: use lowercase letters, digits and hyphens, starting with a letter, at most 49 characters", config.Service)
+	}
+	if err := validateImageReference(config.Image); err != nil {
+		return err
+	}
+	return validateEnv(config.Env)
+}
+
+// validateImageReference checks that image is a well-formed container image
+// reference. Expressions are only checked once they have been resolved.
+func validateImageReference(image string) error {
+	if image == "" {
+		return fmt.Errorf("image is required")
+	}
+	if strings.Contains(image, "{{") {
+		return nil
+	}
+	if !imageReferencePattern.MatchString(image) {
+		return fmt.Errorf("invalid image reference %q", image)
+	}
+	return nil
+}
+
+func validateEnv(env []EnvVar) error {
+	seen := map[string]bool{}
+	for i, e := range env {
+		if e.Name == "" {
+			return fmt.Errorf("environment variable #%d: name is required", i+1)
+		}
+		if !envNamePattern.MatchString(e.Name) {
+			return fmt.Errorf("environment variable #%d: invalid name %q", i+1, e.Name)
+		}
+		if reservedEnvNames[e.Name] {
+			return fmt.Errorf("environment variable #%d: %s is reserved by Cloud Run", i+1, e.Name)
+		}
+		if seen[e.Name] {
+			return fmt.Errorf("environment variable %s is set more than once", e.Name)
+		}
+		seen[e.Name] = true
+	}
+	return nil
+}
+
+func (c *DeployService) Setup(ctx core.SetupContext) error {
+	config, err := decodeDeployServiceConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+	return validateDeployServiceConfiguration(config, gcpcommon.AllowedRegions(ctx.Integration))
+}
+
+func (c *DeployService) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeDeployServiceConfiguration(ctx.Configuration)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+	if err := validateDeployServiceConfiguration(config, gcpcommon.AllowedRegions(ctx.Integration)); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	client, err := getClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	reqCtx := context.Background()
+	project := client.ProjectID()
+
+	var response []byte
+	created := false
+	existing, err := getServiceRaw(reqCtx, client, project, config.Region, config.Service)
+	switch {
+	case gcpcommon.IsNotFoundError(err):
+		created = true
+		response, err = client.PostURL(reqCtx, createServiceURL(project, config.Region, config.Service), buildNewService(config))
+		if err != nil {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create Cloud Run service: %v", err))
+		}
+	case err != nil:
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get Cloud Run service: %v", err))
+	default:
+		if err := applyDeployToService(existing, config); err != nil {
+			return ctx.ExecutionState.Fail("error", err.Error())
+		}
+		response, err = client.PatchURL(reqCtx, serviceURL(project, config.Region, config.Service), existing)
+		if err != nil {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to update Cloud Run service: %v", err))
+		}
+	}
+
+	op, err := parseOperation(response)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	if err := ctx.Metadata.Set(DeployServicePollMetadata{
+		Operation: op.Name,
+		Region:    config.Region,
+		Service:   config.Service,
+		Image:     config.Image,
+		Created:   created,
+		StartedAt: time.Now().UTC().Format(time.RFC3339),
+	}); err != nil {
+		return fmt.Errorf("failed to set poll metadata: %w", err)
+	}
+
+	return ctx.Requests.ScheduleActionCall(pollOperationActionName, map[string]any{}, pollInterval)
+}
+
+func buildNewService(config DeployServiceConfiguration) map[string]any {
+	container := map[string]any{"image": config.Image}
+	if len(config.Env) > 0 {
+		container["env"] = envToAPI(config.Env)
+	}
+
+	return map[string]any{
+		"template": map[string]any{
+			"containers": []any{container},
+		},
+	}
+}
+
+func envToAPI(env []EnvVar) []any {
+	out := make([]any, 0, len(env))
+	for _, e := range env {
+		out = append(out, map[string]any{"name": e.Name, "value": e.Value})
+	}
+	return out
+}
+
+// applyDeployToService sets the image of the service's first container and
+// merges the configured variables into its environment. Variables set from
+// secrets are kept unless the configuration overrides them.
+func applyDeployToService(service map[string]any, config DeployServiceConfiguration) error {
+	template, _ := service["template"].(map[string]any)
+	if template == nil {
+		template = map[string]any{}
+		service["template"] = template
+	}
+
+	containers, _ := template["containers"].([]any)
+	if len(containers) == 0 {
+		containers = []any{map[string]any{}}
+	}
+
+	container, ok := containers[0].(map[string]any)
+	if !ok {
+		return fmt.Errorf("unexpected container definition in service %q", config.Service)
+	}
+
+	container["image"] = config.Image
+	if len(config.Env) > 0 {
+		container["env"] = mergeEnv(container["env"], config.Env)
+	}
+
+	containers[0] = container
+	template["containers"] = containers
+
+	// The revision name must be unique, so let Cloud Run generate a new one.
+	delete(template, "revision")
+	return nil
+}
+
+func mergeEnv(current any, env []EnvVar) []any {
+	existing, _ := current.([]any)
+	overrides := map[string]EnvVar{}
+	for _, e := range env {
+		overrides[e.Name] = e
+	}
+
+	out := make([]any, 0, len(existing)+len(env))
+	applied := map[string]bool{}
+	for _, item := range existing {
+		entry, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := entry["name"].(string)
+		if e, ok := overrides[name]; ok {
+			out = append(out, map[string]any{"name": e.Name, "value": e.Value})
+			applied[name] = true
+			continue
+		}
+		out = append(out, entry)
+	}
+
+	for _, e := range env {
+		if !applied[e.Name] {
+			out = append(out, map[string]any{"name": e.Name, "value": e.Value})
+		}
+	}
+	return out
+}
+
+func (c *DeployService) Hooks() []core.Hook {
+	return []core.Hook{
+		{Name: pollOperationActionName, Type: core.HookTypeInternal},
+	}
+}
+
+func (c *DeployService) HandleHook(ctx core.ActionHookContext) error {
+	switch ctx.Name {
+	case pollOperationActionName:
+		return pollDeployUntilReady(ctx)
+	default:
+		return fmt.Errorf("unknown hook: %s", ctx.Name)
+	}
+}
+
+// pollDeployUntilReady polls the deploy operation. Once it is done, the service
+// is read back and the execution passes only if its terminal condition succeeded.
+func pollDeployUntilReady(ctx core.ActionHookContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	var meta DeployServicePollMetadata
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &meta); err != nil {
+		return fmt.Errorf("failed to decode poll metadata: %w", err)
+	}
+
+	client, err := getClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create GCP client: %w", err)
+	}
+
+	reqCtx := context.Background()
+	op, err := getOperation(reqCtx, client, meta.Operation)
+	if err != nil {
+		return fmt.Errorf("failed to get operation status: %w", err)
+	}
+
+	if !op.Done {
+		if deployTimedOut(meta.StartedAt) {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("timed out after %s waiting for service %q to become ready", deployTimeout, meta.Service))
+		}
+		return ctx.Requests.ScheduleActionCall(pollOperationActionName, map[string]any{}, pollInterval)
+	}
+
+	if op.Error != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("deploy of service %q failed: %s", meta.Service, op.Error.Message))
+	}
+
+	service, err := getService(reqCtx, client, client.ProjectID(), meta.Region, meta.Service)
+	if err != nil {
+		return fmt.Errorf("failed to get service: %w", err)
+	}
+
+	if service.TerminalCondition == nil || service.TerminalCondition.State != conditionSucceeded {
+		message := "service did not become ready"
+		if service.TerminalCondition != nil && service.TerminalCondition.Message != "" {
+			message = service.TerminalCondition.Message
+		}
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("deploy of service %q failed: %s", meta.Service, message))
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		deployServicePayloadType,
+		[]any{map[string]any{
+			"service":  meta.Service,
+			"region":   meta.Region,
+			"url":      service.URI,
+			"revision": lastSegment(service.LatestReadyRevision),
+			"image":    meta.Image,
+			"created":  meta.Created,
+		}},
+	)
+}
+
+func deployTimedOut(startedAt string) bool {
+	started, err := time.Parse(time.RFC3339, startedAt)
+	if err != nil {
+		return false
+	}
+	return time.Since(started) > deployTimeout
+}
+
+func lastSegment(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+func (c *DeployService) HandleWebhook(_ core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *DeployService) Cancel(_ core.ExecutionContext) error { return nil }
+func (c *DeployService) Cleanup(_ core.SetupContext) error    { return nil }
+func (c *DeployService) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
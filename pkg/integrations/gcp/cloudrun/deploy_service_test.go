@@ -0,0 +1,420 @@
+package cloudrun
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+	testcontexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const testImage = "us-docker.pkg.dev/my-project/apps/checkout-api:1.4.2"
+
+func validDeployConfig() map[string]any {
+	return map[string]any{
+		"region":  "us-central1",
+		"service": "checkout-api",
+		"image":   testImage,
+		"env": []map[string]any{
+			{"name": "LOG_LEVEL", "value": "debug"},
+		},
+	}
+}
+
+func TestDeployService_Metadata(t *testing.T) {
+	c := &DeployService{}
+	assert.Equal(t, "gcp.cloudrun.deployService", c.Name())
+	assert.Equal(t, "Cloud Run • Deploy Service", c.Label())
+	assert.NotEmpty(t, c.Description())
+	assert.NotEmpty(t, c.Documentation())
+	assert.Equal(t, "gcp", c.Icon())
+	assert.Equal(t, "gray", c.Color())
+}
+
+func TestDeployService_ExampleOutput(t *testing.T) {
+	output := (&DeployService{}).ExampleOutput()
+	assert.Equal(t, deployServicePayloadType, output["type"])
+	payload, ok := output["data"].(map[string]any)
+	require.True(t, ok)
+	assert.NotEmpty(t, payload["url"])
+	assert.NotEmpty(t, payload["revision"])
+}
+
+func TestDeployService_Setup(t *testing.T) {
+	c := &DeployService{}
+
+	t.Run("succeeds with valid config", func(t *testing.T) {
+		err := c.Setup(core.SetupContext{Configuration: validDeployConfig(), Metadata: &testcontexts.MetadataContext{}})
+		require.NoError(t, err)
+	})
+
+	t.Run("fails when region is not allowed", func(t *testing.T) {
+		err := c.Setup(core.SetupContext{
+			Configuration: validDeployConfig(),
+			Metadata:      &testcontexts.MetadataContext{},
+			Integration: &testcontexts.IntegrationContext{
+				Configuration: map[string]any{gcpcommon.ConfigNameAllowedRegions: "europe-west1"},
+			},
+		})
+		require.ErrorContains(t, err, "not allowed by the integration's region allowlist")
+	})
+
+	t.Run("fails with an invalid service name", func(t *testing.T) {
+		config := validDeployConfig()
+		config["service"] = "Checkout_API"
+		err := c.Setup(core.SetupContext{Configuration: config, Metadata: &testcontexts.MetadataContext{}})
+		require.ErrorContains(t, err, "invalid service name")
+	})
+
+	t.Run("fails with a reserved environment variable", func(t *testing.T) {
+		config := validDeployConfig()
+		config["env"] = []map[string]any{{"name": "PORT", "value": "9090"}}
+		err := c.Setup(core.SetupContext{Configuration: config, Metadata: &testcontexts.MetadataContext{}})
+		require.ErrorContains(t, err, "PORT is reserved")
+	})
+
+	t.Run("fails when image is missing", func(t *testing.T) {
+		config := validDeployConfig()
+		delete(config, "image")
+		err := c.Setup(core.SetupContext{Configuration: config, Metadata: &testcontexts.MetadataContext{}})
+		require.ErrorContains(t, err, "image is required")
+	})
+}
+
+func TestValidateImageReference(t *testing.T) {
+	valid := []string{
+		"nginx",
+		"nginx:1.27",
+		"gcr.io/my-project/app",
+		"us-docker.pkg.dev/my-project/apps/checkout-api:1.4.2",
+		"localhost:5000/team/app:latest",
+		"gcr.io/my-project/app@sha256:" + "a3ed95caeb02ffe68cdd9fd84406680ae93d633cb16422d00e8a7c22955b46d4",
+		"gcr.io/my-project/app:v2@sha256:" + "a3ed95caeb02ffe68cdd9fd84406680ae93d633cb16422d00e8a7c22955b46d4",
+		"{{ $['Build'].data.image }}",
+	}
+	for _, image := range valid {
+		assert.NoError(t, validateImageReference(image), image)
+	}
+
+	invalid := []string{
+		"gcr.io/My-Project/app",
+		"gcr.io/my-project/app:",
+		"gcr.io/my-project/app:tag with space",
+		"gcr.io/my-project/app@sha256:abc",
+		"https://gcr.io/my-project/app",
+		"gcr.io//app",
+	}
+	for _, image := range invalid {
+		assert.ErrorContains(t, validateImageReference(image), "invalid image reference", image)
+	}
+}
+
+func TestDeployService_Execute(t *testing.T) {
+	t.Run("creates the service when it does not exist", func(t *testing.T) {
+		var postedURL string
+		var postedBody map[string]any
+		SetClientFactory(func(_ core.HTTPContext, _ core.IntegrationContext) (Client, error) {
+			return &mockClient{
+				projectID: "my-project",
+				getURL: func(_ context.Context, _ string) ([]byte, error) {
+					return nil, &gcpcommon.GCPAPIError{StatusCode: 404, Message: "not found"}
+				},
+				postURL: func(_ context.Context, fullURL string, body any) ([]byte, error) {
+					postedURL = fullURL
+					postedBody = body.(map[string]any)
+					return json.Marshal(map[string]any{"name": "projects/my-project/locations/us-central1/operations/op-1"})
+				},
+			}, nil
+		})
+
+		state := &testcontexts.ExecutionStateContext{KVs: map[string]string{}}
+		requests := &testcontexts.RequestContext{}
+		metadata := &testcontexts.MetadataContext{}
+		err := (&DeployService{}).Execute(core.ExecutionContext{
+			Configuration:  validDeployConfig(),
+			ExecutionState: state,
+			Metadata:       metadata,
+			Requests:       requests,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Finished)
+		assert.Equal(t, "https://run.googleapis.com/v2/projects/my-project/locations/us-central1/services?serviceId=checkout-api", postedURL)
+
+		container := postedBody["template"].(map[string]any)["containers"].([]any)[0].(map[string]any)
+		assert.Equal(t, testImage, container["image"])
+		assert.Equal(t, []any{map[string]any{"name": "LOG_LEVEL", "value": "debug"}}, container["env"])
+
+		assert.Equal(t, pollOperationActionName, requests.Action)
+		assert.Equal(t, pollInterval, requests.Duration)
+		meta := metadata.Metadata.(DeployServicePollMetadata)
+		assert.Equal(t, "projects/my-project/locations/us-central1/operations/op-1", meta.Operation)
+		assert.True(t, meta.Created)
+	})
+
+	t.Run("updates the image and merges env of an existing service", func(t *testing.T) {
+		var patchedURL string
+		var patchedBody map[string]any
+		SetClientFactory(func(_ core.HTTPContext, _ core.IntegrationContext) (Client, error) {
+			return &mockClient{
+				projectID: "my-project",
+				getURL: func(_ context.Context, _ string) ([]byte, error) {
+					return json.Marshal(map[string]any{
+						"name": "projects/my-project/locations/us-central1/services/checkout-api",
+						"template": map[string]any{
+							"revision":       "checkout-api-00011-abc",
+							"serviceAccount": "runtime@my-project.iam.gserviceaccount.com",
+							"containers": []any{map[string]any{
+								"image": "us-docker.pkg.dev/my-project/apps/checkout-api:1.4.1",
+								"env": []any{
+									map[string]any{"name": "LOG_LEVEL", "value": "info"},
+									map[string]any{"name": "DB_PASSWORD", "valueSource": map[string]any{"secretKeyRef": map[string]any{"secret": "db"}}},
+								},
+							}},
+						},
+					})
+				},
+				patchURL: func(_ context.Context, fullURL string, body any) ([]byte, error) {
+					patchedURL = fullURL
+					patchedBody = body.(map[string]any)
+					return json.Marshal(map[string]any{"name": "projects/my-project/locations/us-central1/operations/op-2"})
+				},
+			}, nil
+		})
+
+		state := &testcontexts.ExecutionStateContext{KVs: map[string]string{}}
+		metadata := &testcontexts.MetadataContext{}
+		err := (&DeployService{}).Execute(core.ExecutionContext{
+			Configuration:  validDeployConfig(),
+			ExecutionState: state,
+			Metadata:       metadata,
+			Requests:       &testcontexts.RequestContext{},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://run.googleapis.com/v2/projects/my-project/locations/us-central1/services/checkout-api", patchedURL)
+
+		template := patchedBody["template"].(map[string]any)
+		assert.NotContains(t, template, "revision")
+		assert.Equal(t, "runtime@my-project.iam.gserviceaccount.com", template["serviceAccount"])
+		container := template["containers"].([]any)[0].(map[string]any)
+		assert.Equal(t, testImage, container["image"])
+		env := container["env"].([]any)
+		require.Len(t, env, 2)
+		assert.Equal(t, map[string]any{"name": "LOG_LEVEL", "value": "debug"}, env[0])
+		assert.Equal(t, "DB_PASSWORD", env[1].(map[string]any)["name"])
+		assert.False(t, metadata.Metadata.(DeployServicePollMetadata).Created)
+	})
+
+	t.Run("fails when the service cannot be read", func(t *testing.T) {
+		SetClientFactory(func(_ core.HTTPContext, _ core.IntegrationContext) (Client, error) {
+			return &mockClient{
+				projectID: "my-project",
+				getURL: func(_ context.Context, _ string) ([]byte, error) {
+					return nil, &gcpcommon.GCPAPIError{StatusCode: 403, Message: "permission denied"}
+				},
+			}, nil
+		})
+
+		state := &testcontexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := (&DeployService{}).Execute(core.ExecutionContext{
+			Configuration:  validDeployConfig(),
+			ExecutionState: state,
+			Metadata:       &testcontexts.MetadataContext{},
+			Requests:       &testcontexts.RequestContext{},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Finished)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "failed to get Cloud Run service")
+	})
+}
+
+func TestPollDeployUntilReady(t *testing.T) {
+	pollMetadata := func(startedAt time.Time) *testcontexts.MetadataContext {
+		return &testcontexts.MetadataContext{Metadata: DeployServicePollMetadata{
+			Operation: "projects/my-project/locations/us-central1/operations/op-1",
+			Region:    "us-central1",
+			Service:   "checkout-api",
+			Image:     testImage,
+			StartedAt: startedAt.UTC().Format(time.RFC3339),
+		}}
+	}
+
+	t.Run("schedules another poll while the operation runs", func(t *testing.T) {
+		SetClientFactory(func(_ core.HTTPContext, _ core.IntegrationContext) (Client, error) {
+			return &mockClient{
+				projectID: "my-project",
+				getURL: func(_ context.Context, _ string) ([]byte, error) {
+					return json.Marshal(map[string]any{"name": "projects/my-project/locations/us-central1/operations/op-1"})
+				},
+			}, nil
+		})
+
+		state := &testcontexts.ExecutionStateContext{KVs: map[string]string{}}
+		requests := &testcontexts.RequestContext{}
+		err := pollDeployUntilReady(core.ActionHookContext{
+			ExecutionState: state,
+			Requests:       requests,
+			Metadata:       pollMetadata(time.Now()),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, pollOperationActionName, requests.Action)
+		assert.False(t, state.IsFinished())
+	})
+
+	t.Run("emits the service URL once the service is ready", func(t *testing.T) {
+		var requested []string
+		SetClientFactory(func(_ core.HTTPContext, _ core.IntegrationContext) (Client, error) {
+			return &mockClient{
+				projectID: "my-project",
+				getURL: func(_ context.Context, fullURL string) ([]byte, error) {
+					requested = append(requested, fullURL)
+					if len(requested) == 1 {
+						return json.Marshal(map[string]any{"name": "projects/my-project/locations/us-central1/operations/op-1", "done": true})
+					}
+					return json.Marshal(map[string]any{
+						"name":                "projects/my-project/locations/us-central1/services/checkout-api",
+						"uri":                 "https://checkout-api-4f7xq2nd6a-uc.a.run.app",
+						"latestReadyRevision": "projects/my-project/locations/us-central1/services/checkout-api/revisions/checkout-api-00012-x9k",
+						"terminalCondition":   map[string]any{"type": "Ready", "state": "CONDITION_SUCCEEDED"},
+					})
+				},
+			}, nil
+		})
+
+		state := &testcontexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := pollDeployUntilReady(core.ActionHookContext{
+			ExecutionState: state,
+			Requests:       &testcontexts.RequestContext{},
+			Metadata:       pollMetadata(time.Now()),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"https://run.googleapis.com/v2/projects/my-project/locations/us-central1/operations/op-1",
+			"https://run.googleapis.com/v2/projects/my-project/locations/us-central1/services/checkout-api",
+		}, requested)
+		assert.True(t, state.Passed)
+		require.Len(t, state.Payloads, 1)
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "https://checkout-api-4f7xq2nd6a-uc.a.run.app", data["url"])
+		assert.Equal(t, "checkout-api-00012-x9k", data["revision"])
+		assert.Equal(t, testImage, data["image"])
+	})
+
+	t.Run("fails when the operation returns an error", func(t *testing.T) {
+		SetClientFactory(func(_ core.HTTPContext, _ core.IntegrationContext) (Client, error) {
+			return &mockClient{
+				projectID: "my-project",
+				getURL: func(_ context.Context, _ string) ([]byte, error) {
+					return json.Marshal(map[string]any{
+						"name":  "projects/my-project/locations/us-central1/operations/op-1",
+						"done":  true,
+						"error": map[string]any{"code": 9, "message": "Revision 'checkout-api-00012-x9k' is not ready"},
+					})
+				},
+			}, nil
+		})
+
+		state := &testcontexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := pollDeployUntilReady(core.ActionHookContext{
+			ExecutionState: state,
+			Requests:       &testcontexts.RequestContext{},
+			Metadata:       pollMetadata(time.Now()),
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Finished)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "is not ready")
+	})
+
+	t.Run("fails when the service is not ready", func(t *testing.T) {
+		calls := 0
+		SetClientFactory(func(_ core.HTTPContext, _ core.IntegrationContext) (Client, error) {
+			return &mockClient{
+				projectID: "my-project",
+				getURL: func(_ context.Context, _ string) ([]byte, error) {
+					calls++
+					if calls == 1 {
+						return json.Marshal(map[string]any{"name": "projects/my-project/locations/us-central1/operations/op-1", "done": true})
+					}
+					return json.Marshal(map[string]any{
+						"terminalCondition": map[string]any{"type": "Ready", "state": "CONDITION_FAILED", "message": "container failed to start"},
+					})
+				},
+			}, nil
+		})
+
+		state := &testcontexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := pollDeployUntilReady(core.ActionHookContext{
+			ExecutionState: state,
+			Requests:       &testcontexts.RequestContext{},
+			Metadata:       pollMetadata(time.Now()),
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "container failed to start")
+	})
+
+	t.Run("fails after the deploy timeout", func(t *testing.T) {
+		SetClientFactory(func(_ core.HTTPContext, _ core.IntegrationContext) (Client, error) {
+			return &mockClient{
+				projectID: "my-project",
+				getURL: func(_ context.Context, _ string) ([]byte, error) {
+					return json.Marshal(map[string]any{"name": "projects/my-project/locations/us-central1/operations/op-1"})
+				},
+			}, nil
+		})
+
+		state := &testcontexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := pollDeployUntilReady(core.ActionHookContext{
+			ExecutionState: state,
+			Requests:       &testcontexts.RequestContext{},
+			Metadata:       pollMetadata(time.Now().Add(-deployTimeout - time.Minute)),
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "timed out")
+	})
+}
+
+type mockClient struct {
+	projectID string
+	getURL    func(ctx context.Context, fullURL string) ([]byte, error)
+	postURL   func(ctx context.Context, fullURL string, body any) ([]byte, error)
+	patchURL  func(ctx context.Context, fullURL string, body any) ([]byte, error)
+}
+
+func (m *mockClient) ProjectID() string { return m.projectID }
+
+func (m *mockClient) GetURL(ctx context.Context, fullURL string) ([]byte, error) {
+	if m.getURL != nil {
+		return m.getURL(ctx, fullURL)
+	}
+	return nil, nil
+}
+
+func (m *mockClient) PostURL(ctx context.Context, fullURL string, body any) ([]byte, error) {
+	if m.postURL != nil {
+		return m.postURL(ctx, fullURL, body)
+	}
+	return nil, nil
+}
+
+func (m *mockClient) PatchURL(ctx context.Context, fullURL string, body any) ([]byte, error) {
+	if m.patchURL != nil {
+		return m.patchURL(ctx, fullURL, body)
+	}
+	return nil, nil
+}
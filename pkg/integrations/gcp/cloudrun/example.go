@@ -0,0 +1,20 @@
+package cloudrun
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/utils"
+)
+
+//go:embed example_output_deploy_service.json
+var exampleOutputDeployServiceBytes []byte
+
+var (
+	exampleOutputDeployServiceOnce sync.Once
+	exampleOutputDeployService     map[string]any
+)
+
+func (c *DeployService) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputDeployServiceOnce, exampleOutputDeployServiceBytes, &exampleOutputDeployService)
+}
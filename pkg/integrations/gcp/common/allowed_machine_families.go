@@ -0,0 +1,40 @@
+package common
+
+import (
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const ConfigNameAllowedMachineFamilies = "allowedMachineFamilies"
+
+// AllowedMachineFamilies returns the machine families allowed by the
+// integration's machine family allowlist. An empty result means no restriction.
+func AllowedMachineFamilies(integration core.IntegrationContext) []string {
+	if integration == nil {
+		return nil
+	}
+
+	raw, err := integration.GetConfig(ConfigNameAllowedMachineFamilies)
+	if err != nil {
+		return nil
+	}
+
+	return ParseAllowedRegions(string(raw))
+}
+
+// MachineTypeFamily returns the family of a machine type name or path,
+// e.g. "a2" for "zones/us-central1-a/machineTypes/a2-highgpu-1g".
+func MachineTypeFamily(machineType string) string {
+	machineType = strings.TrimSpace(machineType)
+	if i := strings.LastIndex(machineType, "/"); i >= 0 {
+		machineType = machineType[i+1:]
+	}
+
+	family, _, _ := strings.Cut(machineType, "-")
+	return strings.ToLower(family)
+}
+
+func IsMachineFamilyAllowed(allowed []string, family string) bool {
+	return IsRegionAllowed(allowed, family)
+}
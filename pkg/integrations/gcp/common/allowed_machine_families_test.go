@@ -0,0 +1,37 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test_AllowedMachineFamilies(t *testing.T) {
+	t.Run("missing config means no restriction", func(t *testing.T) {
+		integration := &contexts.IntegrationContext{Configuration: map[string]any{}}
+		assert.Empty(t, AllowedMachineFamilies(integration))
+		assert.True(t, IsMachineFamilyAllowed(AllowedMachineFamilies(integration), "a3"))
+	})
+
+	t.Run("parses comma separated list", func(t *testing.T) {
+		integration := &contexts.IntegrationContext{Configuration: map[string]any{
+			"allowedMachineFamilies": " e2, N2D ,,",
+		}}
+		assert.Equal(t, []string{"e2", "n2d"}, AllowedMachineFamilies(integration))
+	})
+}
+
+func Test_MachineTypeFamily(t *testing.T) {
+	assert.Equal(t, "e2", MachineTypeFamily("e2-medium"))
+	assert.Equal(t, "a2", MachineTypeFamily("zones/us-central1-a/machineTypes/a2-highgpu-1g"))
+	assert.Equal(t, "n2d", MachineTypeFamily(" N2D-standard-4 "))
+	assert.Equal(t, "", MachineTypeFamily(""))
+}
+
+func Test_IsMachineFamilyAllowed(t *testing.T) {
+	allowed := []string{"e2", "n2"}
+	assert.True(t, IsMachineFamilyAllowed(allowed, "E2"))
+	assert.False(t, IsMachineFamilyAllowed(allowed, "a2"))
+	assert.True(t, IsMachineFamilyAllowed(nil, "a3"))
+}
@@ -0,0 +1,51 @@
+package common
+
+import (
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const ConfigNameAllowedRegions = "allowedRegions"
+
+// AllowedRegions returns the regions allowed by the integration's region
+// allowlist. An empty result means no restriction.
+func AllowedRegions(integration core.IntegrationContext) []string {
+	if integration == nil {
+		return nil
+	}
+
+	raw, err := integration.GetConfig(ConfigNameAllowedRegions)
+	if err != nil {
+		return nil
+	}
+
+	return ParseAllowedRegions(string(raw))
+}
+
+func ParseAllowedRegions(raw string) []string {
+	var regions []string
+	for _, part := range strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == '\n' || r == ' ' || r == '\t'
+	}) {
+		region := strings.ToLower(strings.TrimSpace(part))
+		if region != "" {
+			regions = append(regions, region)
+		}
+	}
+	return regions
+}
+
+func IsRegionAllowed(allowed []string, region string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	region = strings.ToLower(strings.TrimSpace(region))
+	for _, r := range allowed {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
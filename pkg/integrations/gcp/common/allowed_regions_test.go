@@ -0,0 +1,31 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test_AllowedRegions(t *testing.T) {
+	t.Run("missing config means no restriction", func(t *testing.T) {
+		integration := &contexts.IntegrationContext{Configuration: map[string]any{}}
+		assert.Empty(t, AllowedRegions(integration))
+		assert.True(t, IsRegionAllowed(AllowedRegions(integration), "asia-east1"))
+	})
+
+	t.Run("parses comma separated list", func(t *testing.T) {
+		integration := &contexts.IntegrationContext{Configuration: map[string]any{
+			"allowedRegions": " us-central1, EUROPE-WEST1 ,,",
+		}}
+		assert.Equal(t, []string{"us-central1", "europe-west1"}, AllowedRegions(integration))
+	})
+}
+
+func Test_IsRegionAllowed(t *testing.T) {
+	allowed := []string{"us-central1", "europe-west1"}
+	assert.True(t, IsRegionAllowed(allowed, "us-central1"))
+	assert.True(t, IsRegionAllowed(allowed, "Europe-West1"))
+	assert.False(t, IsRegionAllowed(allowed, "asia-east1"))
+	assert.True(t, IsRegionAllowed(nil, "asia-east1"))
+}
@@ -0,0 +1,51 @@
+package common
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const ConfigNameAPIEndpoint = "apiEndpoint"
+
+// APIEndpoint returns the base URL used for path-based Compute Engine requests.
+// It falls back to the public endpoint when the integration does not override it.
+func APIEndpoint(integration core.IntegrationContext) (string, error) {
+	if integration == nil {
+		return defaultComputeBaseURL, nil
+	}
+
+	raw, err := integration.GetConfig(ConfigNameAPIEndpoint)
+	if err != nil {
+		return defaultComputeBaseURL, nil
+	}
+
+	return ParseAPIEndpoint(string(raw))
+}
+
+// ParseAPIEndpoint validates an endpoint override. An empty value selects the
+// public endpoint.
+func ParseAPIEndpoint(raw string) (string, error) {
+	endpoint := strings.TrimSpace(raw)
+	if endpoint == "" {
+		return defaultComputeBaseURL, nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid API endpoint %q: %w", endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("invalid API endpoint %q: scheme must be http or https", endpoint)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("invalid API endpoint %q: host is required", endpoint)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("invalid API endpoint %q: query and fragment are not allowed", endpoint)
+	}
+
+	return strings.TrimSuffix(endpoint, "/"), nil
+}
@@ -0,0 +1,110 @@
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test_ParseAPIEndpoint(t *testing.T) {
+	t.Run("empty value uses the public endpoint", func(t *testing.T) {
+		endpoint, err := ParseAPIEndpoint("  ")
+		require.NoError(t, err)
+		assert.Equal(t, "https://compute.googleapis.com/compute/v1", endpoint)
+	})
+
+	t.Run("trailing slash is trimmed", func(t *testing.T) {
+		endpoint, err := ParseAPIEndpoint(" http://localhost:8085/compute/v1/ ")
+		require.NoError(t, err)
+		assert.Equal(t, "http://localhost:8085/compute/v1", endpoint)
+	})
+
+	invalid := map[string]string{
+		"localhost:8085/compute/v1":               "scheme must be http or https",
+		"ftp://compute.example.com":               "scheme must be http or https",
+		"https:///compute/v1":                     "host is required",
+		"https://compute.example.com/v1?alt=json": "query and fragment are not allowed",
+	}
+	for raw, message := range invalid {
+		_, err := ParseAPIEndpoint(raw)
+		assert.ErrorContains(t, err, message, raw)
+	}
+}
+
+func Test_NewClient_APIEndpoint(t *testing.T) {
+	integration := func(config map[string]any) *contexts.IntegrationContext {
+		return &contexts.IntegrationContext{
+			Configuration: config,
+			Metadata: Metadata{
+				ProjectID:  "demo-project",
+				AuthMethod: AuthMethodWIF,
+			},
+			CurrentSecrets: map[string]core.IntegrationSecret{
+				SecretNameAccessToken: {Name: SecretNameAccessToken, Value: []byte("test-access-token")},
+			},
+		}
+	}
+
+	okResponse := func() *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))}
+	}
+
+	t.Run("path requests go to the overridden host", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{okResponse(), okResponse()}}
+		client, err := NewClient(httpContext, integration(map[string]any{
+			ConfigNameAPIEndpoint: "http://localhost:8085/compute/v1/",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "http://localhost:8085/compute/v1", client.BaseURL())
+
+		_, err = client.Get(context.Background(), "projects/demo-project/zones/us-central1-a/instances")
+		require.NoError(t, err)
+		_, err = client.Post(context.Background(), "/projects/demo-project/global/networks", map[string]any{"name": "vpc"})
+		require.NoError(t, err)
+
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "http://localhost:8085/compute/v1/projects/demo-project/zones/us-central1-a/instances", httpContext.Requests[0].URL.String())
+		assert.Equal(t, http.MethodPost, httpContext.Requests[1].Method)
+		assert.Equal(t, "http://localhost:8085/compute/v1/projects/demo-project/global/networks", httpContext.Requests[1].URL.String())
+	})
+
+	t.Run("absolute URL requests are not rewritten", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{okResponse()}}
+		client, err := NewClient(httpContext, integration(map[string]any{
+			ConfigNameAPIEndpoint: "http://localhost:8085/compute/v1",
+		}))
+		require.NoError(t, err)
+
+		_, err = client.GetURL(context.Background(), "https://run.googleapis.com/v2/projects/demo-project/locations/us-central1/services")
+		require.NoError(t, err)
+
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "run.googleapis.com", httpContext.Requests[0].URL.Host)
+	})
+
+	t.Run("no override uses the public endpoint", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{okResponse()}}
+		client, err := NewClient(httpContext, integration(map[string]any{}))
+		require.NoError(t, err)
+
+		_, err = client.Get(context.Background(), "projects/demo-project/regions")
+		require.NoError(t, err)
+
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://compute.googleapis.com/compute/v1/projects/demo-project/regions", httpContext.Requests[0].URL.String())
+	})
+
+	t.Run("invalid override fails client creation", func(t *testing.T) {
+		_, err := NewClient(&contexts.HTTPContext{}, integration(map[string]any{
+			ConfigNameAPIEndpoint: "localhost:8085",
+		}))
+		require.ErrorContains(t, err, "invalid API endpoint")
+	})
+}
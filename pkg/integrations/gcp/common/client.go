@@ -11,6 +11,7 @@ import (
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
 	"golang.org/x/oauth2/google"
 )
 
@@ -46,11 +47,16 @@ func NewClient(httpClient core.HTTPContext, integration core.IntegrationContext)
 		return nil, fmt.Errorf("integration metadata has no project ID")
 	}
 
+	baseURL, err := APIEndpoint(integration)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Client{
 		creds:     creds,
-		http:      httpClient,
+		http:      registry.InstrumentIntegrationHTTP("gcp", httpClient),
 		projectID: projectID,
-		baseURL:   defaultComputeBaseURL,
+		baseURL:   baseURL,
 	}, nil
 }
 
@@ -17,14 +17,13 @@ type wifMetadata struct {
 }
 
 func TokenSourceFromIntegration(ctx core.IntegrationContext, scopes ...string) (oauth2.TokenSource, error) {
-	secrets, err := ctx.GetSecrets()
+	keyJSON, accessToken, err := integrationCredentials(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get integration secrets: %w", err)
 	}
 
 	authMethod := AuthMethodFromMetadata(ctx.GetMetadata())
 
-	keyJSON := FindSecretValue(secrets, SecretNameServiceAccountKey)
 	if authMethod != AuthMethodWIF && len(keyJSON) > 0 {
 		if len(scopes) == 0 {
 			scopes = []string{ScopeCloudPlatform}
@@ -36,7 +35,6 @@ func TokenSourceFromIntegration(ctx core.IntegrationContext, scopes ...string) (
 		return creds.TokenSource, nil
 	}
 
-	accessToken := FindSecretValue(secrets, SecretNameAccessToken)
 	if authMethod != AuthMethodWIF || len(accessToken) == 0 {
 		return nil, fmt.Errorf("no GCP credentials found: add a service account key or use Workload Identity Federation and resync")
 	}
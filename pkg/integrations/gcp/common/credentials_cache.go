@@ -0,0 +1,72 @@
+package common
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// credentialsCacheTTL bounds how long decrypted credentials are reused.
+// It is short on purpose: the cache only saves repeated decryption when
+// one execution creates several clients for the same integration.
+const credentialsCacheTTL = time.Minute
+
+type cachedCredentials struct {
+	serviceAccountKey []byte
+	accessToken       []byte
+	expiresAt         time.Time
+}
+
+var credentialsCache = struct {
+	sync.Mutex
+	entries map[uuid.UUID]cachedCredentials
+}{entries: map[uuid.UUID]cachedCredentials{}}
+
+// Overridable in tests.
+var credentialsCacheNow = time.Now
+
+// InvalidateCredentialsCache drops the cached credentials for an integration.
+// It must be called whenever the integration's secrets change, e.g. on resync.
+func InvalidateCredentialsCache(integrationID uuid.UUID) {
+	credentialsCache.Lock()
+	defer credentialsCache.Unlock()
+	delete(credentialsCache.entries, integrationID)
+}
+
+// integrationCredentials returns the service account key and access token
+// secrets of the integration, decrypting them at most once per TTL.
+func integrationCredentials(ctx core.IntegrationContext) (serviceAccountKey, accessToken []byte, err error) {
+	id := ctx.ID()
+	now := credentialsCacheNow()
+
+	if id != uuid.Nil {
+		credentialsCache.Lock()
+		entry, ok := credentialsCache.entries[id]
+		credentialsCache.Unlock()
+		if ok && now.Before(entry.expiresAt) {
+			return entry.serviceAccountKey, entry.accessToken, nil
+		}
+	}
+
+	secrets, err := ctx.GetSecrets()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serviceAccountKey = FindSecretValue(secrets, SecretNameServiceAccountKey)
+	accessToken = FindSecretValue(secrets, SecretNameAccessToken)
+
+	if id != uuid.Nil {
+		credentialsCache.Lock()
+		credentialsCache.entries[id] = cachedCredentials{
+			serviceAccountKey: serviceAccountKey,
+			accessToken:       accessToken,
+			expiresAt:         now.Add(credentialsCacheTTL),
+		}
+		credentialsCache.Unlock()
+	}
+
+	return serviceAccountKey, accessToken, nil
+}
@@ -0,0 +1,90 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+// countingIntegrationContext counts how many times secrets are read (decrypted).
+type countingIntegrationContext struct {
+	*contexts.IntegrationContext
+	secretReads int
+}
+
+func (c *countingIntegrationContext) GetSecrets() ([]core.IntegrationSecret, error) {
+	c.secretReads++
+	return c.IntegrationContext.GetSecrets()
+}
+
+func newCountingIntegrationContext(token string) *countingIntegrationContext {
+	return &countingIntegrationContext{
+		IntegrationContext: &contexts.IntegrationContext{
+			CurrentSecrets: map[string]core.IntegrationSecret{
+				SecretNameAccessToken: {Name: SecretNameAccessToken, Value: []byte(token)},
+			},
+			Metadata: map[string]any{"authMethod": AuthMethodWIF, "projectId": "my-project"},
+		},
+	}
+}
+
+func clientAccessToken(t *testing.T, client *Client) string {
+	tok, err := client.creds.TokenSource.Token()
+	require.NoError(t, err)
+	return tok.AccessToken
+}
+
+func Test_NewClient_CredentialsCache(t *testing.T) {
+	t.Run("second client within the TTL does not re-read secrets", func(t *testing.T) {
+		integration := newCountingIntegrationContext("token-1")
+		t.Cleanup(func() { InvalidateCredentialsCache(integration.ID()) })
+
+		first, err := NewClient(&contexts.HTTPContext{}, integration)
+		require.NoError(t, err)
+		second, err := NewClient(&contexts.HTTPContext{}, integration)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, integration.secretReads)
+		assert.Equal(t, "token-1", clientAccessToken(t, first))
+		assert.Equal(t, "token-1", clientAccessToken(t, second))
+	})
+
+	t.Run("expired entry is read again", func(t *testing.T) {
+		integration := newCountingIntegrationContext("token-1")
+		t.Cleanup(func() { InvalidateCredentialsCache(integration.ID()) })
+
+		now := time.Now()
+		credentialsCacheNow = func() time.Time { return now }
+		t.Cleanup(func() { credentialsCacheNow = time.Now })
+
+		_, err := NewClient(&contexts.HTTPContext{}, integration)
+		require.NoError(t, err)
+
+		now = now.Add(credentialsCacheTTL + time.Second)
+		_, err = NewClient(&contexts.HTTPContext{}, integration)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, integration.secretReads)
+	})
+
+	t.Run("invalidation picks up rotated secrets", func(t *testing.T) {
+		integration := newCountingIntegrationContext("token-1")
+		t.Cleanup(func() { InvalidateCredentialsCache(integration.ID()) })
+
+		_, err := NewClient(&contexts.HTTPContext{}, integration)
+		require.NoError(t, err)
+
+		integration.CurrentSecrets[SecretNameAccessToken] = core.IntegrationSecret{Name: SecretNameAccessToken, Value: []byte("token-2")}
+		InvalidateCredentialsCache(integration.ID())
+
+		client, err := NewClient(&contexts.HTTPContext{}, integration)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, integration.secretReads)
+		assert.Equal(t, "token-2", clientAccessToken(t, client))
+	})
+}
@@ -0,0 +1,68 @@
+package common
+
+import (
+	"crypto/rand"
+	"strings"
+)
+
+// ResourceNameMaxLength is the maximum length of a Compute Engine resource
+// name (RFC 1035 label).
+const ResourceNameMaxLength = 63
+
+const (
+	resourceNameSuffixLength = 6
+	resourceNameFallback     = "resource"
+	resourceNameAlphabet     = "abcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+// randomResourceNameSuffix is replaced in tests to make suffixes predictable.
+var randomResourceNameSuffix = func() string {
+	b := make([]byte, resourceNameSuffixLength)
+	_, _ = rand.Read(b)
+	for i := range b {
+		b[i] = resourceNameAlphabet[int(b[i])%len(resourceNameAlphabet)]
+	}
+	return string(b)
+}
+
+// SafeResourceName derives a valid GCP resource name (VMs, disks, firewall
+// rules, images) from a free-form label: lowercased, with every run of other
+// characters turned into a single hyphen, starting with a letter and capped at
+// 63 characters. With randomSuffix, a short random suffix is appended so
+// repeated runs do not collide.
+func SafeResourceName(label string, randomSuffix bool) string {
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(label) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(r)
+			continue
+		}
+		pendingHyphen = true
+	}
+
+	name := b.String()
+	if name == "" {
+		name = resourceNameFallback
+	}
+	if name[0] < 'a' || name[0] > 'z' {
+		name = "r-" + name
+	}
+
+	maxLength := ResourceNameMaxLength
+	suffix := ""
+	if randomSuffix {
+		suffix = "-" + randomResourceNameSuffix()
+		maxLength -= len(suffix)
+	}
+
+	if len(name) > maxLength {
+		name = strings.TrimRight(name[:maxLength], "-")
+	}
+
+	return name + suffix
+}
@@ -0,0 +1,62 @@
+package common
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var resourceNameRegex = regexp.MustCompile(`^[a-z](?:[-a-z0-9]{0,61}[a-z0-9])?$`)
+
+func TestSafeResourceName(t *testing.T) {
+	t.Run("normalizes case, spaces and punctuation", func(t *testing.T) {
+		cases := map[string]string{
+			"My VM":                     "my-vm",
+			"  Web Server #1  ":         "web-server-1",
+			"deploy/main_branch--build": "deploy-main-branch-build",
+			"API.Gateway (EU)":          "api-gateway-eu",
+			"café-prod":                 "caf-prod",
+			"42nd build":                "r-42nd-build",
+			"!!!":                       "resource",
+			"":                          "resource",
+		}
+		for label, want := range cases {
+			got := SafeResourceName(label, false)
+			assert.Equal(t, want, got, label)
+			assert.Regexp(t, resourceNameRegex, got, label)
+		}
+	})
+
+	t.Run("caps over-length names without a trailing hyphen", func(t *testing.T) {
+		label := strings.Repeat("a", 62) + " b"
+		got := SafeResourceName(label, false)
+		assert.Equal(t, strings.Repeat("a", 62), got)
+
+		got = SafeResourceName(strings.Repeat("Long Label ", 20), false)
+		assert.LessOrEqual(t, len(got), ResourceNameMaxLength)
+		assert.Regexp(t, resourceNameRegex, got)
+	})
+
+	t.Run("appends a random suffix within the length cap", func(t *testing.T) {
+		original := randomResourceNameSuffix
+		randomResourceNameSuffix = func() string { return "x7k2p9" }
+		defer func() { randomResourceNameSuffix = original }()
+
+		assert.Equal(t, "my-vm-x7k2p9", SafeResourceName("My VM", true))
+
+		got := SafeResourceName(strings.Repeat("b", 100), true)
+		assert.Len(t, got, ResourceNameMaxLength)
+		assert.True(t, strings.HasSuffix(got, "-x7k2p9"))
+		assert.Regexp(t, resourceNameRegex, got)
+	})
+
+	t.Run("random suffixes are valid and differ", func(t *testing.T) {
+		first := SafeResourceName("build", true)
+		second := SafeResourceName("build", true)
+		assert.Regexp(t, resourceNameRegex, first)
+		assert.Regexp(t, resourceNameRegex, second)
+		assert.NotEqual(t, first, second)
+	})
+}
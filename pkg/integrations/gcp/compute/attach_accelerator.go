@@ -0,0 +1,404 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+	compute "google.golang.org/api/compute/v1"
+)
+
+type AttachAccelerator struct{}
+
+type AttachAcceleratorSpec struct {
+	Instance           string `mapstructure:"instance"`
+	AcceleratorType    string `mapstructure:"acceleratorType"`
+	AcceleratorCount   int64  `mapstructure:"acceleratorCount"`
+	MachineType        string `mapstructure:"machineType"`
+	RestartAfterUpdate *bool  `mapstructure:"restartAfterUpdate"`
+}
+
+// instanceAcceleratorsResp is the part of an instance read needed to attach accelerators.
+type instanceAcceleratorsResp struct {
+	Status            string                       `json:"status"`
+	Scheduling        *compute.Scheduling          `json:"scheduling"`
+	GuestAccelerators []*compute.AcceleratorConfig `json:"guestAccelerators"`
+}
+
+func (a *AttachAccelerator) Name() string {
+	return "gcp.attachAccelerator"
+}
+
+func (a *AttachAccelerator) Label() string {
+	return "Compute • Attach GPU"
+}
+
+func (a *AttachAccelerator) Description() string {
+	return "Attach a GPU to an existing Google Compute Engine VM instance"
+}
+
+func (a *AttachAccelerator) Documentation() string {
+	return `The Attach GPU component adds GPUs to an existing Compute Engine VM instance, for example to run a batch job on a VM that was provisioned without one.
+
+## Use Cases
+
+- **Batch jobs**: Provision a CPU VM, then add a GPU only for the step that needs it
+- **Cost optimization**: Keep a VM on CPU until GPU work is scheduled
+
+## Configuration
+
+- **VM Instance**: Pick from the list of VMs in your project, or pass an expression chained from an upstream node (e.g. the ` + "`selfLink`" + ` emitted by ` + "`gcp.createVM`" + `).
+- **Accelerator Type**: The GPU type, e.g. ` + "`nvidia-tesla-t4`" + ` or ` + "`nvidia-l4`" + ` (required, supports expressions).
+- **Accelerator Count**: Number of GPUs to attach. Defaults to 1.
+- **Machine Type**: Optional new machine type, for GPUs that need a specific machine series (e.g. N1 for T4, G2 for L4).
+- **Restart after update**: Whether to start the instance again after the GPU is attached. Enabled by default.
+
+## Output
+
+Returns the instance state after the update completes:
+- **instanceId**, **name**, **zone**, **status**, **selfLink**, **machineType**, **internalIP**, **externalIP**
+- **guestAccelerators**: The attached accelerators, each with **acceleratorType** and **acceleratorCount**
+- **stopped**: Whether the component had to stop the instance
+
+## Important Notes
+
+- The accelerator type, count and machine type are validated against the instance's zone before the instance is stopped.
+- Compute Engine only changes accelerators while the instance is **stopped (TERMINATED)**. A running instance is stopped automatically.
+- Instances with GPUs cannot live-migrate, so the host maintenance policy is switched to **Terminate** when needed.
+- The configured accelerators replace any accelerators already attached to the instance.`
+}
+
+func (a *AttachAccelerator) Icon() string {
+	return "cpu"
+}
+
+func (a *AttachAccelerator) Color() string {
+	return "blue"
+}
+
+func (a *AttachAccelerator) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (a *AttachAccelerator) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "instance",
+			Label:       "VM Instance",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The VM instance to attach the GPU to. Lists every VM in your project across all zones.",
+			Placeholder: "Select instance",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeInstance,
+				},
+			},
+		},
+		{
+			Name:        "acceleratorType",
+			Label:       "Accelerator Type",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "GPU type available in the instance's zone.",
+			Placeholder: "e.g. nvidia-tesla-t4",
+		},
+		{
+			Name:        "acceleratorCount",
+			Label:       "Accelerator Count",
+			Type:        configuration.FieldTypeNumber,
+			Required:    true,
+			Default:     1,
+			Description: "Number of GPUs to attach.",
+		},
+		{
+			Name:        "machineType",
+			Label:       "Machine Type",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    false,
+			Description: "Optional machine type to switch to, for GPUs that require a specific machine series. Leave empty to keep the current machine type.",
+			Placeholder: "Keep current machine type",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeInstanceMachineType,
+					Parameters: []configuration.ParameterRef{
+						{Name: "instance", ValueFrom: &configuration.ParameterValueFrom{Field: "instance"}},
+					},
+				},
+			},
+		},
+		{
+			Name:        "restartAfterUpdate",
+			Label:       "Restart after update",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     true,
+			Description: "Start the instance again after attaching the GPU.",
+		},
+	}
+}
+
+func (a *AttachAccelerator) Setup(ctx core.SetupContext) error {
+	spec := AttachAcceleratorSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	if strings.TrimSpace(spec.Instance) == "" {
+		return errors.New("instance is required")
+	}
+
+	if strings.TrimSpace(spec.AcceleratorType) == "" {
+		return errors.New("acceleratorType is required")
+	}
+
+	if spec.AcceleratorCount < 1 {
+		return errors.New("acceleratorCount must be at least 1")
+	}
+
+	return resolveInstanceNodeMetadata(ctx, spec.Instance)
+}
+
+func (a *AttachAccelerator) Execute(ctx core.ExecutionContext) error {
+	spec := AttachAcceleratorSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to decode configuration: %v", err))
+	}
+
+	accelerators := BuildGuestAccelerators(AdvancedConfig{
+		GuestAccelerators: []GuestAcceleratorEntry{{
+			AcceleratorType:  spec.AcceleratorType,
+			AcceleratorCount: spec.AcceleratorCount,
+		}},
+	})
+	if len(accelerators) == 0 {
+		return ctx.ExecutionState.Fail("error", "acceleratorType and an acceleratorCount of at least 1 are required")
+	}
+
+	machineType := strings.TrimSpace(spec.MachineType)
+	if machineType != "" {
+		if err := checkMachineFamilyAllowed(gcpcommon.AllowedMachineFamilies(ctx.Integration), machineType); err != nil {
+			return ctx.ExecutionState.Fail("error", err.Error())
+		}
+	}
+
+	urlProject, zone, instanceName, err := parseInstancePath(spec.Instance)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	project := client.ProjectID()
+	if urlProject != "" && urlProject != project {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf(
+			"instance belongs to project %q but this GCP integration is bound to project %q; cross-project operations are not supported",
+			urlProject, project,
+		))
+	}
+
+	// Same zone qualification as gcp.createVM.
+	for _, accelerator := range accelerators {
+		if !strings.Contains(accelerator.AcceleratorType, "/") {
+			accelerator.AcceleratorType = fmt.Sprintf("zones/%s/acceleratorTypes/%s", zone, accelerator.AcceleratorType)
+		}
+	}
+	if machineType != "" && !strings.Contains(machineType, "/") {
+		machineType = fmt.Sprintf("zones/%s/machineTypes/%s", zone, machineType)
+	}
+
+	callCtx := context.Background()
+
+	// Validate everything before touching the instance, so an unavailable GPU
+	// or machine type never leaves a running instance stopped.
+	for _, accelerator := range accelerators {
+		if err := ensureAcceleratorTypeAvailable(callCtx, client, zone, accelerator); err != nil {
+			return ctx.ExecutionState.Fail("error", err.Error())
+		}
+	}
+	if machineType != "" {
+		if err := ensureMachineTypeAvailable(callCtx, client, zone, machineType); err != nil {
+			return ctx.ExecutionState.Fail("error", err.Error())
+		}
+	}
+
+	body, err := GetInstance(callCtx, client, project, zone, instanceName)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to read instance: %v", err))
+	}
+
+	var current instanceAcceleratorsResp
+	if err := json.Unmarshal(body, &current); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to parse instance: %v", err))
+	}
+
+	stopped := false
+	if current.Status != "TERMINATED" {
+		if err := runInstancePowerOperation(callCtx, client, project, zone, instanceName, "stop"); err != nil {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to stop instance before update: %v", err))
+		}
+		stopped = true
+	}
+
+	if machineType != "" {
+		if err := setMachineType(callCtx, client, project, zone, instanceName, machineType); err != nil {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to update machine type: %v", err))
+		}
+	}
+
+	// GPU instances cannot live-migrate, so Compute Engine rejects accelerators
+	// while the instance is set to migrate on host maintenance.
+	if scheduling := acceleratorScheduling(current.Scheduling); scheduling != nil {
+		if err := setScheduling(callCtx, client, project, zone, instanceName, scheduling); err != nil {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to set host maintenance policy: %v", err))
+		}
+	}
+
+	if err := setMachineResources(callCtx, client, project, zone, instanceName, accelerators); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to attach accelerator: %v", err))
+	}
+
+	restart := spec.RestartAfterUpdate == nil || *spec.RestartAfterUpdate
+	if restart {
+		if err := runInstancePowerOperation(callCtx, client, project, zone, instanceName, "start"); err != nil {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to start instance after update: %v", err))
+		}
+	}
+
+	updated, err := GetInstance(callCtx, client, project, zone, instanceName)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to read instance after update: %v", err))
+	}
+
+	payload, err := InstancePayloadFromGetResponse(updated, zone)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to parse updated instance: %v", err))
+	}
+
+	var after instanceAcceleratorsResp
+	if err := json.Unmarshal(updated, &after); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to parse updated instance: %v", err))
+	}
+	if len(after.GuestAccelerators) == 0 {
+		after.GuestAccelerators = accelerators
+	}
+
+	payload["guestAccelerators"] = acceleratorsPayload(after.GuestAccelerators)
+	payload["stopped"] = stopped
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"gcp.compute.vmInstance.acceleratorAttached",
+		[]any{payload},
+	)
+}
+
+// ensureAcceleratorTypeAvailable checks that the accelerator type exists in the
+// given zone and that the requested count fits on one instance.
+func ensureAcceleratorTypeAvailable(ctx context.Context, client Client, zone string, accelerator *compute.AcceleratorConfig) error {
+	name := lastSegment(accelerator.AcceleratorType)
+	path := fmt.Sprintf("projects/%s/zones/%s/acceleratorTypes/%s", client.ProjectID(), zone, name)
+	body, err := client.Get(ctx, path)
+	if err != nil {
+		if gcpcommon.IsNotFoundError(err) {
+			return fmt.Errorf("accelerator type %q is not available in zone %q", name, zone)
+		}
+		return fmt.Errorf("failed to validate accelerator type %q: %v", name, err)
+	}
+
+	var acceleratorType compute.AcceleratorType
+	if err := json.Unmarshal(body, &acceleratorType); err != nil {
+		return fmt.Errorf("parse acceleratorType response: %w", err)
+	}
+	if maxCards := acceleratorType.MaximumCardsPerInstance; maxCards > 0 && accelerator.AcceleratorCount > maxCards {
+		return fmt.Errorf("accelerator type %q supports at most %d per instance, got %d", name, maxCards, accelerator.AcceleratorCount)
+	}
+
+	return nil
+}
+
+// acceleratorScheduling returns the current scheduling switched to terminate
+// on host maintenance, or nil when it already does.
+func acceleratorScheduling(current *compute.Scheduling) *compute.Scheduling {
+	if current != nil && current.OnHostMaintenance == OnHostMaintenanceTerminate {
+		return nil
+	}
+
+	scheduling := &compute.Scheduling{}
+	if current != nil {
+		copied := *current
+		scheduling = &copied
+	}
+	scheduling.OnHostMaintenance = OnHostMaintenanceTerminate
+	return scheduling
+}
+
+func acceleratorsPayload(accelerators []*compute.AcceleratorConfig) []map[string]any {
+	out := make([]map[string]any, 0, len(accelerators))
+	for _, accelerator := range accelerators {
+		if accelerator == nil {
+			continue
+		}
+		out = append(out, map[string]any{
+			"acceleratorType":  lastSegment(accelerator.AcceleratorType),
+			"acceleratorCount": accelerator.AcceleratorCount,
+		})
+	}
+	return out
+}
+
+// setMachineResources issues the setMachineResources POST and waits for the zone operation.
+func setMachineResources(ctx context.Context, client Client, project, zone, instanceName string, accelerators []*compute.AcceleratorConfig) error {
+	path := fmt.Sprintf("projects/%s/zones/%s/instances/%s/setMachineResources", project, zone, instanceName)
+	body, err := client.Post(ctx, path, &compute.InstancesSetMachineResourcesRequest{GuestAccelerators: accelerators})
+	if err != nil {
+		return err
+	}
+
+	var opResp struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(body, &opResp); err != nil {
+		return fmt.Errorf("parse setMachineResources operation response: %w", err)
+	}
+	if opResp.Name == "" {
+		return errors.New("setMachineResources operation response missing operation name")
+	}
+
+	return WaitForZoneOperation(ctx, client, project, zone, lastSegment(opResp.Name))
+}
+
+func (a *AttachAccelerator) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (a *AttachAccelerator) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (a *AttachAccelerator) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (a *AttachAccelerator) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (a *AttachAccelerator) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (a *AttachAccelerator) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
@@ -0,0 +1,262 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+	"github.com/superplanehq/superplane/test/support/contexts"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func Test__AttachAccelerator__Setup(t *testing.T) {
+	component := &AttachAccelerator{}
+
+	t.Run("missing instance returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"acceleratorType": "nvidia-tesla-t4", "acceleratorCount": 1},
+			Metadata:      &contexts.MetadataContext{},
+		})
+		require.ErrorContains(t, err, "instance is required")
+	})
+
+	t.Run("missing acceleratorType returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"instance": "zones/us-central1-a/instances/my-vm", "acceleratorCount": 1},
+			Metadata:      &contexts.MetadataContext{},
+		})
+		require.ErrorContains(t, err, "acceleratorType is required")
+	})
+
+	t.Run("zero acceleratorCount returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"instance":         "zones/us-central1-a/instances/my-vm",
+				"acceleratorType":  "nvidia-tesla-t4",
+				"acceleratorCount": 0,
+			},
+			Metadata: &contexts.MetadataContext{},
+		})
+		require.ErrorContains(t, err, "acceleratorCount must be at least 1")
+	})
+}
+
+func Test__AttachAccelerator__Execute(t *testing.T) {
+	component := &AttachAccelerator{}
+
+	t.Run("running instance -> stops, sets machine type, scheduling and GPU, restarts, emits", func(t *testing.T) {
+		var postedPaths []string
+		var machineTypeBody map[string]any
+		var schedulingBody *compute.Scheduling
+		var resourcesBody *compute.InstancesSetMachineResourcesRequest
+		getCalls := 0
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				postedPaths = append(postedPaths, path)
+				switch {
+				case strings.HasSuffix(path, "/setMachineType"):
+					machineTypeBody, _ = body.(map[string]any)
+				case strings.HasSuffix(path, "/setScheduling"):
+					schedulingBody, _ = body.(*compute.Scheduling)
+				case strings.HasSuffix(path, "/setMachineResources"):
+					resourcesBody, _ = body.(*compute.InstancesSetMachineResourcesRequest)
+				}
+				return opDone("op-1"), nil
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				if isOperationPath(path) {
+					return opDone("op-1"), nil
+				}
+				if isAcceleratorTypePath(path) {
+					return []byte(`{"name":"nvidia-tesla-t4","maximumCardsPerInstance":4}`), nil
+				}
+				if isMachineTypePath(path) {
+					return []byte(`{"name":"n1-standard-8"}`), nil
+				}
+				getCalls++
+				if getCalls == 1 {
+					return instanceWithScheduling(t, "RUNNING", "e2-standard-8", "MIGRATE", nil), nil
+				}
+				return instanceWithScheduling(t, "RUNNING", "n1-standard-8", "TERMINATE", []map[string]any{
+					{"acceleratorType": "https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/acceleratorTypes/nvidia-tesla-t4", "acceleratorCount": 2},
+				}), nil
+			},
+		}
+
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"instance":         "zones/us-central1-a/instances/my-vm",
+				"acceleratorType":  "nvidia-tesla-t4",
+				"acceleratorCount": 2,
+				"machineType":      "n1-standard-8",
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+		assert.Equal(t, "gcp.compute.vmInstance.acceleratorAttached", state.Type)
+
+		require.Len(t, postedPaths, 5)
+		assert.True(t, strings.HasSuffix(postedPaths[0], "/stop"))
+		assert.True(t, strings.HasSuffix(postedPaths[1], "/setMachineType"))
+		assert.True(t, strings.HasSuffix(postedPaths[2], "/setScheduling"))
+		assert.True(t, strings.HasSuffix(postedPaths[3], "/setMachineResources"))
+		assert.True(t, strings.HasSuffix(postedPaths[4], "/start"))
+
+		assert.Equal(t, "zones/us-central1-a/machineTypes/n1-standard-8", machineTypeBody["machineType"])
+		require.NotNil(t, schedulingBody)
+		assert.Equal(t, OnHostMaintenanceTerminate, schedulingBody.OnHostMaintenance)
+		require.NotNil(t, resourcesBody)
+		assert.Equal(t, []*compute.AcceleratorConfig{
+			{AcceleratorType: "zones/us-central1-a/acceleratorTypes/nvidia-tesla-t4", AcceleratorCount: 2},
+		}, resourcesBody.GuestAccelerators)
+
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "n1-standard-8", data["machineType"])
+		assert.Equal(t, true, data["stopped"])
+		assert.Equal(t, []map[string]any{
+			{"acceleratorType": "nvidia-tesla-t4", "acceleratorCount": int64(2)},
+		}, data["guestAccelerators"])
+	})
+
+	t.Run("stopped instance already terminating on maintenance + no restart -> only attaches", func(t *testing.T) {
+		var postedPaths []string
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				postedPaths = append(postedPaths, path)
+				return opDone("op-1"), nil
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				if isOperationPath(path) {
+					return opDone("op-1"), nil
+				}
+				if isAcceleratorTypePath(path) {
+					return []byte(`{"name":"nvidia-l4","maximumCardsPerInstance":8}`), nil
+				}
+				return instanceWithScheduling(t, "TERMINATED", "g2-standard-8", "TERMINATE", nil), nil
+			},
+		}
+
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"instance":           "zones/us-central1-a/instances/my-vm",
+				"acceleratorType":    "nvidia-l4",
+				"acceleratorCount":   1,
+				"restartAfterUpdate": false,
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+		require.Len(t, postedPaths, 1)
+		assert.True(t, strings.HasSuffix(postedPaths[0], "/setMachineResources"))
+
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, false, data["stopped"])
+	})
+
+	t.Run("accelerator unavailable in zone -> fails without stopping", func(t *testing.T) {
+		var postedPaths []string
+		var acceleratorTypePath string
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				postedPaths = append(postedPaths, path)
+				return opDone("op-1"), nil
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				if isAcceleratorTypePath(path) {
+					acceleratorTypePath = path
+					return nil, &gcpcommon.GCPAPIError{StatusCode: http.StatusNotFound, Message: "not found"}
+				}
+				return instanceWithScheduling(t, "RUNNING", "n1-standard-8", "MIGRATE", nil), nil
+			},
+		}
+
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"instance":         "zones/us-central1-a/instances/my-vm",
+				"acceleratorType":  "nvidia-h100-80gb",
+				"acceleratorCount": 1,
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, `accelerator type "nvidia-h100-80gb" is not available in zone "us-central1-a"`)
+		assert.Equal(t, "projects/my-project/zones/us-central1-a/acceleratorTypes/nvidia-h100-80gb", acceleratorTypePath)
+		assert.Empty(t, postedPaths)
+	})
+
+	t.Run("count above the per-instance maximum -> fails without stopping", func(t *testing.T) {
+		var postedPaths []string
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				postedPaths = append(postedPaths, path)
+				return opDone("op-1"), nil
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				if isAcceleratorTypePath(path) {
+					return []byte(`{"name":"nvidia-tesla-t4","maximumCardsPerInstance":4}`), nil
+				}
+				return instanceWithScheduling(t, "RUNNING", "n1-standard-8", "MIGRATE", nil), nil
+			},
+		}
+
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"instance":         "zones/us-central1-a/instances/my-vm",
+				"acceleratorType":  "nvidia-tesla-t4",
+				"acceleratorCount": 8,
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, `accelerator type "nvidia-tesla-t4" supports at most 4 per instance, got 8`)
+		assert.Empty(t, postedPaths)
+	})
+}
+
+// isAcceleratorTypePath reports whether a Get path targets a zone accelerator type.
+func isAcceleratorTypePath(path string) bool {
+	return strings.Contains(path, "/acceleratorTypes/")
+}
+
+// instanceWithScheduling extends instanceGetJSON with scheduling and guest accelerators.
+func instanceWithScheduling(t *testing.T, status, machineType, onHostMaintenance string, accelerators []map[string]any) []byte {
+	var instance map[string]any
+	require.NoError(t, json.Unmarshal(instanceGetJSON("123", "my-vm", "us-central1-a", status, machineType), &instance))
+	instance["scheduling"] = map[string]any{"onHostMaintenance": onHostMaintenance}
+	if accelerators != nil {
+		instance["guestAccelerators"] = accelerators
+	}
+	b, err := json.Marshal(instance)
+	require.NoError(t, err)
+	return b
+}
@@ -65,7 +65,7 @@ func (c *CreateImage) Documentation() string {
   - **Disk**: A persistent disk (pick the region, zone, then the disk).
   - **Snapshot**: A disk snapshot.
   - **Image**: Another custom image in the project.
-- **Image family**: Optional family to group related images (e.g. ` + "`my-app`" + `).
+- **Image family**: Optional family to group related images (e.g. ` + "`my-app`" + `). Follows the same naming rules as the image name.
 - **Description**: Optional human-readable description.
 - **Labels**: Optional key-value labels (billing, environment, team).
 - **Storage location**: Optional single region or multi-region to store the image (e.g. ` + "`us`" + ` or ` + "`europe-west1`" + `). Defaults to the source's region.
@@ -290,6 +290,14 @@ func (c *CreateImage) Setup(ctx core.SetupContext) error {
 		return errors.New("image name is required")
 	}
 
+	if err := validateImageName(spec.Name); err != nil {
+		return err
+	}
+
+	if err := validateImageFamily(spec.Family); err != nil {
+		return err
+	}
+
 	if err := validateImageSource(spec); err != nil {
 		return err
 	}
@@ -297,6 +305,28 @@ func (c *CreateImage) Setup(ctx core.SetupContext) error {
 	return ctx.Metadata.Set(ImageNodeMetadata{ImageName: strings.TrimSpace(spec.Name)})
 }
 
+func validateImageName(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.Contains(name, "{{") {
+		return nil
+	}
+	if !gcpInstanceNameRegex.MatchString(name) {
+		return fmt.Errorf("image name must be 1–63 characters: start with a lowercase letter, use only lowercase letters (a-z), digits (0-9), and hyphens (-), and end with a letter or digit")
+	}
+	return nil
+}
+
+func validateImageFamily(family string) error {
+	family = strings.TrimSpace(family)
+	if family == "" || strings.Contains(family, "{{") {
+		return nil
+	}
+	if !gcpInstanceNameRegex.MatchString(family) {
+		return fmt.Errorf("image family must be 1–63 characters: start with a lowercase letter, use only lowercase letters (a-z), digits (0-9), and hyphens (-), and end with a letter or digit")
+	}
+	return nil
+}
+
 func validateImageSource(spec CreateImageSpec) error {
 	sourceType := normalizeImageSourceType(spec.SourceType)
 	switch sourceType {
@@ -343,6 +373,12 @@ func (c *CreateImage) Execute(ctx core.ExecutionContext) error {
 	if name == "" {
 		return ctx.ExecutionState.Fail("error", "image name is required")
 	}
+	if err := validateImageName(name); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+	if err := validateImageFamily(spec.Family); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
 	if err := validateImageSource(spec); err != nil {
 		return ctx.ExecutionState.Fail("error", err.Error())
 	}
@@ -71,6 +71,35 @@ func Test__CreateImage__Setup(t *testing.T) {
 		assert.Equal(t, "img", stored.ImageName)
 	})
 
+	t.Run("invalid image name returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"name": "My_Image", "zone": "us-central1-a", "sourceDisk": "my-disk"},
+			Metadata:      &contexts.MetadataContext{},
+		})
+		require.ErrorContains(t, err, "image name must be 1–63 characters")
+	})
+
+	t.Run("invalid image family returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"name": "img", "family": "my-app-", "zone": "us-central1-a", "sourceDisk": "my-disk"},
+			Metadata:      &contexts.MetadataContext{},
+		})
+		require.ErrorContains(t, err, "image family must be 1–63 characters")
+	})
+
+	t.Run("expression image name and family are not validated", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"name":       "{{ $['Build'].data.version }}",
+				"family":     "{{ $['Build'].data.family }}",
+				"zone":       "us-central1-a",
+				"sourceDisk": "my-disk",
+			},
+			Metadata: &contexts.MetadataContext{},
+		})
+		require.NoError(t, err)
+	})
+
 	t.Run("empty source type defaults to disk", func(t *testing.T) {
 		err := component.Setup(core.SetupContext{
 			Configuration: map[string]any{"name": "img", "zone": "us-central1-a", "sourceDisk": "my-disk"},
@@ -83,6 +112,69 @@ func Test__CreateImage__Setup(t *testing.T) {
 func Test__CreateImage__Execute(t *testing.T) {
 	component := &CreateImage{}
 
+	t.Run("waits for the global operation -> emits image selfLink", func(t *testing.T) {
+		opPolls := 0
+		mc := &mockImageClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				return []byte(`{"name":"op-create","status":"RUNNING"}`), nil
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				if isOperationPath(path) {
+					assert.Equal(t, "projects/my-project/global/operations/op-create", path)
+					opPolls++
+					if opPolls == 1 {
+						return []byte(`{"name":"op-create","status":"RUNNING"}`), nil
+					}
+					return opDone("op-create"), nil
+				}
+				return imageGetJSON("golden-2026-06-02", "READY", "golden", nil, "fp", ""), nil
+			},
+		}
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"name":       "golden-2026-06-02",
+				"zone":       "us-central1-a",
+				"sourceDisk": "my-disk",
+				"family":     "golden",
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, opPolls)
+		assert.True(t, state.Passed)
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "https://www.googleapis.com/compute/v1/projects/my-project/global/images/golden-2026-06-02", data["selfLink"])
+		assert.Equal(t, "golden", data["family"])
+	})
+
+	t.Run("invalid image family fails without creating the image", func(t *testing.T) {
+		posted := false
+		mc := &mockImageClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				posted = true
+				return opDone("op-create"), nil
+			},
+		}
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"name": "img", "family": "Golden", "zone": "us-central1-a", "sourceDisk": "my-disk"},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "image family must be")
+		assert.False(t, posted)
+	})
+
 	t.Run("creates image from disk -> emits created event", func(t *testing.T) {
 		var postPath string
 		var postBody any
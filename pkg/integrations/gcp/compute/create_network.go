@@ -0,0 +1,321 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+)
+
+type CreateNetwork struct{}
+
+type CreateNetworkSpec struct {
+	Name        string `mapstructure:"name"`
+	SubnetMode  string `mapstructure:"subnetMode"`
+	RoutingMode string `mapstructure:"routingMode"`
+	MTU         int64  `mapstructure:"mtu"`
+	Description string `mapstructure:"description"`
+}
+
+const (
+	NetworkSubnetModeCustom = "custom"
+	NetworkSubnetModeAuto   = "auto"
+
+	NetworkRoutingModeRegional = "REGIONAL"
+	NetworkRoutingModeGlobal   = "GLOBAL"
+
+	minNetworkMTU = 1300
+	maxNetworkMTU = 8896
+)
+
+// networkGetResp is the subset of a Compute Engine network resource we read
+// back after creating it.
+type networkGetResp struct {
+	Name                  string `json:"name"`
+	SelfLink              string `json:"selfLink"`
+	AutoCreateSubnetworks bool   `json:"autoCreateSubnetworks"`
+	MTU                   int64  `json:"mtu"`
+	RoutingConfig         *struct {
+		RoutingMode string `json:"routingMode"`
+	} `json:"routingConfig"`
+}
+
+func (c *CreateNetwork) Name() string {
+	return "gcp.compute.createNetwork"
+}
+
+func (c *CreateNetwork) Label() string {
+	return "Compute • Create Network"
+}
+
+func (c *CreateNetwork) Description() string {
+	return "Create a VPC network in a Google Cloud project"
+}
+
+func (c *CreateNetwork) Documentation() string {
+	return `The Create Network component creates a VPC network in Compute Engine.
+
+## Use Cases
+
+- **Environment bootstrap**: Create the network for a new environment before creating subnets, firewall rules and VMs
+- **Isolation**: Give a workload its own network instead of sharing the default one
+
+## Configuration
+
+- **Name**: The name for the new network (required, lowercase RFC1035 — e.g. ` + "`staging-vpc`" + `)
+- **Subnet Mode**: ` + "`Custom`" + ` (default) creates an empty network for **Create Subnetwork**; ` + "`Auto`" + ` creates one subnet per region automatically
+- **Routing Mode**: ` + "`Regional`" + ` (default) or ` + "`Global`" + ` dynamic routing
+- **MTU**: Optional maximum transmission unit, between 1300 and 8896. Compute Engine defaults to 1460
+- **Description**: Optional human-readable description
+
+## Output
+
+Returns the network:
+- **name**, **selfLink**, **subnetMode**, **routingMode**, **mtu**
+- **alreadyExisted**: true when a network with this name already existed and was reused
+
+## Important Notes
+
+- If a network with the same name already exists, the component succeeds and returns the existing network without changing it
+- The component waits for the underlying global operation to complete before reading the network back`
+}
+
+func (c *CreateNetwork) Icon() string {
+	return "network"
+}
+
+func (c *CreateNetwork) Color() string {
+	return "blue"
+}
+
+func (c *CreateNetwork) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateNetwork) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "name",
+			Label:       "Name",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Name for the network (lowercase letters, numbers and hyphens).",
+			Placeholder: "e.g. staging-vpc",
+		},
+		{
+			Name:        "subnetMode",
+			Label:       "Subnet Mode",
+			Type:        configuration.FieldTypeSelect,
+			Required:    false,
+			Description: "Custom networks start without subnets; auto networks get one subnet per region.",
+			Default:     NetworkSubnetModeCustom,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Custom", Value: NetworkSubnetModeCustom},
+						{Label: "Auto", Value: NetworkSubnetModeAuto},
+					},
+				},
+			},
+		},
+		{
+			Name:        "routingMode",
+			Label:       "Routing Mode",
+			Type:        configuration.FieldTypeSelect,
+			Required:    false,
+			Description: "Whether Cloud Routers advertise routes for this region only or for all regions.",
+			Default:     NetworkRoutingModeRegional,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Regional", Value: NetworkRoutingModeRegional},
+						{Label: "Global", Value: NetworkRoutingModeGlobal},
+					},
+				},
+			},
+		},
+		{
+			Name:        "mtu",
+			Label:       "MTU",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Description: "Maximum transmission unit in bytes (1300-8896). Leave empty for the Compute Engine default of 1460.",
+			Placeholder: "e.g. 1460",
+		},
+		{
+			Name:        "description",
+			Label:       "Description",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Optional description for the network.",
+			Placeholder: "e.g. Staging environment network",
+		},
+	}
+}
+
+func (c *CreateNetwork) Setup(ctx core.SetupContext) error {
+	spec := CreateNetworkSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	return validateCreateNetworkSpec(spec)
+}
+
+func validateCreateNetworkSpec(spec CreateNetworkSpec) error {
+	name := strings.TrimSpace(spec.Name)
+	if name == "" {
+		return errors.New("name is required")
+	}
+	if !strings.Contains(name, "{{") && !gcpInstanceNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid network name %q: use 1-63 lowercase letters, digits and hyphens, starting with a letter", name)
+	}
+
+	switch spec.SubnetMode {
+	case "", NetworkSubnetModeCustom, NetworkSubnetModeAuto:
+	default:
+		return fmt.Errorf("invalid subnetMode %q: must be %s or %s", spec.SubnetMode, NetworkSubnetModeCustom, NetworkSubnetModeAuto)
+	}
+
+	switch spec.RoutingMode {
+	case "", NetworkRoutingModeRegional, NetworkRoutingModeGlobal:
+	default:
+		return fmt.Errorf("invalid routingMode %q: must be %s or %s", spec.RoutingMode, NetworkRoutingModeRegional, NetworkRoutingModeGlobal)
+	}
+
+	if spec.MTU != 0 && (spec.MTU < minNetworkMTU || spec.MTU > maxNetworkMTU) {
+		return fmt.Errorf("invalid mtu %d: must be between %d and %d", spec.MTU, minNetworkMTU, maxNetworkMTU)
+	}
+
+	return nil
+}
+
+func (c *CreateNetwork) Execute(ctx core.ExecutionContext) error {
+	spec := CreateNetworkSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to decode configuration: %v", err))
+	}
+
+	if err := validateCreateNetworkSpec(spec); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	project := client.ProjectID()
+	name := strings.TrimSpace(spec.Name)
+	callCtx := context.Background()
+
+	alreadyExisted, err := createNetwork(callCtx, client, project, BuildNetwork(spec))
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create network: %v", err))
+	}
+
+	body, err := client.Get(callCtx, fmt.Sprintf("projects/%s/global/networks/%s", project, name))
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to read network: %v", err))
+	}
+
+	var network networkGetResp
+	if err := json.Unmarshal(body, &network); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("parse network response: %v", err))
+	}
+
+	subnetMode := NetworkSubnetModeCustom
+	if network.AutoCreateSubnetworks {
+		subnetMode = NetworkSubnetModeAuto
+	}
+	routingMode := ""
+	if network.RoutingConfig != nil {
+		routingMode = network.RoutingConfig.RoutingMode
+	}
+
+	payload := map[string]any{
+		"name":           network.Name,
+		"selfLink":       network.SelfLink,
+		"subnetMode":     subnetMode,
+		"routingMode":    routingMode,
+		"mtu":            network.MTU,
+		"alreadyExisted": alreadyExisted,
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"gcp.compute.network.created",
+		[]any{payload},
+	)
+}
+
+// BuildNetwork builds the networks.insert request body from the spec.
+func BuildNetwork(spec CreateNetworkSpec) map[string]any {
+	routingMode := spec.RoutingMode
+	if routingMode == "" {
+		routingMode = NetworkRoutingModeRegional
+	}
+
+	body := map[string]any{
+		"name":                  strings.TrimSpace(spec.Name),
+		"autoCreateSubnetworks": spec.SubnetMode == NetworkSubnetModeAuto,
+		"routingConfig":         map[string]any{"routingMode": routingMode},
+	}
+	if spec.MTU != 0 {
+		body["mtu"] = spec.MTU
+	}
+	if description := strings.TrimSpace(spec.Description); description != "" {
+		body["description"] = description
+	}
+	return body
+}
+
+// createNetwork inserts the network and waits for the global operation. An
+// existing network with the same name (409) is reported as alreadyExisted.
+func createNetwork(ctx context.Context, client Client, project string, network map[string]any) (alreadyExisted bool, err error) {
+	body, err := client.Post(ctx, fmt.Sprintf("projects/%s/global/networks", project), network)
+	if err != nil {
+		if gcpcommon.IsAlreadyExistsError(err) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	opName, err := operationNameFromResponse(body, "create network")
+	if err != nil {
+		return false, err
+	}
+	return false, WaitForGlobalOperation(ctx, client, project, opName)
+}
+
+func (c *CreateNetwork) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateNetwork) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CreateNetwork) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CreateNetwork) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *CreateNetwork) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *CreateNetwork) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
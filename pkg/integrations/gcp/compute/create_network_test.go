@@ -0,0 +1,154 @@
+package compute
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__CreateNetwork__Setup(t *testing.T) {
+	component := &CreateNetwork{}
+
+	t.Run("missing name returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{}})
+		require.ErrorContains(t, err, "name is required")
+	})
+
+	t.Run("invalid name returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"name": "Staging_VPC"}})
+		require.ErrorContains(t, err, `invalid network name "Staging_VPC"`)
+	})
+
+	t.Run("mtu out of range returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"name": "staging-vpc", "mtu": 9000}})
+		require.ErrorContains(t, err, "invalid mtu 9000")
+	})
+
+	t.Run("expression name is accepted", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"name": "{{ $.env }}-vpc"}})
+		require.NoError(t, err)
+	})
+}
+
+func Test__CreateNetwork__Execute(t *testing.T) {
+	component := &CreateNetwork{}
+
+	t.Run("creates network -> waits for global operation -> emits created event", func(t *testing.T) {
+		var postPath string
+		var postBody map[string]any
+		var getPaths []string
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				postPath = path
+				postBody, _ = body.(map[string]any)
+				return opDone("op-network"), nil
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				getPaths = append(getPaths, path)
+				if isOperationPath(path) {
+					return opDone("op-network"), nil
+				}
+				return []byte(`{
+					"name": "staging-vpc",
+					"selfLink": "https://www.googleapis.com/compute/v1/projects/my-project/global/networks/staging-vpc",
+					"autoCreateSubnetworks": false,
+					"mtu": 1500,
+					"routingConfig": {"routingMode": "GLOBAL"}
+				}`), nil
+			},
+		}
+
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"name":        "staging-vpc",
+				"subnetMode":  NetworkSubnetModeCustom,
+				"routingMode": NetworkRoutingModeGlobal,
+				"mtu":         1500,
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+		assert.Equal(t, "gcp.compute.network.created", state.Type)
+
+		assert.Equal(t, "projects/my-project/global/networks", postPath)
+		assert.Equal(t, "staging-vpc", postBody["name"])
+		assert.Equal(t, false, postBody["autoCreateSubnetworks"])
+		assert.Equal(t, map[string]any{"routingMode": "GLOBAL"}, postBody["routingConfig"])
+		assert.EqualValues(t, 1500, postBody["mtu"])
+
+		require.Len(t, getPaths, 2)
+		assert.Equal(t, "projects/my-project/global/operations/op-network", getPaths[0])
+		assert.Equal(t, "projects/my-project/global/networks/staging-vpc", getPaths[1])
+
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "https://www.googleapis.com/compute/v1/projects/my-project/global/networks/staging-vpc", data["selfLink"])
+		assert.Equal(t, NetworkSubnetModeCustom, data["subnetMode"])
+		assert.Equal(t, "GLOBAL", data["routingMode"])
+		assert.Equal(t, false, data["alreadyExisted"])
+	})
+
+	t.Run("already exists (409) -> success with existing network", func(t *testing.T) {
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				return nil, &gcpcommon.GCPAPIError{StatusCode: http.StatusConflict, Message: "already exists"}
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				require.False(t, isOperationPath(path), "no operation to wait on for an existing network")
+				return []byte(`{
+					"name": "staging-vpc",
+					"selfLink": "https://www.googleapis.com/compute/v1/projects/my-project/global/networks/staging-vpc",
+					"autoCreateSubnetworks": true
+				}`), nil
+			},
+		}
+
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"name": "staging-vpc"},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, true, data["alreadyExisted"])
+		assert.Equal(t, NetworkSubnetModeAuto, data["subnetMode"])
+	})
+
+	t.Run("API error -> fails", func(t *testing.T) {
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				return nil, &gcpcommon.GCPAPIError{StatusCode: http.StatusForbidden, Message: "permission denied"}
+			},
+		}
+
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"name": "staging-vpc"},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Passed)
+		assert.True(t, strings.HasPrefix(state.FailureMessage, "failed to create network"))
+	})
+}
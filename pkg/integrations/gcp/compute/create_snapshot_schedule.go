@@ -0,0 +1,222 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type CreateSnapshotSchedule struct{}
+
+type CreateSnapshotScheduleSpec struct {
+	Project               string `mapstructure:"project"`
+	Region                string `mapstructure:"region"`
+	SnapshotScheduleEntry `mapstructure:",squash"`
+	Description           string `mapstructure:"description"`
+}
+
+// resourcePolicyGetResp is the subset of a resource policy we read back after
+// creating it.
+type resourcePolicyGetResp struct {
+	Name     string `json:"name"`
+	SelfLink string `json:"selfLink"`
+	Status   string `json:"status"`
+}
+
+func (c *CreateSnapshotSchedule) Name() string {
+	return "gcp.compute.createSnapshotSchedule"
+}
+
+func (c *CreateSnapshotSchedule) Label() string {
+	return "Compute • Create Snapshot Schedule"
+}
+
+func (c *CreateSnapshotSchedule) Description() string {
+	return "Create a snapshot schedule resource policy for persistent disks"
+}
+
+func (c *CreateSnapshotSchedule) Documentation() string {
+	return `The Create Snapshot Schedule component creates a snapshot schedule resource policy in a region. Attach it to boot disks with the **Snapshot schedule** option of **Create VM**.
+
+## Use Cases
+
+- **Backups**: Define a backup cadence once and reuse it for every VM created in the region
+- **Environment bootstrap**: Create the schedules a new environment needs before creating its VMs
+
+## Configuration
+
+- **Project**: Optional project ID. Defaults to the integration's project
+- **Region**: The region to create the schedule in. It can only be attached to disks in the same region
+- **Name**: The name of the resource policy (lowercase RFC1035 — e.g. ` + "`daily-14d`" + `)
+- **Frequency**: ` + "`Hourly`" + `, ` + "`Daily`" + ` (default) or ` + "`Weekly`" + `
+- **Day of week**: The day weekly snapshots are taken on
+- **Start time (UTC)**: The hour the snapshot window starts, in ` + "`HH:00`" + ` format (defaults to 04:00)
+- **Retention (days)**: Snapshots older than this are deleted automatically
+- **Description**: Optional human-readable description
+
+## Output
+
+Returns the schedule:
+- **name**, **selfLink**, **region**, **frequency**, **retentionDays**, **status**
+- **alreadyExisted**: true when a schedule with this name already existed and was reused
+
+## Important Notes
+
+- If a schedule with the same name already exists in the region, the component succeeds and returns it without changing it
+- Snapshots are kept when the source disk is deleted, matching the console default
+- The component waits for the underlying regional operation to complete before reading the schedule back`
+}
+
+func (c *CreateSnapshotSchedule) Icon() string {
+	return "calendar-clock"
+}
+
+func (c *CreateSnapshotSchedule) Color() string {
+	return "blue"
+}
+
+func (c *CreateSnapshotSchedule) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateSnapshotSchedule) Configuration() []configuration.Field {
+	fields := []configuration.Field{
+		{
+			Name:        "project",
+			Label:       "Project",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Project to create the schedule in. Defaults to the integration's project.",
+			Placeholder: "e.g. my-project",
+		},
+		{
+			Name:        "region",
+			Label:       "Region",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The region to create the schedule in (e.g. us-central1).",
+			Placeholder: "Select region",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeRegion,
+				},
+			},
+		},
+	}
+
+	fields = append(fields, snapshotScheduleFields()...)
+	return append(fields, configuration.Field{
+		Name:        "description",
+		Label:       "Description",
+		Type:        configuration.FieldTypeString,
+		Required:    false,
+		Description: "Optional description for the schedule.",
+		Placeholder: "e.g. Daily backups kept for two weeks",
+	})
+}
+
+func (c *CreateSnapshotSchedule) Setup(ctx core.SetupContext) error {
+	spec := CreateSnapshotScheduleSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	return validateCreateSnapshotScheduleSpec(spec)
+}
+
+func validateCreateSnapshotScheduleSpec(spec CreateSnapshotScheduleSpec) error {
+	if strings.TrimSpace(spec.Region) == "" {
+		return errors.New("region is required")
+	}
+	if strings.TrimSpace(spec.Name) == "" {
+		return errors.New("name is required")
+	}
+	if strings.Contains(spec.Name, "{{") || strings.Contains(spec.StartTime, "{{") {
+		return nil
+	}
+
+	return validateSnapshotScheduleEntry(spec.SnapshotScheduleEntry)
+}
+
+func (c *CreateSnapshotSchedule) Execute(ctx core.ExecutionContext) error {
+	spec := CreateSnapshotScheduleSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to decode configuration: %v", err))
+	}
+
+	if err := validateCreateSnapshotScheduleSpec(spec); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	project := ensureProject(strings.TrimSpace(spec.Project), client)
+	region := lastSegment(strings.TrimSpace(spec.Region))
+	callCtx := context.Background()
+
+	policyPath, alreadyExisted, err := createSnapshotSchedule(callCtx, client, project, region, spec.SnapshotScheduleEntry, strings.TrimSpace(spec.Description))
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create snapshot schedule: %v", err))
+	}
+
+	body, err := client.Get(callCtx, policyPath)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to read snapshot schedule: %v", err))
+	}
+
+	var policy resourcePolicyGetResp
+	if err := json.Unmarshal(body, &policy); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("parse snapshot schedule response: %v", err))
+	}
+
+	payload := map[string]any{
+		"name":           policy.Name,
+		"selfLink":       policy.SelfLink,
+		"region":         region,
+		"frequency":      snapshotScheduleFrequency(spec.SnapshotScheduleEntry),
+		"retentionDays":  spec.RetentionDays,
+		"status":         policy.Status,
+		"alreadyExisted": alreadyExisted,
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"gcp.compute.snapshotSchedule.created",
+		[]any{payload},
+	)
+}
+
+func (c *CreateSnapshotSchedule) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateSnapshotSchedule) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CreateSnapshotSchedule) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CreateSnapshotSchedule) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *CreateSnapshotSchedule) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *CreateSnapshotSchedule) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
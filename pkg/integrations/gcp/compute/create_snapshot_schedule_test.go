@@ -0,0 +1,224 @@
+package compute
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+	"github.com/superplanehq/superplane/test/support/contexts"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func Test__CreateSnapshotSchedule__Setup(t *testing.T) {
+	component := &CreateSnapshotSchedule{}
+	config := func() map[string]any {
+		return map[string]any{
+			"region":        "us-central1",
+			"name":          "daily-14d",
+			"frequency":     "daily",
+			"retentionDays": 14,
+		}
+	}
+
+	t.Run("missing region returns error", func(t *testing.T) {
+		c := config()
+		delete(c, "region")
+		err := component.Setup(core.SetupContext{Configuration: c})
+		require.ErrorContains(t, err, "region is required")
+	})
+
+	t.Run("invalid frequency returns error", func(t *testing.T) {
+		c := config()
+		c["frequency"] = "monthly"
+		err := component.Setup(core.SetupContext{Configuration: c})
+		require.ErrorContains(t, err, "invalid snapshot schedule frequency")
+	})
+
+	t.Run("missing retention returns error", func(t *testing.T) {
+		c := config()
+		delete(c, "retentionDays")
+		err := component.Setup(core.SetupContext{Configuration: c})
+		require.ErrorContains(t, err, "retention days must be greater than 0")
+	})
+
+	t.Run("invalid start time returns error", func(t *testing.T) {
+		c := config()
+		c["startTime"] = "04:30"
+		err := component.Setup(core.SetupContext{Configuration: c})
+		require.ErrorContains(t, err, "HH:00 format")
+	})
+
+	t.Run("expression name is accepted", func(t *testing.T) {
+		c := config()
+		c["name"] = "{{ $.env }}-daily"
+		require.NoError(t, component.Setup(core.SetupContext{Configuration: c}))
+	})
+
+	t.Run("valid config", func(t *testing.T) {
+		require.NoError(t, component.Setup(core.SetupContext{Configuration: config()}))
+	})
+}
+
+func Test__CreateSnapshotSchedule__Execute(t *testing.T) {
+	component := &CreateSnapshotSchedule{}
+	policyJSON := func(name string) []byte {
+		return []byte(`{
+			"name": "` + name + `",
+			"selfLink": "https://www.googleapis.com/compute/v1/projects/my-project/regions/us-central1/resourcePolicies/` + name + `",
+			"status": "READY"
+		}`)
+	}
+
+	run := func(t *testing.T, configuration map[string]any, policyName string) (*contexts.ExecutionStateContext, string, *compute.ResourcePolicy, []string) {
+		var postPath string
+		var postBody *compute.ResourcePolicy
+		var getPaths []string
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				postPath = path
+				postBody, _ = body.(*compute.ResourcePolicy)
+				return opDone("op-policy"), nil
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				getPaths = append(getPaths, path)
+				if isOperationPath(path) {
+					return opDone("op-policy"), nil
+				}
+				return policyJSON(policyName), nil
+			},
+		}
+
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{Configuration: configuration, ExecutionState: state})
+		require.NoError(t, err)
+		return state, postPath, postBody, getPaths
+	}
+
+	t.Run("daily schedule -> waits for regional operation -> emits created event", func(t *testing.T) {
+		state, postPath, postBody, getPaths := run(t, map[string]any{
+			"region":        "us-central1",
+			"name":          "daily-14d",
+			"frequency":     "daily",
+			"startTime":     "02:00",
+			"retentionDays": 14,
+			"description":   "Daily backups",
+		}, "daily-14d")
+
+		assert.True(t, state.Passed)
+		assert.Equal(t, "gcp.compute.snapshotSchedule.created", state.Type)
+		assert.Equal(t, "projects/my-project/regions/us-central1/resourcePolicies", postPath)
+
+		require.NotNil(t, postBody)
+		assert.Equal(t, "daily-14d", postBody.Name)
+		assert.Equal(t, "Daily backups", postBody.Description)
+		schedule := postBody.SnapshotSchedulePolicy.Schedule
+		require.NotNil(t, schedule.DailySchedule)
+		assert.Equal(t, int64(1), schedule.DailySchedule.DaysInCycle)
+		assert.Equal(t, "02:00", schedule.DailySchedule.StartTime)
+		assert.Nil(t, schedule.WeeklySchedule)
+		assert.Nil(t, schedule.HourlySchedule)
+		assert.Equal(t, int64(14), postBody.SnapshotSchedulePolicy.RetentionPolicy.MaxRetentionDays)
+
+		require.Len(t, getPaths, 2)
+		assert.Equal(t, "projects/my-project/regions/us-central1/operations/op-policy", getPaths[0])
+		assert.Equal(t, "projects/my-project/regions/us-central1/resourcePolicies/daily-14d", getPaths[1])
+
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "https://www.googleapis.com/compute/v1/projects/my-project/regions/us-central1/resourcePolicies/daily-14d", data["selfLink"])
+		assert.Equal(t, "daily", data["frequency"])
+		assert.Equal(t, "READY", data["status"])
+		assert.Equal(t, false, data["alreadyExisted"])
+	})
+
+	t.Run("weekly schedule -> sends weekly cycle on the selected day", func(t *testing.T) {
+		state, postPath, postBody, _ := run(t, map[string]any{
+			"project":       "other-project",
+			"region":        "https://www.googleapis.com/compute/v1/projects/other-project/regions/us-central1",
+			"name":          "weekly-8w",
+			"frequency":     "weekly",
+			"dayOfWeek":     "sunday",
+			"retentionDays": 56,
+		}, "weekly-8w")
+
+		assert.True(t, state.Passed)
+		assert.Equal(t, "projects/other-project/regions/us-central1/resourcePolicies", postPath)
+
+		require.NotNil(t, postBody)
+		schedule := postBody.SnapshotSchedulePolicy.Schedule
+		require.NotNil(t, schedule.WeeklySchedule)
+		require.Len(t, schedule.WeeklySchedule.DayOfWeeks, 1)
+		assert.Equal(t, "SUNDAY", schedule.WeeklySchedule.DayOfWeeks[0].Day)
+		assert.Equal(t, defaultSnapshotScheduleStartTime, schedule.WeeklySchedule.DayOfWeeks[0].StartTime)
+		assert.Nil(t, schedule.DailySchedule)
+		assert.Equal(t, int64(56), postBody.SnapshotSchedulePolicy.RetentionPolicy.MaxRetentionDays)
+
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "weekly", data["frequency"])
+		assert.Equal(t, "us-central1", data["region"])
+	})
+
+	t.Run("already exists (409) -> success with existing schedule", func(t *testing.T) {
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				return nil, &gcpcommon.GCPAPIError{StatusCode: http.StatusConflict, Message: "already exists"}
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				require.False(t, isOperationPath(path), "no operation to wait on for an existing schedule")
+				return policyJSON("daily-14d"), nil
+			},
+		}
+
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":        "us-central1",
+				"name":          "daily-14d",
+				"retentionDays": 14,
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, true, data["alreadyExisted"])
+	})
+
+	t.Run("invalid schedule -> fails without calling the API", func(t *testing.T) {
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				t.Fatal("unexpected POST")
+				return nil, nil
+			},
+		}
+
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":        "us-central1",
+				"name":          "weekly-8w",
+				"frequency":     "weekly",
+				"dayOfWeek":     "someday",
+				"retentionDays": 56,
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "invalid snapshot schedule day of week")
+	})
+}
@@ -0,0 +1,339 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+)
+
+type CreateSubnetwork struct{}
+
+type CreateSubnetworkSpec struct {
+	Name                  string `mapstructure:"name"`
+	Network               string `mapstructure:"network"`
+	Region                string `mapstructure:"region"`
+	IPCidrRange           string `mapstructure:"ipCidrRange"`
+	PrivateIPGoogleAccess bool   `mapstructure:"privateIpGoogleAccess"`
+	Description           string `mapstructure:"description"`
+}
+
+// Compute Engine accepts primary IPv4 subnet ranges from /8 to /29.
+const (
+	minSubnetPrefixLength = 8
+	maxSubnetPrefixLength = 29
+)
+
+// subnetworkGetResp is the subset of a Compute Engine subnetwork resource we
+// read back after creating it.
+type subnetworkGetResp struct {
+	Name                  string `json:"name"`
+	SelfLink              string `json:"selfLink"`
+	Network               string `json:"network"`
+	Region                string `json:"region"`
+	IPCidrRange           string `json:"ipCidrRange"`
+	GatewayAddress        string `json:"gatewayAddress"`
+	PrivateIPGoogleAccess bool   `json:"privateIpGoogleAccess"`
+}
+
+func (c *CreateSubnetwork) Name() string {
+	return "gcp.compute.createSubnetwork"
+}
+
+func (c *CreateSubnetwork) Label() string {
+	return "Compute • Create Subnetwork"
+}
+
+func (c *CreateSubnetwork) Description() string {
+	return "Create a regional subnetwork in a Google Cloud VPC network"
+}
+
+func (c *CreateSubnetwork) Documentation() string {
+	return `The Create Subnetwork component creates a regional subnet in a custom-mode VPC network.
+
+## Use Cases
+
+- **Environment bootstrap**: Add subnets to a network created with **Create Network** before creating VMs
+- **Regional expansion**: Add a subnet in a new region to an existing network
+
+## Configuration
+
+- **Name**: The name for the new subnetwork (required, lowercase RFC1035 — e.g. ` + "`staging-us-central1`" + `)
+- **Network**: The VPC network to create the subnet in (required). Accepts a network name or the ` + "`selfLink`" + ` emitted by **Create Network**
+- **Region**: The region for the subnet (required)
+- **IP Range**: The primary IPv4 range in CIDR notation, e.g. ` + "`10.10.0.0/20`" + ` (required). Must be a network address between /8 and /29
+- **Private Google Access**: Let VMs without external IPs reach Google APIs
+- **Description**: Optional human-readable description
+
+## Output
+
+Returns the subnetwork:
+- **name**, **selfLink**, **network**, **region**, **ipCidrRange**, **gatewayAddress**, **privateIpGoogleAccess**
+- **alreadyExisted**: true when a subnetwork with this name already existed in the region and was reused
+
+## Important Notes
+
+- If a subnetwork with the same name already exists in the region, the component succeeds and returns the existing subnetwork without changing it
+- The IP range must not overlap other subnets in the network; Compute Engine rejects overlapping ranges
+- The component waits for the underlying regional operation to complete before reading the subnetwork back`
+}
+
+func (c *CreateSubnetwork) Icon() string {
+	return "network"
+}
+
+func (c *CreateSubnetwork) Color() string {
+	return "blue"
+}
+
+func (c *CreateSubnetwork) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateSubnetwork) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "name",
+			Label:       "Name",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Name for the subnetwork (lowercase letters, numbers and hyphens).",
+			Placeholder: "e.g. staging-us-central1",
+		},
+		{
+			Name:        "network",
+			Label:       "Network",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The VPC network to create the subnetwork in.",
+			Placeholder: "Select network",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeNetwork,
+				},
+			},
+		},
+		{
+			Name:        "region",
+			Label:       "Region",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The region to create the subnetwork in (e.g. us-central1).",
+			Placeholder: "Select region",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeRegion,
+				},
+			},
+		},
+		{
+			Name:        "ipCidrRange",
+			Label:       "IP Range",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Primary IPv4 range in CIDR notation, between /8 and /29.",
+			Placeholder: "e.g. 10.10.0.0/20",
+		},
+		{
+			Name:        "privateIpGoogleAccess",
+			Label:       "Private Google Access",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Allow VMs without external IP addresses to reach Google APIs and services.",
+		},
+		{
+			Name:        "description",
+			Label:       "Description",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Optional description for the subnetwork.",
+			Placeholder: "e.g. Staging workloads in us-central1",
+		},
+	}
+}
+
+func (c *CreateSubnetwork) Setup(ctx core.SetupContext) error {
+	spec := CreateSubnetworkSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	return validateCreateSubnetworkSpec(spec)
+}
+
+// validateCreateSubnetworkSpec checks the spec. Expression values are only
+// checked for presence; they are validated again once resolved in Execute.
+func validateCreateSubnetworkSpec(spec CreateSubnetworkSpec) error {
+	name := strings.TrimSpace(spec.Name)
+	if name == "" {
+		return errors.New("name is required")
+	}
+	if !strings.Contains(name, "{{") && !gcpInstanceNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid subnetwork name %q: use 1-63 lowercase letters, digits and hyphens, starting with a letter", name)
+	}
+
+	if strings.TrimSpace(spec.Network) == "" {
+		return errors.New("network is required")
+	}
+
+	if strings.TrimSpace(spec.Region) == "" {
+		return errors.New("region is required")
+	}
+
+	cidr := strings.TrimSpace(spec.IPCidrRange)
+	if cidr == "" {
+		return errors.New("ipCidrRange is required")
+	}
+	if strings.Contains(cidr, "{{") {
+		return nil
+	}
+	return validateSubnetCIDR(cidr)
+}
+
+// validateSubnetCIDR rejects ranges Compute Engine would refuse for a primary
+// subnet range: non-IPv4, host bits set, prefixes outside /8-/29, and
+// reserved address blocks.
+func validateSubnetCIDR(cidr string) error {
+	ip, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return fmt.Errorf("invalid ipCidrRange %q: must be in CIDR notation, e.g. 10.10.0.0/20", cidr)
+	}
+
+	if ip.To4() == nil {
+		return fmt.Errorf("invalid ipCidrRange %q: must be an IPv4 range", cidr)
+	}
+
+	if !ip.Equal(ipNet.IP) {
+		return fmt.Errorf("invalid ipCidrRange %q: host bits must be zero, did you mean %s?", cidr, ipNet.String())
+	}
+
+	prefixLength, _ := ipNet.Mask.Size()
+	if prefixLength < minSubnetPrefixLength || prefixLength > maxSubnetPrefixLength {
+		return fmt.Errorf("invalid ipCidrRange %q: prefix length must be between /%d and /%d", cidr, minSubnetPrefixLength, maxSubnetPrefixLength)
+	}
+
+	if ip.IsUnspecified() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+		return fmt.Errorf("invalid ipCidrRange %q: range is reserved", cidr)
+	}
+
+	return nil
+}
+
+func (c *CreateSubnetwork) Execute(ctx core.ExecutionContext) error {
+	spec := CreateSubnetworkSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to decode configuration: %v", err))
+	}
+
+	if err := validateCreateSubnetworkSpec(spec); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	project := client.ProjectID()
+	name := strings.TrimSpace(spec.Name)
+	region := lastSegment(strings.TrimSpace(spec.Region))
+	callCtx := context.Background()
+
+	alreadyExisted, err := createSubnetwork(callCtx, client, project, region, BuildSubnetwork(project, spec))
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create subnetwork: %v", err))
+	}
+
+	body, err := client.Get(callCtx, fmt.Sprintf("projects/%s/regions/%s/subnetworks/%s", project, region, name))
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to read subnetwork: %v", err))
+	}
+
+	var subnetwork subnetworkGetResp
+	if err := json.Unmarshal(body, &subnetwork); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("parse subnetwork response: %v", err))
+	}
+
+	payload := map[string]any{
+		"name":                  subnetwork.Name,
+		"selfLink":              subnetwork.SelfLink,
+		"network":               lastSegment(subnetwork.Network),
+		"region":                region,
+		"ipCidrRange":           subnetwork.IPCidrRange,
+		"gatewayAddress":        subnetwork.GatewayAddress,
+		"privateIpGoogleAccess": subnetwork.PrivateIPGoogleAccess,
+		"alreadyExisted":        alreadyExisted,
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"gcp.compute.subnetwork.created",
+		[]any{payload},
+	)
+}
+
+// BuildSubnetwork builds the subnetworks.insert request body from the spec.
+func BuildSubnetwork(project string, spec CreateSubnetworkSpec) map[string]any {
+	body := map[string]any{
+		"name":                  strings.TrimSpace(spec.Name),
+		"network":               resolveNetworkURL(project, strings.TrimSpace(spec.Network)),
+		"ipCidrRange":           strings.TrimSpace(spec.IPCidrRange),
+		"privateIpGoogleAccess": spec.PrivateIPGoogleAccess,
+	}
+	if description := strings.TrimSpace(spec.Description); description != "" {
+		body["description"] = description
+	}
+	return body
+}
+
+// createSubnetwork inserts the subnetwork and waits for the regional operation.
+// An existing subnetwork with the same name (409) is reported as alreadyExisted.
+func createSubnetwork(ctx context.Context, client Client, project, region string, subnetwork map[string]any) (alreadyExisted bool, err error) {
+	body, err := client.Post(ctx, fmt.Sprintf("projects/%s/regions/%s/subnetworks", project, region), subnetwork)
+	if err != nil {
+		if gcpcommon.IsAlreadyExistsError(err) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	opName, err := operationNameFromResponse(body, "create subnetwork")
+	if err != nil {
+		return false, err
+	}
+	return false, WaitForRegionOperation(ctx, client, project, region, opName)
+}
+
+func (c *CreateSubnetwork) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateSubnetwork) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CreateSubnetwork) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CreateSubnetwork) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *CreateSubnetwork) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *CreateSubnetwork) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
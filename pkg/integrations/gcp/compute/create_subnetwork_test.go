@@ -0,0 +1,228 @@
+package compute
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__validateSubnetCIDR(t *testing.T) {
+	valid := []string{"10.10.0.0/20", "192.168.1.0/24", "10.0.0.0/8", "172.16.0.8/29"}
+	for _, cidr := range valid {
+		assert.NoError(t, validateSubnetCIDR(cidr), cidr)
+	}
+
+	invalid := map[string]string{
+		"10.10.0.0":      "must be in CIDR notation",
+		"10.10.0.1/20":   "host bits must be zero, did you mean 10.10.0.0/20?",
+		"fd00::/64":      "must be an IPv4 range",
+		"10.0.0.0/7":     "prefix length must be between /8 and /29",
+		"10.0.0.0/30":    "prefix length must be between /8 and /29",
+		"127.0.0.0/8":    "range is reserved",
+		"169.254.0.0/16": "range is reserved",
+		"224.0.0.0/8":    "range is reserved",
+	}
+	for cidr, message := range invalid {
+		assert.ErrorContains(t, validateSubnetCIDR(cidr), message, cidr)
+	}
+}
+
+func Test__CreateSubnetwork__Setup(t *testing.T) {
+	component := &CreateSubnetwork{}
+	config := func(cidr string) map[string]any {
+		return map[string]any{
+			"name":        "staging-us-central1",
+			"network":     "staging-vpc",
+			"region":      "us-central1",
+			"ipCidrRange": cidr,
+		}
+	}
+
+	t.Run("missing network returns error", func(t *testing.T) {
+		c := config("10.10.0.0/20")
+		delete(c, "network")
+		err := component.Setup(core.SetupContext{Configuration: c})
+		require.ErrorContains(t, err, "network is required")
+	})
+
+	t.Run("missing ipCidrRange returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: config("")})
+		require.ErrorContains(t, err, "ipCidrRange is required")
+	})
+
+	t.Run("invalid ipCidrRange returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: config("10.10.0.1/20")})
+		require.ErrorContains(t, err, "host bits must be zero")
+	})
+
+	t.Run("expression ipCidrRange is accepted", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: config("{{ $.cidr }}")})
+		require.NoError(t, err)
+	})
+}
+
+func Test__CreateSubnetwork__Execute(t *testing.T) {
+	component := &CreateSubnetwork{}
+	networkSelfLink := "https://www.googleapis.com/compute/v1/projects/my-project/global/networks/staging-vpc"
+	subnetworkJSON := []byte(`{
+		"name": "staging-us-central1",
+		"selfLink": "https://www.googleapis.com/compute/v1/projects/my-project/regions/us-central1/subnetworks/staging-us-central1",
+		"network": "https://www.googleapis.com/compute/v1/projects/my-project/global/networks/staging-vpc",
+		"region": "https://www.googleapis.com/compute/v1/projects/my-project/regions/us-central1",
+		"ipCidrRange": "10.10.0.0/20",
+		"gatewayAddress": "10.10.0.1",
+		"privateIpGoogleAccess": true
+	}`)
+
+	t.Run("creates subnetwork -> waits for regional operation -> emits created event", func(t *testing.T) {
+		var postPath string
+		var postBody map[string]any
+		var getPaths []string
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				postPath = path
+				postBody, _ = body.(map[string]any)
+				return opDone("op-subnet"), nil
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				getPaths = append(getPaths, path)
+				if isOperationPath(path) {
+					return opDone("op-subnet"), nil
+				}
+				return subnetworkJSON, nil
+			},
+		}
+
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"name":                  "staging-us-central1",
+				"network":               networkSelfLink,
+				"region":                "us-central1",
+				"ipCidrRange":           "10.10.0.0/20",
+				"privateIpGoogleAccess": true,
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+		assert.Equal(t, "gcp.compute.subnetwork.created", state.Type)
+
+		assert.Equal(t, "projects/my-project/regions/us-central1/subnetworks", postPath)
+		assert.Equal(t, networkSelfLink, postBody["network"])
+		assert.Equal(t, "10.10.0.0/20", postBody["ipCidrRange"])
+		assert.Equal(t, true, postBody["privateIpGoogleAccess"])
+
+		require.Len(t, getPaths, 2)
+		assert.Equal(t, "projects/my-project/regions/us-central1/operations/op-subnet", getPaths[0])
+		assert.Equal(t, "projects/my-project/regions/us-central1/subnetworks/staging-us-central1", getPaths[1])
+
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "https://www.googleapis.com/compute/v1/projects/my-project/regions/us-central1/subnetworks/staging-us-central1", data["selfLink"])
+		assert.Equal(t, "staging-vpc", data["network"])
+		assert.Equal(t, "10.10.0.1", data["gatewayAddress"])
+		assert.Equal(t, false, data["alreadyExisted"])
+	})
+
+	t.Run("network name is expanded to a project path", func(t *testing.T) {
+		var postBody map[string]any
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				postBody, _ = body.(map[string]any)
+				return opDone("op-subnet"), nil
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				if isOperationPath(path) {
+					return opDone("op-subnet"), nil
+				}
+				return subnetworkJSON, nil
+			},
+		}
+
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"name":        "staging-us-central1",
+				"network":     "staging-vpc",
+				"region":      "us-central1",
+				"ipCidrRange": "10.10.0.0/20",
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+		assert.Equal(t, "projects/my-project/global/networks/staging-vpc", postBody["network"])
+	})
+
+	t.Run("already exists (409) -> success with existing subnetwork", func(t *testing.T) {
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				return nil, &gcpcommon.GCPAPIError{StatusCode: http.StatusConflict, Message: "already exists"}
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				require.False(t, isOperationPath(path), "no operation to wait on for an existing subnetwork")
+				return subnetworkJSON, nil
+			},
+		}
+
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"name":        "staging-us-central1",
+				"network":     networkSelfLink,
+				"region":      "us-central1",
+				"ipCidrRange": "10.10.0.0/20",
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, true, data["alreadyExisted"])
+	})
+
+	t.Run("resolved expression with invalid CIDR -> fails before calling the API", func(t *testing.T) {
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				t.Fatal("subnetwork must not be created with an invalid range")
+				return nil, nil
+			},
+		}
+
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"name":        "staging-us-central1",
+				"network":     networkSelfLink,
+				"region":      "us-central1",
+				"ipCidrRange": "10.10.0.0/30",
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "prefix length must be between /8 and /29")
+	})
+}
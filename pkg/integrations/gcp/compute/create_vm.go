@@ -3,8 +3,10 @@ package compute
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
 	"sync"
@@ -14,6 +16,7 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
 	compute "google.golang.org/api/compute/v1"
 )
 
@@ -83,6 +86,15 @@ const (
 	BootDiskSourceExistingDisk = "existingDisk"
 )
 
+const (
+	CreateVMSourceConfiguration    = "configuration"
+	CreateVMSourceInstanceTemplate = "instanceTemplate"
+)
+
+var visibleWhenCreateVMFromConfiguration = []configuration.VisibilityCondition{
+	{Field: "source", Values: []string{CreateVMSourceConfiguration}},
+}
+
 const (
 	AdditionalDiskModeNew      = "newDisk"
 	AdditionalDiskModeExisting = "existingDisk"
@@ -102,28 +114,31 @@ const (
 )
 
 type OSAndStorageConfig struct {
-	BootDiskSourceType       string                `mapstructure:"bootDiskSourceType"`
-	BootDiskOS               string                `mapstructure:"bootDiskOS"`
-	BootDiskPublicImage      string                `mapstructure:"bootDiskPublicImage"`
-	BootDiskCustomImage      string                `mapstructure:"bootDiskCustomImage"`
-	BootDiskSnapshot         string                `mapstructure:"bootDiskSnapshot"`
-	BootDiskExistingDisk     string                `mapstructure:"bootDiskExistingDisk"`
-	BootDiskType             string                `mapstructure:"bootDiskType"`
-	BootDiskSizeGb           int64                 `mapstructure:"bootDiskSizeGb"`
-	BootDiskEncryptionKey    string                `mapstructure:"bootDiskEncryptionKey"`
-	BootDiskSnapshotSchedule string                `mapstructure:"bootDiskSnapshotSchedule"`
-	BootDiskAutoDelete       bool                  `mapstructure:"bootDiskAutoDelete"`
-	LocalSSDCount            int64                 `mapstructure:"localSSDCount"`
-	AdditionalDisks          []AdditionalDiskEntry `mapstructure:"additionalDisks"`
+	BootDiskSourceType          string                 `mapstructure:"bootDiskSourceType"`
+	BootDiskOS                  string                 `mapstructure:"bootDiskOS"`
+	BootDiskPublicImage         string                 `mapstructure:"bootDiskPublicImage"`
+	BootDiskCustomImage         string                 `mapstructure:"bootDiskCustomImage"`
+	BootDiskSnapshot            string                 `mapstructure:"bootDiskSnapshot"`
+	BootDiskExistingDisk        string                 `mapstructure:"bootDiskExistingDisk"`
+	BootDiskType                string                 `mapstructure:"bootDiskType"`
+	BootDiskSizeGb              int64                  `mapstructure:"bootDiskSizeGb"`
+	BootDiskEncryptionKey       string                 `mapstructure:"bootDiskEncryptionKey"`
+	BootDiskSnapshotSchedule    string                 `mapstructure:"bootDiskSnapshotSchedule"`
+	BootDiskNewSnapshotSchedule *SnapshotScheduleEntry `mapstructure:"bootDiskNewSnapshotSchedule"`
+	BootDiskAutoDelete          bool                   `mapstructure:"bootDiskAutoDelete"`
+	BootDiskReplicaZones        []string               `mapstructure:"bootDiskReplicaZones"`
+	LocalSSDCount               int64                  `mapstructure:"localSSDCount"`
+	AdditionalDisks             []AdditionalDiskEntry  `mapstructure:"additionalDisks"`
 }
 
 type AdditionalDiskEntry struct {
-	Mode         string `mapstructure:"mode"`
-	Name         string `mapstructure:"name"`
-	SizeGb       int64  `mapstructure:"sizeGb"`
-	DiskType     string `mapstructure:"diskType"`
-	ExistingDisk string `mapstructure:"existingDisk"`
-	AutoDelete   bool   `mapstructure:"autoDelete"`
+	Mode         string   `mapstructure:"mode"`
+	Name         string   `mapstructure:"name"`
+	SizeGb       int64    `mapstructure:"sizeGb"`
+	DiskType     string   `mapstructure:"diskType"`
+	ExistingDisk string   `mapstructure:"existingDisk"`
+	AutoDelete   bool     `mapstructure:"autoDelete"`
+	ReplicaZones []string `mapstructure:"replicaZones"`
 }
 
 type BootDiskConfig struct {
@@ -136,14 +151,16 @@ type BootDiskConfig struct {
 	SnapshotSchedule  string
 	AutoDelete        bool
 	DiskEncryptionKey string
+	ReplicaZones      []string
 }
 
 type AdditionalDisk struct {
-	Name       string
-	SizeGb     int64
-	DiskType   string
-	SourceDisk string
-	AutoDelete bool
+	Name         string
+	SizeGb       int64
+	DiskType     string
+	SourceDisk   string
+	AutoDelete   bool
+	ReplicaZones []string
 }
 
 func BuildBootDisk(project, zone string, config BootDiskConfig) *compute.AttachedDisk {
@@ -172,9 +189,10 @@ func BuildBootDisk(project, zone string, config BootDiskConfig) *compute.Attache
 	}
 
 	params := &compute.AttachedDiskInitializeParams{
-		DiskName:   strings.TrimSpace(config.Name),
-		DiskSizeGb: sizeGb,
-		DiskType:   resolveDiskTypeURL(project, zone, diskType),
+		DiskName:     strings.TrimSpace(config.Name),
+		DiskSizeGb:   sizeGb,
+		DiskType:     resolveDiskTypeURLForDisk(project, zone, diskType, config.ReplicaZones),
+		ReplicaZones: resolveReplicaZoneURLs(project, config.ReplicaZones),
 	}
 	if config.SourceImage != "" {
 		params.SourceImage = strings.TrimSpace(config.SourceImage)
@@ -236,8 +254,9 @@ func buildOneAdditionalDisk(project, zone string, d AdditionalDisk) *compute.Att
 	}
 	isLocalSSD := diskType == "local-ssd"
 	params := &compute.AttachedDiskInitializeParams{
-		DiskName: name,
-		DiskType: resolveDiskTypeURL(project, zone, diskType),
+		DiskName:     name,
+		DiskType:     resolveDiskTypeURLForDisk(project, zone, diskType, d.ReplicaZones),
+		ReplicaZones: resolveReplicaZoneURLs(project, d.ReplicaZones),
 	}
 	if !isLocalSSD {
 		sizeGb := d.SizeGb
@@ -259,6 +278,42 @@ func buildOneAdditionalDisk(project, zone string, d AdditionalDisk) *compute.Att
 	return att
 }
 
+// ValidateAdditionalDiskTypes checks that every new additional disk uses a disk type
+// offered in the zone, so an unsupported type fails before the instance is inserted.
+// Disk types come from ListDiskTypes, which is cached per zone.
+func ValidateAdditionalDiskTypes(ctx context.Context, c Client, project, zone string, disks []AdditionalDisk) error {
+	var available map[string]bool
+	for i, d := range disks {
+		if strings.TrimSpace(d.SourceDisk) != "" {
+			continue
+		}
+		diskType := lastSegment(strings.TrimSpace(d.DiskType))
+		if diskType == "" {
+			diskType = DefaultDiskType
+		}
+
+		if available == nil {
+			types, err := ListDiskTypes(ctx, c, project, zone)
+			if err != nil {
+				return fmt.Errorf("list disk types in zone %s: %w", zone, err)
+			}
+			available = make(map[string]bool, len(types))
+			for _, t := range types {
+				available[t.Name] = true
+			}
+		}
+
+		if !available[diskType] {
+			disk := fmt.Sprintf("#%d", i+1)
+			if name := strings.TrimSpace(d.Name); name != "" {
+				disk = fmt.Sprintf("%s (%s)", disk, name)
+			}
+			return fmt.Errorf("additional disk %s: disk type %q is not available in zone %s", disk, diskType, zone)
+		}
+	}
+	return nil
+}
+
 func BuildLocalSSDDisks(project, zone string, count int) []*compute.AttachedDisk {
 	if count <= 0 {
 		return nil
@@ -331,6 +386,18 @@ func BuildShieldedInstanceConfig(config SecurityConfig) *compute.ShieldedInstanc
 	}
 }
 
+// validateShieldedVM rejects integrity monitoring without vTPM, which
+// Compute Engine needs to take the boot measurements it monitors.
+func validateShieldedVM(config SecurityConfig) error {
+	if !config.ShieldedVM {
+		return nil
+	}
+	if config.ShieldedVMEnableIntegrityMonitoring && !config.ShieldedVMEnableVtpm {
+		return fmt.Errorf("integrity monitoring requires vTPM to be enabled")
+	}
+	return nil
+}
+
 func BuildConfidentialInstanceConfig(config SecurityConfig) *compute.ConfidentialInstanceConfig {
 	if !config.ConfidentialVM {
 		return nil
@@ -349,6 +416,7 @@ type IdentityConfig struct {
 	ServiceAccount      string   `mapstructure:"serviceAccount"`
 	OAuthScopes         []string `mapstructure:"oauthScopes"`
 	BlockProjectSSHKeys bool     `mapstructure:"blockProjectSSHKeys"`
+	SSHKeys             []string `mapstructure:"sshKeys"`
 	EnableOSLogin       bool     `mapstructure:"enableOSLogin"`
 }
 
@@ -499,13 +567,36 @@ func parseAllowed(s string) ([]*compute.FirewallAllowed, error) {
 
 // CreateFirewallRule creates a single firewall rule in the project. If the rule already exists (409), it is treated as success.
 func CreateFirewallRule(ctx context.Context, c Client, project, network string, rule CreateFirewallRuleEntry) error {
+	project = ensureProject(project, c)
+	fw, err := buildFirewallFromEntry(project, network, rule)
+	if err != nil {
+		return err
+	}
+	return insertFirewallRule(ctx, c, project, fw)
+}
+
+func insertFirewallRule(ctx context.Context, c Client, project string, fw *compute.Firewall) error {
+	path := fmt.Sprintf("projects/%s/global/firewalls", project)
+	_, err := c.Post(ctx, path, fw)
+	if err != nil {
+		errStr := err.Error()
+		if strings.Contains(errStr, "already exists") || strings.Contains(errStr, "409") {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// buildFirewallFromEntry builds the ingress firewall rule a Create VM entry describes.
+func buildFirewallFromEntry(project, network string, rule CreateFirewallRuleEntry) (*compute.Firewall, error) {
 	name := strings.TrimSpace(rule.Name)
 	if name == "" {
-		return fmt.Errorf("firewall rule name is required")
+		return nil, fmt.Errorf("firewall rule name is required")
 	}
 	allowed, err := parseAllowed(rule.Allowed)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	sourceRanges := strings.Split(rule.SourceRanges, ",")
 	for i := range sourceRanges {
@@ -521,50 +612,138 @@ func CreateFirewallRule(ctx context.Context, c Client, project, network string,
 		}
 	}
 	if len(trimmed) == 0 {
-		return fmt.Errorf("sourceRanges is required")
+		return nil, fmt.Errorf("sourceRanges is required")
 	}
-	targetTag := strings.TrimSpace(rule.TargetTag)
-	if targetTag == "" {
-		return fmt.Errorf("targetTag is required")
+	if err := validateFirewallRuleTarget(rule); err != nil {
+		return nil, err
 	}
-	project = ensureProject(project, c)
 	networkURL := resolveNetworkURL(project, network)
 	if networkURL == "" {
 		networkURL = fmt.Sprintf("projects/%s/global/networks/default", project)
 	}
-	fw := &compute.Firewall{
+	firewall := &compute.Firewall{
 		Name:         name,
 		Network:      networkURL,
 		Direction:    "INGRESS",
 		Allowed:      allowed,
 		SourceRanges: trimmed,
-		TargetTags:   []string{targetTag},
 	}
-	path := fmt.Sprintf("projects/%s/global/firewalls", project)
-	_, err = c.Post(ctx, path, fw)
-	if err != nil {
-		errStr := err.Error()
-		if strings.Contains(errStr, "already exists") || strings.Contains(errStr, "409") {
-			return nil
+	if serviceAccount := strings.TrimSpace(rule.TargetServiceAccount); serviceAccount != "" {
+		firewall.TargetServiceAccounts = []string{serviceAccount}
+	} else {
+		firewall.TargetTags = []string{strings.TrimSpace(rule.TargetTag)}
+	}
+	return firewall, nil
+}
+
+// validateFirewallRuleTarget checks that a rule targets the VM by exactly one
+// mechanism: a network tag or a service account.
+func validateFirewallRuleTarget(rule CreateFirewallRuleEntry) error {
+	hasTag := strings.TrimSpace(rule.TargetTag) != ""
+	hasServiceAccount := strings.TrimSpace(rule.TargetServiceAccount) != ""
+	switch {
+	case hasTag && hasServiceAccount:
+		return fmt.Errorf("firewall rule %q: set either targetTag or targetServiceAccount, not both", strings.TrimSpace(rule.Name))
+	case !hasTag && !hasServiceAccount:
+		return fmt.Errorf("firewall rule %q: targetTag or targetServiceAccount is required", strings.TrimSpace(rule.Name))
+	}
+	return nil
+}
+
+// validateFirewallRuleTargets checks the targeting of every rule Create VM will
+// create. A rule targeting a service account only applies if the VM runs as that
+// account, so it must match the instance service account (or, when none is set,
+// be a Compute Engine default service account).
+func validateFirewallRuleTargets(config CreateVMConfig) error {
+	instanceServiceAccount := strings.TrimSpace(config.ServiceAccount)
+	for _, rule := range config.CreateFirewallRules {
+		if strings.TrimSpace(rule.Name) == "" {
+			continue
+		}
+		if err := validateFirewallRuleTarget(rule); err != nil {
+			return err
+		}
+
+		target := strings.TrimSpace(rule.TargetServiceAccount)
+		if target == "" || config.Source == CreateVMSourceInstanceTemplate {
+			continue
+		}
+		if instanceServiceAccount == "" && !strings.HasSuffix(target, defaultComputeServiceAccountSuffix) {
+			return fmt.Errorf("firewall rule %q targets service account %q, but the instance uses the default service account; set the instance service account to %q", strings.TrimSpace(rule.Name), target, target)
+		}
+		if instanceServiceAccount != "" && target != instanceServiceAccount {
+			return fmt.Errorf("firewall rule %q targets service account %q, but the instance runs as %q", strings.TrimSpace(rule.Name), target, instanceServiceAccount)
 		}
-		return err
 	}
 	return nil
 }
 
+// defaultComputeServiceAccountSuffix ends the email of the Compute Engine
+// default service account (PROJECT_NUMBER-compute@developer.gserviceaccount.com).
+const defaultComputeServiceAccountSuffix = "-compute@developer.gserviceaccount.com"
+
+// DefaultFirewallRuleConcurrency is how many firewall rules EnsureFirewallRules
+// creates at the same time.
+const DefaultFirewallRuleConcurrency = 4
+
 // EnsureFirewallRules creates each rule and returns the list of target tags to apply to the instance.
-func EnsureFirewallRules(ctx context.Context, c Client, project, network string, rules []CreateFirewallRuleEntry) ([]string, error) {
+// Rules that already exist are left as they are; when their settings differ from the
+// entry, the differences are returned as conflicts so the divergence is not silent.
+func EnsureFirewallRules(ctx context.Context, c Client, project, network string, rules []CreateFirewallRuleEntry) ([]string, []FirewallRuleConflict, error) {
+	return EnsureFirewallRulesWithConcurrency(ctx, c, project, network, rules, DefaultFirewallRuleConcurrency)
+}
+
+// EnsureFirewallRulesWithConcurrency is EnsureFirewallRules with at most
+// concurrency rules created at the same time. Tags and conflicts keep the order
+// of the rules, and the errors of every failed rule are returned together.
+func EnsureFirewallRulesWithConcurrency(ctx context.Context, c Client, project, network string, rules []CreateFirewallRuleEntry, concurrency int) ([]string, []FirewallRuleConflict, error) {
 	if len(rules) == 0 {
-		return nil, nil
+		return nil, nil, nil
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	project = ensureProject(project, c)
+
+	type ruleResult struct {
+		conflict *FirewallRuleConflict
+		err      error
+	}
+	results := make([]ruleResult, len(rules))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, r := range rules {
+		if strings.TrimSpace(r.Name) == "" {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, r CreateFirewallRuleEntry) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			conflict, err := ensureFirewallRule(ctx, c, project, network, r)
+			if err != nil {
+				err = fmt.Errorf("create firewall rule %q: %w", r.Name, err)
+			}
+			results[i] = ruleResult{conflict: conflict, err: err}
+		}(i, r)
 	}
+	wg.Wait()
+
 	seen := make(map[string]struct{})
 	var tags []string
-	for _, r := range rules {
+	var conflicts []FirewallRuleConflict
+	var errs []error
+	for i, r := range rules {
 		if strings.TrimSpace(r.Name) == "" {
 			continue
 		}
-		if err := CreateFirewallRule(ctx, c, project, network, r); err != nil {
-			return nil, fmt.Errorf("create firewall rule %q: %w", r.Name, err)
+		if results[i].err != nil {
+			errs = append(errs, results[i].err)
+			continue
+		}
+		if results[i].conflict != nil {
+			conflicts = append(conflicts, *results[i].conflict)
 		}
 		tag := strings.TrimSpace(r.TargetTag)
 		if tag != "" {
@@ -574,7 +753,10 @@ func EnsureFirewallRules(ctx context.Context, c Client, project, network string,
 			}
 		}
 	}
-	return tags, nil
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+	return tags, conflicts, nil
 }
 
 type NetworkingConfig struct {
@@ -591,10 +773,11 @@ type NetworkingConfig struct {
 }
 
 type CreateFirewallRuleEntry struct {
-	Name         string `mapstructure:"name"`
-	Allowed      string `mapstructure:"allowed"`
-	SourceRanges string `mapstructure:"sourceRanges"`
-	TargetTag    string `mapstructure:"targetTag"`
+	Name                 string `mapstructure:"name"`
+	Allowed              string `mapstructure:"allowed"`
+	SourceRanges         string `mapstructure:"sourceRanges"`
+	TargetTag            string `mapstructure:"targetTag"`
+	TargetServiceAccount string `mapstructure:"targetServiceAccount"`
 }
 
 func ParseNetworkTags(s string) []string {
@@ -742,6 +925,14 @@ const (
 	NodeAffinityOperatorNotIn = "NOT_IN"
 )
 
+const (
+	ReservationAffinityAny      = "ANY_RESERVATION"
+	ReservationAffinityNone     = "NO_RESERVATION"
+	ReservationAffinitySpecific = "SPECIFIC_RESERVATION"
+
+	reservationNameAffinityKey = "compute.googleapis.com/reservation-name"
+)
+
 type AdvancedConfig struct {
 	GuestAccelerators      []GuestAcceleratorEntry `mapstructure:"guestAccelerators"`
 	NodeAffinities         []NodeAffinityEntry     `mapstructure:"nodeAffinities"`
@@ -818,6 +1009,42 @@ func BuildNodeAffinities(config AdvancedConfig) []*compute.SchedulingNodeAffinit
 	return out
 }
 
+// BuildReservationAffinity maps the reservation affinity settings to the
+// instance payload. An empty affinity leaves the Compute Engine default, which
+// consumes any matching reservation. reservation is a reservation name, or
+// projects/<project>/reservations/<name> for a shared reservation.
+func BuildReservationAffinity(affinity, reservation string) *compute.ReservationAffinity {
+	switch strings.TrimSpace(affinity) {
+	case ReservationAffinityAny:
+		return &compute.ReservationAffinity{ConsumeReservationType: ReservationAffinityAny}
+	case ReservationAffinityNone:
+		return &compute.ReservationAffinity{ConsumeReservationType: ReservationAffinityNone}
+	case ReservationAffinitySpecific:
+		return &compute.ReservationAffinity{
+			ConsumeReservationType: ReservationAffinitySpecific,
+			Key:                    reservationNameAffinityKey,
+			Values:                 []string{strings.TrimSpace(reservation)},
+		}
+	}
+	return nil
+}
+
+func validateReservationAffinity(config CreateVMConfig) error {
+	switch strings.TrimSpace(config.ReservationAffinity) {
+	case "", ReservationAffinityAny, ReservationAffinityNone:
+		return nil
+	case ReservationAffinitySpecific:
+		if strings.TrimSpace(config.ReservationName) == "" {
+			return fmt.Errorf("reservation name is required when reservation affinity is a specific reservation")
+		}
+		if strings.EqualFold(strings.TrimSpace(config.ProvisioningModel), string(ProvisioningSpot)) {
+			return fmt.Errorf("spot VMs cannot consume a specific reservation; use the Standard provisioning model")
+		}
+		return nil
+	}
+	return fmt.Errorf("invalid reservation affinity %q: use %s, %s or %s", config.ReservationAffinity, ReservationAffinityAny, ReservationAffinityNone, ReservationAffinitySpecific)
+}
+
 func BuildInstanceResourcePolicies(config AdvancedConfig) []string {
 	return trimmedNonEmptyStrings(config.ResourcePolicies)
 }
@@ -871,6 +1098,7 @@ func bootDiskConfigFromOSConfig(project, zone string, c OSAndStorageConfig) Boot
 		SnapshotSchedule:  strings.TrimSpace(c.BootDiskSnapshotSchedule),
 		AutoDelete:        c.BootDiskAutoDelete,
 		DiskEncryptionKey: strings.TrimSpace(c.BootDiskEncryptionKey),
+		ReplicaZones:      normalizeReplicaZones(c.BootDiskReplicaZones),
 	}
 	if cfg.DiskType == "" {
 		cfg.DiskType = DefaultDiskType
@@ -881,7 +1109,7 @@ func bootDiskConfigFromOSConfig(project, zone string, c OSAndStorageConfig) Boot
 	case BootDiskSourceExistingDisk:
 		s := strings.TrimSpace(c.BootDiskExistingDisk)
 		if s != "" {
-			cfg.SourceDisk = resolveDiskURL(project, zone, s)
+			cfg.SourceDisk = resolveDiskURLForDisk(project, zone, s, cfg.ReplicaZones)
 		}
 	case BootDiskSourceSnapshot:
 		s := strings.TrimSpace(c.BootDiskSnapshot)
@@ -912,16 +1140,18 @@ func additionalDisksFromOSConfig(c OSAndStorageConfig) []AdditionalDisk {
 	for _, e := range c.AdditionalDisks {
 		if e.Mode == AdditionalDiskModeExisting && strings.TrimSpace(e.ExistingDisk) != "" {
 			out = append(out, AdditionalDisk{
-				SourceDisk: e.ExistingDisk,
-				AutoDelete: e.AutoDelete,
+				SourceDisk:   e.ExistingDisk,
+				AutoDelete:   e.AutoDelete,
+				ReplicaZones: normalizeReplicaZones(e.ReplicaZones),
 			})
 			continue
 		}
 		out = append(out, AdditionalDisk{
-			Name:       strings.TrimSpace(e.Name),
-			SizeGb:     e.SizeGb,
-			DiskType:   strings.TrimSpace(e.DiskType),
-			AutoDelete: e.AutoDelete,
+			Name:         strings.TrimSpace(e.Name),
+			SizeGb:       e.SizeGb,
+			DiskType:     strings.TrimSpace(e.DiskType),
+			AutoDelete:   e.AutoDelete,
+			ReplicaZones: normalizeReplicaZones(e.ReplicaZones),
 		})
 		if out[len(out)-1].DiskType == "" {
 			out[len(out)-1].DiskType = DefaultDiskType
@@ -1005,7 +1235,7 @@ func buildDisks(project, zone string, config CreateVMConfig) ([]*compute.Attache
 	additional := additionalDisksFromOSConfig(config.OSAndStorageConfig)
 	for i := range additional {
 		if additional[i].SourceDisk != "" && !strings.Contains(additional[i].SourceDisk, "/") {
-			additional[i].SourceDisk = resolveDiskURL(project, zone, additional[i].SourceDisk)
+			additional[i].SourceDisk = resolveDiskURLForDisk(project, zone, additional[i].SourceDisk, additional[i].ReplicaZones)
 		}
 	}
 	disks = append(disks, BuildAdditionalDisks(project, zone, additional)...)
@@ -1016,8 +1246,7 @@ func buildDisks(project, zone string, config CreateVMConfig) ([]*compute.Attache
 }
 
 func buildSchedulingAndResourcePolicies(zone string, config CreateVMConfig) (*compute.Scheduling, []string) {
-	mgmt := managementConfigFromCreateVMConfig(config)
-	scheduling := BuildScheduling(mgmt)
+	scheduling := BuildSchedulingForProvisioningModel(config.ProvisioningModel, managementConfigFromCreateVMConfig(config))
 	adv := advancedConfigFromCreateVMConfig(config)
 	ApplyAdvancedScheduling(scheduling, adv)
 
@@ -1026,20 +1255,24 @@ func buildSchedulingAndResourcePolicies(zone string, config CreateVMConfig) (*co
 		resourcePolicies = append([]string{strings.TrimSpace(config.MaintenancePolicy)}, resourcePolicies...)
 	}
 
-	provisioningModel := strings.TrimSpace(config.ProvisioningModel)
-	if provisioningModel == "" {
-		provisioningModel = string(ProvisioningStandard)
-	}
-	if provisioningModel == string(ProvisioningSpot) {
+	return scheduling, resourcePolicies
+}
+
+// BuildSchedulingForProvisioningModel builds the scheduling for a provisioning
+// model. Spot VMs always terminate on host maintenance and never restart
+// automatically, whatever the management config says.
+func BuildSchedulingForProvisioningModel(provisioningModel string, mgmt ManagementConfig) *compute.Scheduling {
+	scheduling := BuildScheduling(mgmt)
+	if strings.TrimSpace(provisioningModel) == string(ProvisioningSpot) {
 		scheduling.Preemptible = true
 		scheduling.ProvisioningModel = string(ProvisioningSpot)
 		scheduling.OnHostMaintenance = OnHostMaintenanceTerminate
 		automaticRestart := false
 		scheduling.AutomaticRestart = &automaticRestart
-		return scheduling, resourcePolicies
+		return scheduling
 	}
 	scheduling.ProvisioningModel = string(ProvisioningStandard)
-	return scheduling, resourcePolicies
+	return scheduling
 }
 
 func buildInstanceMetadataFromConfig(mgmt ManagementConfig, config CreateVMConfig) *compute.Metadata {
@@ -1047,6 +1280,9 @@ func buildInstanceMetadataFromConfig(mgmt ManagementConfig, config CreateVMConfi
 	if config.BlockProjectSSHKeys {
 		metadata = ensureMetadataItem(metadata, "block-project-ssh-keys", "true")
 	}
+	if sshKeys, err := buildSSHKeysMetadataValue(config.SSHKeys); err == nil && sshKeys != "" {
+		metadata = ensureMetadataItem(metadata, metadataKeySSHKeys, sshKeys)
+	}
 	if config.EnableOSLogin {
 		metadata = ensureMetadataItem(metadata, "enable-oslogin", "true")
 	}
@@ -1132,6 +1368,7 @@ func BuildInstanceFromConfig(project, zone, region string, config CreateVMConfig
 		GuestAccelerators:          guestAccel,
 		ResourcePolicies:           resourcePolicies,
 		DisplayDevice:              displayDevice,
+		ReservationAffinity:        BuildReservationAffinity(config.ReservationAffinity, config.ReservationName),
 	}
 	if len(serviceAccounts) > 0 {
 		instance.ServiceAccounts = serviceAccounts
@@ -1158,6 +1395,16 @@ type zoneOperationResp struct {
 	} `json:"error"`
 }
 
+// OperationFailedError is returned by the operation wait helpers when the
+// operation completed with an error, as opposed to failing to poll it.
+type OperationFailedError struct {
+	Message string
+}
+
+func (e *OperationFailedError) Error() string {
+	return fmt.Sprintf("operation failed: %s", e.Message)
+}
+
 func WaitForZoneOperation(ctx context.Context, client Client, project, zone, operationName string) error {
 	path := fmt.Sprintf("projects/%s/zones/%s/operations/%s", project, zone, operationName)
 	deadline := time.Now().Add(defaultOperationWaitTimeout)
@@ -1182,7 +1429,7 @@ func WaitForZoneOperation(ctx context.Context, client Client, project, zone, ope
 				if msg == "" {
 					msg = op.Error.Errors[0].Code
 				}
-				return fmt.Errorf("operation failed: %s", msg)
+				return &OperationFailedError{Message: msg}
 			}
 			return nil
 		case opStatusPending, opStatusRunning:
@@ -1261,6 +1508,26 @@ func CreateVMAndWait(ctx context.Context, client Client, config CreateVMConfig)
 	}
 	zone = lastSegment(zone)
 	region = lastSegment(region)
+	if !gcpcommon.IsRegionAllowed(config.AllowedRegions, region) {
+		return nil, fmt.Errorf("region %q is not allowed by the integration's region allowlist (%s)", region, strings.Join(config.AllowedRegions, ", "))
+	}
+	if config.Source != CreateVMSourceInstanceTemplate {
+		if err := checkMachineFamilyAllowed(config.AllowedMachineFamilies, config.MachineType); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.Source == CreateVMSourceInstanceTemplate {
+		return createVMFromTemplateAndWait(ctx, client, project, zone, config)
+	}
+
+	if config.BootDiskNewSnapshotSchedule != nil {
+		policyPath, err := EnsureSnapshotSchedule(ctx, client, project, region, *config.BootDiskNewSnapshotSchedule)
+		if err != nil {
+			return nil, fmt.Errorf("snapshot schedule: %w", err)
+		}
+		config.BootDiskSnapshotSchedule = policyPath
+	}
 
 	if config.InternalIPType == InternalIPStatic && strings.TrimSpace(config.InternalIPAddress) != "" {
 		resolved, err := ResolveInternalIPAddress(ctx, client, project, region, config.InternalIPAddress)
@@ -1270,18 +1537,30 @@ func CreateVMAndWait(ctx context.Context, client Client, config CreateVMConfig)
 		config.InternalIPAddress = resolved
 	}
 
+	if err := ValidateAdditionalDiskTypes(ctx, client, project, zone, additionalDisksFromOSConfig(config.OSAndStorageConfig)); err != nil {
+		return nil, err
+	}
+
+	if config.CheckQuota {
+		if err := CheckVMQuota(ctx, client, project, zone, region, config); err != nil {
+			return nil, err
+		}
+	}
+
 	instance, err := BuildInstanceFromConfig(project, zone, region, config)
 	if err != nil {
 		return nil, err
 	}
 
 	var firewallTags []string
+	var firewallConflicts []FirewallRuleConflict
 	if len(config.CreateFirewallRules) > 0 {
-		createdTags, err := EnsureFirewallRules(ctx, client, project, config.Network, config.CreateFirewallRules)
+		createdTags, conflicts, err := EnsureFirewallRules(ctx, client, project, config.Network, config.CreateFirewallRules)
 		if err != nil {
 			return nil, err
 		}
 		firewallTags = append(firewallTags, createdTags...)
+		firewallConflicts = conflicts
 	}
 	if len(firewallTags) > 0 {
 		instance.Tags = &compute.Tags{Items: BuildInstanceTags(config.NetworkTags, firewallTags)}
@@ -1302,13 +1581,85 @@ func CreateVMAndWait(ctx context.Context, client Client, config CreateVMConfig)
 		return nil, err
 	}
 
-	instBody, err := GetInstance(ctx, client, project, zone, instance.Name)
+	payload, err := createdInstancePayload(ctx, client, project, zone, instance.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(firewallConflicts) > 0 {
+		payload["firewallRuleConflicts"] = firewallConflicts
+	}
+	return payload, nil
+}
+
+// createdInstancePayload fetches the inserted instance.
+func createdInstancePayload(ctx context.Context, client Client, project, zone, name string) (map[string]any, error) {
+	instBody, err := GetInstance(ctx, client, project, zone, name)
 	if err != nil {
 		return nil, fmt.Errorf("fetch created instance: %w", err)
 	}
 	return InstancePayloadFromGetResponse(instBody, zone)
 }
 
+// ResolveInstanceTemplatePath turns an instance template reference into an API path.
+// A bare name refers to a global template in the project; full paths and URLs,
+// global or regional, are used as they are.
+func ResolveInstanceTemplatePath(project, ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return "", fmt.Errorf("instance template is required")
+	}
+
+	if i := strings.Index(ref, "projects/"); i >= 0 {
+		ref = ref[i:]
+	}
+
+	if !strings.Contains(ref, "/") {
+		return fmt.Sprintf("projects/%s/global/instanceTemplates/%s", project, ref), nil
+	}
+
+	parts := strings.Split(ref, "/")
+	switch {
+	case len(parts) == 5 && parts[0] == "projects" && parts[2] == "global" && parts[3] == "instanceTemplates":
+		return ref, nil
+	case len(parts) == 6 && parts[0] == "projects" && parts[2] == "regions" && parts[4] == "instanceTemplates":
+		return ref, nil
+	}
+
+	return "", fmt.Errorf("invalid instance template %q: use a template name or projects/{project}/global/instanceTemplates/{name}", ref)
+}
+
+// createVMFromTemplateAndWait inserts an instance from an existing instance template,
+// overriding only its name. The template is read first so a bad reference fails
+// with a clear error instead of a failed insert operation.
+func createVMFromTemplateAndWait(ctx context.Context, client Client, project, zone string, config CreateVMConfig) (map[string]any, error) {
+	templatePath, err := ResolveInstanceTemplatePath(project, config.InstanceTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := client.Get(ctx, templatePath); err != nil {
+		return nil, fmt.Errorf("instance template %q could not be resolved: %w", config.InstanceTemplate, err)
+	}
+
+	instance := &compute.Instance{Name: strings.TrimSpace(config.InstanceName)}
+	path := fmt.Sprintf("projects/%s/zones/%s/instances?sourceInstanceTemplate=%s", project, zone, url.QueryEscape(templatePath))
+	body, err := client.Post(ctx, path, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	opName, err := operationNameFromResponse(body, "insert")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := WaitForZoneOperation(ctx, client, project, zone, opName); err != nil {
+		return nil, err
+	}
+
+	return createdInstancePayload(ctx, client, project, zone, instance.Name)
+}
+
 var gcpInstanceNameRegex = regexp.MustCompile(`^[a-z](?:[-a-z0-9]{0,61}[a-z0-9])?$`)
 
 const (
@@ -1335,17 +1686,25 @@ func (c *CreateVM) Documentation() string {
 
 ## Steps
 
+Set **Create from** to **Instance template** to create the VM from an existing instance template instead. Only the instance name and zone are taken from the node; every other setting comes from the template, and the steps below are ignored.
+
 1. **Machine Configuration** – Region, zone, machine type, provisioning model (Spot/Standard), instance name.
-2. **OS & Storage** – Boot disk source (public/custom image, snapshot, existing disk), disk type, size, snapshot schedule.
+2. **OS & Storage** – Boot disk source (public/custom image, snapshot, existing disk), disk type, size, snapshot schedule (existing, or created inline with a retention window). Set two **Replica zones** on the boot disk or an additional disk to make it a regional persistent disk, replicated across both zones for high availability; the zones must be in the VM's region and include its zone.
 3. **Security** – Shielded VM (secure boot, vTPM, integrity monitoring), Confidential VM (AMD SEV/SEV-SNP, Intel TDX).
-4. **Identity & API access** – VM service account, OAuth scopes, OS Login, block project-wide SSH keys.
+4. **Identity & API access** – VM service account, OAuth scopes, OS Login, block project-wide SSH keys, instance-level SSH keys (user:key entries written to the ssh-keys metadata item).
 5. **Networking** – VPC, subnet, NIC type, internal/external IP (including static), network tags, firewall rules.
 6. **Management** – Metadata, startup script, automatic restart, on host maintenance, maintenance policy.
 7. **Advanced** – GPU accelerators, placement policy (min node CPUs), sole-tenant/host affinity, resource policies.
 
+Enable **Check quota before creating** to compare the CPUs, ephemeral external IP and new persistent/local SSD disk capacity the VM needs against the region's remaining quota, and fail with a clear "would exceed CPUS quota" error before anything is inserted. The check does not apply to VMs created from an instance template.
+
+Enable **Wait until running** to hold the output until the VM reports RUNNING (and, with **Readiness port** set, until that port accepts TCP connections), so downstream steps that SSH in do not race the boot. The instance is checked every 10 seconds, and the wait fails after **Readiness timeout** (at most 30 minutes). The readiness port is only checked on the VM's public external IP.
+
 ## Output
 
-Emits a payload with instance details: instanceId, selfLink, internalIP, externalIP, status, zone, name, machineType.`
+Emits a payload with instance details: instanceId, selfLink, internalIP, externalIP, status, zone, name, machineType.
+
+Firewall rules under **Create firewall rules** that already exist are left unchanged. When an existing rule's direction, network, allowed protocols/ports, source ranges, target tags or target service accounts differ from the entry, the output includes **firewallRuleConflicts** listing each rule name and its differences.`
 }
 
 func (c *CreateVM) Icon() string {
@@ -1372,6 +1731,14 @@ func (c *CreateVM) Configuration() []configuration.Field {
 			Description: "Start with a letter; use only a-z, 0-9, and hyphens; end with a letter or digit. 1 to 63 characters length.",
 			Placeholder: "e.g. my-vm-01",
 		},
+		{
+			Name:        "autoName",
+			Label:       "Auto-generate name",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Description: "Treat the instance name as a free-form label: normalize it into a valid name and append a random suffix so repeated runs do not collide.",
+			Default:     false,
+		},
 		{
 			Name:        "region",
 			Label:       "Region",
@@ -1400,11 +1767,42 @@ func (c *CreateVM) Configuration() []configuration.Field {
 			},
 		},
 		{
-			Name:        "machineFamily",
-			Label:       "Machine family",
-			Type:        configuration.FieldTypeIntegrationResource,
+			Name:        "source",
+			Label:       "Create from",
+			Type:        configuration.FieldTypeSelect,
+			Required:    false,
+			Description: "Configure the VM here, or create it from an existing instance template.",
+			Default:     CreateVMSourceConfiguration,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Custom configuration", Value: CreateVMSourceConfiguration},
+						{Label: "Instance template", Value: CreateVMSourceInstanceTemplate},
+					},
+				},
+			},
+		},
+		{
+			Name:        "instanceTemplate",
+			Label:       "Instance template",
+			Type:        configuration.FieldTypeString,
 			Required:    false,
-			Description: "Optional. Filter machine types by family (e.g. E2). Leave empty to see all.",
+			Description: "Name of a global instance template in the project, or its full path or URL (global or regional). Only the instance name and zone are taken from this node.",
+			Placeholder: "e.g. web-server-template",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "source", Values: []string{CreateVMSourceInstanceTemplate}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "source", Values: []string{CreateVMSourceInstanceTemplate}},
+			},
+		},
+		{
+			Name:                 "machineFamily",
+			Label:                "Machine family",
+			Type:                 configuration.FieldTypeIntegrationResource,
+			Required:             false,
+			VisibilityConditions: visibleWhenCreateVMFromConfiguration,
+			Description:          "Optional. Filter machine types by family (e.g. E2). Leave empty to see all.",
 			TypeOptions: &configuration.TypeOptions{
 				Resource: &configuration.ResourceTypeOptions{
 					Type: ResourceTypeMachineFamily,
@@ -1415,10 +1813,14 @@ func (c *CreateVM) Configuration() []configuration.Field {
 			},
 		},
 		{
-			Name:        "machineType",
-			Label:       "Machine type",
-			Type:        configuration.FieldTypeIntegrationResource,
-			Required:    true,
+			Name:                 "machineType",
+			Label:                "Machine type",
+			Type:                 configuration.FieldTypeIntegrationResource,
+			Required:             false,
+			VisibilityConditions: visibleWhenCreateVMFromConfiguration,
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "source", Values: []string{CreateVMSourceConfiguration}},
+			},
 			Description: "Machine type for the VM (e.g. e2-medium, n2-standard-4).",
 			TypeOptions: &configuration.TypeOptions{
 				Resource: &configuration.ResourceTypeOptions{
@@ -1426,17 +1828,19 @@ func (c *CreateVM) Configuration() []configuration.Field {
 					Parameters: []configuration.ParameterRef{
 						{Name: "zone", ValueFrom: &configuration.ParameterValueFrom{Field: "zone"}},
 						{Name: "machineFamily", ValueFrom: &configuration.ParameterValueFrom{Field: "machineFamily"}},
+						{Name: "provisioningModel", ValueFrom: &configuration.ParameterValueFrom{Field: "provisioningModel"}},
 					},
 				},
 			},
 		},
 		{
-			Name:        "provisioningModel",
-			Label:       "Provisioning model",
-			Type:        configuration.FieldTypeSelect,
-			Required:    false,
-			Description: "Standard (on-demand) or Spot (preemptible).",
-			Default:     string(ProvisioningStandard),
+			Name:                 "provisioningModel",
+			Label:                "Provisioning model",
+			Type:                 configuration.FieldTypeSelect,
+			Required:             false,
+			VisibilityConditions: visibleWhenCreateVMFromConfiguration,
+			Description:          "Standard (on-demand) or Spot (preemptible).",
+			Default:              string(ProvisioningStandard),
 			TypeOptions: &configuration.TypeOptions{
 				Select: &configuration.SelectTypeOptions{
 					Options: []configuration.FieldOption{
@@ -1606,6 +2010,19 @@ func (c *CreateVM) Configuration() []configuration.Field {
 				},
 			},
 		},
+		{
+			Name:        "bootDiskNewSnapshotSchedule",
+			Label:       "Create snapshot schedule",
+			Type:        configuration.FieldTypeObject,
+			Required:    false,
+			Togglable:   true,
+			Description: "Create a snapshot schedule with a retention window in the VM's region and attach it to the boot disk. An existing schedule with the same name is reused.",
+			TypeOptions: &configuration.TypeOptions{
+				Object: &configuration.ObjectTypeOptions{
+					Schema: snapshotScheduleFields(),
+				},
+			},
+		},
 		{
 			Name:        "bootDiskAutoDelete",
 			Label:       "Delete boot disk on termination",
@@ -1614,6 +2031,22 @@ func (c *CreateVM) Configuration() []configuration.Field {
 			Description: "Delete the boot disk when the instance is deleted.",
 			Default:     true,
 		},
+		{
+			Name:        "bootDiskReplicaZones",
+			Label:       "Boot disk replica zones",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Togglable:   true,
+			Description: "Make the boot disk a regional persistent disk replicated across exactly two zones of the VM's region, one of them the VM's zone (e.g. us-central1-a, us-central1-b).",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Zone",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+		},
 		{
 			Name:        "localSSDCount",
 			Label:       "Local SSD count",
@@ -1728,6 +2161,21 @@ func (c *CreateVM) Configuration() []configuration.Field {
 								Description: "Delete this disk when the instance is terminated.",
 								Default:     true,
 							},
+							{
+								Name:        "replicaZones",
+								Label:       "Replica zones",
+								Type:        configuration.FieldTypeList,
+								Required:    false,
+								Description: "For a regional persistent disk: exactly two zones of the VM's region, one of them the VM's zone. Leave empty for a zonal disk.",
+								TypeOptions: &configuration.TypeOptions{
+									List: &configuration.ListTypeOptions{
+										ItemLabel: "Zone",
+										ItemDefinition: &configuration.ListItemDefinition{
+											Type: configuration.FieldTypeString,
+										},
+									},
+								},
+							},
 						},
 					},
 				},
@@ -1764,7 +2212,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 			Label:                "Integrity monitoring",
 			Type:                 configuration.FieldTypeBool,
 			Required:             false,
-			Description:          "Monitor boot integrity against a baseline from the trusted boot image.",
+			Description:          "Monitor boot integrity against a baseline from the trusted boot image. Requires vTPM.",
 			Default:              true,
 			VisibilityConditions: visibleWhenShieldedVM,
 		},
@@ -1826,6 +2274,22 @@ func (c *CreateVM) Configuration() []configuration.Field {
 			Description: "If enabled, only instance-level SSH keys or OS Login will work; project-wide SSH keys are ignored.",
 			Default:     false,
 		},
+		{
+			Name:        "sshKeys",
+			Label:       "SSH keys",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Togglable:   true,
+			Description: "Instance-level SSH public keys, written to the ssh-keys metadata item. Each entry is user:key (e.g. alice:ssh-ed25519 AAAA... alice@laptop). Not used with OS Login.",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "SSH key",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+		},
 		{
 			Name:        "enableOSLogin",
 			Label:       "Enable OS Login",
@@ -1969,7 +2433,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 			Type:        configuration.FieldTypeList,
 			Required:    false,
 			Togglable:   true,
-			Description: "Create new firewall rules in the project and apply their target tag to this instance (e.g. allow SSH from any IP, or HTTP/HTTPS from a specific IP).",
+			Description: "Create new firewall rules in the project and apply them to this instance through a target tag or its service account (e.g. allow SSH from any IP, or HTTP/HTTPS from a specific IP).",
 			TypeOptions: &configuration.TypeOptions{
 				List: &configuration.ListTypeOptions{
 					ItemLabel: "Firewall rule to create",
@@ -2004,10 +2468,20 @@ func (c *CreateVM) Configuration() []configuration.Field {
 								Name:        "targetTag",
 								Label:       "Target tag",
 								Type:        configuration.FieldTypeString,
-								Required:    true,
-								Description: "Tag applied to this rule and to the VM so the rule applies (e.g. ssh or web).",
+								Required:    false,
+								Description: "Tag applied to this rule and to the VM so the rule applies (e.g. ssh or web). Set this or a target service account.",
 								Placeholder: "e.g. ssh",
 							},
+							{
+								Name:        "targetServiceAccount",
+								Label:       "Target service account",
+								Type:        configuration.FieldTypeIntegrationResource,
+								Required:    false,
+								Description: "Target the rule at the VM's service account instead of a tag. Must match the service account the VM runs as.",
+								TypeOptions: &configuration.TypeOptions{
+									Resource: &configuration.ResourceTypeOptions{Type: ResourceTypeServiceAccount},
+								},
+							},
 						},
 					},
 				},
@@ -2244,6 +2718,38 @@ func (c *CreateVM) Configuration() []configuration.Field {
 				},
 			},
 		},
+		{
+			Name:                 "reservationAffinity",
+			Label:                "Reservation affinity",
+			Type:                 configuration.FieldTypeSelect,
+			Required:             false,
+			VisibilityConditions: visibleWhenCreateVMFromConfiguration,
+			Description:          "Whether the VM consumes capacity from Compute Engine reservations. Spot VMs cannot consume a specific reservation.",
+			Default:              ReservationAffinityAny,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Any matching reservation", Value: ReservationAffinityAny},
+						{Label: "No reservation", Value: ReservationAffinityNone},
+						{Label: "Specific reservation", Value: ReservationAffinitySpecific},
+					},
+				},
+			},
+		},
+		{
+			Name:        "reservationName",
+			Label:       "Reservation name",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Reservation to consume. Use projects/<project>/reservations/<name> for a reservation shared from another project.",
+			Placeholder: "e.g. my-reservation",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "reservationAffinity", Values: []string{ReservationAffinitySpecific}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "reservationAffinity", Values: []string{ReservationAffinitySpecific}},
+			},
+		},
 		{
 			Name:        "enableDisplayDevice",
 			Label:       "Enable display device",
@@ -2260,6 +2766,51 @@ func (c *CreateVM) Configuration() []configuration.Field {
 			Description: "Allow connecting to the instance serial console.",
 			Default:     false,
 		},
+		{
+			Name:        "checkQuota",
+			Label:       "Check quota before creating",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Description: "Compare the VM's CPUs, external IP and disk size against the region's remaining quota before creating it. Costs extra API calls.",
+			Default:     false,
+		},
+		{
+			Name:        "waitForRunning",
+			Label:       "Wait until running",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Description: "After the VM is created, wait until it reports RUNNING before emitting. Useful when the next step connects to the VM.",
+			Default:     false,
+		},
+		{
+			Name:        "readinessPort",
+			Label:       "Readiness port",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Togglable:   true,
+			Description: "Also wait until this TCP port (e.g. 22 for SSH) accepts connections on the VM's external IP. The VM must have a public external IP.",
+			Placeholder: "e.g. 22",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{Min: intPtr(1), Max: intPtr(65535)},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "waitForRunning", Values: []string{"true"}},
+			},
+		},
+		{
+			Name:        "readinessTimeout",
+			Label:       "Readiness timeout (seconds)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Description: "How long to wait for the VM to become ready before failing. At most 1800 seconds.",
+			Default:     defaultReadinessTimeoutSeconds,
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{Min: intPtr(1), Max: intPtr(maxReadinessTimeoutSeconds)},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "waitForRunning", Values: []string{"true"}},
+			},
+		},
 	}
 }
 
@@ -2276,6 +2827,9 @@ func (c *CreateVM) Execute(ctx core.ExecutionContext) error {
 	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
 		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to decode configuration: %v", err))
 	}
+	if config.AutoName {
+		config.InstanceName = gcpcommon.SafeResourceName(config.InstanceName, true)
+	}
 	if msg, ok := validateCreateVMConfig(config); !ok {
 		return ctx.ExecutionState.Fail("error", msg)
 	}
@@ -2285,11 +2839,17 @@ func (c *CreateVM) Execute(ctx core.ExecutionContext) error {
 		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
 	}
 
+	config.AllowedRegions = gcpcommon.AllowedRegions(ctx.Integration)
+	config.AllowedMachineFamilies = gcpcommon.AllowedMachineFamilies(ctx.Integration)
+
 	callCtx := context.Background()
 	payload, err := CreateVMAndWait(callCtx, client, config)
 	if err != nil {
 		return ctx.ExecutionState.Fail("error", err.Error())
 	}
+	if config.WaitForRunning {
+		return startReadinessWait(ctx, lastSegment(strings.TrimSpace(config.Zone)), strings.TrimSpace(config.InstanceName), payload)
+	}
 	return ctx.ExecutionState.Emit(createVMOutputChannel, createVMPayloadType, []any{payload})
 }
 
@@ -2316,6 +2876,38 @@ func validateCreateVMConfig(config CreateVMConfig) (invalidMessage string, ok bo
 	if strings.TrimSpace(config.Zone) == "" {
 		return "zone is required", false
 	}
+	if config.BootDiskNewSnapshotSchedule != nil {
+		if strings.TrimSpace(config.BootDiskSnapshotSchedule) != "" {
+			return "choose either an existing snapshot schedule or a new one, not both", false
+		}
+		if err := validateSnapshotScheduleEntry(*config.BootDiskNewSnapshotSchedule); err != nil {
+			return err.Error(), false
+		}
+	}
+	if err := validateReadinessConfig(config.ReadinessConfig); err != nil {
+		return err.Error(), false
+	}
+	if err := validateInstanceSSHKeys(config); err != nil {
+		return err.Error(), false
+	}
+	if err := validateRegionalDisks(config); err != nil {
+		return err.Error(), false
+	}
+	if err := validateFirewallRuleTargets(config); err != nil {
+		return err.Error(), false
+	}
+	if config.Source == CreateVMSourceInstanceTemplate {
+		if strings.TrimSpace(config.InstanceTemplate) == "" {
+			return "instance template is required", false
+		}
+		return "", true
+	}
+	if err := validateReservationAffinity(config); err != nil {
+		return err.Error(), false
+	}
+	if err := validateShieldedVM(config.SecurityConfig); err != nil {
+		return err.Error(), false
+	}
 	if strings.TrimSpace(config.MachineType) == "" {
 		return "machine type is required", false
 	}
@@ -2324,6 +2916,9 @@ func validateCreateVMConfig(config CreateVMConfig) (invalidMessage string, ok bo
 
 type CreateVMConfig struct {
 	InstanceName           string                  `mapstructure:"instanceName"`
+	AutoName               bool                    `mapstructure:"autoName"`
+	Source                 string                  `mapstructure:"source"`
+	InstanceTemplate       string                  `mapstructure:"instanceTemplate"`
 	Region                 string                  `mapstructure:"region"`
 	Zone                   string                  `mapstructure:"zone"`
 	MachineFamily          string                  `mapstructure:"machineFamily"`
@@ -2340,18 +2935,42 @@ type CreateVMConfig struct {
 	MinNodeCpus            int64                   `mapstructure:"minNodeCpus"`
 	NodeAffinities         []NodeAffinityEntry     `mapstructure:"nodeAffinities"`
 	ResourcePolicies       []string                `mapstructure:"resourcePolicies"`
+	ReservationAffinity    string                  `mapstructure:"reservationAffinity"`
+	ReservationName        string                  `mapstructure:"reservationName"`
 	EnableDisplayDevice    bool                    `mapstructure:"enableDisplayDevice"`
 	EnableSerialPortAccess bool                    `mapstructure:"enableSerialPortAccess"`
 	SecurityConfig         `mapstructure:",squash"`
 	IdentityConfig         `mapstructure:",squash"`
 	NetworkingConfig       `mapstructure:",squash"`
 	OSAndStorageConfig     `mapstructure:",squash"`
+	ReadinessConfig        `mapstructure:",squash"`
+	QuotaPreflightConfig   `mapstructure:",squash"`
+
+	// AllowedRegions and AllowedMachineFamilies are populated from the
+	// integration configuration, not the node.
+	AllowedRegions         []string `mapstructure:"-"`
+	AllowedMachineFamilies []string `mapstructure:"-"`
+}
+
+func checkMachineFamilyAllowed(allowed []string, machineType string) error {
+	family := gcpcommon.MachineTypeFamily(machineType)
+	if gcpcommon.IsMachineFamilyAllowed(allowed, family) {
+		return nil
+	}
+	return fmt.Errorf("machine family %q is not allowed by the integration's machine family allowlist (%s)", family, strings.Join(allowed, ", "))
 }
 
 func (c *CreateVM) Hooks() []core.Hook {
-	return []core.Hook{}
+	return []core.Hook{
+		{Name: readinessPollAction, Type: core.HookTypeInternal},
+	}
 }
 
 func (c *CreateVM) HandleHook(ctx core.ActionHookContext) error {
-	return nil
+	switch ctx.Name {
+	case readinessPollAction:
+		return c.pollReadiness(ctx)
+	default:
+		return fmt.Errorf("unknown action: %s", ctx.Name)
+	}
 }
@@ -1,11 +1,16 @@
 package compute
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+	"github.com/superplanehq/superplane/test/support/contexts"
 	compute "google.golang.org/api/compute/v1"
 )
 
@@ -33,10 +38,12 @@ func Test_CreateVMConfiguration(t *testing.T) {
 	assert.Contains(t, names, "bootDiskEncryptionKey")
 	assert.Contains(t, names, "bootDiskSnapshotSchedule")
 	assert.Contains(t, names, "bootDiskAutoDelete")
+	assert.Contains(t, names, "bootDiskReplicaZones")
 	assert.Contains(t, names, "localSSDCount")
 	assert.Contains(t, names, "additionalDisks")
 	assert.Contains(t, names, "network")
 	assert.Contains(t, names, "serviceAccount")
+	assert.Contains(t, names, "sshKeys")
 	assert.Contains(t, names, "labels")
 }
 
@@ -663,6 +670,140 @@ func Test_BuildInstanceFromConfig(t *testing.T) {
 	})
 }
 
+func Test_BuildInstanceFromConfig_ReservationAffinity(t *testing.T) {
+	build := func(t *testing.T, affinity, reservation string) *compute.Instance {
+		config := CreateVMConfig{
+			InstanceName:        "test-vm",
+			Zone:                "us-central1-a",
+			MachineType:         "n2-standard-4",
+			ReservationAffinity: affinity,
+			ReservationName:     reservation,
+		}
+		inst, err := BuildInstanceFromConfig("my-proj", "us-central1-a", "us-central1", config)
+		require.NoError(t, err)
+		return inst
+	}
+
+	t.Run("unset leaves the Compute Engine default", func(t *testing.T) {
+		assert.Nil(t, build(t, "", "").ReservationAffinity)
+	})
+
+	t.Run("any reservation", func(t *testing.T) {
+		assert.Equal(t, &compute.ReservationAffinity{ConsumeReservationType: "ANY_RESERVATION"}, build(t, ReservationAffinityAny, "").ReservationAffinity)
+	})
+
+	t.Run("no reservation ignores a leftover reservation name", func(t *testing.T) {
+		assert.Equal(t, &compute.ReservationAffinity{ConsumeReservationType: "NO_RESERVATION"}, build(t, ReservationAffinityNone, "my-reservation").ReservationAffinity)
+	})
+
+	t.Run("specific reservation", func(t *testing.T) {
+		assert.Equal(t, &compute.ReservationAffinity{
+			ConsumeReservationType: "SPECIFIC_RESERVATION",
+			Key:                    "compute.googleapis.com/reservation-name",
+			Values:                 []string{"projects/shared-proj/reservations/gpu-pool"},
+		}, build(t, ReservationAffinitySpecific, " projects/shared-proj/reservations/gpu-pool ").ReservationAffinity)
+	})
+}
+
+func Test_validateCreateVMConfig_ReservationAffinity(t *testing.T) {
+	base := func() CreateVMConfig {
+		return CreateVMConfig{InstanceName: "my-vm", Zone: "us-central1-a", MachineType: "n2-standard-4"}
+	}
+
+	t.Run("specific reservation requires a name", func(t *testing.T) {
+		config := base()
+		config.ReservationAffinity = ReservationAffinitySpecific
+		msg, ok := validateCreateVMConfig(config)
+		require.False(t, ok)
+		assert.Equal(t, "reservation name is required when reservation affinity is a specific reservation", msg)
+
+		config.ReservationName = "my-reservation"
+		_, ok = validateCreateVMConfig(config)
+		assert.True(t, ok)
+	})
+
+	t.Run("spot cannot consume a specific reservation", func(t *testing.T) {
+		config := base()
+		config.ProvisioningModel = string(ProvisioningSpot)
+		config.ReservationAffinity = ReservationAffinitySpecific
+		config.ReservationName = "my-reservation"
+		msg, ok := validateCreateVMConfig(config)
+		require.False(t, ok)
+		assert.Contains(t, msg, "spot VMs cannot consume a specific reservation")
+	})
+
+	t.Run("unknown affinity is rejected", func(t *testing.T) {
+		config := base()
+		config.ReservationAffinity = "SOME_RESERVATION"
+		msg, ok := validateCreateVMConfig(config)
+		require.False(t, ok)
+		assert.Contains(t, msg, `invalid reservation affinity "SOME_RESERVATION"`)
+	})
+}
+
+func Test_BuildInstanceFromConfig_ShieldedVM(t *testing.T) {
+	build := func(t *testing.T, security SecurityConfig) *compute.Instance {
+		config := CreateVMConfig{
+			InstanceName:   "test-vm",
+			Zone:           "us-central1-a",
+			MachineType:    "n2-standard-4",
+			SecurityConfig: security,
+		}
+		inst, err := BuildInstanceFromConfig("my-proj", "us-central1-a", "us-central1", config)
+		require.NoError(t, err)
+		return inst
+	}
+
+	t.Run("disabled leaves the shielded config unset", func(t *testing.T) {
+		assert.Nil(t, build(t, SecurityConfig{ShieldedVMEnableVtpm: true}).ShieldedInstanceConfig)
+	})
+
+	t.Run("secure boot", func(t *testing.T) {
+		out := build(t, SecurityConfig{ShieldedVM: true, ShieldedVMEnableSecureBoot: true}).ShieldedInstanceConfig
+		assert.Equal(t, &compute.ShieldedInstanceConfig{EnableSecureBoot: true}, out)
+	})
+
+	t.Run("vTPM", func(t *testing.T) {
+		out := build(t, SecurityConfig{ShieldedVM: true, ShieldedVMEnableVtpm: true}).ShieldedInstanceConfig
+		assert.Equal(t, &compute.ShieldedInstanceConfig{EnableVtpm: true}, out)
+	})
+
+	t.Run("integrity monitoring", func(t *testing.T) {
+		out := build(t, SecurityConfig{
+			ShieldedVM:                          true,
+			ShieldedVMEnableVtpm:                true,
+			ShieldedVMEnableIntegrityMonitoring: true,
+		}).ShieldedInstanceConfig
+		assert.Equal(t, &compute.ShieldedInstanceConfig{EnableVtpm: true, EnableIntegrityMonitoring: true}, out)
+	})
+}
+
+func Test_validateCreateVMConfig_ShieldedVM(t *testing.T) {
+	base := func() CreateVMConfig {
+		return CreateVMConfig{InstanceName: "my-vm", Zone: "us-central1-a", MachineType: "n2-standard-4"}
+	}
+
+	t.Run("integrity monitoring requires vTPM", func(t *testing.T) {
+		config := base()
+		config.ShieldedVM = true
+		config.ShieldedVMEnableIntegrityMonitoring = true
+		msg, ok := validateCreateVMConfig(config)
+		require.False(t, ok)
+		assert.Equal(t, "integrity monitoring requires vTPM to be enabled", msg)
+
+		config.ShieldedVMEnableVtpm = true
+		_, ok = validateCreateVMConfig(config)
+		assert.True(t, ok)
+	})
+
+	t.Run("options are ignored when shielded VM is disabled", func(t *testing.T) {
+		config := base()
+		config.ShieldedVMEnableIntegrityMonitoring = true
+		_, ok := validateCreateVMConfig(config)
+		assert.True(t, ok)
+	})
+}
+
 func Test_validateCreateVMConfig(t *testing.T) {
 	t.Run("valid config returns ok", func(t *testing.T) {
 		config := CreateVMConfig{
@@ -712,4 +853,228 @@ func Test_validateCreateVMConfig(t *testing.T) {
 		require.False(t, ok)
 		assert.Equal(t, "machine type is required", msg)
 	})
+
+	t.Run("instance template source does not need a machine type", func(t *testing.T) {
+		config := CreateVMConfig{
+			InstanceName:     "my-vm",
+			Zone:             "us-central1-a",
+			Source:           CreateVMSourceInstanceTemplate,
+			InstanceTemplate: "web-template",
+		}
+		_, ok := validateCreateVMConfig(config)
+		require.True(t, ok)
+	})
+
+	t.Run("instance template source requires a template", func(t *testing.T) {
+		config := CreateVMConfig{InstanceName: "my-vm", Zone: "us-central1-a", Source: CreateVMSourceInstanceTemplate}
+		msg, ok := validateCreateVMConfig(config)
+		require.False(t, ok)
+		assert.Equal(t, "instance template is required", msg)
+	})
+}
+
+func Test_CreateVMAndWait_AllowedRegions(t *testing.T) {
+	t.Run("region outside the allowlist is rejected before any API call", func(t *testing.T) {
+		client := &mockInstanceClient{projectID: "my-project"}
+		_, err := CreateVMAndWait(context.Background(), client, CreateVMConfig{
+			InstanceName:   "my-vm",
+			Zone:           "asia-east1-a",
+			MachineType:    "e2-medium",
+			AllowedRegions: []string{"us-central1", "europe-west1"},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `region "asia-east1" is not allowed`)
+	})
+}
+
+func Test_CreateVMAndWait_AllowedMachineFamilies(t *testing.T) {
+	t.Run("machine family outside the allowlist is rejected before any API call", func(t *testing.T) {
+		client := &mockInstanceClient{projectID: "my-project"}
+		_, err := CreateVMAndWait(context.Background(), client, CreateVMConfig{
+			InstanceName:           "my-vm",
+			Zone:                   "us-central1-a",
+			MachineType:            "a2-highgpu-1g",
+			AllowedMachineFamilies: []string{"e2", "n2"},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `machine family "a2" is not allowed`)
+	})
+
+	t.Run("machine type path of an allowed family passes the check", func(t *testing.T) {
+		assert.NoError(t, checkMachineFamilyAllowed([]string{"e2", "n2"}, "zones/us-central1-a/machineTypes/n2-standard-4"))
+		assert.NoError(t, checkMachineFamilyAllowed(nil, "a3-highgpu-8g"))
+	})
+}
+
+func Test_ResolveInstanceTemplatePath(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"web-template", "projects/my-project/global/instanceTemplates/web-template"},
+		{"projects/other/global/instanceTemplates/web-template", "projects/other/global/instanceTemplates/web-template"},
+		{"projects/other/regions/us-central1/instanceTemplates/web-template", "projects/other/regions/us-central1/instanceTemplates/web-template"},
+		{"https://www.googleapis.com/compute/v1/projects/other/global/instanceTemplates/web-template", "projects/other/global/instanceTemplates/web-template"},
+	}
+	for _, tt := range tests {
+		got, err := ResolveInstanceTemplatePath("my-project", tt.ref)
+		require.NoError(t, err, tt.ref)
+		assert.Equal(t, tt.want, got)
+	}
+
+	_, err := ResolveInstanceTemplatePath("my-project", "")
+	require.Error(t, err)
+
+	_, err = ResolveInstanceTemplatePath("my-project", "projects/other/global/images/web-template")
+	require.ErrorContains(t, err, "invalid instance template")
+}
+
+func Test_CreateVMAndWait_FromInstanceTemplate(t *testing.T) {
+	t.Run("inserts with sourceInstanceTemplate and only the instance name", func(t *testing.T) {
+		var gets []string
+		var postPath string
+		var postBody any
+		client := &mockInstanceClient{
+			projectID: "my-project",
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				if isOperationPath(path) {
+					return opDone("op-1"), nil
+				}
+				gets = append(gets, path)
+				if path == "projects/my-project/global/instanceTemplates/web-template" {
+					return []byte(`{"name":"web-template"}`), nil
+				}
+				return instanceGetJSON("123", "my-vm", "us-central1-a", "RUNNING", "e2-medium"), nil
+			},
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				postPath = path
+				postBody = body
+				return opDone("op-1"), nil
+			},
+		}
+
+		payload, err := CreateVMAndWait(context.Background(), client, CreateVMConfig{
+			InstanceName:     "my-vm",
+			Zone:             "us-central1-a",
+			Source:           CreateVMSourceInstanceTemplate,
+			InstanceTemplate: "web-template",
+			MachineType:      "n2-standard-8",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "my-vm", payload["name"])
+		assert.Equal(t, []string{
+			"projects/my-project/global/instanceTemplates/web-template",
+			"projects/my-project/zones/us-central1-a/instances/my-vm",
+		}, gets)
+		assert.Equal(t,
+			"projects/my-project/zones/us-central1-a/instances?sourceInstanceTemplate=projects%2Fmy-project%2Fglobal%2FinstanceTemplates%2Fweb-template",
+			postPath,
+		)
+		assert.Equal(t, &compute.Instance{Name: "my-vm"}, postBody)
+	})
+
+	t.Run("template that does not resolve fails before insert", func(t *testing.T) {
+		posted := false
+		client := &mockInstanceClient{
+			projectID: "my-project",
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				return nil, fmt.Errorf("404 not found")
+			},
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				posted = true
+				return opDone("op-1"), nil
+			},
+		}
+
+		_, err := CreateVMAndWait(context.Background(), client, CreateVMConfig{
+			InstanceName:     "my-vm",
+			Zone:             "us-central1-a",
+			Source:           CreateVMSourceInstanceTemplate,
+			InstanceTemplate: "missing-template",
+		})
+
+		require.ErrorContains(t, err, `instance template "missing-template" could not be resolved`)
+		assert.False(t, posted)
+	})
+}
+
+func Test_ValidateAdditionalDiskTypes(t *testing.T) {
+	diskTypesGets := 0
+	client := &mockInstanceClient{
+		projectID: "my-project",
+		getFunc: func(ctx context.Context, path string) ([]byte, error) {
+			diskTypesGets++
+			assert.Equal(t, "projects/my-project/zones/disk-types-zone-a/diskTypes", path)
+			return []byte(`{"items":[{"name":"pd-balanced"},{"name":"pd-ssd"},{"name":"hyperdisk-balanced"}]}`), nil
+		},
+	}
+
+	t.Run("supported disk types pass", func(t *testing.T) {
+		err := ValidateAdditionalDiskTypes(context.Background(), client, "my-project", "disk-types-zone-a", []AdditionalDisk{
+			{Name: "data", DiskType: "pd-ssd"},
+			{Name: "logs", DiskType: "projects/my-project/zones/disk-types-zone-a/diskTypes/hyperdisk-balanced"},
+			{SourceDisk: "projects/my-project/zones/disk-types-zone-a/disks/existing"},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("unsupported disk type fails with the disk it belongs to", func(t *testing.T) {
+		err := ValidateAdditionalDiskTypes(context.Background(), client, "my-project", "disk-types-zone-a", []AdditionalDisk{
+			{Name: "data", DiskType: "pd-ssd"},
+			{Name: "scratch", DiskType: "hyperdisk-throughput"},
+		})
+
+		require.EqualError(t, err, `additional disk #2 (scratch): disk type "hyperdisk-throughput" is not available in zone disk-types-zone-a`)
+	})
+
+	t.Run("disk types are listed once per zone", func(t *testing.T) {
+		assert.Equal(t, 1, diskTypesGets)
+	})
+
+	t.Run("existing disks only do not list disk types", func(t *testing.T) {
+		err := ValidateAdditionalDiskTypes(context.Background(), &mockInstanceClient{projectID: "my-project"}, "my-project", "disk-types-zone-b", []AdditionalDisk{
+			{SourceDisk: "projects/my-project/zones/disk-types-zone-b/disks/existing"},
+		})
+
+		require.NoError(t, err)
+	})
+}
+
+func Test_CreateVM_Execute_AutoName(t *testing.T) {
+	var postedName string
+	SetClientFactory(func(ctx core.ExecutionContext) (Client, error) {
+		return &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				if inst, ok := body.(*compute.Instance); ok {
+					postedName = inst.Name
+				}
+				return nil, &gcpcommon.GCPAPIError{StatusCode: http.StatusForbidden, Message: "permission denied"}
+			},
+		}, nil
+	})
+
+	configuration := map[string]any{
+		"instanceName": "Web Server (Nightly Build)",
+		"zone":         "us-central1-a",
+		"machineType":  "e2-medium",
+	}
+
+	t.Run("free-form name is rejected without auto-naming", func(t *testing.T) {
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		require.NoError(t, (&CreateVM{}).Execute(core.ExecutionContext{Configuration: configuration, ExecutionState: state}))
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "instance name must be 1–63 characters")
+		assert.Empty(t, postedName)
+	})
+
+	t.Run("free-form name is normalized with auto-naming", func(t *testing.T) {
+		configuration["autoName"] = true
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		require.NoError(t, (&CreateVM{}).Execute(core.ExecutionContext{Configuration: configuration, ExecutionState: state}))
+		assert.Regexp(t, `^web-server-nightly-build-[a-z0-9]{6}$`, postedName)
+		assert.Regexp(t, gcpInstanceNameRegex, postedName)
+	})
 }
@@ -16,6 +16,9 @@ var exampleOutputDeleteVMInstanceBytes []byte
 //go:embed example_data_on_vm_instance.json
 var exampleDataOnVMInstanceBytes []byte
 
+//go:embed example_data_on_vm_status_changed.json
+var exampleDataOnVMStatusChangedBytes []byte
+
 //go:embed example_output_get_vm_instance.json
 var exampleOutputGetVMInstanceBytes []byte
 
@@ -25,6 +28,18 @@ var exampleOutputManageVMInstancePowerBytes []byte
 //go:embed example_output_update_vm_instance_type.json
 var exampleOutputUpdateVMInstanceTypeBytes []byte
 
+//go:embed example_output_set_vm_labels.json
+var exampleOutputSetVMLabelsBytes []byte
+
+//go:embed example_output_set_deletion_protection.json
+var exampleOutputSetDeletionProtectionBytes []byte
+
+//go:embed example_output_set_vm_scheduling.json
+var exampleOutputSetVMSchedulingBytes []byte
+
+//go:embed example_output_attach_accelerator.json
+var exampleOutputAttachAcceleratorBytes []byte
+
 //go:embed example_output_get_vm_instance_metrics.json
 var exampleOutputGetVMInstanceMetricsBytes []byte
 
@@ -46,6 +61,15 @@ var exampleOutputDeleteStaticIPBytes []byte
 //go:embed example_output_manage_static_ip.json
 var exampleOutputManageStaticIPBytes []byte
 
+//go:embed example_output_create_network.json
+var exampleOutputCreateNetworkBytes []byte
+
+//go:embed example_output_create_subnetwork.json
+var exampleOutputCreateSubnetworkBytes []byte
+
+//go:embed example_output_create_snapshot_schedule.json
+var exampleOutputCreateSnapshotScheduleBytes []byte
+
 //go:embed example_output_create_load_balancer.json
 var exampleOutputCreateLoadBalancerBytes []byte
 
@@ -61,6 +85,12 @@ var exampleOutputUpdateFirewallRuleBytes []byte
 //go:embed example_output_delete_firewall_rule.json
 var exampleOutputDeleteFirewallRuleBytes []byte
 
+//go:embed example_output_snapshot_vm.json
+var exampleOutputSnapshotVMBytes []byte
+
+//go:embed example_output_wait_for_operation.json
+var exampleOutputWaitForOperationBytes []byte
+
 var (
 	exampleOutputCreateVMOnce sync.Once
 	exampleOutputCreateVM     map[string]any
@@ -71,6 +101,9 @@ var (
 	exampleDataOnVMInstanceOnce sync.Once
 	exampleDataOnVMInstance     map[string]any
 
+	exampleDataOnVMStatusChangedOnce sync.Once
+	exampleDataOnVMStatusChanged     map[string]any
+
 	exampleOutputGetVMInstanceOnce sync.Once
 	exampleOutputGetVMInstance     map[string]any
 
@@ -80,6 +113,18 @@ var (
 	exampleOutputUpdateVMInstanceTypeOnce sync.Once
 	exampleOutputUpdateVMInstanceType     map[string]any
 
+	exampleOutputAttachAcceleratorOnce sync.Once
+	exampleOutputAttachAccelerator     map[string]any
+
+	exampleOutputSetVMLabelsOnce sync.Once
+	exampleOutputSetVMLabels     map[string]any
+
+	exampleOutputSetDeletionProtectionOnce sync.Once
+	exampleOutputSetDeletionProtection     map[string]any
+
+	exampleOutputSetVMSchedulingOnce sync.Once
+	exampleOutputSetVMScheduling     map[string]any
+
 	exampleOutputGetVMInstanceMetricsOnce sync.Once
 	exampleOutputGetVMInstanceMetrics     map[string]any
 
@@ -101,6 +146,15 @@ var (
 	exampleOutputManageStaticIPOnce sync.Once
 	exampleOutputManageStaticIP     map[string]any
 
+	exampleOutputCreateNetworkOnce sync.Once
+	exampleOutputCreateNetwork     map[string]any
+
+	exampleOutputCreateSubnetworkOnce sync.Once
+	exampleOutputCreateSubnetwork     map[string]any
+
+	exampleOutputCreateSnapshotScheduleOnce sync.Once
+	exampleOutputCreateSnapshotSchedule     map[string]any
+
 	exampleOutputCreateLoadBalancerOnce sync.Once
 	exampleOutputCreateLoadBalancer     map[string]any
 
@@ -115,6 +169,12 @@ var (
 
 	exampleOutputDeleteFirewallRuleOnce sync.Once
 	exampleOutputDeleteFirewallRule     map[string]any
+
+	exampleOutputSnapshotVMOnce sync.Once
+	exampleOutputSnapshotVM     map[string]any
+
+	exampleOutputWaitForOperationOnce sync.Once
+	exampleOutputWaitForOperation     map[string]any
 )
 
 func (c *CreateVM) ExampleOutput() map[string]any {
@@ -133,6 +193,10 @@ func (t *OnVMInstance) ExampleData() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleDataOnVMInstanceOnce, exampleDataOnVMInstanceBytes, &exampleDataOnVMInstance)
 }
 
+func (t *OnVMStatusChanged) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleDataOnVMStatusChangedOnce, exampleDataOnVMStatusChangedBytes, &exampleDataOnVMStatusChanged)
+}
+
 func (m *ManageVMInstancePower) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputManageVMInstancePowerOnce, exampleOutputManageVMInstancePowerBytes, &exampleOutputManageVMInstancePower)
 }
@@ -141,6 +205,22 @@ func (u *UpdateVMInstanceType) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputUpdateVMInstanceTypeOnce, exampleOutputUpdateVMInstanceTypeBytes, &exampleOutputUpdateVMInstanceType)
 }
 
+func (a *AttachAccelerator) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputAttachAcceleratorOnce, exampleOutputAttachAcceleratorBytes, &exampleOutputAttachAccelerator)
+}
+
+func (s *SetVMLabels) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputSetVMLabelsOnce, exampleOutputSetVMLabelsBytes, &exampleOutputSetVMLabels)
+}
+
+func (s *SetDeletionProtection) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputSetDeletionProtectionOnce, exampleOutputSetDeletionProtectionBytes, &exampleOutputSetDeletionProtection)
+}
+
+func (s *SetVMScheduling) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputSetVMSchedulingOnce, exampleOutputSetVMSchedulingBytes, &exampleOutputSetVMScheduling)
+}
+
 func (g *GetVMInstanceMetrics) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputGetVMInstanceMetricsOnce, exampleOutputGetVMInstanceMetricsBytes, &exampleOutputGetVMInstanceMetrics)
 }
@@ -169,6 +249,18 @@ func (m *ManageStaticIP) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputManageStaticIPOnce, exampleOutputManageStaticIPBytes, &exampleOutputManageStaticIP)
 }
 
+func (c *CreateNetwork) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputCreateNetworkOnce, exampleOutputCreateNetworkBytes, &exampleOutputCreateNetwork)
+}
+
+func (c *CreateSubnetwork) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputCreateSubnetworkOnce, exampleOutputCreateSubnetworkBytes, &exampleOutputCreateSubnetwork)
+}
+
+func (c *CreateSnapshotSchedule) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputCreateSnapshotScheduleOnce, exampleOutputCreateSnapshotScheduleBytes, &exampleOutputCreateSnapshotSchedule)
+}
+
 func (c *CreateLoadBalancer) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputCreateLoadBalancerOnce, exampleOutputCreateLoadBalancerBytes, &exampleOutputCreateLoadBalancer)
 }
@@ -188,3 +280,11 @@ func (u *UpdateFirewall) ExampleOutput() map[string]any {
 func (d *DeleteFirewall) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputDeleteFirewallRuleOnce, exampleOutputDeleteFirewallRuleBytes, &exampleOutputDeleteFirewallRule)
 }
+
+func (s *SnapshotVM) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputSnapshotVMOnce, exampleOutputSnapshotVMBytes, &exampleOutputSnapshotVM)
+}
+
+func (w *WaitForOperation) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputWaitForOperationOnce, exampleOutputWaitForOperationBytes, &exampleOutputWaitForOperation)
+}
@@ -0,0 +1,107 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	compute "google.golang.org/api/compute/v1"
+)
+
+// FirewallRuleConflict reports an existing firewall rule whose settings differ
+// from the rule Create VM was asked to create under the same name.
+type FirewallRuleConflict struct {
+	Name        string   `json:"name"`
+	Differences []string `json:"differences"`
+}
+
+// ensureFirewallRule creates the rule unless one with the same name already
+// exists. An existing rule is never modified; if it differs from the desired
+// rule, the differences are returned as a conflict.
+func ensureFirewallRule(ctx context.Context, c Client, project, network string, rule CreateFirewallRuleEntry) (*FirewallRuleConflict, error) {
+	desired, err := buildFirewallFromEntry(project, network, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := GetFirewall(ctx, c, project, desired.Name)
+	if err != nil {
+		// The rule does not exist, or cannot be compared: create it as before,
+		// still treating a concurrent 409 as success.
+		return nil, insertFirewallRule(ctx, c, project, desired)
+	}
+
+	var existing firewallGetResp
+	if err := json.Unmarshal(body, &existing); err != nil {
+		return nil, fmt.Errorf("parse existing firewall rule: %w", err)
+	}
+
+	differences := diffFirewallRule(existing, desired)
+	if len(differences) == 0 {
+		return nil, nil
+	}
+	return &FirewallRuleConflict{Name: desired.Name, Differences: differences}, nil
+}
+
+// diffFirewallRule lists the settings where an existing rule differs from the
+// desired one, as human-readable "field: existing X, desired Y" entries.
+func diffFirewallRule(existing firewallGetResp, desired *compute.Firewall) []string {
+	var differences []string
+	add := func(field, existingValue, desiredValue string) {
+		if existingValue != desiredValue {
+			differences = append(differences, fmt.Sprintf("%s: existing %s, desired %s", field, existingValue, desiredValue))
+		}
+	}
+
+	add("direction", existing.Direction, desired.Direction)
+	add("network", lastSegment(existing.Network), lastSegment(desired.Network))
+	if len(existing.Denied) > 0 {
+		add("action", "DENY", "ALLOW")
+	}
+	add("allowed", formatFirewallList(existingAllowedEntries(existing.Allowed)), formatFirewallList(desiredAllowedEntries(desired.Allowed)))
+	add("sourceRanges", formatFirewallList(existing.SourceRanges), formatFirewallList(desired.SourceRanges))
+	add("targetTags", formatFirewallList(existing.TargetTags), formatFirewallList(desired.TargetTags))
+	add("targetServiceAccounts", formatFirewallList(existing.TargetServiceAccounts), formatFirewallList(desired.TargetServiceAccounts))
+	return differences
+}
+
+func existingAllowedEntries(rules []firewallRule) []string {
+	var out []string
+	for _, r := range rules {
+		out = append(out, firewallProtocolPorts(r.IPProtocol, r.Ports)...)
+	}
+	return out
+}
+
+func desiredAllowedEntries(rules []*compute.FirewallAllowed) []string {
+	var out []string
+	for _, r := range rules {
+		out = append(out, firewallProtocolPorts(r.IPProtocol, r.Ports)...)
+	}
+	return out
+}
+
+// firewallProtocolPorts flattens one allowed entry to "protocol:port" items, or
+// just "protocol" when it matches all ports.
+func firewallProtocolPorts(protocol string, ports []string) []string {
+	protocol = strings.ToLower(strings.TrimSpace(protocol))
+	if len(ports) == 0 {
+		return []string{protocol}
+	}
+	out := make([]string, 0, len(ports))
+	for _, p := range ports {
+		out = append(out, protocol+":"+strings.TrimSpace(p))
+	}
+	return out
+}
+
+// formatFirewallList renders a list order-independently, so reordered but
+// equivalent settings do not count as a difference.
+func formatFirewallList(values []string) string {
+	sorted := slices.Clone(trimList(values))
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+	return "[" + strings.Join(sorted, ", ") + "]"
+}
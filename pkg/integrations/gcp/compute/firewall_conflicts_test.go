@@ -0,0 +1,295 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func existingFirewallJSON(allowed []map[string]any, sourceRanges, targetTags []string) []byte {
+	b, _ := json.Marshal(map[string]any{
+		"name":         "allow-ssh",
+		"network":      "https://www.googleapis.com/compute/v1/projects/my-project/global/networks/default",
+		"direction":    "INGRESS",
+		"allowed":      allowed,
+		"sourceRanges": sourceRanges,
+		"targetTags":   targetTags,
+	})
+	return b
+}
+
+func firewallClient(existing []byte, posted *[]*compute.Firewall) *mockInstanceClient {
+	return &mockInstanceClient{
+		projectID: "my-project",
+		getFunc: func(ctx context.Context, path string) ([]byte, error) {
+			if path != "projects/my-project/global/firewalls/allow-ssh" || existing == nil {
+				return nil, &gcpcommon.GCPAPIError{StatusCode: http.StatusNotFound, Message: "not found"}
+			}
+			return existing, nil
+		},
+		postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+			*posted = append(*posted, body.(*compute.Firewall))
+			return []byte(`{"name":"op-fw"}`), nil
+		},
+	}
+}
+
+func Test_EnsureFirewallRules(t *testing.T) {
+	rule := CreateFirewallRuleEntry{
+		Name:         "allow-ssh",
+		Allowed:      "tcp:22",
+		SourceRanges: "10.0.0.0/8",
+		TargetTag:    "allow-ssh",
+	}
+
+	t.Run("missing rule is created", func(t *testing.T) {
+		var posted []*compute.Firewall
+		client := firewallClient(nil, &posted)
+
+		tags, conflicts, err := EnsureFirewallRules(context.Background(), client, "my-project", "", []CreateFirewallRuleEntry{rule})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"allow-ssh"}, tags)
+		assert.Empty(t, conflicts)
+		require.Len(t, posted, 1)
+		assert.Equal(t, "allow-ssh", posted[0].Name)
+		assert.Equal(t, []string{"10.0.0.0/8"}, posted[0].SourceRanges)
+	})
+
+	t.Run("existing rule with different settings is reported as a conflict", func(t *testing.T) {
+		var posted []*compute.Firewall
+		client := firewallClient(existingFirewallJSON(
+			[]map[string]any{{"IPProtocol": "tcp", "ports": []string{"22", "3389"}}},
+			[]string{"0.0.0.0/0"},
+			[]string{"allow-ssh"},
+		), &posted)
+
+		tags, conflicts, err := EnsureFirewallRules(context.Background(), client, "my-project", "", []CreateFirewallRuleEntry{rule})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"allow-ssh"}, tags)
+		assert.Empty(t, posted, "an existing rule must not be recreated or modified")
+		require.Len(t, conflicts, 1)
+		assert.Equal(t, "allow-ssh", conflicts[0].Name)
+		assert.Equal(t, []string{
+			"allowed: existing [tcp:22, tcp:3389], desired [tcp:22]",
+			"sourceRanges: existing [0.0.0.0/0], desired [10.0.0.0/8]",
+		}, conflicts[0].Differences)
+	})
+
+	t.Run("existing deny rule is reported as a conflict", func(t *testing.T) {
+		var posted []*compute.Firewall
+		existing, _ := json.Marshal(map[string]any{
+			"name":         "allow-ssh",
+			"network":      "projects/my-project/global/networks/default",
+			"direction":    "INGRESS",
+			"denied":       []map[string]any{{"IPProtocol": "tcp", "ports": []string{"22"}}},
+			"sourceRanges": []string{"10.0.0.0/8"},
+			"targetTags":   []string{"allow-ssh"},
+		})
+		client := firewallClient(existing, &posted)
+
+		_, conflicts, err := EnsureFirewallRules(context.Background(), client, "my-project", "", []CreateFirewallRuleEntry{rule})
+		require.NoError(t, err)
+		require.Len(t, conflicts, 1)
+		assert.Equal(t, []string{
+			"action: existing DENY, desired ALLOW",
+			"allowed: existing [], desired [tcp:22]",
+		}, conflicts[0].Differences)
+	})
+
+	t.Run("existing rule with the same settings in a different order is not a conflict", func(t *testing.T) {
+		var posted []*compute.Firewall
+		client := firewallClient(existingFirewallJSON(
+			[]map[string]any{{"IPProtocol": "tcp", "ports": []string{"443", "80"}}},
+			[]string{"192.168.0.0/16", "10.0.0.0/8"},
+			[]string{"web"},
+		), &posted)
+
+		webRule := CreateFirewallRuleEntry{
+			Name:         "allow-ssh",
+			Allowed:      "tcp:80,tcp:443",
+			SourceRanges: "10.0.0.0/8, 192.168.0.0/16",
+			TargetTag:    "web",
+		}
+		tags, conflicts, err := EnsureFirewallRules(context.Background(), client, "my-project", "default", []CreateFirewallRuleEntry{webRule})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"web"}, tags)
+		assert.Empty(t, conflicts)
+		assert.Empty(t, posted)
+	})
+
+	t.Run("service account targeting creates a rule without a tag", func(t *testing.T) {
+		var posted []*compute.Firewall
+		client := firewallClient(nil, &posted)
+
+		saRule := CreateFirewallRuleEntry{
+			Name:                 "allow-ssh",
+			Allowed:              "tcp:22",
+			SourceRanges:         "10.0.0.0/8",
+			TargetServiceAccount: "web@my-project.iam.gserviceaccount.com",
+		}
+		tags, conflicts, err := EnsureFirewallRules(context.Background(), client, "my-project", "", []CreateFirewallRuleEntry{saRule})
+		require.NoError(t, err)
+		assert.Empty(t, tags, "a service-account rule adds no network tag to the instance")
+		assert.Empty(t, conflicts)
+		require.Len(t, posted, 1)
+		assert.Equal(t, []string{"web@my-project.iam.gserviceaccount.com"}, posted[0].TargetServiceAccounts)
+		assert.Empty(t, posted[0].TargetTags)
+	})
+
+	t.Run("existing tag rule differs from a service account rule", func(t *testing.T) {
+		var posted []*compute.Firewall
+		client := firewallClient(existingFirewallJSON(
+			[]map[string]any{{"IPProtocol": "tcp", "ports": []string{"22"}}},
+			[]string{"10.0.0.0/8"},
+			[]string{"allow-ssh"},
+		), &posted)
+
+		saRule := CreateFirewallRuleEntry{
+			Name:                 "allow-ssh",
+			Allowed:              "tcp:22",
+			SourceRanges:         "10.0.0.0/8",
+			TargetServiceAccount: "web@my-project.iam.gserviceaccount.com",
+		}
+		_, conflicts, err := EnsureFirewallRules(context.Background(), client, "my-project", "", []CreateFirewallRuleEntry{saRule})
+		require.NoError(t, err)
+		assert.Empty(t, posted)
+		require.Len(t, conflicts, 1)
+		assert.Equal(t, []string{
+			"targetTags: existing [allow-ssh], desired []",
+			"targetServiceAccounts: existing [], desired [web@my-project.iam.gserviceaccount.com]",
+		}, conflicts[0].Differences)
+	})
+
+	t.Run("multiple rules are created concurrently and all tags are collected in order", func(t *testing.T) {
+		var mu sync.Mutex
+		var posted []string
+		inFlight, maxInFlight := 0, 0
+		client := &mockInstanceClient{
+			projectID: "my-project",
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				return nil, &gcpcommon.GCPAPIError{StatusCode: http.StatusNotFound, Message: "not found"}
+			},
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				mu.Lock()
+				inFlight++
+				maxInFlight = max(maxInFlight, inFlight)
+				mu.Unlock()
+
+				time.Sleep(10 * time.Millisecond)
+
+				mu.Lock()
+				inFlight--
+				posted = append(posted, body.(*compute.Firewall).Name)
+				mu.Unlock()
+				return []byte(`{"name":"op-fw"}`), nil
+			},
+		}
+
+		var rules []CreateFirewallRuleEntry
+		for i := range 6 {
+			rules = append(rules, CreateFirewallRuleEntry{
+				Name:         fmt.Sprintf("rule-%d", i),
+				Allowed:      "tcp:22",
+				SourceRanges: "10.0.0.0/8",
+				TargetTag:    fmt.Sprintf("tag-%d", i%4),
+			})
+		}
+
+		tags, conflicts, err := EnsureFirewallRulesWithConcurrency(context.Background(), client, "my-project", "", rules, 3)
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+		assert.Equal(t, []string{"tag-0", "tag-1", "tag-2", "tag-3"}, tags)
+		assert.ElementsMatch(t, []string{"rule-0", "rule-1", "rule-2", "rule-3", "rule-4", "rule-5"}, posted)
+		assert.LessOrEqual(t, maxInFlight, 3)
+		assert.Greater(t, maxInFlight, 1)
+	})
+
+	t.Run("errors of every failed rule are returned", func(t *testing.T) {
+		client := &mockInstanceClient{
+			projectID: "my-project",
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				return nil, &gcpcommon.GCPAPIError{StatusCode: http.StatusNotFound, Message: "not found"}
+			},
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				if body.(*compute.Firewall).Name == "allow-web" {
+					return []byte(`{"name":"op-fw"}`), nil
+				}
+				return nil, &gcpcommon.GCPAPIError{StatusCode: http.StatusForbidden, Message: "permission denied"}
+			},
+		}
+
+		rules := []CreateFirewallRuleEntry{
+			{Name: "allow-ssh", Allowed: "tcp:22", SourceRanges: "10.0.0.0/8", TargetTag: "ssh"},
+			{Name: "allow-web", Allowed: "tcp:443", SourceRanges: "0.0.0.0/0", TargetTag: "web"},
+			{Name: "allow-rdp", Allowed: "tcp:3389", SourceRanges: "10.0.0.0/8", TargetTag: "rdp"},
+		}
+
+		tags, _, err := EnsureFirewallRules(context.Background(), client, "my-project", "", rules)
+		require.Error(t, err)
+		assert.Nil(t, tags)
+		assert.Contains(t, err.Error(), `create firewall rule "allow-ssh"`)
+		assert.Contains(t, err.Error(), `create firewall rule "allow-rdp"`)
+		assert.NotContains(t, err.Error(), "allow-web")
+	})
+}
+
+func Test_validateFirewallRuleTargets(t *testing.T) {
+	rule := func(tag, serviceAccount string) CreateFirewallRuleEntry {
+		return CreateFirewallRuleEntry{
+			Name:                 "allow-ssh",
+			Allowed:              "tcp:22",
+			SourceRanges:         "10.0.0.0/8",
+			TargetTag:            tag,
+			TargetServiceAccount: serviceAccount,
+		}
+	}
+
+	t.Run("exactly one targeting mechanism is required", func(t *testing.T) {
+		err := validateFirewallRuleTargets(CreateVMConfig{NetworkingConfig: NetworkingConfig{CreateFirewallRules: []CreateFirewallRuleEntry{rule("", "")}}})
+		require.EqualError(t, err, `firewall rule "allow-ssh": targetTag or targetServiceAccount is required`)
+
+		err = validateFirewallRuleTargets(CreateVMConfig{NetworkingConfig: NetworkingConfig{CreateFirewallRules: []CreateFirewallRuleEntry{rule("ssh", "web@my-project.iam.gserviceaccount.com")}}})
+		require.EqualError(t, err, `firewall rule "allow-ssh": set either targetTag or targetServiceAccount, not both`)
+
+		require.NoError(t, validateFirewallRuleTargets(CreateVMConfig{NetworkingConfig: NetworkingConfig{CreateFirewallRules: []CreateFirewallRuleEntry{rule("ssh", "")}}}))
+	})
+
+	t.Run("target service account must be the one the instance runs as", func(t *testing.T) {
+		config := CreateVMConfig{
+			IdentityConfig:   IdentityConfig{ServiceAccount: "web@my-project.iam.gserviceaccount.com"},
+			NetworkingConfig: NetworkingConfig{CreateFirewallRules: []CreateFirewallRuleEntry{rule("", "web@my-project.iam.gserviceaccount.com")}},
+		}
+		require.NoError(t, validateFirewallRuleTargets(config))
+
+		config.ServiceAccount = "batch@my-project.iam.gserviceaccount.com"
+		require.ErrorContains(t, validateFirewallRuleTargets(config), `but the instance runs as "batch@my-project.iam.gserviceaccount.com"`)
+	})
+
+	t.Run("default service account is only targeted through its own email", func(t *testing.T) {
+		config := CreateVMConfig{NetworkingConfig: NetworkingConfig{CreateFirewallRules: []CreateFirewallRuleEntry{rule("", "123456789-compute@developer.gserviceaccount.com")}}}
+		require.NoError(t, validateFirewallRuleTargets(config))
+
+		config.CreateFirewallRules = []CreateFirewallRuleEntry{rule("", "web@my-project.iam.gserviceaccount.com")}
+		require.ErrorContains(t, validateFirewallRuleTargets(config), "the instance uses the default service account")
+	})
+
+	t.Run("Create VM validation rejects rules without a target", func(t *testing.T) {
+		msg, ok := validateCreateVMConfig(CreateVMConfig{
+			InstanceName:     "my-vm",
+			Zone:             "us-central1-a",
+			MachineType:      "e2-medium",
+			NetworkingConfig: NetworkingConfig{CreateFirewallRules: []CreateFirewallRuleEntry{rule("", "")}},
+		})
+		require.False(t, ok)
+		assert.Contains(t, msg, "targetTag or targetServiceAccount is required")
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
 )
 
 type GetVMInstance struct{}
@@ -136,6 +137,9 @@ func (g *GetVMInstance) Execute(ctx core.ExecutionContext) error {
 	}
 
 	body, err := GetInstance(context.Background(), client, project, zone, instanceName)
+	if gcpcommon.IsNotFoundError(err) {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("VM instance %q not found in zone %q of project %q", instanceName, zone, project))
+	}
 	if err != nil {
 		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get VM instance: %v", err))
 	}
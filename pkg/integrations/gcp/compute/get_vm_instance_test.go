@@ -232,7 +232,7 @@ func Test__GetVMInstance__Execute(t *testing.T) {
 		require.NoError(t, err)
 		assert.False(t, state.Passed)
 		assert.True(t, state.Finished)
-		assert.Contains(t, state.FailureMessage, "failed to get VM instance")
+		assert.Equal(t, `VM instance "my-vm" not found in zone "us-central1-a" of project "my-project"`, state.FailureMessage)
 	})
 
 	t.Run("API error (not 404) -> fails execution", func(t *testing.T) {
@@ -163,7 +163,7 @@ func WaitForGlobalOperation(ctx context.Context, client Client, project, operati
 				if msg == "" {
 					msg = op.Error.Errors[0].Code
 				}
-				return fmt.Errorf("operation failed: %s", msg)
+				return &OperationFailedError{Message: msg}
 			}
 			return nil
 		case opStatusPending, opStatusRunning:
@@ -0,0 +1,201 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	instanceStatusRunning    = "RUNNING"
+	instanceStatusTerminated = "TERMINATED"
+	instanceStatusStopped    = "STOPPED"
+
+	defaultReadinessTimeoutSeconds = 300
+	maxReadinessTimeoutSeconds     = 1800
+	readinessDialTimeout           = 5 * time.Second
+	readinessPollAction            = "pollReadiness"
+	readinessPollInterval          = 10 * time.Second
+)
+
+// Overridable in tests.
+var dialInstancePort = func(ctx context.Context, address string) error {
+	dialer := net.Dialer{Timeout: readinessDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// ReadinessConfig controls the optional wait that runs after the VM is inserted.
+type ReadinessConfig struct {
+	WaitForRunning   bool `mapstructure:"waitForRunning"`
+	ReadinessPort    int  `mapstructure:"readinessPort"`
+	ReadinessTimeout int  `mapstructure:"readinessTimeout"`
+}
+
+func (c ReadinessConfig) timeout() time.Duration {
+	if c.ReadinessTimeout <= 0 {
+		return defaultReadinessTimeoutSeconds * time.Second
+	}
+	return time.Duration(c.ReadinessTimeout) * time.Second
+}
+
+func validateReadinessConfig(c ReadinessConfig) error {
+	if !c.WaitForRunning {
+		return nil
+	}
+	if c.ReadinessPort < 0 || c.ReadinessPort > 65535 {
+		return fmt.Errorf("readiness port must be between 1 and 65535")
+	}
+	if c.ReadinessTimeout < 0 {
+		return fmt.Errorf("readiness timeout must be greater than 0")
+	}
+	if c.ReadinessTimeout > maxReadinessTimeoutSeconds {
+		return fmt.Errorf("readiness timeout must be at most %d seconds", maxReadinessTimeoutSeconds)
+	}
+	return nil
+}
+
+// ReadinessMetadata is stored on the execution while the pollReadiness hook
+// waits for the created instance to become ready.
+type ReadinessMetadata struct {
+	Zone                  string `json:"zone" mapstructure:"zone"`
+	InstanceName          string `json:"instanceName" mapstructure:"instanceName"`
+	WaitStartedAt         string `json:"waitStartedAt" mapstructure:"waitStartedAt"`
+	FirewallRuleConflicts any    `json:"firewallRuleConflicts,omitempty" mapstructure:"firewallRuleConflicts"`
+}
+
+// startReadinessWait records the created instance and schedules the first readiness poll.
+// The wait runs in pollReadiness, so Execute returns as soon as the instance is inserted.
+func startReadinessWait(ctx core.ExecutionContext, zone, name string, payload map[string]any) error {
+	metadata := ReadinessMetadata{
+		Zone:                  zone,
+		InstanceName:          name,
+		WaitStartedAt:         time.Now().Format(time.RFC3339),
+		FirewallRuleConflicts: payload["firewallRuleConflicts"],
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return err
+	}
+
+	return ctx.Requests.ScheduleActionCall(readinessPollAction, map[string]any{}, readinessPollInterval)
+}
+
+func (c *CreateVM) pollReadiness(ctx core.ActionHookContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	var config CreateVMConfig
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	var metadata ReadinessMetadata
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	startedAt, err := time.Parse(time.RFC3339, metadata.WaitStartedAt)
+	if err != nil {
+		return fmt.Errorf("failed to parse readiness wait start: %w", err)
+	}
+
+	client, err := getClient(core.ExecutionContext{HTTP: ctx.HTTP, Integration: ctx.Integration})
+	if err != nil {
+		return fmt.Errorf("failed to create GCP client: %w", err)
+	}
+
+	body, ready, err := checkInstanceReady(context.Background(), client, metadata.Zone, metadata.InstanceName, config.ReadinessConfig)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("wait for instance readiness: %v", err))
+	}
+
+	if ready {
+		payload, err := InstancePayloadFromGetResponse(body, metadata.Zone)
+		if err != nil {
+			return ctx.ExecutionState.Fail("error", err.Error())
+		}
+		if metadata.FirewallRuleConflicts != nil {
+			payload["firewallRuleConflicts"] = metadata.FirewallRuleConflicts
+		}
+		return ctx.ExecutionState.Emit(createVMOutputChannel, createVMPayloadType, []any{payload})
+	}
+
+	if time.Since(startedAt) > config.ReadinessConfig.timeout() {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("wait for instance readiness: %s", readinessTimeoutMessage(body, metadata.InstanceName, config.ReadinessConfig)))
+	}
+
+	return ctx.Requests.ScheduleActionCall(readinessPollAction, map[string]any{}, readinessPollInterval)
+}
+
+// checkInstanceReady fetches the instance once and reports whether its status is RUNNING and,
+// when a port is set, whether that port accepts TCP connections on the instance's external IP.
+// It returns the instances.get response body, and an error if the instance can no longer become ready.
+func checkInstanceReady(ctx context.Context, client Client, zone, name string, config ReadinessConfig) ([]byte, bool, error) {
+	body, err := GetInstance(ctx, client, "", zone, name)
+	if err != nil {
+		return nil, false, fmt.Errorf("fetch instance: %w", err)
+	}
+
+	var inst instanceGetResp
+	if err := json.Unmarshal(body, &inst); err != nil {
+		return nil, false, fmt.Errorf("parse instance response: %w", err)
+	}
+
+	switch inst.Status {
+	case instanceStatusRunning:
+		if config.ReadinessPort == 0 {
+			return body, true, nil
+		}
+
+		address := instanceReadinessAddress(inst, config.ReadinessPort)
+		if address == "" {
+			return nil, false, fmt.Errorf("instance %s has no public external IP to check port %d on", name, config.ReadinessPort)
+		}
+
+		return body, dialInstancePort(ctx, address) == nil, nil
+
+	case instanceStatusTerminated, instanceStatusStopped:
+		return nil, false, fmt.Errorf("instance %s stopped while waiting for it to become ready (status %s)", name, inst.Status)
+	}
+
+	return body, false, nil
+}
+
+func readinessTimeoutMessage(body []byte, name string, config ReadinessConfig) string {
+	var inst instanceGetResp
+	_ = json.Unmarshal(body, &inst)
+
+	if inst.Status != instanceStatusRunning {
+		return fmt.Sprintf("timeout waiting for instance %s to reach RUNNING (last status %s)", name, inst.Status)
+	}
+	return fmt.Sprintf("timeout waiting for port %d on instance %s to accept connections", config.ReadinessPort, name)
+}
+
+// instanceReadinessAddress returns the address of the readiness port on the instance's external IP.
+// Internal IPs are never dialed, since they can reach the network SuperPlane itself runs in.
+func instanceReadinessAddress(inst instanceGetResp, port int) string {
+	if len(inst.NetworkInterfaces) == 0 || len(inst.NetworkInterfaces[0].AccessConfigs) == 0 {
+		return ""
+	}
+
+	ip := net.ParseIP(inst.NetworkInterfaces[0].AccessConfigs[0].NatIP)
+	if ip == nil || !isPublicIP(ip) {
+		return ""
+	}
+	return net.JoinHostPort(ip.String(), strconv.Itoa(port))
+}
+
+func isPublicIP(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast()
+}
@@ -0,0 +1,179 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test_validateReadinessConfig(t *testing.T) {
+	assert.NoError(t, validateReadinessConfig(ReadinessConfig{}))
+	assert.NoError(t, validateReadinessConfig(ReadinessConfig{WaitForRunning: true, ReadinessPort: 22, ReadinessTimeout: 60}))
+	assert.ErrorContains(t, validateReadinessConfig(ReadinessConfig{WaitForRunning: true, ReadinessPort: 70000}), "readiness port")
+	assert.ErrorContains(t, validateReadinessConfig(ReadinessConfig{WaitForRunning: true, ReadinessTimeout: -1}), "readiness timeout")
+	assert.ErrorContains(t, validateReadinessConfig(ReadinessConfig{WaitForRunning: true, ReadinessTimeout: maxReadinessTimeoutSeconds + 1}), "at most 1800 seconds")
+}
+
+func Test_CreateVM_Execute_WaitForRunning(t *testing.T) {
+	SetClientFactory(func(ctx core.ExecutionContext) (Client, error) {
+		return &mockInstanceClient{
+			projectID: "my-project",
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				if isOperationPath(path) {
+					return opDone("op-1"), nil
+				}
+				return instanceGetJSON("123", "my-vm", "us-central1-a", "PROVISIONING", "e2-medium"), nil
+			},
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				return opDone("op-1"), nil
+			},
+		}, nil
+	})
+
+	state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+	metadata := &contexts.MetadataContext{}
+	requests := &contexts.RequestContext{}
+	err := (&CreateVM{}).Execute(core.ExecutionContext{
+		Configuration: map[string]any{
+			"instanceName":   "my-vm",
+			"zone":           "us-central1-a",
+			"machineType":    "e2-medium",
+			"waitForRunning": true,
+		},
+		ExecutionState: state,
+		Metadata:       metadata,
+		Requests:       requests,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, state.Finished)
+	assert.Equal(t, readinessPollAction, requests.Action)
+	assert.Equal(t, readinessPollInterval, requests.Duration)
+
+	stored, ok := metadata.Metadata.(ReadinessMetadata)
+	require.True(t, ok)
+	assert.Equal(t, "us-central1-a", stored.Zone)
+	assert.Equal(t, "my-vm", stored.InstanceName)
+	assert.NotEmpty(t, stored.WaitStartedAt)
+}
+
+func Test_CreateVM_PollReadiness(t *testing.T) {
+	pollReadiness := func(t *testing.T, status string, config map[string]any, startedAt time.Time) (*contexts.ExecutionStateContext, *contexts.RequestContext) {
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) {
+			return &mockInstanceClient{
+				projectID: "my-project",
+				getFunc: func(ctx context.Context, path string) ([]byte, error) {
+					return instanceGetJSON("123", "my-vm", "us-central1-a", status, "e2-medium"), nil
+				},
+			}, nil
+		})
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		requests := &contexts.RequestContext{}
+		err := (&CreateVM{}).HandleHook(core.ActionHookContext{
+			Name:          readinessPollAction,
+			Configuration: config,
+			Metadata: &contexts.MetadataContext{
+				Metadata: ReadinessMetadata{
+					Zone:                  "us-central1-a",
+					InstanceName:          "my-vm",
+					WaitStartedAt:         startedAt.Format(time.RFC3339),
+					FirewallRuleConflicts: []any{map[string]any{"name": "allow-ssh"}},
+				},
+			},
+			ExecutionState: state,
+			Requests:       requests,
+		})
+
+		require.NoError(t, err)
+		return state, requests
+	}
+
+	t.Run("instance not RUNNING yet -> schedules the next poll", func(t *testing.T) {
+		state, requests := pollReadiness(t, "STAGING", map[string]any{"waitForRunning": true}, time.Now())
+
+		assert.False(t, state.Finished)
+		assert.Equal(t, readinessPollAction, requests.Action)
+	})
+
+	t.Run("instance RUNNING -> emits the instance", func(t *testing.T) {
+		state, requests := pollReadiness(t, "RUNNING", map[string]any{"waitForRunning": true}, time.Now())
+
+		require.True(t, state.Passed)
+		assert.Empty(t, requests.Action)
+		assert.Equal(t, createVMPayloadType, state.Type)
+
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "RUNNING", data["status"])
+		assert.Equal(t, "my-vm", data["name"])
+		assert.Equal(t, []any{map[string]any{"name": "allow-ssh"}}, data["firewallRuleConflicts"])
+	})
+
+	t.Run("readiness port is checked on the external IP", func(t *testing.T) {
+		previous := dialInstancePort
+		t.Cleanup(func() { dialInstancePort = previous })
+
+		var dialed []string
+		dialInstancePort = func(ctx context.Context, address string) error {
+			dialed = append(dialed, address)
+			if len(dialed) < 2 {
+				return fmt.Errorf("connection refused")
+			}
+			return nil
+		}
+
+		config := map[string]any{"waitForRunning": true, "readinessPort": 22}
+		state, requests := pollReadiness(t, "RUNNING", config, time.Now())
+		assert.False(t, state.Finished)
+		assert.Equal(t, readinessPollAction, requests.Action)
+
+		state, _ = pollReadiness(t, "RUNNING", config, time.Now())
+		assert.True(t, state.Passed)
+		assert.Equal(t, []string{"34.1.2.3:22", "34.1.2.3:22"}, dialed)
+	})
+
+	t.Run("instance that stops while waiting -> fails", func(t *testing.T) {
+		state, requests := pollReadiness(t, "TERMINATED", map[string]any{"waitForRunning": true}, time.Now())
+
+		assert.True(t, state.Finished)
+		assert.False(t, state.Passed)
+		assert.Empty(t, requests.Action)
+		assert.Contains(t, state.FailureMessage, "status TERMINATED")
+	})
+
+	t.Run("readiness timeout -> fails", func(t *testing.T) {
+		config := map[string]any{"waitForRunning": true, "readinessTimeout": 60}
+		state, requests := pollReadiness(t, "STAGING", config, time.Now().Add(-2*time.Minute))
+
+		assert.True(t, state.Finished)
+		assert.False(t, state.Passed)
+		assert.Empty(t, requests.Action)
+		assert.Contains(t, state.FailureMessage, "timeout waiting for instance my-vm to reach RUNNING (last status STAGING)")
+	})
+}
+
+func Test_instanceReadinessAddress(t *testing.T) {
+	instance := func(networkIP, natIP string) instanceGetResp {
+		var inst instanceGetResp
+		ni := map[string]any{"networkIP": networkIP}
+		if natIP != "" {
+			ni["accessConfigs"] = []map[string]any{{"natIP": natIP}}
+		}
+		b, _ := json.Marshal(map[string]any{"networkInterfaces": []map[string]any{ni}})
+		require.NoError(t, json.Unmarshal(b, &inst))
+		return inst
+	}
+
+	assert.Equal(t, "34.1.2.3:22", instanceReadinessAddress(instance("10.0.0.2", "34.1.2.3"), 22))
+	assert.Empty(t, instanceReadinessAddress(instance("10.0.0.2", ""), 22))
+	assert.Empty(t, instanceReadinessAddress(instance("10.0.0.2", "10.0.0.3"), 22))
+	assert.Empty(t, instanceReadinessAddress(instance("10.0.0.2", "127.0.0.1"), 22))
+	assert.Empty(t, instanceReadinessAddress(instance("10.0.0.2", "169.254.169.254"), 22))
+}
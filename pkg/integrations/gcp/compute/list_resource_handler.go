@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
 )
 
 const cacheTTL = 24 * time.Hour
@@ -418,13 +419,16 @@ func ListMachineFamilies(ctx context.Context, c Client, zone string) ([]MachineF
 	return out, nil
 }
 
-func ListRegionResources(ctx context.Context, c Client) ([]core.IntegrationResource, error) {
+func ListRegionResources(ctx context.Context, c Client, allowedRegions []string) ([]core.IntegrationResource, error) {
 	list, err := ListRegions(ctx, c)
 	if err != nil {
 		return nil, err
 	}
 	out := make([]core.IntegrationResource, 0, len(list))
 	for _, r := range list {
+		if !gcpcommon.IsRegionAllowed(allowedRegions, r.Name) {
+			continue
+		}
 		out = append(out, core.IntegrationResource{Type: ResourceTypeRegion, Name: r.Name, ID: r.Name})
 	}
 	return out, nil
@@ -442,7 +446,9 @@ func ListZoneResources(ctx context.Context, c Client, region string) ([]core.Int
 	return out, nil
 }
 
-func ListMachineFamilyResources(ctx context.Context, c Client, zone string) ([]core.IntegrationResource, error) {
+// ListMachineFamilyResources lists the machine families in a zone, leaving out
+// families outside allowedFamilies when the integration restricts them.
+func ListMachineFamilyResources(ctx context.Context, c Client, zone string, allowedFamilies []string) ([]core.IntegrationResource, error) {
 	if strings.TrimSpace(zone) == "" {
 		return []core.IntegrationResource{}, nil
 	}
@@ -452,12 +458,19 @@ func ListMachineFamilyResources(ctx context.Context, c Client, zone string) ([]c
 	}
 	out := make([]core.IntegrationResource, 0, len(list))
 	for _, f := range list {
+		if !gcpcommon.IsMachineFamilyAllowed(allowedFamilies, f.Family) {
+			continue
+		}
 		out = append(out, core.IntegrationResource{Type: ResourceTypeMachineFamily, Name: f.Family, ID: f.Family})
 	}
 	return out, nil
 }
 
-func ListMachineTypeResources(ctx context.Context, c Client, zone, machineFamily string) ([]core.IntegrationResource, error) {
+// ListMachineTypeResources lists the machine types in a zone, optionally limited
+// to one family. Types of families outside allowedFamilies are left out. The
+// monthly estimate in each name uses Spot rates when provisioningModel is SPOT
+// and Standard rates otherwise.
+func ListMachineTypeResources(ctx context.Context, c Client, zone, machineFamily, provisioningModel string, allowedFamilies []string) ([]core.IntegrationResource, error) {
 	if strings.TrimSpace(zone) == "" {
 		return []core.IntegrationResource{}, nil
 	}
@@ -466,17 +479,24 @@ func ListMachineTypeResources(ctx context.Context, c Client, zone, machineFamily
 		return nil, err
 	}
 	machineFamily = strings.TrimSpace(machineFamily)
+	model := ProvisioningStandard
+	if strings.EqualFold(strings.TrimSpace(provisioningModel), string(ProvisioningSpot)) {
+		model = ProvisioningSpot
+	}
 	out := make([]core.IntegrationResource, 0, len(list))
 	for _, mt := range list {
 		if machineFamily != "" && mt.Family != machineFamily {
 			continue
 		}
+		if !gcpcommon.IsMachineFamilyAllowed(allowedFamilies, gcpcommon.MachineTypeFamily(mt.Name)) {
+			continue
+		}
 		summary := FormatMachineTypeSummary(&mt)
 		name := mt.Name
 		if summary != "" {
 			name = fmt.Sprintf("%s (%s)", mt.Name, summary)
 		}
-		if monthly := monthlyEstimateFromMachineType(&mt, zone, string(ProvisioningStandard)); monthly > 0 {
+		if monthly := monthlyEstimateFromMachineType(&mt, zone, string(model)); monthly > 0 {
 			name += formatMonthlyEstimate(monthly)
 		}
 		out = append(out, core.IntegrationResource{Type: ResourceTypeMachineType, Name: name, ID: mt.Name})
@@ -485,9 +505,10 @@ func ListMachineTypeResources(ctx context.Context, c Client, zone, machineFamily
 }
 
 // ListMachineTypeResourcesForInstance lists every machine type available in the
-// zone of the given instance. instanceValue is the instance path
-// (zones/<zone>/instances/<name>) or a selfLink, from which the zone is parsed.
-func ListMachineTypeResourcesForInstance(ctx context.Context, c Client, instanceValue string) ([]core.IntegrationResource, error) {
+// zone of the given instance, within allowedFamilies. instanceValue is the
+// instance path (zones/<zone>/instances/<name>) or a selfLink, from which the
+// zone is parsed.
+func ListMachineTypeResourcesForInstance(ctx context.Context, c Client, instanceValue string, allowedFamilies []string) ([]core.IntegrationResource, error) {
 	if strings.TrimSpace(instanceValue) == "" {
 		return []core.IntegrationResource{}, nil
 	}
@@ -497,7 +518,7 @@ func ListMachineTypeResourcesForInstance(ctx context.Context, c Client, instance
 		// return an empty list rather than an error so the UI stays clean.
 		return []core.IntegrationResource{}, nil
 	}
-	return ListMachineTypeResources(ctx, c, zone, "")
+	return ListMachineTypeResources(ctx, c, zone, "", "", allowedFamilies)
 }
 
 // ubuntuLTSFamilyOrder defines sort order for Ubuntu LTS families (modern first).
@@ -1440,11 +1461,82 @@ func ListInstances(ctx context.Context, c Client, project string) ([]Instance, e
 	return all, nil
 }
 
-func ListInstanceResources(ctx context.Context, c Client, project string) ([]core.IntegrationResource, error) {
+type instancesListResp struct {
+	Items         []*instanceListItem `json:"items"`
+	NextPageToken string              `json:"nextPageToken"`
+}
+
+// ListZoneInstances returns the VM instances in a single zone.
+func ListZoneInstances(ctx context.Context, c Client, project, zone string) ([]Instance, error) {
+	project = strings.TrimSpace(project)
+	if project == "" {
+		project = c.ProjectID()
+	}
+	path := fmt.Sprintf("projects/%s/zones/%s/instances", project, lastSegment(zone))
+	var all []Instance
+	var pageToken string
+	for {
+		body, err := c.Get(ctx, withPageToken(path, pageToken))
+		if err != nil {
+			return nil, err
+		}
+		var resp instancesListResp
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("parse instances response: %w", err)
+		}
+		for _, it := range resp.Items {
+			if it == nil {
+				continue
+			}
+			all = append(all, Instance{
+				Name:     it.Name,
+				Status:   it.Status,
+				Zone:     lastSegment(it.Zone),
+				SelfLink: it.SelfLink,
+			})
+		}
+		pageToken = resp.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+	return all, nil
+}
+
+// listInstancesIn lists the instances of one zone when zone is set, and
+// otherwise every instance in the project, optionally narrowed to a region.
+func listInstancesIn(ctx context.Context, c Client, project, zone, region string) ([]Instance, error) {
+	if strings.TrimSpace(zone) != "" {
+		return ListZoneInstances(ctx, c, project, zone)
+	}
 	list, err := ListInstances(ctx, c, project)
 	if err != nil {
 		return nil, err
 	}
+	region = lastSegment(strings.TrimSpace(region))
+	if region == "" {
+		return list, nil
+	}
+	filtered := make([]Instance, 0, len(list))
+	for _, inst := range list {
+		if zoneToRegion(inst.Zone) == region {
+			filtered = append(filtered, inst)
+		}
+	}
+	return filtered, nil
+}
+
+func ListInstanceResources(ctx context.Context, c Client, project, zone, region string) ([]core.IntegrationResource, error) {
+	list, err := listInstancesIn(ctx, c, project, zone, region)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(list, func(a, b Instance) int {
+		if c := strings.Compare(a.Zone, b.Zone); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
 	out := make([]core.IntegrationResource, 0, len(list))
 	for _, inst := range list {
 		label := inst.Name
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -89,7 +90,7 @@ func Test_ListMachineTypeResourcesForInstance(t *testing.T) {
 		mc := &mockOSClient{projectID: "p1", get: func(ctx context.Context, path string) ([]byte, error) {
 			return nil, errors.New("API should not be called")
 		}}
-		out, err := ListMachineTypeResourcesForInstance(context.Background(), mc, "")
+		out, err := ListMachineTypeResourcesForInstance(context.Background(), mc, "", nil)
 		assert.NoError(t, err)
 		assert.Empty(t, out)
 	})
@@ -98,7 +99,7 @@ func Test_ListMachineTypeResourcesForInstance(t *testing.T) {
 		mc := &mockOSClient{projectID: "p1", get: func(ctx context.Context, path string) ([]byte, error) {
 			return nil, errors.New("API should not be called")
 		}}
-		out, err := ListMachineTypeResourcesForInstance(context.Background(), mc, "just-a-name")
+		out, err := ListMachineTypeResourcesForInstance(context.Background(), mc, "just-a-name", nil)
 		assert.NoError(t, err)
 		assert.Empty(t, out)
 	})
@@ -110,7 +111,7 @@ func Test_ListMachineTypeResourcesForInstance(t *testing.T) {
 			return []byte(`{"items":[{"name":"n2-standard-4","guestCpus":4,"memoryMb":16384}]}`), nil
 		}}
 		out, err := ListMachineTypeResourcesForInstance(context.Background(), mc,
-			"zones/lmtfi-zone-a/instances/my-vm")
+			"zones/lmtfi-zone-a/instances/my-vm", nil)
 		require.NoError(t, err)
 		assert.Contains(t, requestedPath, "projects/lmtfi-proj/zones/lmtfi-zone-a/machineTypes")
 		require.Len(t, out, 1)
@@ -118,6 +119,58 @@ func Test_ListMachineTypeResourcesForInstance(t *testing.T) {
 	})
 }
 
+func Test_ListMachineTypeResources(t *testing.T) {
+	body := []byte(`{"items":[{"name":"n2-standard-4","guestCpus":4,"memoryMb":16384}]}`)
+	mc := &mockOSClient{projectID: "lmtr-proj", get: func(ctx context.Context, path string) ([]byte, error) {
+		return body, nil
+	}}
+	mt := &MachineType{Name: "n2-standard-4", GuestCPUs: 4, MemoryMB: 16384}
+
+	t.Run("spot estimate is lower than standard for the same type", func(t *testing.T) {
+		standard := monthlyEstimateFromMachineType(mt, "us-central1-a", string(ProvisioningStandard))
+		spot := monthlyEstimateFromMachineType(mt, "us-central1-a", string(ProvisioningSpot))
+		require.Greater(t, standard, 0.0)
+		assert.Less(t, spot, standard)
+
+		out, err := ListMachineTypeResources(context.Background(), mc, "us-central1-a", "", string(ProvisioningSpot), nil)
+		require.NoError(t, err)
+		require.Len(t, out, 1)
+		assert.Contains(t, out[0].Name, formatMonthlyEstimate(spot))
+	})
+
+	t.Run("families outside the allowlist are filtered out", func(t *testing.T) {
+		mc := &mockOSClient{projectID: "lmtr-allowlist-proj", get: func(ctx context.Context, path string) ([]byte, error) {
+			return []byte(`{"items":[
+				{"name":"e2-medium","guestCpus":2,"memoryMb":4096},
+				{"name":"a2-highgpu-1g","guestCpus":12,"memoryMb":87040},
+				{"name":"a3-highgpu-8g","guestCpus":208,"memoryMb":1901568}
+			]}`), nil
+		}}
+
+		out, err := ListMachineTypeResources(context.Background(), mc, "us-east1-b", "", "", []string{"e2", "n2"})
+		require.NoError(t, err)
+		require.Len(t, out, 1)
+		assert.Equal(t, "e2-medium", out[0].ID)
+
+		families, err := ListMachineFamilyResources(context.Background(), mc, "us-east1-b", []string{"e2", "n2"})
+		require.NoError(t, err)
+		require.Len(t, families, 1)
+		assert.Equal(t, "E2", families[0].ID)
+
+		out, err = ListMachineTypeResources(context.Background(), mc, "us-east1-b", "", "", nil)
+		require.NoError(t, err)
+		assert.Len(t, out, 3)
+	})
+
+	t.Run("unknown provisioning model defaults to standard", func(t *testing.T) {
+		standard := monthlyEstimateFromMachineType(mt, "us-central1-a", string(ProvisioningStandard))
+		out, err := ListMachineTypeResources(context.Background(), mc, "us-central1-a", "", "", nil)
+		require.NoError(t, err)
+		require.Len(t, out, 1)
+		assert.Contains(t, out[0].Name, formatMonthlyEstimate(standard))
+	})
+}
+
 func Test_isPublicImageProject(t *testing.T) {
 	assert.True(t, isPublicImageProject("debian-cloud"))
 	assert.True(t, isPublicImageProject("ubuntu-os-cloud"))
@@ -500,3 +553,114 @@ func Test_isAllowedBootDiskType(t *testing.T) {
 	assert.False(t, isAllowedBootDiskType("local-ssd"))
 	assert.False(t, isAllowedBootDiskType(""))
 }
+
+func Test_ListRegionResources(t *testing.T) {
+	ctx := context.Background()
+	body := []byte(`{"items":[{"name":"us-central1"},{"name":"europe-west1"},{"name":"asia-east1"}]}`)
+	newClient := func(projectID string) *mockInstanceClient {
+		return &mockInstanceClient{
+			projectID: projectID,
+			getFunc: func(_ context.Context, path string) ([]byte, error) {
+				return body, nil
+			},
+		}
+	}
+
+	t.Run("empty allowlist returns all regions", func(t *testing.T) {
+		resources, err := ListRegionResources(ctx, newClient("regions-all-project"), nil)
+		require.NoError(t, err)
+		require.Len(t, resources, 3)
+	})
+
+	t.Run("denied regions are filtered out", func(t *testing.T) {
+		resources, err := ListRegionResources(ctx, newClient("regions-allowlist-project"), []string{"us-central1", "europe-west1"})
+		require.NoError(t, err)
+		require.Len(t, resources, 2)
+		assert.Equal(t, "us-central1", resources[0].ID)
+		assert.Equal(t, "europe-west1", resources[1].ID)
+	})
+}
+
+func Test_ListInstanceResources(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("zone lists instances of that zone only", func(t *testing.T) {
+		var paths []string
+		mc := &mockInstanceClient{
+			projectID: "instances-zone-project",
+			getFunc: func(_ context.Context, path string) ([]byte, error) {
+				paths = append(paths, path)
+				if !strings.Contains(path, "pageToken=") {
+					return []byte(`{"items":[{"name":"web-2","status":"RUNNING","zone":"https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a"}],"nextPageToken":"next"}`), nil
+				}
+				return []byte(`{"items":[{"name":"web-1","status":"TERMINATED","zone":"https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a"}]}`), nil
+			},
+		}
+
+		out, err := ListInstanceResources(ctx, mc, "", "us-central1-a", "")
+		require.NoError(t, err)
+		require.Len(t, paths, 2)
+		assert.True(t, strings.HasPrefix(paths[0], "projects/instances-zone-project/zones/us-central1-a/instances"))
+		assert.Contains(t, paths[1], "pageToken=next")
+
+		require.Len(t, out, 2)
+		assert.Equal(t, ResourceTypeInstance, out[0].Type)
+		assert.Equal(t, "zones/us-central1-a/instances/web-1", out[0].ID)
+		assert.Equal(t, "web-1 (us-central1-a, TERMINATED)", out[0].Name)
+		assert.Equal(t, "zones/us-central1-a/instances/web-2", out[1].ID)
+	})
+
+	t.Run("region filters the aggregated list to its zones", func(t *testing.T) {
+		var requestedPath string
+		mc := &mockInstanceClient{
+			projectID: "instances-region-project",
+			getFunc: func(_ context.Context, path string) ([]byte, error) {
+				requestedPath = path
+				return []byte(`{"items":{
+					"zones/us-central1-a":{"instances":[{"name":"api","status":"RUNNING","zone":"zones/us-central1-a"}]},
+					"zones/us-central1-b":{"instances":[{"name":"db","status":"RUNNING","zone":"zones/us-central1-b"}]},
+					"zones/europe-west1-b":{"instances":[{"name":"eu-api","status":"RUNNING","zone":"zones/europe-west1-b"}]},
+					"zones/us-east1-b":{"warning":{"code":"NO_RESULTS_ON_PAGE"}}
+				}}`), nil
+			},
+		}
+
+		out, err := ListInstanceResources(ctx, mc, "", "", "us-central1")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(requestedPath, "projects/instances-region-project/aggregated/instances"))
+		require.Len(t, out, 2)
+		assert.Equal(t, "zones/us-central1-a/instances/api", out[0].ID)
+		assert.Equal(t, "zones/us-central1-b/instances/db", out[1].ID)
+	})
+
+	t.Run("no zone or region lists every instance", func(t *testing.T) {
+		mc := &mockInstanceClient{
+			projectID: "instances-all-project",
+			getFunc: func(_ context.Context, path string) ([]byte, error) {
+				return []byte(`{"items":{
+					"zones/us-central1-a":{"instances":[{"name":"api","zone":"zones/us-central1-a"}]},
+					"zones/europe-west1-b":{"instances":[{"name":"eu-api","zone":"zones/europe-west1-b"}]}
+				}}`), nil
+			},
+		}
+
+		out, err := ListInstanceResources(ctx, mc, "", "", "")
+		require.NoError(t, err)
+		require.Len(t, out, 2)
+		assert.Equal(t, "zones/europe-west1-b/instances/eu-api", out[0].ID)
+		assert.Equal(t, "eu-api (europe-west1-b)", out[0].Name)
+		assert.Equal(t, "zones/us-central1-a/instances/api", out[1].ID)
+	})
+
+	t.Run("API error is returned", func(t *testing.T) {
+		mc := &mockInstanceClient{
+			projectID: "instances-error-project",
+			getFunc: func(_ context.Context, path string) ([]byte, error) {
+				return nil, errors.New("permission denied")
+			},
+		}
+
+		_, err := ListInstanceResources(ctx, mc, "", "us-central1-a", "")
+		require.ErrorContains(t, err, "permission denied")
+	})
+}
@@ -91,6 +91,12 @@ func (t *OnVMInstance) Configuration() []configuration.Field {
 }
 
 func (t *OnVMInstance) Setup(ctx core.TriggerContext) error {
+	return setupLoggingSink(ctx, subscriptionPattern())
+}
+
+// setupLoggingSink subscribes the trigger to compute audit log events matching
+// the pattern and schedules provisioning of its Cloud Logging sink.
+func setupLoggingSink(ctx core.TriggerContext, pattern map[string]any) error {
 	if ctx.Integration == nil {
 		return fmt.Errorf("connect the GCP integration to this trigger to enable automatic event routing")
 	}
@@ -104,7 +110,7 @@ func (t *OnVMInstance) Setup(ctx core.TriggerContext) error {
 		return nil
 	}
 
-	subscriptionID, err := ctx.Integration.Subscribe(subscriptionPattern())
+	subscriptionID, err := ctx.Integration.Subscribe(pattern)
 	if err != nil {
 		return fmt.Errorf("failed to subscribe: %w", err)
 	}
@@ -134,10 +140,10 @@ func (t *OnVMInstance) HandleHook(ctx core.TriggerHookContext) (map[string]any,
 		return nil, fmt.Errorf("unknown hook: %s", ctx.Name)
 	}
 
-	return t.provisionSink(ctx)
+	return provisionLoggingSink(ctx, SinkFilter)
 }
 
-func (t *OnVMInstance) provisionSink(ctx core.TriggerHookContext) (map[string]any, error) {
+func provisionLoggingSink(ctx core.TriggerHookContext, filter string) (map[string]any, error) {
 	meta, err := integrationMetadata(ctx.Integration)
 	if err != nil {
 		return nil, err
@@ -160,7 +166,7 @@ func (t *OnVMInstance) provisionSink(ctx core.TriggerHookContext) (map[string]an
 	projectID := client.ProjectID()
 	reqCtx := context.Background()
 
-	writerIdentity, err := gcppubsub.CreateSink(reqCtx, client, projectID, sinkID, meta.PubSubTopic, SinkFilter)
+	writerIdentity, err := gcppubsub.CreateSink(reqCtx, client, projectID, sinkID, meta.PubSubTopic, filter)
 	if err != nil {
 		if !gcpcommon.IsAlreadyExistsError(err) {
 			return nil, fmt.Errorf("create logging sink: %w", err)
@@ -203,6 +209,10 @@ func (t *OnVMInstance) OnIntegrationMessage(ctx core.IntegrationMessageContext)
 }
 
 func (t *OnVMInstance) Cleanup(ctx core.TriggerContext) error {
+	return deleteLoggingSink(ctx)
+}
+
+func deleteLoggingSink(ctx core.TriggerContext) error {
 	var metadata OnVMInstanceMetadata
 	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil || metadata.SinkID == "" {
 		return nil
@@ -0,0 +1,297 @@
+package compute
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	VMStatusChangedEventType    = "gcp.compute.vmStatusChanged"
+	VMPreemptionNoticeEventType = "gcp.compute.vmPreemptionNotice"
+
+	// preemptionNoticeSeconds is how long Compute Engine waits between the
+	// preemption notice and stopping a Spot VM.
+	preemptionNoticeSeconds = 30
+	preemptedMethodName     = "compute.instances.preempted"
+
+	instanceStatusSuspended = "SUSPENDED"
+	instanceStatusRepairing = "REPAIRING"
+
+	// StatusChangeSinkFilter is the Cloud Logging advanced log filter for the
+	// audit events (API calls and system events) that move a VM between statuses.
+	StatusChangeSinkFilter = `protoPayload.serviceName="compute.googleapis.com" AND (` +
+		`protoPayload.methodName:"compute.instances.stop" OR ` +
+		`protoPayload.methodName:"compute.instances.start" OR ` +
+		`protoPayload.methodName:"compute.instances.suspend" OR ` +
+		`protoPayload.methodName:"compute.instances.resume" OR ` +
+		`protoPayload.methodName:"compute.instances.guestTerminate" OR ` +
+		`protoPayload.methodName:"compute.instances.preempted" OR ` +
+		`protoPayload.methodName:"compute.instances.hostError" OR ` +
+		`protoPayload.methodName:"compute.instances.automaticRestart")`
+)
+
+type vmStatusTransition struct {
+	From string
+	To   string
+}
+
+// vmStatusTransitions maps a compute audit log method, without its API version
+// prefix, to the status change it records.
+var vmStatusTransitions = map[string]vmStatusTransition{
+	"compute.instances.stop":                   {From: instanceStatusRunning, To: instanceStatusTerminated},
+	"compute.instances.start":                  {From: instanceStatusTerminated, To: instanceStatusRunning},
+	"compute.instances.startWithEncryptionKey": {From: instanceStatusTerminated, To: instanceStatusRunning},
+	"compute.instances.suspend":                {From: instanceStatusRunning, To: instanceStatusSuspended},
+	"compute.instances.resume":                 {From: instanceStatusSuspended, To: instanceStatusRunning},
+	"compute.instances.guestTerminate":         {From: instanceStatusRunning, To: instanceStatusTerminated},
+	"compute.instances.preempted":              {From: instanceStatusRunning, To: instanceStatusTerminated},
+	"compute.instances.hostError":              {From: instanceStatusRunning, To: instanceStatusRepairing},
+	"compute.instances.automaticRestart":       {From: instanceStatusRepairing, To: instanceStatusRunning},
+}
+
+type OnVMStatusChanged struct{}
+
+type OnVMStatusChangedConfiguration struct {
+	Statuses          []string `json:"statuses" mapstructure:"statuses"`
+	PreemptionNotices bool     `json:"preemptionNotices" mapstructure:"preemptionNotices"`
+}
+
+func (t *OnVMStatusChanged) Name() string {
+	return "gcp.compute.onVMStatusChanged"
+}
+
+func (t *OnVMStatusChanged) Label() string {
+	return "Compute • On VM Status Changed"
+}
+
+func (t *OnVMStatusChanged) Description() string {
+	return "Listen to GCP Compute Engine VM instance status changes"
+}
+
+func (t *OnVMStatusChanged) Documentation() string {
+	return `The On VM Status Changed trigger starts a workflow execution when a Compute Engine VM instance moves to a new status, for example when it is stopped, preempted or being repaired.
+
+**Trigger behavior:** SuperPlane creates a Cloud Logging sink that captures Compute Engine audit log events for stop, start, suspend and resume calls, as well as system events such as guest shutdowns, preemptions, host errors and automatic restarts. Events are pushed to SuperPlane and matched to this trigger automatically. Long-running operations emit once, when the operation completes.
+
+## Use Cases
+
+- **Failure response**: Page on-call or recreate workloads when a VM is preempted or hits a host error
+- **Cost tracking**: Record when VMs are stopped or started
+- **Recovery automation**: Run checks once a VM is back to RUNNING after a repair
+
+## Configuration
+
+- **Statuses**: Only emit when the VM moves to one of these statuses. Leave empty to emit on every status change.
+- **Preemption Notices**: Also emit a ` + "`gcp.compute.vmPreemptionNotice`" + ` event as soon as a Spot VM is told it will be preempted, about 30 seconds before it stops. Use it to drain or checkpoint work. Only Spot (and legacy preemptible) VMs are preempted, so other VMs never emit it.
+
+## Setup
+
+**Required GCP setup:** Ensure the **Pub/Sub** API is enabled in your project and the integration's service account has ` + "`roles/logging.configWriter`" + ` and ` + "`roles/pubsub.admin`" + ` permissions.
+
+SuperPlane automatically creates a Cloud Logging sink to capture VM status change events.
+
+## Event Data
+
+Each event includes the instance name, zone, project, resourceName, previousStatus and status (e.g. RUNNING → TERMINATED), the audit log methodName that caused the change, and the full log entry data.
+
+Preemption notices include the same instance fields, status ` + "`RUNNING`" + `, and noticePeriodSeconds, the time left before the VM stops.`
+}
+
+func (t *OnVMStatusChanged) Icon() string {
+	return "gcp"
+}
+
+func (t *OnVMStatusChanged) Color() string {
+	return "gray"
+}
+
+func (t *OnVMStatusChanged) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "statuses",
+			Label:       "Statuses",
+			Type:        configuration.FieldTypeMultiSelect,
+			Required:    false,
+			Description: "Only emit when the VM moves to one of these statuses. Leave empty to emit on every status change.",
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Running", Value: instanceStatusRunning},
+						{Label: "Terminated", Value: instanceStatusTerminated},
+						{Label: "Suspended", Value: instanceStatusSuspended},
+						{Label: "Repairing", Value: instanceStatusRepairing},
+					},
+				},
+			},
+		},
+		{
+			Name:        "preemptionNotices",
+			Label:       "Preemption Notices",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Also emit an event when a Spot VM is about to be preempted, so work can be drained before it stops.",
+		},
+	}
+}
+
+func (t *OnVMStatusChanged) Setup(ctx core.TriggerContext) error {
+	return setupLoggingSink(ctx, statusChangeSubscriptionPattern())
+}
+
+func (t *OnVMStatusChanged) Hooks() []core.Hook {
+	return []core.Hook{
+		{Name: "provisionSink", Type: core.HookTypeInternal},
+	}
+}
+
+func (t *OnVMStatusChanged) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	if ctx.Name != "provisionSink" {
+		return nil, fmt.Errorf("unknown hook: %s", ctx.Name)
+	}
+
+	return provisionLoggingSink(ctx, StatusChangeSinkFilter)
+}
+
+func (t *OnVMStatusChanged) OnIntegrationMessage(ctx core.IntegrationMessageContext) error {
+	config := OnVMStatusChangedConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	var event struct {
+		ServiceName  string `mapstructure:"serviceName"`
+		MethodName   string `mapstructure:"methodName"`
+		ResourceName string `mapstructure:"resourceName"`
+		Timestamp    string `mapstructure:"timestamp"`
+		Data         any    `mapstructure:"data"`
+	}
+	if err := mapstructure.Decode(ctx.Message, &event); err != nil {
+		return fmt.Errorf("failed to decode event: %w", err)
+	}
+
+	if event.ServiceName != computeServiceName {
+		return nil
+	}
+
+	transition, ok := vmStatusTransitionFor(event.MethodName)
+	if !ok {
+		return nil
+	}
+
+	if operationInProgress(event.Data) {
+		if config.PreemptionNotices && isPreemptionNotice(event.MethodName) {
+			return emitPreemptionNotice(ctx, event.MethodName, event.ResourceName, event.Timestamp, event.Data)
+		}
+		return nil
+	}
+
+	if len(config.Statuses) > 0 && !slices.Contains(config.Statuses, transition.To) {
+		ctx.Logger.Infof("Skipping VM status change to %s, expected %s", transition.To, config.Statuses)
+		return nil
+	}
+
+	project, zone, name, err := parseInstancePath(event.ResourceName)
+	if err != nil {
+		return fmt.Errorf("failed to parse instance from event: %w", err)
+	}
+
+	return ctx.Events.Emit(VMStatusChangedEventType, map[string]any{
+		"instanceName":   name,
+		"zone":           zone,
+		"projectId":      project,
+		"resourceName":   event.ResourceName,
+		"previousStatus": transition.From,
+		"status":         transition.To,
+		"methodName":     event.MethodName,
+		"timestamp":      event.Timestamp,
+		"data":           event.Data,
+	})
+}
+
+// emitPreemptionNotice emits the drain event for a Spot VM whose preemption
+// has started. The VM is still RUNNING and stops once the notice period ends.
+func emitPreemptionNotice(ctx core.IntegrationMessageContext, methodName, resourceName, timestamp string, data any) error {
+	project, zone, name, err := parseInstancePath(resourceName)
+	if err != nil {
+		return fmt.Errorf("failed to parse instance from event: %w", err)
+	}
+
+	return ctx.Events.Emit(VMPreemptionNoticeEventType, map[string]any{
+		"instanceName":        name,
+		"zone":                zone,
+		"projectId":           project,
+		"resourceName":        resourceName,
+		"status":              instanceStatusRunning,
+		"noticePeriodSeconds": preemptionNoticeSeconds,
+		"methodName":          methodName,
+		"timestamp":           timestamp,
+		"data":                data,
+	})
+}
+
+func (t *OnVMStatusChanged) Cleanup(ctx core.TriggerContext) error {
+	return deleteLoggingSink(ctx)
+}
+
+func (t *OnVMStatusChanged) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func statusChangeSubscriptionPattern() map[string]any {
+	return map[string]any{
+		"serviceName": computeServiceName,
+	}
+}
+
+// vmStatusTransitionFor extracts the status change recorded by an audit log
+// method, e.g. v1.compute.instances.stop is RUNNING → TERMINATED.
+func vmStatusTransitionFor(methodName string) (vmStatusTransition, bool) {
+	transition, ok := vmStatusTransitions[unversionedMethodName(methodName)]
+	if !ok || transition.From == transition.To {
+		return vmStatusTransition{}, false
+	}
+
+	return transition, true
+}
+
+// isPreemptionNotice reports whether the audit log method is the preemption of
+// a Spot VM. Its first log entry is the termination notice: the VM keeps
+// running for the notice period before it is stopped.
+func isPreemptionNotice(methodName string) bool {
+	return unversionedMethodName(methodName) == preemptedMethodName
+}
+
+// unversionedMethodName strips the API version prefix of an audit log method,
+// e.g. v1.compute.instances.stop is compute.instances.stop.
+func unversionedMethodName(methodName string) string {
+	method := strings.TrimSpace(methodName)
+	for _, prefix := range []string{"v1.", "beta."} {
+		method = strings.TrimPrefix(method, prefix)
+	}
+
+	return method
+}
+
+// operationInProgress reports whether the log entry opens a long-running
+// operation that has not completed yet. The VM only reaches its new status
+// once the operation's last entry is logged.
+func operationInProgress(data any) bool {
+	var entry struct {
+		Operation struct {
+			First bool `mapstructure:"first"`
+			Last  bool `mapstructure:"last"`
+		} `mapstructure:"operation"`
+	}
+	if err := mapstructure.Decode(data, &entry); err != nil {
+		return false
+	}
+
+	return entry.Operation.First && !entry.Operation.Last
+}
@@ -0,0 +1,231 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test_OnVMStatusChanged_StatusChangeSinkFilter(t *testing.T) {
+	assert.Contains(t, StatusChangeSinkFilter, "compute.googleapis.com")
+	assert.Contains(t, StatusChangeSinkFilter, "compute.instances.stop")
+	assert.Contains(t, StatusChangeSinkFilter, "compute.instances.preempted")
+	assert.Contains(t, StatusChangeSinkFilter, "compute.instances.hostError")
+}
+
+func Test_OnVMStatusChanged_OnIntegrationMessage(t *testing.T) {
+	trigger := &OnVMStatusChanged{}
+	logger := logrus.NewEntry(logrus.New())
+
+	t.Run("RUNNING -> TERMINATED transition emits", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+			Message: map[string]any{
+				"serviceName":  computeServiceName,
+				"methodName":   "v1.compute.instances.stop",
+				"resourceName": "projects/my-proj/zones/us-central1-a/instances/my-vm",
+				"timestamp":    "2025-02-14T12:00:00Z",
+				"data": map[string]any{
+					"operation": map[string]any{"id": "op-1", "last": true},
+				},
+			},
+			Logger: logger,
+			Events: events,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, VMStatusChangedEventType, events.Payloads[0].Type)
+
+		payload, ok := events.Payloads[0].Data.(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, "RUNNING", payload["previousStatus"])
+		assert.Equal(t, "TERMINATED", payload["status"])
+		assert.Equal(t, "my-vm", payload["instanceName"])
+		assert.Equal(t, "us-central1-a", payload["zone"])
+		assert.Equal(t, "my-proj", payload["projectId"])
+	})
+
+	t.Run("system event without operation emits", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+			Message: map[string]any{
+				"serviceName":  computeServiceName,
+				"methodName":   "compute.instances.hostError",
+				"resourceName": "projects/p/zones/z/instances/vm1",
+			},
+			Logger: logger,
+			Events: events,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, events.Count())
+
+		payload := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, "RUNNING", payload["previousStatus"])
+		assert.Equal(t, "REPAIRING", payload["status"])
+	})
+
+	t.Run("operation still in progress does not emit", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+			Message: map[string]any{
+				"serviceName":  computeServiceName,
+				"methodName":   "v1.compute.instances.stop",
+				"resourceName": "projects/p/zones/z/instances/vm1",
+				"data": map[string]any{
+					"operation": map[string]any{"id": "op-1", "first": true},
+				},
+			},
+			Logger: logger,
+			Events: events,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 0, events.Count())
+	})
+
+	t.Run("event that does not change status does not emit", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+			Message: map[string]any{
+				"serviceName":  computeServiceName,
+				"methodName":   "v1.compute.instances.setLabels",
+				"resourceName": "projects/p/zones/z/instances/vm1",
+			},
+			Logger: logger,
+			Events: events,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 0, events.Count())
+	})
+
+	t.Run("wrong service name does not emit", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+			Message: map[string]any{
+				"serviceName":  "storage.googleapis.com",
+				"methodName":   "v1.compute.instances.stop",
+				"resourceName": "projects/p/zones/z/instances/vm1",
+			},
+			Logger: logger,
+			Events: events,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 0, events.Count())
+	})
+
+	t.Run("status outside the filter does not emit", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		message := map[string]any{
+			"serviceName":  computeServiceName,
+			"methodName":   "v1.compute.instances.start",
+			"resourceName": "projects/p/zones/z/instances/vm1",
+		}
+
+		err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+			Message:       message,
+			Configuration: map[string]any{"statuses": []string{"TERMINATED", "REPAIRING"}},
+			Logger:        logger,
+			Events:        events,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 0, events.Count())
+
+		err = trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+			Message:       message,
+			Configuration: map[string]any{"statuses": []string{"RUNNING"}},
+			Logger:        logger,
+			Events:        events,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, events.Count())
+	})
+
+	t.Run("preemption notice emits the drain event", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+			Message: map[string]any{
+				"serviceName":  computeServiceName,
+				"methodName":   "compute.instances.preempted",
+				"resourceName": "projects/my-proj/zones/us-central1-a/instances/spot-vm",
+				"timestamp":    "2025-02-14T12:00:00Z",
+				"data": map[string]any{
+					"operation": map[string]any{"id": "op-1", "first": true},
+				},
+			},
+			Configuration: map[string]any{"preemptionNotices": true},
+			Logger:        logger,
+			Events:        events,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, VMPreemptionNoticeEventType, events.Payloads[0].Type)
+
+		payload := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, "spot-vm", payload["instanceName"])
+		assert.Equal(t, "us-central1-a", payload["zone"])
+		assert.Equal(t, "my-proj", payload["projectId"])
+		assert.Equal(t, "RUNNING", payload["status"])
+		assert.Equal(t, preemptionNoticeSeconds, payload["noticePeriodSeconds"])
+	})
+
+	t.Run("preemption notice does not emit when not enabled", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+			Message: map[string]any{
+				"serviceName":  computeServiceName,
+				"methodName":   "compute.instances.preempted",
+				"resourceName": "projects/p/zones/z/instances/spot-vm",
+				"data": map[string]any{
+					"operation": map[string]any{"id": "op-1", "first": true},
+				},
+			},
+			Logger: logger,
+			Events: events,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 0, events.Count())
+	})
+
+	t.Run("completed preemption emits the status change, not a notice", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+			Message: map[string]any{
+				"serviceName":  computeServiceName,
+				"methodName":   "compute.instances.preempted",
+				"resourceName": "projects/p/zones/z/instances/spot-vm",
+				"data": map[string]any{
+					"operation": map[string]any{"id": "op-1", "last": true},
+				},
+			},
+			Configuration: map[string]any{"preemptionNotices": true},
+			Logger:        logger,
+			Events:        events,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, VMStatusChangedEventType, events.Payloads[0].Type)
+		assert.Equal(t, "TERMINATED", events.Payloads[0].Data.(map[string]any)["status"])
+	})
+
+	t.Run("other operations in progress do not emit a notice", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+			Message: map[string]any{
+				"serviceName":  computeServiceName,
+				"methodName":   "v1.compute.instances.stop",
+				"resourceName": "projects/p/zones/z/instances/vm1",
+				"data": map[string]any{
+					"operation": map[string]any{"id": "op-1", "first": true},
+				},
+			},
+			Configuration: map[string]any{"preemptionNotices": true},
+			Logger:        logger,
+			Events:        events,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 0, events.Count())
+	})
+}
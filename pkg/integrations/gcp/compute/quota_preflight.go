@@ -0,0 +1,160 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	quotaMetricCPUs            = "CPUS"
+	quotaMetricInUseAddresses  = "IN_USE_ADDRESSES"
+	quotaMetricDisksTotalGB    = "DISKS_TOTAL_GB"
+	quotaMetricSSDTotalGB      = "SSD_TOTAL_GB"
+	quotaMetricLocalSSDTotalGB = "LOCAL_SSD_TOTAL_GB"
+
+	// defaultBootDiskSizeGb is what GCP uses for a boot disk without an explicit size.
+	defaultBootDiskSizeGb = 10
+	localSSDSizeGb        = 375
+)
+
+// QuotaPreflightConfig enables checking regional quotas before inserting the VM.
+// It is off by default because it costs extra API calls.
+type QuotaPreflightConfig struct {
+	CheckQuota bool `mapstructure:"checkQuota"`
+}
+
+type regionQuota struct {
+	Metric string  `json:"metric"`
+	Limit  float64 `json:"limit"`
+	Usage  float64 `json:"usage"`
+}
+
+type regionQuotasResp struct {
+	Quotas []regionQuota `json:"quotas"`
+}
+
+// GetRegionQuotas returns the project's compute quotas for the region, keyed by metric.
+func GetRegionQuotas(ctx context.Context, c Client, project, region string) (map[string]regionQuota, error) {
+	body, err := c.Get(ctx, fmt.Sprintf("projects/%s/regions/%s", project, region))
+	if err != nil {
+		return nil, err
+	}
+	var resp regionQuotasResp
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parse region response: %w", err)
+	}
+	quotas := make(map[string]regionQuota, len(resp.Quotas))
+	for _, q := range resp.Quotas {
+		quotas[q.Metric] = q
+	}
+	return quotas, nil
+}
+
+// CheckVMQuota compares what the VM will consume against the region's remaining quota
+// and fails with the first metric it would exceed.
+func CheckVMQuota(ctx context.Context, c Client, project, zone, region string, config CreateVMConfig) error {
+	quotas, err := GetRegionQuotas(ctx, c, project, region)
+	if err != nil {
+		return fmt.Errorf("quota preflight: get region quotas: %w", err)
+	}
+
+	demand, err := vmQuotaDemand(ctx, c, zone, config, quotas)
+	if err != nil {
+		return fmt.Errorf("quota preflight: %w", err)
+	}
+
+	metrics := make([]string, 0, len(demand))
+	for metric := range demand {
+		metrics = append(metrics, metric)
+	}
+	sort.Strings(metrics)
+
+	for _, metric := range metrics {
+		quota, ok := quotas[metric]
+		if !ok {
+			continue
+		}
+		available := quota.Limit - quota.Usage
+		if demand[metric] > available {
+			return fmt.Errorf(
+				"would exceed %s quota in region %s: VM needs %s, %s of %s available",
+				metric, region, formatQuota(demand[metric]), formatQuota(math.Max(available, 0)), formatQuota(quota.Limit),
+			)
+		}
+	}
+	return nil
+}
+
+func vmQuotaDemand(ctx context.Context, c Client, zone string, config CreateVMConfig, quotas map[string]regionQuota) (map[string]float64, error) {
+	demand := map[string]float64{}
+
+	machineType := lastSegment(strings.TrimSpace(config.MachineType))
+	mt, err := GetMachineType(ctx, c, zone, machineType)
+	if err != nil {
+		return nil, fmt.Errorf("get machine type %q: %w", machineType, err)
+	}
+	demand[cpuQuotaMetric(machineType, quotas)] += float64(mt.GuestCPUs)
+
+	externalType := strings.TrimSpace(config.ExternalIPType)
+	if externalType == "" || externalType == ExternalIPEphemeral {
+		demand[quotaMetricInUseAddresses]++
+	}
+
+	if strings.TrimSpace(config.BootDiskSourceType) != BootDiskSourceExistingDisk {
+		size := config.BootDiskSizeGb
+		if size <= 0 {
+			size = defaultBootDiskSizeGb
+		}
+		addDiskQuotaDemand(demand, config.BootDiskType, size)
+	}
+
+	for _, d := range additionalDisksFromOSConfig(config.OSAndStorageConfig) {
+		if d.SourceDisk != "" {
+			continue
+		}
+		addDiskQuotaDemand(demand, d.DiskType, d.SizeGb)
+	}
+
+	if config.LocalSSDCount > 0 {
+		demand[quotaMetricLocalSSDTotalGB] += float64(config.LocalSSDCount * localSSDSizeGb)
+	}
+
+	return demand, nil
+}
+
+// cpuQuotaMetric picks the family-specific CPU quota (e.g. N2_CPUS) when the
+// region reports one, and the general CPUS quota otherwise.
+func cpuQuotaMetric(machineType string, quotas map[string]regionQuota) string {
+	family, _, _ := strings.Cut(machineType, "-")
+	metric := strings.ToUpper(family) + "_CPUS"
+	if _, ok := quotas[metric]; ok && family != "" {
+		return metric
+	}
+	return quotaMetricCPUs
+}
+
+// addDiskQuotaDemand counts persistent disk capacity. Disk types without a
+// regional capacity quota (e.g. Hyperdisk) are skipped.
+func addDiskQuotaDemand(demand map[string]float64, diskType string, sizeGb int64) {
+	if sizeGb <= 0 {
+		return
+	}
+	diskType = lastSegment(strings.TrimSpace(diskType))
+	if diskType == "" {
+		diskType = DefaultDiskType
+	}
+	switch diskType {
+	case "pd-standard":
+		demand[quotaMetricDisksTotalGB] += float64(sizeGb)
+	case "pd-ssd", "pd-balanced":
+		demand[quotaMetricSSDTotalGB] += float64(sizeGb)
+	}
+}
+
+func formatQuota(v float64) string {
+	return fmt.Sprintf("%g", v)
+}
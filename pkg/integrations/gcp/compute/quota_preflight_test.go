@@ -0,0 +1,132 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func quotaClient(regionJSON string) *mockInstanceClient {
+	return &mockInstanceClient{
+		projectID: "my-project",
+		getFunc: func(ctx context.Context, path string) ([]byte, error) {
+			switch path {
+			case "projects/my-project/regions/us-central1":
+				return []byte(regionJSON), nil
+			case "projects/my-project/zones/us-central1-a/machineTypes/n2-standard-8":
+				return []byte(`{"name":"n2-standard-8","guestCpus":8,"memoryMb":32768}`), nil
+			case "projects/my-project/zones/us-central1-a/machineTypes/e2-medium":
+				return []byte(`{"name":"e2-medium","guestCpus":2,"memoryMb":4096}`), nil
+			}
+			return nil, fmt.Errorf("unexpected GET %s", path)
+		},
+	}
+}
+
+func Test_CheckVMQuota(t *testing.T) {
+	config := func(machineType string) CreateVMConfig {
+		return CreateVMConfig{
+			InstanceName: "my-vm",
+			Zone:         "us-central1-a",
+			MachineType:  machineType,
+		}
+	}
+
+	t.Run("enough quota passes", func(t *testing.T) {
+		client := quotaClient(`{"quotas":[
+			{"metric":"CPUS","limit":24,"usage":4},
+			{"metric":"IN_USE_ADDRESSES","limit":8,"usage":1},
+			{"metric":"SSD_TOTAL_GB","limit":500,"usage":100}
+		]}`)
+
+		err := CheckVMQuota(context.Background(), client, "my-project", "us-central1-a", "us-central1", config("e2-medium"))
+		require.NoError(t, err)
+	})
+
+	t.Run("over-quota CPUs are rejected", func(t *testing.T) {
+		client := quotaClient(`{"quotas":[
+			{"metric":"CPUS","limit":24,"usage":20},
+			{"metric":"IN_USE_ADDRESSES","limit":8,"usage":1}
+		]}`)
+
+		err := CheckVMQuota(context.Background(), client, "my-project", "us-central1-a", "us-central1", config("n2-standard-8"))
+		require.EqualError(t, err, "would exceed CPUS quota in region us-central1: VM needs 8, 4 of 24 available")
+	})
+
+	t.Run("family CPU quota is used when the region reports one", func(t *testing.T) {
+		client := quotaClient(`{"quotas":[
+			{"metric":"CPUS","limit":100,"usage":0},
+			{"metric":"N2_CPUS","limit":8,"usage":4}
+		]}`)
+
+		err := CheckVMQuota(context.Background(), client, "my-project", "us-central1-a", "us-central1", config("n2-standard-8"))
+		require.EqualError(t, err, "would exceed N2_CPUS quota in region us-central1: VM needs 8, 4 of 8 available")
+	})
+
+	t.Run("ephemeral external IP counts against addresses", func(t *testing.T) {
+		client := quotaClient(`{"quotas":[{"metric":"IN_USE_ADDRESSES","limit":8,"usage":8}]}`)
+
+		err := CheckVMQuota(context.Background(), client, "my-project", "us-central1-a", "us-central1", config("e2-medium"))
+		require.EqualError(t, err, "would exceed IN_USE_ADDRESSES quota in region us-central1: VM needs 1, 0 of 8 available")
+
+		noExternalIP := config("e2-medium")
+		noExternalIP.ExternalIPType = ExternalIPNone
+		require.NoError(t, CheckVMQuota(context.Background(), client, "my-project", "us-central1-a", "us-central1", noExternalIP))
+	})
+
+	t.Run("new disks count against disk quotas", func(t *testing.T) {
+		client := quotaClient(`{"quotas":[
+			{"metric":"SSD_TOTAL_GB","limit":500,"usage":300},
+			{"metric":"DISKS_TOTAL_GB","limit":4096,"usage":0}
+		]}`)
+
+		c := config("e2-medium")
+		c.BootDiskSizeGb = 50
+		c.AdditionalDisks = []AdditionalDiskEntry{
+			{Name: "data", SizeGb: 200, DiskType: "pd-ssd"},
+			{Name: "archive", SizeGb: 1000, DiskType: "pd-standard"},
+			{Mode: AdditionalDiskModeExisting, ExistingDisk: "zones/us-central1-a/disks/existing"},
+		}
+
+		err := CheckVMQuota(context.Background(), client, "my-project", "us-central1-a", "us-central1", c)
+		require.EqualError(t, err, "would exceed SSD_TOTAL_GB quota in region us-central1: VM needs 250, 200 of 500 available")
+	})
+}
+
+func Test_CreateVMAndWait_QuotaPreflight(t *testing.T) {
+	t.Run("over-quota VM is rejected before insert", func(t *testing.T) {
+		client := quotaClient(`{"quotas":[{"metric":"CPUS","limit":8,"usage":6}]}`)
+		client.postFunc = func(ctx context.Context, path string, body any) ([]byte, error) {
+			t.Fatalf("unexpected POST %s", path)
+			return nil, nil
+		}
+
+		_, err := CreateVMAndWait(context.Background(), client, CreateVMConfig{
+			InstanceName:         "my-vm",
+			Zone:                 "us-central1-a",
+			MachineType:          "n2-standard-8",
+			QuotaPreflightConfig: QuotaPreflightConfig{CheckQuota: true},
+		})
+		require.EqualError(t, err, "would exceed CPUS quota in region us-central1: VM needs 8, 2 of 8 available")
+	})
+
+	t.Run("preflight is skipped by default", func(t *testing.T) {
+		client := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				return nil, fmt.Errorf("insert failed")
+			},
+		}
+
+		_, err := CreateVMAndWait(context.Background(), client, CreateVMConfig{
+			InstanceName: "my-vm",
+			Zone:         "us-central1-a",
+			MachineType:  "n2-standard-8",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "insert failed")
+	})
+}
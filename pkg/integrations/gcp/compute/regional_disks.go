@@ -0,0 +1,133 @@
+package compute
+
+import (
+	"fmt"
+	"strings"
+)
+
+// regionalDiskReplicaZoneCount is the number of zones a regional persistent
+// disk is replicated to.
+const regionalDiskReplicaZoneCount = 2
+
+// normalizeReplicaZones trims the configured replica zones, drops empty entries
+// and reduces zone URLs to their names.
+func normalizeReplicaZones(zones []string) []string {
+	out := make([]string, 0, len(zones))
+	for _, z := range zones {
+		z = lastSegment(strings.TrimSpace(z))
+		if z != "" {
+			out = append(out, z)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// validateReplicaZones checks the replica zones of a regional disk attached to
+// a VM in vmZone: exactly two distinct zones, in the VM's region, one of them
+// being the VM's zone.
+func validateReplicaZones(vmZone string, zones []string) error {
+	zones = normalizeReplicaZones(zones)
+	if len(zones) != regionalDiskReplicaZoneCount {
+		return fmt.Errorf("a regional disk needs exactly %d replica zones, got %d", regionalDiskReplicaZoneCount, len(zones))
+	}
+	if zones[0] == zones[1] {
+		return fmt.Errorf("replica zones must be different, got %s twice", zones[0])
+	}
+
+	region := deriveRegionFromZone(zones[0])
+	if region == "" || deriveRegionFromZone(zones[1]) != region {
+		return fmt.Errorf("replica zones %s and %s must be in the same region", zones[0], zones[1])
+	}
+
+	vmZone = lastSegment(strings.TrimSpace(vmZone))
+	if vmZone != "" && zones[0] != vmZone && zones[1] != vmZone {
+		return fmt.Errorf("replica zones %s and %s must include the VM zone %s", zones[0], zones[1], vmZone)
+	}
+	return nil
+}
+
+// validateRegionalDisks checks the replica zones of every regional boot and
+// additional disk in the Create VM configuration.
+func validateRegionalDisks(config CreateVMConfig) error {
+	if normalizeReplicaZones(config.BootDiskReplicaZones) != nil {
+		if err := validateReplicaZones(config.Zone, config.BootDiskReplicaZones); err != nil {
+			return fmt.Errorf("boot disk: %w", err)
+		}
+	}
+
+	for i, d := range config.AdditionalDisks {
+		if normalizeReplicaZones(d.ReplicaZones) == nil {
+			continue
+		}
+		if d.Mode != AdditionalDiskModeExisting && lastSegment(strings.TrimSpace(d.DiskType)) == "local-ssd" {
+			return fmt.Errorf("additional disk #%d: Local SSD disks cannot be regional", i+1)
+		}
+		if err := validateReplicaZones(config.Zone, d.ReplicaZones); err != nil {
+			return fmt.Errorf("additional disk #%d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func resolveZoneURL(project, zone string) string {
+	if strings.Contains(zone, "/") {
+		return zone
+	}
+	if project == "" {
+		return zone
+	}
+	return fmt.Sprintf("projects/%s/zones/%s", project, zone)
+}
+
+func resolveReplicaZoneURLs(project string, zones []string) []string {
+	zones = normalizeReplicaZones(zones)
+	if zones == nil {
+		return nil
+	}
+	out := make([]string, 0, len(zones))
+	for _, z := range zones {
+		out = append(out, resolveZoneURL(project, z))
+	}
+	return out
+}
+
+func resolveRegionalDiskTypeURL(project, region, diskType string) string {
+	if strings.Contains(diskType, "/") {
+		return diskType
+	}
+	if project == "" || region == "" {
+		return diskType
+	}
+	return fmt.Sprintf("projects/%s/regions/%s/diskTypes/%s", project, region, diskType)
+}
+
+func resolveRegionalDiskURL(project, region, diskRef string) string {
+	if strings.Contains(diskRef, "/") {
+		return diskRef
+	}
+	if project == "" || region == "" {
+		return diskRef
+	}
+	return fmt.Sprintf("projects/%s/regions/%s/disks/%s", project, region, diskRef)
+}
+
+// resolveDiskTypeURLForDisk returns the regional disk type URL when the disk
+// has replica zones, and the zonal one otherwise.
+func resolveDiskTypeURLForDisk(project, zone, diskType string, replicaZones []string) string {
+	if normalizeReplicaZones(replicaZones) != nil {
+		return resolveRegionalDiskTypeURL(project, deriveRegionFromZone(zone), diskType)
+	}
+	return resolveDiskTypeURL(project, zone, diskType)
+}
+
+// resolveDiskURLForDisk returns the regional disk URL when the disk has replica
+// zones, and the zonal one otherwise.
+func resolveDiskURLForDisk(project, zone, diskRef string, replicaZones []string) string {
+	if normalizeReplicaZones(replicaZones) != nil {
+		return resolveRegionalDiskURL(project, deriveRegionFromZone(zone), diskRef)
+	}
+	return resolveDiskURL(project, zone, diskRef)
+}
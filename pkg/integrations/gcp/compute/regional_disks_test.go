@@ -0,0 +1,156 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateReplicaZones(t *testing.T) {
+	t.Run("two zones in the VM region including the VM zone", func(t *testing.T) {
+		assert.NoError(t, validateReplicaZones("us-central1-a", []string{"us-central1-a", "us-central1-b"}))
+	})
+
+	t.Run("zone URLs are accepted", func(t *testing.T) {
+		assert.NoError(t, validateReplicaZones("us-central1-b", []string{"projects/p/zones/us-central1-a", "projects/p/zones/us-central1-b"}))
+	})
+
+	t.Run("one zone -> error", func(t *testing.T) {
+		err := validateReplicaZones("us-central1-a", []string{"us-central1-a", " "})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exactly 2 replica zones, got 1")
+	})
+
+	t.Run("three zones -> error", func(t *testing.T) {
+		err := validateReplicaZones("us-central1-a", []string{"us-central1-a", "us-central1-b", "us-central1-c"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "got 3")
+	})
+
+	t.Run("same zone twice -> error", func(t *testing.T) {
+		err := validateReplicaZones("us-central1-a", []string{"us-central1-a", "us-central1-a"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be different")
+	})
+
+	t.Run("zones in different regions -> error", func(t *testing.T) {
+		err := validateReplicaZones("us-central1-a", []string{"us-central1-a", "us-east1-b"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "same region")
+	})
+
+	t.Run("zones not including the VM zone -> error", func(t *testing.T) {
+		err := validateReplicaZones("us-central1-a", []string{"us-central1-b", "us-central1-c"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must include the VM zone us-central1-a")
+	})
+}
+
+func Test_validateRegionalDisks(t *testing.T) {
+	t.Run("zonal disks only", func(t *testing.T) {
+		config := CreateVMConfig{Zone: "us-central1-a"}
+		config.AdditionalDisks = []AdditionalDiskEntry{{Mode: AdditionalDiskModeNew, Name: "data"}}
+		assert.NoError(t, validateRegionalDisks(config))
+	})
+
+	t.Run("invalid boot disk replica zones -> error", func(t *testing.T) {
+		config := CreateVMConfig{Zone: "us-central1-a"}
+		config.BootDiskReplicaZones = []string{"us-central1-a"}
+		err := validateRegionalDisks(config)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "boot disk:")
+	})
+
+	t.Run("invalid additional disk replica zones -> error", func(t *testing.T) {
+		config := CreateVMConfig{Zone: "us-central1-a"}
+		config.AdditionalDisks = []AdditionalDiskEntry{
+			{Mode: AdditionalDiskModeNew, Name: "data"},
+			{Mode: AdditionalDiskModeNew, Name: "ha", ReplicaZones: []string{"us-central1-a", "europe-west1-b"}},
+		}
+		err := validateRegionalDisks(config)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "additional disk #2:")
+	})
+
+	t.Run("regional local SSD -> error", func(t *testing.T) {
+		config := CreateVMConfig{Zone: "us-central1-a"}
+		config.AdditionalDisks = []AdditionalDiskEntry{
+			{Mode: AdditionalDiskModeNew, DiskType: "local-ssd", ReplicaZones: []string{"us-central1-a", "us-central1-b"}},
+		}
+		err := validateRegionalDisks(config)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Local SSD")
+	})
+
+	t.Run("validateCreateVMConfig rejects invalid replica zones", func(t *testing.T) {
+		config := CreateVMConfig{InstanceName: "vm-1", Zone: "us-central1-a", MachineType: "e2-medium"}
+		config.BootDiskReplicaZones = []string{"us-central1-b", "us-central1-c"}
+		msg, ok := validateCreateVMConfig(config)
+		assert.False(t, ok)
+		assert.Contains(t, msg, "must include the VM zone")
+	})
+}
+
+func Test_resolveRegionalDiskURLs(t *testing.T) {
+	assert.Equal(t, "projects/p/zones/us-central1-a", resolveZoneURL("p", "us-central1-a"))
+	assert.Equal(t, "projects/p/regions/us-central1/diskTypes/pd-ssd", resolveRegionalDiskTypeURL("p", "us-central1", "pd-ssd"))
+	assert.Equal(t, "projects/p/regions/us-central1/disks/ha-disk", resolveRegionalDiskURL("p", "us-central1", "ha-disk"))
+	assert.Equal(t, "projects/x/regions/r/disks/d", resolveRegionalDiskURL("p", "us-central1", "projects/x/regions/r/disks/d"))
+	assert.Equal(t, "ha-disk", resolveRegionalDiskURL("p", "", "ha-disk"))
+}
+
+func Test_BuildRegionalDisks(t *testing.T) {
+	replicaZones := []string{"us-central1-a", "us-central1-b"}
+
+	t.Run("regional boot disk from image", func(t *testing.T) {
+		out := BuildBootDisk("my-proj", "us-central1-a", BootDiskConfig{
+			SourceImage:  "projects/debian-cloud/global/images/debian-12",
+			DiskType:     "pd-balanced",
+			SizeGb:       20,
+			ReplicaZones: replicaZones,
+		})
+		require.NotNil(t, out.InitializeParams)
+		assert.Equal(t, "projects/my-proj/regions/us-central1/diskTypes/pd-balanced", out.InitializeParams.DiskType)
+		assert.Equal(t, []string{"projects/my-proj/zones/us-central1-a", "projects/my-proj/zones/us-central1-b"}, out.InitializeParams.ReplicaZones)
+	})
+
+	t.Run("zonal boot disk has no replica zones", func(t *testing.T) {
+		out := BuildBootDisk("my-proj", "us-central1-a", BootDiskConfig{SourceImage: "img"})
+		require.NotNil(t, out.InitializeParams)
+		assert.Equal(t, "projects/my-proj/zones/us-central1-a/diskTypes/pd-balanced", out.InitializeParams.DiskType)
+		assert.Nil(t, out.InitializeParams.ReplicaZones)
+	})
+
+	t.Run("new regional additional disk", func(t *testing.T) {
+		out := BuildAdditionalDisks("my-proj", "us-central1-a", []AdditionalDisk{
+			{Name: "ha-data", SizeGb: 200, DiskType: "pd-ssd", ReplicaZones: replicaZones},
+		})
+		require.Len(t, out, 1)
+		require.NotNil(t, out[0].InitializeParams)
+		assert.Equal(t, "projects/my-proj/regions/us-central1/diskTypes/pd-ssd", out[0].InitializeParams.DiskType)
+		assert.Len(t, out[0].InitializeParams.ReplicaZones, 2)
+	})
+
+	t.Run("existing regional disks resolve to regional URLs", func(t *testing.T) {
+		config := CreateVMConfig{
+			InstanceName: "vm-1",
+			Zone:         "us-central1-a",
+			MachineType:  "e2-medium",
+		}
+		config.BootDiskSourceType = BootDiskSourceExistingDisk
+		config.BootDiskExistingDisk = "ha-boot"
+		config.BootDiskReplicaZones = replicaZones
+		config.AdditionalDisks = []AdditionalDiskEntry{
+			{Mode: AdditionalDiskModeExisting, ExistingDisk: "ha-data", ReplicaZones: replicaZones},
+			{Mode: AdditionalDiskModeExisting, ExistingDisk: "zonal-data"},
+		}
+
+		disks, err := buildDisks("my-proj", "us-central1-a", config)
+		require.NoError(t, err)
+		require.Len(t, disks, 3)
+		assert.Equal(t, "projects/my-proj/regions/us-central1/disks/ha-boot", disks[0].Source)
+		assert.Equal(t, "projects/my-proj/regions/us-central1/disks/ha-data", disks[1].Source)
+		assert.Equal(t, "projects/my-proj/zones/us-central1-a/disks/zonal-data", disks[2].Source)
+	})
+}
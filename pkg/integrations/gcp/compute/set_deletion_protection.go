@@ -0,0 +1,214 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+)
+
+type SetDeletionProtection struct{}
+
+type SetDeletionProtectionSpec struct {
+	Instance           string `mapstructure:"instance"`
+	DeletionProtection bool   `mapstructure:"deletionProtection"`
+}
+
+type instanceDeletionProtectionResp struct {
+	DeletionProtection bool `json:"deletionProtection"`
+}
+
+func (s *SetDeletionProtection) Name() string {
+	return "gcp.setVMDeletionProtection"
+}
+
+func (s *SetDeletionProtection) Label() string {
+	return "Compute • Set VM Deletion Protection"
+}
+
+func (s *SetDeletionProtection) Description() string {
+	return "Enable or disable deletion protection on an existing Google Compute Engine VM instance"
+}
+
+func (s *SetDeletionProtection) Documentation() string {
+	return `The Set VM Deletion Protection component turns deletion protection on or off for an existing Compute Engine VM instance.
+
+## Use Cases
+
+- **Protect production VMs**: Lock important instances right after they are created
+- **Planned teardown**: Turn protection off as the step before **Delete VM Instance**
+
+## Configuration
+
+- **VM Instance**: Pick from the list of VMs in your project, or pass an expression chained from an upstream node (e.g. the ` + "`selfLink`" + ` emitted by ` + "`gcp.createVM`" + `).
+- **Deletion protection**: Enabled (default) prevents the instance from being deleted until protection is turned off again.
+
+## Output
+
+Returns the instance after the update:
+- **instanceId**, **name**, **zone**, **status**, **selfLink**, **machineType**, **internalIP**, **externalIP**
+- **deletionProtection**: Whether deletion protection is enabled on the instance
+
+## Important Notes
+
+- The instance is read first, so a missing instance fails the execution before any change is made.
+- The component waits for the underlying zone operation to complete before emitting.`
+}
+
+func (s *SetDeletionProtection) Icon() string {
+	return "shield"
+}
+
+func (s *SetDeletionProtection) Color() string {
+	return "blue"
+}
+
+func (s *SetDeletionProtection) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (s *SetDeletionProtection) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "instance",
+			Label:       "VM Instance",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The VM instance to update. Lists every VM in your project across all zones.",
+			Placeholder: "Select instance",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeInstance,
+				},
+			},
+		},
+		{
+			Name:        "deletionProtection",
+			Label:       "Deletion protection",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     true,
+			Description: "Enable to prevent the instance from being deleted, disable to allow deletion again.",
+		},
+	}
+}
+
+func (s *SetDeletionProtection) Setup(ctx core.SetupContext) error {
+	spec := SetDeletionProtectionSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	if strings.TrimSpace(spec.Instance) == "" {
+		return errors.New("instance is required")
+	}
+
+	return resolveInstanceNodeMetadata(ctx, spec.Instance)
+}
+
+func (s *SetDeletionProtection) Execute(ctx core.ExecutionContext) error {
+	spec := SetDeletionProtectionSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to decode configuration: %v", err))
+	}
+
+	urlProject, zone, instanceName, err := parseInstancePath(spec.Instance)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	project := client.ProjectID()
+	if urlProject != "" && urlProject != project {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf(
+			"instance belongs to project %q but this GCP integration is bound to project %q; cross-project operations are not supported",
+			urlProject, project,
+		))
+	}
+
+	callCtx := context.Background()
+
+	if _, err := GetInstance(callCtx, client, project, zone, instanceName); err != nil {
+		if gcpcommon.IsNotFoundError(err) {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("instance %s not found in zone %s", instanceName, zone))
+		}
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to read instance: %v", err))
+	}
+
+	if err := setInstanceDeletionProtection(callCtx, client, project, zone, instanceName, spec.DeletionProtection); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to set deletion protection: %v", err))
+	}
+
+	updated, err := GetInstance(callCtx, client, project, zone, instanceName)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to read instance after update: %v", err))
+	}
+
+	payload, err := InstancePayloadFromGetResponse(updated, zone)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to parse updated instance: %v", err))
+	}
+
+	var protection instanceDeletionProtectionResp
+	if err := json.Unmarshal(updated, &protection); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to parse updated instance deletion protection: %v", err))
+	}
+	payload["deletionProtection"] = protection.DeletionProtection
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"gcp.compute.vmInstance.deletionProtectionUpdated",
+		[]any{payload},
+	)
+}
+
+// setInstanceDeletionProtection issues the instances.setDeletionProtection POST and waits for the zone operation.
+func setInstanceDeletionProtection(ctx context.Context, client Client, project, zone, instanceName string, enabled bool) error {
+	path := fmt.Sprintf("projects/%s/zones/%s/instances/%s/setDeletionProtection?deletionProtection=%s", project, zone, instanceName, strconv.FormatBool(enabled))
+	body, err := client.Post(ctx, path, nil)
+	if err != nil {
+		return err
+	}
+	opName, err := operationNameFromResponse(body, "set deletion protection")
+	if err != nil {
+		return err
+	}
+	return WaitForZoneOperation(ctx, client, project, zone, opName)
+}
+
+func (s *SetDeletionProtection) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (s *SetDeletionProtection) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (s *SetDeletionProtection) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (s *SetDeletionProtection) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (s *SetDeletionProtection) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (s *SetDeletionProtection) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
@@ -0,0 +1,142 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+// instanceWithDeletionProtectionJSON extends instanceGetJSON with the deletionProtection flag.
+func instanceWithDeletionProtectionJSON(enabled bool) []byte {
+	var inst map[string]any
+	_ = json.Unmarshal(instanceGetJSON("123", "my-vm", "us-central1-a", "RUNNING", "e2-medium"), &inst)
+	inst["deletionProtection"] = enabled
+	b, _ := json.Marshal(inst)
+	return b
+}
+
+func Test__SetDeletionProtection__Setup(t *testing.T) {
+	component := &SetDeletionProtection{}
+
+	t.Run("missing instance returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"deletionProtection": true},
+			Metadata:      &contexts.MetadataContext{},
+		})
+		require.ErrorContains(t, err, "instance is required")
+	})
+
+	t.Run("valid instance resolves node metadata", func(t *testing.T) {
+		metadata := &contexts.MetadataContext{}
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"instance":           "zones/us-central1-a/instances/my-vm",
+				"deletionProtection": true,
+			},
+			Metadata: metadata,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, VMInstanceNodeMetadata{InstanceName: "my-vm", Zone: "us-central1-a"}, metadata.Metadata)
+	})
+}
+
+func Test__SetDeletionProtection__Execute(t *testing.T) {
+	component := &SetDeletionProtection{}
+
+	run := func(t *testing.T, enabled bool) (*contexts.ExecutionStateContext, []string) {
+		var calls []string
+		protected := !enabled
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				calls = append(calls, "POST "+path)
+				assert.Nil(t, body)
+				protected = enabled
+				return opDone("op-1"), nil
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				if isOperationPath(path) {
+					calls = append(calls, "WAIT "+path)
+					return opDone("op-1"), nil
+				}
+				calls = append(calls, "GET "+path)
+				return instanceWithDeletionProtectionJSON(protected), nil
+			},
+		}
+
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"instance":           "zones/us-central1-a/instances/my-vm",
+				"deletionProtection": enabled,
+			},
+			ExecutionState: state,
+		})
+		require.NoError(t, err)
+		return state, calls
+	}
+
+	t.Run("enable -> sets protection, waits for operation, emits new state", func(t *testing.T) {
+		state, calls := run(t, true)
+
+		assert.True(t, state.Passed)
+		assert.Equal(t, "gcp.compute.vmInstance.deletionProtectionUpdated", state.Type)
+		assert.Equal(t, []string{
+			"GET projects/my-project/zones/us-central1-a/instances/my-vm",
+			"POST projects/my-project/zones/us-central1-a/instances/my-vm/setDeletionProtection?deletionProtection=true",
+			"WAIT projects/my-project/zones/us-central1-a/operations/op-1",
+			"GET projects/my-project/zones/us-central1-a/instances/my-vm",
+		}, calls)
+
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "my-vm", data["name"])
+		assert.Equal(t, true, data["deletionProtection"])
+	})
+
+	t.Run("disable -> clears protection and emits new state", func(t *testing.T) {
+		state, calls := run(t, false)
+
+		assert.True(t, state.Passed)
+		assert.Contains(t, calls, "POST projects/my-project/zones/us-central1-a/instances/my-vm/setDeletionProtection?deletionProtection=false")
+
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, false, data["deletionProtection"])
+	})
+
+	t.Run("missing instance -> fails without calling setDeletionProtection", func(t *testing.T) {
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				t.Fatal("unexpected POST")
+				return nil, nil
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				return nil, &gcpcommon.GCPAPIError{StatusCode: http.StatusNotFound, Message: "not found"}
+			},
+		}
+
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"instance":           "zones/us-central1-a/instances/my-vm",
+				"deletionProtection": true,
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Passed)
+		assert.Equal(t, "instance my-vm not found in zone us-central1-a", state.FailureMessage)
+	})
+}
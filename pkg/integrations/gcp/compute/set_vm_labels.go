@@ -0,0 +1,317 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	compute "google.golang.org/api/compute/v1"
+)
+
+const maxInstanceLabels = 64
+
+var (
+	labelKeyRegex   = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`)
+	labelValueRegex = regexp.MustCompile(`^[a-z0-9_-]{0,63}$`)
+)
+
+type SetVMLabels struct{}
+
+type SetVMLabelsSpec struct {
+	Instance        string       `mapstructure:"instance"`
+	Labels          []LabelEntry `mapstructure:"labels"`
+	ReplaceExisting bool         `mapstructure:"replaceExisting"`
+}
+
+type instanceLabelsResp struct {
+	Labels           map[string]string `json:"labels"`
+	LabelFingerprint string            `json:"labelFingerprint"`
+}
+
+func (s *SetVMLabels) Name() string {
+	return "gcp.setVMLabels"
+}
+
+func (s *SetVMLabels) Label() string {
+	return "Compute • Set VM Labels"
+}
+
+func (s *SetVMLabels) Description() string {
+	return "Add, update, or replace the labels on an existing Google Compute Engine VM instance"
+}
+
+func (s *SetVMLabels) Documentation() string {
+	return `The Set VM Labels component updates the labels of an existing Compute Engine VM instance.
+
+## Use Cases
+
+- **Retagging**: Change environment, team, or ownership labels after a VM is created
+- **Cost attribution**: Keep billing labels accurate as workloads move between teams
+- **Lifecycle tracking**: Mark instances (e.g. ` + "`state=draining`" + `) as part of a workflow
+
+## Configuration
+
+- **VM Instance**: Pick from the list of VMs in your project, or pass an expression chained from an upstream node (e.g. the ` + "`selfLink`" + ` emitted by ` + "`gcp.createVM`" + `).
+- **Labels**: Key-value labels to set on the instance.
+- **Replace existing labels**: When enabled, the instance's labels are replaced with exactly the labels listed. When disabled (default), the listed labels are merged into the existing ones and labels you don't list are kept.
+
+## Output
+
+Returns the instance after the update:
+- **instanceId**, **name**, **zone**, **status**, **selfLink**, **machineType**, **internalIP**, **externalIP**
+- **labels**: The full set of labels on the instance after the update
+
+## Important Notes
+
+- Label keys must start with a lowercase letter and may contain only lowercase letters, digits, underscores, and hyphens (up to 63 characters). Values follow the same character rules, may be empty, and are up to 63 characters.
+- An instance can have at most 64 labels.
+- The current label fingerprint is read first, as required by the API, so concurrent label changes are rejected instead of silently overwritten.
+- The component waits for the underlying zone operation to complete before emitting.`
+}
+
+func (s *SetVMLabels) Icon() string {
+	return "tag"
+}
+
+func (s *SetVMLabels) Color() string {
+	return "blue"
+}
+
+func (s *SetVMLabels) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (s *SetVMLabels) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "instance",
+			Label:       "VM Instance",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The VM instance to label. Lists every VM in your project across all zones.",
+			Placeholder: "Select instance",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeInstance,
+				},
+			},
+		},
+		{
+			Name:        "labels",
+			Label:       "Labels",
+			Type:        configuration.FieldTypeList,
+			Required:    true,
+			Description: "Key-value labels to set on the instance.",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Label",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:        "key",
+								Label:       "Key",
+								Type:        configuration.FieldTypeString,
+								Required:    true,
+								Description: "Label key (e.g. env, team, cost-center).",
+								Placeholder: "e.g. env",
+							},
+							{
+								Name:        "value",
+								Label:       "Value",
+								Type:        configuration.FieldTypeString,
+								Required:    false,
+								Description: "Label value.",
+								Placeholder: "e.g. production",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "replaceExisting",
+			Label:       "Replace existing labels",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Replace all labels on the instance instead of merging with the existing ones.",
+		},
+	}
+}
+
+func (s *SetVMLabels) Setup(ctx core.SetupContext) error {
+	spec := SetVMLabelsSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	if strings.TrimSpace(spec.Instance) == "" {
+		return errors.New("instance is required")
+	}
+
+	if len(spec.Labels) == 0 {
+		return errors.New("at least one label is required")
+	}
+
+	if err := validateLabelEntries(spec.Labels); err != nil {
+		return err
+	}
+
+	return resolveInstanceNodeMetadata(ctx, spec.Instance)
+}
+
+// validateLabelEntries checks label keys and values against the Compute Engine
+// label rules. Entries containing expressions are skipped; they are validated
+// again at execution time once resolved.
+func validateLabelEntries(entries []LabelEntry) error {
+	for i, e := range entries {
+		key := strings.TrimSpace(e.Key)
+		value := strings.TrimSpace(e.Value)
+		if key == "" {
+			return fmt.Errorf("labels[%d].key is required", i)
+		}
+		if !strings.Contains(key, "{{") && !labelKeyRegex.MatchString(key) {
+			return fmt.Errorf("invalid label key %q: must start with a lowercase letter and contain only lowercase letters, digits, underscores, or hyphens (max 63 characters)", key)
+		}
+		if !strings.Contains(value, "{{") && !labelValueRegex.MatchString(value) {
+			return fmt.Errorf("invalid value for label %q: must contain only lowercase letters, digits, underscores, or hyphens (max 63 characters)", key)
+		}
+	}
+	return nil
+}
+
+func (s *SetVMLabels) Execute(ctx core.ExecutionContext) error {
+	spec := SetVMLabelsSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to decode configuration: %v", err))
+	}
+
+	if err := validateLabelEntries(spec.Labels); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	labels := imageLabelsFromEntries(spec.Labels)
+	if labels == nil {
+		return ctx.ExecutionState.Fail("error", "at least one label is required")
+	}
+
+	urlProject, zone, instanceName, err := parseInstancePath(spec.Instance)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	project := client.ProjectID()
+	if urlProject != "" && urlProject != project {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf(
+			"instance belongs to project %q but this GCP integration is bound to project %q; cross-project operations are not supported",
+			urlProject, project,
+		))
+	}
+
+	callCtx := context.Background()
+
+	// Read the current instance to obtain the label fingerprint, which
+	// instances.setLabels requires, and the existing labels to merge with.
+	body, err := GetInstance(callCtx, client, project, zone, instanceName)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to read instance: %v", err))
+	}
+
+	var current instanceLabelsResp
+	if err := json.Unmarshal(body, &current); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to parse instance: %v", err))
+	}
+
+	if !spec.ReplaceExisting {
+		labels = mergeImageLabels(current.Labels, labels)
+	}
+
+	if len(labels) > maxInstanceLabels {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("an instance can have at most %d labels, got %d", maxInstanceLabels, len(labels)))
+	}
+
+	if err := setInstanceLabels(callCtx, client, project, zone, instanceName, labels, current.LabelFingerprint); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to set instance labels: %v", err))
+	}
+
+	updated, err := GetInstance(callCtx, client, project, zone, instanceName)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to read instance after update: %v", err))
+	}
+
+	payload, err := InstancePayloadFromGetResponse(updated, zone)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to parse updated instance: %v", err))
+	}
+
+	var updatedLabels instanceLabelsResp
+	if err := json.Unmarshal(updated, &updatedLabels); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to parse updated instance labels: %v", err))
+	}
+	if updatedLabels.Labels == nil {
+		updatedLabels.Labels = map[string]string{}
+	}
+	payload["labels"] = updatedLabels.Labels
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"gcp.compute.vmInstance.labelsUpdated",
+		[]any{payload},
+	)
+}
+
+// setInstanceLabels issues the instances.setLabels POST and waits for the zone operation.
+func setInstanceLabels(ctx context.Context, client Client, project, zone, instanceName string, labels map[string]string, fingerprint string) error {
+	path := fmt.Sprintf("projects/%s/zones/%s/instances/%s/setLabels", project, zone, instanceName)
+	reqBody := &compute.InstancesSetLabelsRequest{
+		Labels:           labels,
+		LabelFingerprint: fingerprint,
+	}
+	body, err := client.Post(ctx, path, reqBody)
+	if err != nil {
+		return err
+	}
+	opName, err := operationNameFromResponse(body, "set labels")
+	if err != nil {
+		return err
+	}
+	return WaitForZoneOperation(ctx, client, project, zone, opName)
+}
+
+func (s *SetVMLabels) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (s *SetVMLabels) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (s *SetVMLabels) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (s *SetVMLabels) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (s *SetVMLabels) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (s *SetVMLabels) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
@@ -0,0 +1,219 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+	compute "google.golang.org/api/compute/v1"
+)
+
+// instanceWithLabelsJSON extends instanceGetJSON with labels and a label fingerprint.
+func instanceWithLabelsJSON(labels map[string]string, fingerprint string) []byte {
+	var inst map[string]any
+	_ = json.Unmarshal(instanceGetJSON("123", "my-vm", "us-central1-a", "RUNNING", "e2-medium"), &inst)
+	inst["labels"] = labels
+	inst["labelFingerprint"] = fingerprint
+	b, _ := json.Marshal(inst)
+	return b
+}
+
+func Test__SetVMLabels__Setup(t *testing.T) {
+	component := &SetVMLabels{}
+
+	t.Run("missing instance returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"labels": []any{map[string]any{"key": "env", "value": "prod"}},
+			},
+			Metadata: &contexts.MetadataContext{},
+		})
+		require.ErrorContains(t, err, "instance is required")
+	})
+
+	t.Run("missing labels returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"instance": "zones/us-central1-a/instances/my-vm"},
+			Metadata:      &contexts.MetadataContext{},
+		})
+		require.ErrorContains(t, err, "at least one label is required")
+	})
+
+	t.Run("invalid label key returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"instance": "zones/us-central1-a/instances/my-vm",
+				"labels":   []any{map[string]any{"key": "Env", "value": "prod"}},
+			},
+			Metadata: &contexts.MetadataContext{},
+		})
+		require.ErrorContains(t, err, `invalid label key "Env"`)
+	})
+
+	t.Run("invalid label value returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"instance": "zones/us-central1-a/instances/my-vm",
+				"labels":   []any{map[string]any{"key": "env", "value": "Prod Env"}},
+			},
+			Metadata: &contexts.MetadataContext{},
+		})
+		require.ErrorContains(t, err, `invalid value for label "env"`)
+	})
+
+	t.Run("expression values are accepted", func(t *testing.T) {
+		metadata := &contexts.MetadataContext{}
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"instance": "zones/us-central1-a/instances/my-vm",
+				"labels":   []any{map[string]any{"key": "env", "value": "{{ $.data.env }}"}},
+			},
+			Metadata: metadata,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, VMInstanceNodeMetadata{InstanceName: "my-vm", Zone: "us-central1-a"}, metadata.Metadata)
+	})
+}
+
+func Test__SetVMLabels__Execute(t *testing.T) {
+	component := &SetVMLabels{}
+
+	t.Run("reads fingerprint, sets merged labels, emits", func(t *testing.T) {
+		var calls []string
+		var setLabelsBody *compute.InstancesSetLabelsRequest
+		labelsSet := false
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				calls = append(calls, "POST "+path)
+				setLabelsBody, _ = body.(*compute.InstancesSetLabelsRequest)
+				labelsSet = true
+				return opDone("op-1"), nil
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				if isOperationPath(path) {
+					return opDone("op-1"), nil
+				}
+				calls = append(calls, "GET "+path)
+				if labelsSet {
+					return instanceWithLabelsJSON(map[string]string{"env": "production", "team": "platform"}, "fp-2"), nil
+				}
+				return instanceWithLabelsJSON(map[string]string{"env": "staging", "team": "platform"}, "fp-1"), nil
+			},
+		}
+
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"instance": "zones/us-central1-a/instances/my-vm",
+				"labels":   []any{map[string]any{"key": "env", "value": "production"}},
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+		assert.Equal(t, "gcp.compute.vmInstance.labelsUpdated", state.Type)
+
+		// get (fingerprint), setLabels, get (updated), in order
+		require.Len(t, calls, 3)
+		assert.Equal(t, "GET projects/my-project/zones/us-central1-a/instances/my-vm", calls[0])
+		assert.Equal(t, "POST projects/my-project/zones/us-central1-a/instances/my-vm/setLabels", calls[1])
+		assert.Equal(t, "GET projects/my-project/zones/us-central1-a/instances/my-vm", calls[2])
+
+		require.NotNil(t, setLabelsBody)
+		assert.Equal(t, "fp-1", setLabelsBody.LabelFingerprint)
+		assert.Equal(t, map[string]string{"env": "production", "team": "platform"}, setLabelsBody.Labels)
+
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "my-vm", data["name"])
+		assert.Equal(t, map[string]string{"env": "production", "team": "platform"}, data["labels"])
+	})
+
+	t.Run("replace existing drops labels that are not listed", func(t *testing.T) {
+		var setLabelsBody *compute.InstancesSetLabelsRequest
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				setLabelsBody, _ = body.(*compute.InstancesSetLabelsRequest)
+				return opDone("op-1"), nil
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				if isOperationPath(path) {
+					return opDone("op-1"), nil
+				}
+				return instanceWithLabelsJSON(map[string]string{"env": "staging", "team": "platform"}, "fp-1"), nil
+			},
+		}
+
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"instance":        "zones/us-central1-a/instances/my-vm",
+				"labels":          []any{map[string]any{"key": "env", "value": "production"}},
+				"replaceExisting": true,
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+		require.NotNil(t, setLabelsBody)
+		assert.Equal(t, map[string]string{"env": "production"}, setLabelsBody.Labels)
+	})
+
+	t.Run("invalid resolved label -> fails without calling the API", func(t *testing.T) {
+		mc := &mockInstanceClient{projectID: "my-project"}
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"instance": "zones/us-central1-a/instances/my-vm",
+				"labels":   []any{map[string]any{"key": "env", "value": "Production!"}},
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, `invalid value for label "env"`)
+	})
+
+	t.Run("setLabels error -> fails", func(t *testing.T) {
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				return nil, errors.New("labelFingerprint mismatch")
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				return instanceWithLabelsJSON(nil, "fp-1"), nil
+			},
+		}
+
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"instance": "zones/us-central1-a/instances/my-vm",
+				"labels":   []any{map[string]any{"key": "env", "value": "production"}},
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Passed)
+		assert.True(t, strings.HasPrefix(state.FailureMessage, "failed to set instance labels"))
+	})
+}
@@ -0,0 +1,387 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	compute "google.golang.org/api/compute/v1"
+)
+
+type SetVMScheduling struct{}
+
+type SetVMSchedulingSpec struct {
+	Instance           string `mapstructure:"instance"`
+	ProvisioningModel  string `mapstructure:"provisioningModel"`
+	OnHostMaintenance  string `mapstructure:"onHostMaintenance"`
+	AutomaticRestart   *bool  `mapstructure:"automaticRestart"`
+	RestartAfterUpdate *bool  `mapstructure:"restartAfterUpdate"`
+}
+
+// instanceSchedulingResp is the part of an instance read needed to update its scheduling.
+type instanceSchedulingResp struct {
+	Status            string               `json:"status"`
+	Scheduling        *compute.Scheduling  `json:"scheduling"`
+	GuestAccelerators []acceleratorTypeRef `json:"guestAccelerators"`
+}
+
+type acceleratorTypeRef struct {
+	AcceleratorType string `json:"acceleratorType"`
+}
+
+func (s *SetVMScheduling) Name() string {
+	return "gcp.setVMScheduling"
+}
+
+func (s *SetVMScheduling) Label() string {
+	return "Compute • Set VM Scheduling"
+}
+
+func (s *SetVMScheduling) Description() string {
+	return "Change the provisioning model and maintenance policy of a Google Compute Engine VM instance"
+}
+
+func (s *SetVMScheduling) Documentation() string {
+	return `The Set VM Scheduling component changes how an existing Compute Engine VM instance is scheduled, without recreating it.
+
+## Use Cases
+
+- **Cost optimization**: Move a batch VM from Standard to Spot
+- **Reliability**: Move a VM back from Spot to Standard before a critical run
+- **Maintenance**: Choose whether a VM live-migrates or stops during host maintenance, and whether it restarts automatically
+
+## Configuration
+
+- **VM Instance**: Pick from the list of VMs in your project, or pass an expression chained from an upstream node (e.g. the ` + "`selfLink`" + ` emitted by ` + "`gcp.createVM`" + `).
+- **Provisioning Model**: Standard or Spot.
+- **On host maintenance**: Migrate or Terminate (Standard only).
+- **Automatic restart**: Restart the instance if Compute Engine stops it (Standard only).
+- **Restart after update**: Start the instance again if it had to be stopped for the change. Enabled by default.
+
+## Output
+
+Returns the instance after the update, with its new scheduling:
+- **instanceId**, **name**, **zone**, **status**, **selfLink**, **machineType**, **internalIP**, **externalIP**
+- **scheduling**: **provisioningModel**, **preemptible**, **onHostMaintenance**, **automaticRestart**
+- **stopped**: whether the instance had to be stopped for the change
+
+## Important Notes
+
+- Changing the provisioning model requires the instance to be **stopped (TERMINATED)**. A running instance is stopped automatically first. Maintenance and restart settings alone are changed in place.
+- Spot VMs always terminate on host maintenance and never restart automatically, as with ` + "`gcp.createVM`" + `.
+- Instances with GPUs must terminate on host maintenance.
+- Legacy preemptible VMs cannot be converted; recreate them as Spot or Standard instead.
+- Node affinities and other scheduling settings of the instance are kept.`
+}
+
+func (s *SetVMScheduling) Icon() string {
+	return "calendar-clock"
+}
+
+func (s *SetVMScheduling) Color() string {
+	return "blue"
+}
+
+func (s *SetVMScheduling) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (s *SetVMScheduling) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "instance",
+			Label:       "VM Instance",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The VM instance to update. Lists every VM in your project across all zones.",
+			Placeholder: "Select instance",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeInstance,
+				},
+			},
+		},
+		{
+			Name:     "provisioningModel",
+			Label:    "Provisioning Model",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  string(ProvisioningStandard),
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Standard", Value: string(ProvisioningStandard)},
+						{Label: "Spot", Value: string(ProvisioningSpot)},
+					},
+				},
+			},
+		},
+		{
+			Name:        "onHostMaintenance",
+			Label:       "On host maintenance",
+			Type:        configuration.FieldTypeSelect,
+			Required:    false,
+			Default:     OnHostMaintenanceMigrate,
+			Description: "What happens to the instance during host maintenance.",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Migrate", Value: OnHostMaintenanceMigrate},
+						{Label: "Terminate", Value: OnHostMaintenanceTerminate},
+					},
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "provisioningModel", Values: []string{string(ProvisioningStandard)}},
+			},
+		},
+		{
+			Name:        "automaticRestart",
+			Label:       "Automatic restart",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     true,
+			Description: "Restart the instance if Compute Engine stops it.",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "provisioningModel", Values: []string{string(ProvisioningStandard)}},
+			},
+		},
+		{
+			Name:        "restartAfterUpdate",
+			Label:       "Restart after update",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     true,
+			Description: "Start the instance again if it had to be stopped to change the provisioning model.",
+		},
+	}
+}
+
+func (s *SetVMScheduling) Setup(ctx core.SetupContext) error {
+	spec := SetVMSchedulingSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	if strings.TrimSpace(spec.Instance) == "" {
+		return errors.New("instance is required")
+	}
+
+	if err := validateProvisioningModel(spec.ProvisioningModel); err != nil {
+		return err
+	}
+
+	return resolveInstanceNodeMetadata(ctx, spec.Instance)
+}
+
+func validateProvisioningModel(model string) error {
+	switch strings.TrimSpace(model) {
+	case "", string(ProvisioningStandard), string(ProvisioningSpot):
+		return nil
+	default:
+		return fmt.Errorf("invalid provisioningModel %q: must be %s or %s", model, ProvisioningStandard, ProvisioningSpot)
+	}
+}
+
+func (s *SetVMScheduling) Execute(ctx core.ExecutionContext) error {
+	spec := SetVMSchedulingSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to decode configuration: %v", err))
+	}
+
+	if err := validateProvisioningModel(spec.ProvisioningModel); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	urlProject, zone, instanceName, err := parseInstancePath(spec.Instance)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	project := client.ProjectID()
+	if urlProject != "" && urlProject != project {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf(
+			"instance belongs to project %q but this GCP integration is bound to project %q; cross-project operations are not supported",
+			urlProject, project,
+		))
+	}
+
+	callCtx := context.Background()
+	body, err := GetInstance(callCtx, client, project, zone, instanceName)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to read instance: %v", err))
+	}
+
+	var current instanceSchedulingResp
+	if err := json.Unmarshal(body, &current); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to parse instance: %v", err))
+	}
+
+	desired := desiredInstanceScheduling(current.Scheduling, spec)
+	if err := validateSchedulingTransition(current, desired); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	// The provisioning model can only change while the instance is stopped;
+	// maintenance and restart settings can change while it runs.
+	stopped := false
+	if provisioningModelOf(current.Scheduling) != desired.ProvisioningModel && current.Status != "TERMINATED" {
+		if err := runInstancePowerOperation(callCtx, client, project, zone, instanceName, "stop"); err != nil {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to stop instance before update: %v", err))
+		}
+		stopped = true
+	}
+
+	if err := setScheduling(callCtx, client, project, zone, instanceName, desired); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to update scheduling: %v", err))
+	}
+
+	restart := spec.RestartAfterUpdate == nil || *spec.RestartAfterUpdate
+	if stopped && restart {
+		if err := runInstancePowerOperation(callCtx, client, project, zone, instanceName, "start"); err != nil {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to start instance after update: %v", err))
+		}
+	}
+
+	updated, err := GetInstance(callCtx, client, project, zone, instanceName)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to read instance after update: %v", err))
+	}
+
+	payload, err := InstancePayloadFromGetResponse(updated, zone)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to parse updated instance: %v", err))
+	}
+
+	var after instanceSchedulingResp
+	if err := json.Unmarshal(updated, &after); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to parse updated instance: %v", err))
+	}
+	if after.Scheduling == nil {
+		after.Scheduling = desired
+	}
+
+	payload["scheduling"] = schedulingPayload(after.Scheduling)
+	payload["stopped"] = stopped
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"gcp.compute.vmInstance.schedulingUpdated",
+		[]any{payload},
+	)
+}
+
+// desiredInstanceScheduling applies the spec on top of the current scheduling,
+// using the same provisioning-model rules as gcp.createVM. Settings the
+// component does not manage, such as node affinities, are kept.
+func desiredInstanceScheduling(current *compute.Scheduling, spec SetVMSchedulingSpec) *compute.Scheduling {
+	desired := BuildSchedulingForProvisioningModel(spec.ProvisioningModel, ManagementConfig{
+		AutomaticRestart:  spec.AutomaticRestart,
+		OnHostMaintenance: spec.OnHostMaintenance,
+	})
+
+	// Send preemptible=false explicitly so moving off Spot clears it.
+	desired.ForceSendFields = append(desired.ForceSendFields, "Preemptible")
+
+	if current == nil {
+		return desired
+	}
+
+	desired.NodeAffinities = current.NodeAffinities
+	desired.MinNodeCpus = current.MinNodeCpus
+	if desired.ProvisioningModel == string(ProvisioningSpot) && current.ProvisioningModel == string(ProvisioningSpot) {
+		desired.InstanceTerminationAction = current.InstanceTerminationAction
+	}
+
+	return desired
+}
+
+// validateSchedulingTransition rejects changes Compute Engine does not allow.
+func validateSchedulingTransition(current instanceSchedulingResp, desired *compute.Scheduling) error {
+	if current.Scheduling != nil && current.Scheduling.Preemptible && current.Scheduling.ProvisioningModel != string(ProvisioningSpot) {
+		return errors.New("legacy preemptible instances cannot change scheduling; recreate the instance as Spot or Standard instead")
+	}
+
+	if len(current.GuestAccelerators) > 0 && desired.OnHostMaintenance != OnHostMaintenanceTerminate {
+		return fmt.Errorf("instances with GPUs (%s) must terminate on host maintenance", lastSegment(current.GuestAccelerators[0].AcceleratorType))
+	}
+
+	return nil
+}
+
+func provisioningModelOf(scheduling *compute.Scheduling) string {
+	if scheduling == nil || scheduling.ProvisioningModel == "" {
+		return string(ProvisioningStandard)
+	}
+	return scheduling.ProvisioningModel
+}
+
+func schedulingPayload(scheduling *compute.Scheduling) map[string]any {
+	automaticRestart := true
+	if scheduling.AutomaticRestart != nil {
+		automaticRestart = *scheduling.AutomaticRestart
+	}
+	return map[string]any{
+		"provisioningModel": provisioningModelOf(scheduling),
+		"preemptible":       scheduling.Preemptible,
+		"onHostMaintenance": scheduling.OnHostMaintenance,
+		"automaticRestart":  automaticRestart,
+	}
+}
+
+// setScheduling issues the setScheduling POST and waits for the zone operation.
+func setScheduling(ctx context.Context, client Client, project, zone, instanceName string, scheduling *compute.Scheduling) error {
+	path := fmt.Sprintf("projects/%s/zones/%s/instances/%s/setScheduling", project, zone, instanceName)
+	body, err := client.Post(ctx, path, scheduling)
+	if err != nil {
+		return err
+	}
+
+	var opResp struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(body, &opResp); err != nil {
+		return fmt.Errorf("parse setScheduling operation response: %w", err)
+	}
+	if opResp.Name == "" {
+		return errors.New("setScheduling operation response missing operation name")
+	}
+
+	return WaitForZoneOperation(ctx, client, project, zone, lastSegment(opResp.Name))
+}
+
+func (s *SetVMScheduling) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (s *SetVMScheduling) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (s *SetVMScheduling) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (s *SetVMScheduling) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (s *SetVMScheduling) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (s *SetVMScheduling) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
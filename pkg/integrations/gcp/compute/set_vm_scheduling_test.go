@@ -0,0 +1,212 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func instanceWithSchedulingJSON(status string, scheduling map[string]any, accelerators ...string) []byte {
+	var inst map[string]any
+	_ = json.Unmarshal(instanceGetJSON("123", "my-vm", "us-central1-a", status, "e2-medium"), &inst)
+	inst["scheduling"] = scheduling
+	if len(accelerators) > 0 {
+		var guestAccelerators []map[string]any
+		for _, a := range accelerators {
+			guestAccelerators = append(guestAccelerators, map[string]any{"acceleratorType": a, "acceleratorCount": 1})
+		}
+		inst["guestAccelerators"] = guestAccelerators
+	}
+	b, _ := json.Marshal(inst)
+	return b
+}
+
+var standardScheduling = map[string]any{
+	"provisioningModel": "STANDARD",
+	"onHostMaintenance": "MIGRATE",
+	"automaticRestart":  true,
+	"nodeAffinities": []map[string]any{
+		{"key": "workload", "operator": "IN", "values": []string{"batch"}},
+	},
+}
+
+var spotScheduling = map[string]any{
+	"provisioningModel": "SPOT",
+	"preemptible":       true,
+	"onHostMaintenance": "TERMINATE",
+	"automaticRestart":  false,
+}
+
+func Test__SetVMScheduling__Setup(t *testing.T) {
+	component := &SetVMScheduling{}
+
+	t.Run("missing instance returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"provisioningModel": "SPOT"},
+			Metadata:      &contexts.MetadataContext{},
+		})
+		require.ErrorContains(t, err, "instance is required")
+	})
+
+	t.Run("invalid provisioning model returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"instance":          "zones/us-central1-a/instances/my-vm",
+				"provisioningModel": "RESERVED",
+			},
+			Metadata: &contexts.MetadataContext{},
+		})
+		require.ErrorContains(t, err, `invalid provisioningModel "RESERVED"`)
+	})
+}
+
+func Test__SetVMScheduling__Execute(t *testing.T) {
+	component := &SetVMScheduling{}
+
+	run := func(t *testing.T, instances [][]byte, configuration map[string]any) ([]string, *compute.Scheduling, *contexts.ExecutionStateContext) {
+		var postedPaths []string
+		var posted *compute.Scheduling
+		getCalls := 0
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				postedPaths = append(postedPaths, path)
+				if strings.HasSuffix(path, "/setScheduling") {
+					posted = body.(*compute.Scheduling)
+				}
+				return opDone("op-1"), nil
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				if isOperationPath(path) {
+					return opDone("op-1"), nil
+				}
+				body := instances[min(getCalls, len(instances)-1)]
+				getCalls++
+				return body, nil
+			},
+		}
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		configuration["instance"] = "zones/us-central1-a/instances/my-vm"
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		require.NoError(t, component.Execute(core.ExecutionContext{Configuration: configuration, ExecutionState: state}))
+		return postedPaths, posted, state
+	}
+
+	t.Run("running Standard -> Spot stops, sets scheduling, restarts, emits", func(t *testing.T) {
+		postedPaths, posted, state := run(t, [][]byte{
+			instanceWithSchedulingJSON("RUNNING", standardScheduling),
+			instanceWithSchedulingJSON("RUNNING", spotScheduling),
+		}, map[string]any{"provisioningModel": "SPOT"})
+
+		assert.True(t, state.Passed)
+		assert.Equal(t, "gcp.compute.vmInstance.schedulingUpdated", state.Type)
+
+		require.Len(t, postedPaths, 3)
+		assert.True(t, strings.HasSuffix(postedPaths[0], "/stop"))
+		assert.Equal(t, "projects/my-project/zones/us-central1-a/instances/my-vm/setScheduling", postedPaths[1])
+		assert.True(t, strings.HasSuffix(postedPaths[2], "/start"))
+
+		require.NotNil(t, posted)
+		assert.Equal(t, "SPOT", posted.ProvisioningModel)
+		assert.True(t, posted.Preemptible)
+		assert.Equal(t, OnHostMaintenanceTerminate, posted.OnHostMaintenance)
+		assert.False(t, *posted.AutomaticRestart)
+		require.Len(t, posted.NodeAffinities, 1, "node affinities must be kept")
+		assert.Equal(t, "workload", posted.NodeAffinities[0].Key)
+
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "my-vm", data["name"])
+		assert.Equal(t, true, data["stopped"])
+		assert.Equal(t, map[string]any{
+			"provisioningModel": "SPOT",
+			"preemptible":       true,
+			"onHostMaintenance": "TERMINATE",
+			"automaticRestart":  false,
+		}, data["scheduling"])
+	})
+
+	t.Run("Spot -> Standard on a stopped instance skips stop and start", func(t *testing.T) {
+		postedPaths, posted, state := run(t, [][]byte{
+			instanceWithSchedulingJSON("TERMINATED", spotScheduling),
+		}, map[string]any{"provisioningModel": "STANDARD", "onHostMaintenance": "MIGRATE", "automaticRestart": true})
+
+		assert.True(t, state.Passed)
+		require.Len(t, postedPaths, 1)
+		assert.True(t, strings.HasSuffix(postedPaths[0], "/setScheduling"))
+		assert.Equal(t, "STANDARD", posted.ProvisioningModel)
+		assert.False(t, posted.Preemptible)
+		assert.Contains(t, posted.ForceSendFields, "Preemptible")
+		assert.Equal(t, OnHostMaintenanceMigrate, posted.OnHostMaintenance)
+		assert.True(t, *posted.AutomaticRestart)
+	})
+
+	t.Run("maintenance change on a running Standard instance is applied in place", func(t *testing.T) {
+		postedPaths, posted, state := run(t, [][]byte{
+			instanceWithSchedulingJSON("RUNNING", standardScheduling),
+		}, map[string]any{"provisioningModel": "STANDARD", "onHostMaintenance": "TERMINATE", "automaticRestart": false})
+
+		assert.True(t, state.Passed)
+		require.Len(t, postedPaths, 1)
+		assert.True(t, strings.HasSuffix(postedPaths[0], "/setScheduling"))
+		assert.Equal(t, OnHostMaintenanceTerminate, posted.OnHostMaintenance)
+		assert.False(t, *posted.AutomaticRestart)
+
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, false, data["stopped"])
+	})
+
+	t.Run("no restart after update leaves the instance stopped", func(t *testing.T) {
+		postedPaths, _, state := run(t, [][]byte{
+			instanceWithSchedulingJSON("RUNNING", standardScheduling),
+		}, map[string]any{"provisioningModel": "SPOT", "restartAfterUpdate": false})
+
+		assert.True(t, state.Passed)
+		require.Len(t, postedPaths, 2)
+		assert.True(t, strings.HasSuffix(postedPaths[0], "/stop"))
+		assert.True(t, strings.HasSuffix(postedPaths[1], "/setScheduling"))
+	})
+
+	t.Run("legacy preemptible instance is rejected without changes", func(t *testing.T) {
+		postedPaths, _, state := run(t, [][]byte{
+			instanceWithSchedulingJSON("RUNNING", map[string]any{"preemptible": true, "onHostMaintenance": "TERMINATE", "automaticRestart": false}),
+		}, map[string]any{"provisioningModel": "STANDARD"})
+
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "legacy preemptible instances cannot change scheduling")
+		assert.Empty(t, postedPaths)
+	})
+
+	t.Run("GPU instance cannot live-migrate", func(t *testing.T) {
+		postedPaths, _, state := run(t, [][]byte{
+			instanceWithSchedulingJSON("RUNNING", spotScheduling, "projects/my-project/zones/us-central1-a/acceleratorTypes/nvidia-tesla-t4"),
+		}, map[string]any{"provisioningModel": "STANDARD", "onHostMaintenance": "MIGRATE"})
+
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "instances with GPUs (nvidia-tesla-t4) must terminate on host maintenance")
+		assert.Empty(t, postedPaths)
+	})
+
+	t.Run("cross-project instance fails", func(t *testing.T) {
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) {
+			return &mockInstanceClient{projectID: "my-project"}, nil
+		})
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		require.NoError(t, component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"instance":          "projects/other/zones/us-central1-a/instances/my-vm",
+				"provisioningModel": "SPOT",
+			},
+			ExecutionState: state,
+		}))
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "cross-project operations are not supported")
+	})
+}
@@ -0,0 +1,232 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+	compute "google.golang.org/api/compute/v1"
+)
+
+const (
+	SnapshotScheduleFrequencyHourly = "hourly"
+	SnapshotScheduleFrequencyDaily  = "daily"
+	SnapshotScheduleFrequencyWeekly = "weekly"
+
+	defaultSnapshotScheduleStartTime = "04:00"
+	defaultSnapshotScheduleDayOfWeek = "SUNDAY"
+)
+
+// Snapshot schedules must start on the hour, in UTC.
+var snapshotScheduleStartTimeRegex = regexp.MustCompile(`^([01][0-9]|2[0-3]):00$`)
+
+var snapshotScheduleDaysOfWeek = []string{"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY", "SUNDAY"}
+
+// SnapshotScheduleEntry describes a snapshot schedule created inline by CreateVM
+// or by the Create Snapshot Schedule component.
+type SnapshotScheduleEntry struct {
+	Name          string `mapstructure:"name"`
+	Frequency     string `mapstructure:"frequency"`
+	DayOfWeek     string `mapstructure:"dayOfWeek"`
+	StartTime     string `mapstructure:"startTime"`
+	RetentionDays int64  `mapstructure:"retentionDays"`
+}
+
+// snapshotScheduleFields are the fields of a SnapshotScheduleEntry, shared by
+// the inline schedule of Create VM and the Create Snapshot Schedule component.
+func snapshotScheduleFields() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "name",
+			Label:       "Name",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Name of the snapshot schedule resource policy.",
+			Placeholder: "e.g. daily-14d",
+		},
+		{
+			Name:     "frequency",
+			Label:    "Frequency",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  SnapshotScheduleFrequencyDaily,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Hourly", Value: SnapshotScheduleFrequencyHourly},
+						{Label: "Daily", Value: SnapshotScheduleFrequencyDaily},
+						{Label: "Weekly", Value: SnapshotScheduleFrequencyWeekly},
+					},
+				},
+			},
+		},
+		{
+			Name:     "dayOfWeek",
+			Label:    "Day of week",
+			Type:     configuration.FieldTypeSelect,
+			Required: false,
+			Default:  defaultSnapshotScheduleDayOfWeek,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Monday", Value: "MONDAY"},
+						{Label: "Tuesday", Value: "TUESDAY"},
+						{Label: "Wednesday", Value: "WEDNESDAY"},
+						{Label: "Thursday", Value: "THURSDAY"},
+						{Label: "Friday", Value: "FRIDAY"},
+						{Label: "Saturday", Value: "SATURDAY"},
+						{Label: "Sunday", Value: "SUNDAY"},
+					},
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "frequency", Values: []string{SnapshotScheduleFrequencyWeekly}},
+			},
+		},
+		{
+			Name:        "startTime",
+			Label:       "Start time (UTC)",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Default:     defaultSnapshotScheduleStartTime,
+			Description: "Hour the snapshot window starts, in HH:00 format.",
+			Placeholder: "04:00",
+		},
+		{
+			Name:        "retentionDays",
+			Label:       "Retention (days)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    true,
+			Default:     14,
+			Description: "Snapshots older than this are deleted automatically.",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{Min: intPtr(1)},
+			},
+		},
+	}
+}
+
+func validateSnapshotScheduleEntry(e SnapshotScheduleEntry) error {
+	if !gcpInstanceNameRegex.MatchString(strings.TrimSpace(e.Name)) {
+		return fmt.Errorf("snapshot schedule name must be 1–63 characters: start with a lowercase letter, use only lowercase letters, digits, and hyphens, and end with a letter or digit")
+	}
+	if e.RetentionDays <= 0 {
+		return fmt.Errorf("snapshot schedule retention days must be greater than 0")
+	}
+
+	switch snapshotScheduleFrequency(e) {
+	case SnapshotScheduleFrequencyHourly, SnapshotScheduleFrequencyDaily:
+	case SnapshotScheduleFrequencyWeekly:
+		day := snapshotScheduleDayOfWeek(e)
+		found := false
+		for _, d := range snapshotScheduleDaysOfWeek {
+			if d == day {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("invalid snapshot schedule day of week %q", e.DayOfWeek)
+		}
+	default:
+		return fmt.Errorf("invalid snapshot schedule frequency %q: use hourly, daily, or weekly", e.Frequency)
+	}
+
+	if !snapshotScheduleStartTimeRegex.MatchString(snapshotScheduleStartTime(e)) {
+		return fmt.Errorf("snapshot schedule start time must be on the hour in HH:00 format (UTC), got %q", e.StartTime)
+	}
+	return nil
+}
+
+func snapshotScheduleFrequency(e SnapshotScheduleEntry) string {
+	frequency := strings.ToLower(strings.TrimSpace(e.Frequency))
+	if frequency == "" {
+		return SnapshotScheduleFrequencyDaily
+	}
+	return frequency
+}
+
+func snapshotScheduleStartTime(e SnapshotScheduleEntry) string {
+	startTime := strings.TrimSpace(e.StartTime)
+	if startTime == "" {
+		return defaultSnapshotScheduleStartTime
+	}
+	return startTime
+}
+
+func snapshotScheduleDayOfWeek(e SnapshotScheduleEntry) string {
+	day := strings.ToUpper(strings.TrimSpace(e.DayOfWeek))
+	if day == "" {
+		return defaultSnapshotScheduleDayOfWeek
+	}
+	return day
+}
+
+// BuildSnapshotSchedulePolicy builds the resource policy for an inline snapshot schedule.
+// Snapshots are kept when the source disk is deleted, matching the console default.
+func BuildSnapshotSchedulePolicy(region string, e SnapshotScheduleEntry) *compute.ResourcePolicy {
+	startTime := snapshotScheduleStartTime(e)
+	schedule := &compute.ResourcePolicySnapshotSchedulePolicySchedule{}
+	switch snapshotScheduleFrequency(e) {
+	case SnapshotScheduleFrequencyHourly:
+		schedule.HourlySchedule = &compute.ResourcePolicyHourlyCycle{HoursInCycle: 1, StartTime: startTime}
+	case SnapshotScheduleFrequencyWeekly:
+		schedule.WeeklySchedule = &compute.ResourcePolicyWeeklyCycle{
+			DayOfWeeks: []*compute.ResourcePolicyWeeklyCycleDayOfWeek{
+				{Day: snapshotScheduleDayOfWeek(e), StartTime: startTime},
+			},
+		}
+	default:
+		schedule.DailySchedule = &compute.ResourcePolicyDailyCycle{DaysInCycle: 1, StartTime: startTime}
+	}
+
+	return &compute.ResourcePolicy{
+		Name:   strings.TrimSpace(e.Name),
+		Region: region,
+		SnapshotSchedulePolicy: &compute.ResourcePolicySnapshotSchedulePolicy{
+			Schedule: schedule,
+			RetentionPolicy: &compute.ResourcePolicySnapshotSchedulePolicyRetentionPolicy{
+				MaxRetentionDays:   e.RetentionDays,
+				OnSourceDiskDelete: "KEEP_AUTO_SNAPSHOTS",
+			},
+		},
+	}
+}
+
+// EnsureSnapshotSchedule creates the snapshot schedule resource policy in the region and
+// returns its path. If a policy with the same name already exists (409), it is reused as is.
+func EnsureSnapshotSchedule(ctx context.Context, c Client, project, region string, e SnapshotScheduleEntry) (string, error) {
+	policyPath, _, err := createSnapshotSchedule(ctx, c, project, region, e, "")
+	return policyPath, err
+}
+
+func createSnapshotSchedule(ctx context.Context, c Client, project, region string, e SnapshotScheduleEntry, description string) (policyPath string, alreadyExisted bool, err error) {
+	if err := validateSnapshotScheduleEntry(e); err != nil {
+		return "", false, err
+	}
+
+	project = ensureProject(project, c)
+	policy := BuildSnapshotSchedulePolicy(region, e)
+	policy.Description = description
+	policyPath = fmt.Sprintf("projects/%s/regions/%s/resourcePolicies/%s", project, region, policy.Name)
+
+	body, err := c.Post(ctx, fmt.Sprintf("projects/%s/regions/%s/resourcePolicies", project, region), policy)
+	if err != nil {
+		if gcpcommon.IsAlreadyExistsError(err) {
+			return policyPath, true, nil
+		}
+		return "", false, err
+	}
+
+	opName, err := operationNameFromResponse(body, "create snapshot schedule")
+	if err != nil {
+		return "", false, err
+	}
+	if err := WaitForRegionOperation(ctx, c, project, region, opName); err != nil {
+		return "", false, err
+	}
+	return policyPath, false, nil
+}
@@ -0,0 +1,151 @@
+package compute
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func Test_validateSnapshotScheduleEntry(t *testing.T) {
+	valid := SnapshotScheduleEntry{Name: "daily-14d", Frequency: SnapshotScheduleFrequencyDaily, RetentionDays: 14}
+	require.NoError(t, validateSnapshotScheduleEntry(valid))
+
+	t.Run("retention days must be positive", func(t *testing.T) {
+		for _, days := range []int64{0, -1} {
+			e := valid
+			e.RetentionDays = days
+			require.ErrorContains(t, validateSnapshotScheduleEntry(e), "retention days must be greater than 0")
+		}
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		e := valid
+		e.Name = "Daily_Schedule"
+		require.ErrorContains(t, validateSnapshotScheduleEntry(e), "snapshot schedule name")
+	})
+
+	t.Run("start time must be on the hour", func(t *testing.T) {
+		e := valid
+		e.StartTime = "04:30"
+		require.ErrorContains(t, validateSnapshotScheduleEntry(e), "HH:00")
+	})
+
+	t.Run("unknown frequency", func(t *testing.T) {
+		e := valid
+		e.Frequency = "monthly"
+		require.ErrorContains(t, validateSnapshotScheduleEntry(e), "invalid snapshot schedule frequency")
+	})
+}
+
+func Test_BuildSnapshotSchedulePolicy(t *testing.T) {
+	t.Run("daily by default", func(t *testing.T) {
+		policy := BuildSnapshotSchedulePolicy("us-central1", SnapshotScheduleEntry{Name: "daily-14d", RetentionDays: 14})
+		require.NotNil(t, policy.SnapshotSchedulePolicy)
+		assert.Equal(t, "daily-14d", policy.Name)
+		assert.Equal(t, &compute.ResourcePolicyDailyCycle{DaysInCycle: 1, StartTime: "04:00"}, policy.SnapshotSchedulePolicy.Schedule.DailySchedule)
+		assert.Equal(t, int64(14), policy.SnapshotSchedulePolicy.RetentionPolicy.MaxRetentionDays)
+	})
+
+	t.Run("weekly uses the selected day", func(t *testing.T) {
+		policy := BuildSnapshotSchedulePolicy("us-central1", SnapshotScheduleEntry{
+			Name:          "weekly-90d",
+			Frequency:     SnapshotScheduleFrequencyWeekly,
+			DayOfWeek:     "friday",
+			StartTime:     "22:00",
+			RetentionDays: 90,
+		})
+		schedule := policy.SnapshotSchedulePolicy.Schedule
+		assert.Nil(t, schedule.DailySchedule)
+		require.NotNil(t, schedule.WeeklySchedule)
+		assert.Equal(t, []*compute.ResourcePolicyWeeklyCycleDayOfWeek{{Day: "FRIDAY", StartTime: "22:00"}}, schedule.WeeklySchedule.DayOfWeeks)
+	})
+}
+
+func Test_CreateVMAndWait_NewSnapshotSchedule(t *testing.T) {
+	config := CreateVMConfig{
+		InstanceName: "my-vm",
+		Zone:         "us-central1-a",
+		MachineType:  "e2-medium",
+		OSAndStorageConfig: OSAndStorageConfig{
+			BootDiskPublicImage: "projects/debian-cloud/global/images/family/debian-12",
+			BootDiskNewSnapshotSchedule: &SnapshotScheduleEntry{
+				Name:          "daily-14d",
+				Frequency:     SnapshotScheduleFrequencyDaily,
+				RetentionDays: 14,
+			},
+		},
+	}
+
+	newClient := func(policyErr error, posts *[]string, inserted **compute.Instance) *mockInstanceClient {
+		return &mockInstanceClient{
+			projectID: "my-project",
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				if isOperationPath(path) {
+					return opDone("op-1"), nil
+				}
+				return instanceGetJSON("123", "my-vm", "us-central1-a", "RUNNING", "e2-medium"), nil
+			},
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				*posts = append(*posts, path)
+				if strings.HasSuffix(path, "/resourcePolicies") {
+					if policyErr != nil {
+						return nil, policyErr
+					}
+					return opDone("op-policy"), nil
+				}
+				*inserted, _ = body.(*compute.Instance)
+				return opDone("op-1"), nil
+			},
+		}
+	}
+
+	t.Run("creates the schedule, then attaches it to the boot disk", func(t *testing.T) {
+		var posts []string
+		var inserted *compute.Instance
+		client := newClient(nil, &posts, &inserted)
+
+		_, err := CreateVMAndWait(context.Background(), client, config)
+		require.NoError(t, err)
+
+		require.Equal(t, []string{
+			"projects/my-project/regions/us-central1/resourcePolicies",
+			"projects/my-project/zones/us-central1-a/instances",
+		}, posts)
+		require.NotNil(t, inserted)
+		require.NotEmpty(t, inserted.Disks)
+		assert.Equal(t,
+			[]string{"projects/my-project/regions/us-central1/resourcePolicies/daily-14d"},
+			inserted.Disks[0].InitializeParams.ResourcePolicies,
+		)
+	})
+
+	t.Run("existing schedule is reused", func(t *testing.T) {
+		var posts []string
+		var inserted *compute.Instance
+		client := newClient(&gcpcommon.GCPAPIError{StatusCode: 409, Message: "already exists"}, &posts, &inserted)
+
+		_, err := CreateVMAndWait(context.Background(), client, config)
+		require.NoError(t, err)
+		require.NotNil(t, inserted)
+		assert.Equal(t,
+			[]string{"projects/my-project/regions/us-central1/resourcePolicies/daily-14d"},
+			inserted.Disks[0].InitializeParams.ResourcePolicies,
+		)
+	})
+
+	t.Run("other errors stop before the instance is inserted", func(t *testing.T) {
+		var posts []string
+		var inserted *compute.Instance
+		client := newClient(&gcpcommon.GCPAPIError{StatusCode: 403, Message: "forbidden"}, &posts, &inserted)
+
+		_, err := CreateVMAndWait(context.Background(), client, config)
+		require.ErrorContains(t, err, "snapshot schedule")
+		assert.Len(t, posts, 1)
+		assert.Nil(t, inserted)
+	})
+}
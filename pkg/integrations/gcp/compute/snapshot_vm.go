@@ -0,0 +1,362 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+	compute "google.golang.org/api/compute/v1"
+)
+
+const snapshotVMPayloadType = "gcp.compute.snapshot.created"
+
+type SnapshotVM struct{}
+
+type SnapshotVMSpec struct {
+	Instance     string       `mapstructure:"instance"`
+	SnapshotName string       `mapstructure:"snapshotName"`
+	GuestFlush   bool         `mapstructure:"guestFlush"`
+	Description  string       `mapstructure:"description"`
+	Labels       []LabelEntry `mapstructure:"labels"`
+}
+
+func (s *SnapshotVM) Name() string {
+	return "gcp.compute.snapshotVM"
+}
+
+func (s *SnapshotVM) Label() string {
+	return "Compute • Snapshot VM"
+}
+
+func (s *SnapshotVM) Description() string {
+	return "Snapshot the boot disk of a Google Compute Engine VM instance"
+}
+
+func (s *SnapshotVM) Documentation() string {
+	return `The Snapshot VM component creates a snapshot of a VM instance's boot disk and waits for it to be ready.
+
+## Use Cases
+
+- **Pre-change backups**: Snapshot a VM before an upgrade, migration, or risky deploy
+- **Scheduled backups**: Take consistent backups of running databases or file servers
+- **Cloning**: Capture a VM's disk to create new disks or images from
+
+## Configuration
+
+- **VM Instance**: The VM whose boot disk is snapshotted. Pick from the list or pass an expression chained from an upstream node (e.g. ` + "`selfLink`" + ` from ` + "`gcp.createVM`" + `).
+- **Snapshot name**: Optional name for the snapshot. Defaults to the instance name followed by a UTC timestamp.
+- **Flush guest before snapshot**: Take an application-consistent snapshot. The guest environment freezes the file systems first and runs the guest's pre-snapshot script (` + "`/etc/google/snapshots/pre.sh`" + ` on Linux, VSS writers on Windows), then thaws them and runs the post-snapshot script.
+- **Description**: Optional human-readable description.
+- **Labels**: Optional key-value labels for the snapshot.
+
+## Output
+
+Emits the created snapshot: snapshotId, name, selfLink, status, diskSizeGb, storageBytes, sourceDisk, instance, zone, guestFlush, labels, creationTimestamp.
+
+## Important Notes
+
+- The action fails if the instance does not exist or has no boot disk.
+- Guest flush needs a running VM with the guest environment installed, and on Linux snapshot scripts enabled in the guest agent configuration. It is skipped when the VM is not running, since a stopped VM's disk is already consistent; **guestFlush** in the output records whether it was used.
+- If the guest cannot flush, the snapshot operation fails rather than silently producing a crash-consistent snapshot.`
+}
+
+func (s *SnapshotVM) Icon() string {
+	return "camera"
+}
+
+func (s *SnapshotVM) Color() string {
+	return "blue"
+}
+
+func (s *SnapshotVM) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (s *SnapshotVM) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "instance",
+			Label:       "VM Instance",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The VM instance whose boot disk is snapshotted.",
+			Placeholder: "Select instance",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeInstance,
+				},
+			},
+		},
+		{
+			Name:        "snapshotName",
+			Label:       "Snapshot name",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Name for the snapshot. Start with a letter; use only a-z, 0-9, and hyphens; 1 to 63 characters. Defaults to the instance name and a UTC timestamp.",
+			Placeholder: "e.g. my-vm-before-upgrade",
+		},
+		{
+			Name:        "guestFlush",
+			Label:       "Flush guest before snapshot",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Freeze the guest file systems and run the guest's pre/post snapshot scripts for an application-consistent snapshot. Skipped when the VM is not running.",
+		},
+		{
+			Name:        "description",
+			Label:       "Description",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Placeholder: "Optional snapshot description",
+		},
+		{
+			Name:        "labels",
+			Label:       "Labels",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Togglable:   true,
+			Description: "Key-value labels for the snapshot (billing, environment, team).",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Label",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:        "key",
+								Label:       "Key",
+								Type:        configuration.FieldTypeString,
+								Required:    true,
+								Description: "Label key (e.g. env, team, cost-center).",
+								Placeholder: "e.g. env",
+							},
+							{
+								Name:        "value",
+								Label:       "Value",
+								Type:        configuration.FieldTypeString,
+								Required:    false,
+								Description: "Label value.",
+								Placeholder: "e.g. production",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *SnapshotVM) Setup(ctx core.SetupContext) error {
+	spec := SnapshotVMSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	instanceValue := strings.TrimSpace(spec.Instance)
+	if instanceValue == "" {
+		return fmt.Errorf("instance is required")
+	}
+
+	if err := validateSnapshotName(spec.SnapshotName); err != nil {
+		return err
+	}
+
+	return resolveInstanceNodeMetadata(ctx, instanceValue)
+}
+
+func validateSnapshotName(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.Contains(name, "{{") {
+		return nil
+	}
+	if !gcpInstanceNameRegex.MatchString(name) {
+		return fmt.Errorf("snapshot name must be 1–63 characters: start with a lowercase letter, use only lowercase letters (a-z), digits (0-9), and hyphens (-), and end with a letter or digit")
+	}
+	return nil
+}
+
+func (s *SnapshotVM) Execute(ctx core.ExecutionContext) error {
+	spec := SnapshotVMSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to decode configuration: %v", err))
+	}
+
+	if err := validateSnapshotName(spec.SnapshotName); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	urlProject, zone, instanceName, err := parseInstancePath(spec.Instance)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	project := client.ProjectID()
+	if urlProject != "" && urlProject != project {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf(
+			"instance belongs to project %q but this GCP integration is bound to project %q; cross-project snapshots are not supported",
+			urlProject, project,
+		))
+	}
+
+	payload, err := SnapshotVMAndWait(context.Background(), client, project, zone, instanceName, spec)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, snapshotVMPayloadType, []any{payload})
+}
+
+type snapshotInstanceResp struct {
+	Status string `json:"status"`
+	Disks  []struct {
+		Boot   bool   `json:"boot"`
+		Source string `json:"source"`
+	} `json:"disks"`
+}
+
+type snapshotGetResp struct {
+	Id                uint64            `json:"id,string"`
+	Name              string            `json:"name"`
+	SelfLink          string            `json:"selfLink"`
+	Status            string            `json:"status"`
+	DiskSizeGb        int64             `json:"diskSizeGb,string"`
+	StorageBytes      int64             `json:"storageBytes,string"`
+	SourceDisk        string            `json:"sourceDisk"`
+	CreationTimestamp string            `json:"creationTimestamp"`
+	Labels            map[string]string `json:"labels"`
+}
+
+// SnapshotVMAndWait snapshots the instance's boot disk, waits for the zone
+// operation and returns the created snapshot's payload.
+func SnapshotVMAndWait(ctx context.Context, client Client, project, zone, instanceName string, spec SnapshotVMSpec) (map[string]any, error) {
+	body, err := GetInstance(ctx, client, project, zone, instanceName)
+	if gcpcommon.IsNotFoundError(err) {
+		return nil, fmt.Errorf("VM instance %q not found in zone %q of project %q", instanceName, zone, project)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VM instance: %w", err)
+	}
+
+	var inst snapshotInstanceResp
+	if err := json.Unmarshal(body, &inst); err != nil {
+		return nil, fmt.Errorf("parse instance response: %w", err)
+	}
+
+	bootDisk := ""
+	for _, d := range inst.Disks {
+		if d.Boot {
+			bootDisk = lastSegment(d.Source)
+			break
+		}
+	}
+	if bootDisk == "" {
+		return nil, fmt.Errorf("VM instance %q has no boot disk to snapshot", instanceName)
+	}
+
+	// A stopped VM has no guest to flush, and its disk is already consistent.
+	guestFlush := spec.GuestFlush && inst.Status == instanceStatusRunning
+
+	name := strings.TrimSpace(spec.SnapshotName)
+	if name == "" {
+		name = defaultSnapshotName(instanceName, time.Now())
+	}
+
+	snapshot := &compute.Snapshot{
+		Name:        name,
+		Description: strings.TrimSpace(spec.Description),
+		Labels:      imageLabelsFromEntries(spec.Labels),
+	}
+
+	path := fmt.Sprintf("projects/%s/zones/%s/disks/%s/createSnapshot", project, zone, bootDisk)
+	if guestFlush {
+		path += "?guestFlush=true"
+	}
+
+	opBody, err := client.Post(ctx, path, snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot: %w", err)
+	}
+	opName, err := operationNameFromResponse(opBody, "create snapshot")
+	if err != nil {
+		return nil, err
+	}
+	if err := WaitForZoneOperation(ctx, client, project, zone, opName); err != nil {
+		return nil, fmt.Errorf("error waiting for create snapshot operation: %w", err)
+	}
+
+	snapBody, err := client.Get(ctx, fmt.Sprintf("projects/%s/global/snapshots/%s", project, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read created snapshot: %w", err)
+	}
+	var snap snapshotGetResp
+	if err := json.Unmarshal(snapBody, &snap); err != nil {
+		return nil, fmt.Errorf("parse snapshot response: %w", err)
+	}
+
+	payload := map[string]any{
+		"snapshotId":        fmt.Sprintf("%d", snap.Id),
+		"name":              snap.Name,
+		"selfLink":          snap.SelfLink,
+		"status":            snap.Status,
+		"diskSizeGb":        snap.DiskSizeGb,
+		"storageBytes":      snap.StorageBytes,
+		"sourceDisk":        lastSegment(snap.SourceDisk),
+		"instance":          instanceName,
+		"zone":              zone,
+		"guestFlush":        guestFlush,
+		"creationTimestamp": snap.CreationTimestamp,
+	}
+	if len(snap.Labels) > 0 {
+		payload["labels"] = snap.Labels
+	}
+	return payload, nil
+}
+
+// defaultSnapshotName is the instance name followed by a UTC timestamp,
+// shortened so the result stays within the 63 character resource name limit.
+func defaultSnapshotName(instanceName string, now time.Time) string {
+	suffix := now.UTC().Format("-20060102-150405")
+	base := instanceName
+	if max := 63 - len(suffix); len(base) > max {
+		base = strings.TrimRight(base[:max], "-")
+	}
+	return base + suffix
+}
+
+func (s *SnapshotVM) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (s *SnapshotVM) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (s *SnapshotVM) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (s *SnapshotVM) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (s *SnapshotVM) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (s *SnapshotVM) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
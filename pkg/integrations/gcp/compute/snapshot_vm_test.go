@@ -0,0 +1,239 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+	"github.com/superplanehq/superplane/test/support/contexts"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func snapshotInstanceJSON(status string) []byte {
+	b, _ := json.Marshal(map[string]any{
+		"name":   "my-vm",
+		"status": status,
+		"disks": []map[string]any{
+			{"boot": false, "source": "https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/disks/data"},
+			{"boot": true, "source": "https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/disks/my-vm-boot"},
+		},
+	})
+	return b
+}
+
+func snapshotGetJSON(name string) []byte {
+	b, _ := json.Marshal(map[string]any{
+		"id":                "987",
+		"name":              name,
+		"selfLink":          "https://www.googleapis.com/compute/v1/projects/my-project/global/snapshots/" + name,
+		"status":            "READY",
+		"diskSizeGb":        "10",
+		"storageBytes":      "2147483648",
+		"sourceDisk":        "https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/disks/my-vm-boot",
+		"creationTimestamp": "2026-06-02T12:00:00.000-07:00",
+		"labels":            map[string]string{"env": "prod"},
+	})
+	return b
+}
+
+func Test__SnapshotVM__Setup(t *testing.T) {
+	component := &SnapshotVM{}
+
+	t.Run("missing instance returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{},
+			Metadata:      &contexts.MetadataContext{},
+		})
+		require.ErrorContains(t, err, "instance is required")
+	})
+
+	t.Run("invalid snapshot name returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"instance":     "zones/us-central1-a/instances/my-vm",
+				"snapshotName": "My_Snapshot",
+			},
+			Metadata: &contexts.MetadataContext{},
+		})
+		require.ErrorContains(t, err, "snapshot name must be 1–63 characters")
+	})
+
+	t.Run("valid config stores parsed metadata", func(t *testing.T) {
+		meta := &contexts.MetadataContext{}
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"instance": "zones/us-central1-a/instances/my-vm"},
+			Metadata:      meta,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, VMInstanceNodeMetadata{InstanceName: "my-vm", Zone: "us-central1-a"}, meta.Get())
+	})
+}
+
+func Test__SnapshotVM__Execute(t *testing.T) {
+	component := &SnapshotVM{}
+
+	t.Run("running VM -> snapshots boot disk with guest flush, waits and emits", func(t *testing.T) {
+		var postedPath string
+		var postedSnapshot *compute.Snapshot
+		operationPolls := 0
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				postedPath = path
+				postedSnapshot = body.(*compute.Snapshot)
+				return []byte(`{"name":"op-snap","status":"RUNNING"}`), nil
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				switch {
+				case path == "projects/my-project/zones/us-central1-a/operations/op-snap":
+					operationPolls++
+					return opDone("op-snap"), nil
+				case path == "projects/my-project/zones/us-central1-a/instances/my-vm":
+					return snapshotInstanceJSON("RUNNING"), nil
+				case path == "projects/my-project/global/snapshots/my-vm-backup":
+					require.Equal(t, 1, operationPolls, "snapshot must be read after the operation completes")
+					return snapshotGetJSON("my-vm-backup"), nil
+				}
+				return nil, fmt.Errorf("unexpected GET %s", path)
+			},
+		}
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"instance":     "zones/us-central1-a/instances/my-vm",
+				"snapshotName": "my-vm-backup",
+				"guestFlush":   true,
+				"labels":       []map[string]any{{"key": "env", "value": "prod"}},
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+		assert.Equal(t, "projects/my-project/zones/us-central1-a/disks/my-vm-boot/createSnapshot?guestFlush=true", postedPath)
+		require.NotNil(t, postedSnapshot)
+		assert.Equal(t, "my-vm-backup", postedSnapshot.Name)
+		assert.Equal(t, map[string]string{"env": "prod"}, postedSnapshot.Labels)
+
+		assert.Equal(t, snapshotVMPayloadType, state.Type)
+		require.Len(t, state.Payloads, 1)
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "987", data["snapshotId"])
+		assert.Equal(t, "my-vm-backup", data["name"])
+		assert.Equal(t, "READY", data["status"])
+		assert.Equal(t, int64(10), data["diskSizeGb"])
+		assert.Equal(t, int64(2147483648), data["storageBytes"])
+		assert.Equal(t, "my-vm-boot", data["sourceDisk"])
+		assert.Equal(t, "my-vm", data["instance"])
+		assert.Equal(t, true, data["guestFlush"])
+	})
+
+	t.Run("stopped VM -> guest flush is skipped", func(t *testing.T) {
+		var postedPath string
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				postedPath = path
+				return opDone("op-snap"), nil
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				if isOperationPath(path) {
+					return opDone("op-snap"), nil
+				}
+				if strings.Contains(path, "/global/snapshots/") {
+					return snapshotGetJSON(lastSegment(path)), nil
+				}
+				return snapshotInstanceJSON("TERMINATED"), nil
+			},
+		}
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"instance":   "zones/us-central1-a/instances/my-vm",
+				"guestFlush": true,
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+		assert.Equal(t, "projects/my-project/zones/us-central1-a/disks/my-vm-boot/createSnapshot", postedPath)
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, false, data["guestFlush"])
+		assert.True(t, strings.HasPrefix(data["name"].(string), "my-vm-"))
+	})
+
+	t.Run("instance not found -> fails without snapshotting", func(t *testing.T) {
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				return nil, &gcpcommon.GCPAPIError{StatusCode: http.StatusNotFound, Message: "Instance not found"}
+			},
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				t.Fatalf("unexpected POST %s", path)
+				return nil, nil
+			},
+		}
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"instance": "zones/us-central1-a/instances/my-vm"},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Passed)
+		assert.Equal(t, `VM instance "my-vm" not found in zone "us-central1-a" of project "my-project"`, state.FailureMessage)
+	})
+
+	t.Run("failed snapshot operation -> fails execution", func(t *testing.T) {
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				return opDone("op-snap"), nil
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				if isOperationPath(path) {
+					return []byte(`{"name":"op-snap","status":"DONE","error":{"errors":[{"code":"GUEST_FLUSH_FAILED","message":"Guest flush failed"}]}}`), nil
+				}
+				return snapshotInstanceJSON("RUNNING"), nil
+			},
+		}
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"instance":   "zones/us-central1-a/instances/my-vm",
+				"guestFlush": true,
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "Guest flush failed")
+	})
+}
+
+func Test__defaultSnapshotName(t *testing.T) {
+	now := time.Date(2026, 6, 2, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, "my-vm-20260602-120000", defaultSnapshotName("my-vm", now))
+
+	long := defaultSnapshotName(strings.Repeat("a", 46)+"-"+strings.Repeat("b", 16), now)
+	assert.LessOrEqual(t, len(long), 63)
+	assert.Equal(t, strings.Repeat("a", 46)+"-20260602-120000", long)
+}
@@ -0,0 +1,91 @@
+package compute
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const metadataKeySSHKeys = "ssh-keys"
+
+var sshKeyUsernameRegex = regexp.MustCompile(`^[a-z_][a-z0-9._-]{0,31}$`)
+
+var sshPublicKeyTypes = map[string]bool{
+	"ssh-rsa":                            true,
+	"ssh-ed25519":                        true,
+	"ecdsa-sha2-nistp256":                true,
+	"ecdsa-sha2-nistp384":                true,
+	"ecdsa-sha2-nistp521":                true,
+	"sk-ssh-ed25519@openssh.com":         true,
+	"sk-ecdsa-sha2-nistp256@openssh.com": true,
+}
+
+// parseSSHKeyEntry validates a `user:key` entry, where key is an OpenSSH public
+// key line (type, base64 data and an optional comment), and returns it normalized.
+func parseSSHKeyEntry(entry string) (string, error) {
+	user, key, ok := strings.Cut(strings.TrimSpace(entry), ":")
+	if !ok {
+		return "", fmt.Errorf("must be in the form user:ssh-ed25519 AAAA... comment")
+	}
+
+	user = strings.TrimSpace(user)
+	if !sshKeyUsernameRegex.MatchString(user) {
+		return "", fmt.Errorf("invalid username %q: use up to 32 lowercase letters, digits, dots, underscores or hyphens, starting with a letter or underscore", user)
+	}
+
+	fields := strings.Fields(key)
+	if len(fields) < 2 {
+		return "", fmt.Errorf("public key for %q must include the key type and key data", user)
+	}
+	if !sshPublicKeyTypes[fields[0]] {
+		return "", fmt.Errorf("unsupported key type %q for %q", fields[0], user)
+	}
+	if _, err := base64.StdEncoding.DecodeString(fields[1]); err != nil {
+		return "", fmt.Errorf("public key data for %q is not valid base64", user)
+	}
+
+	return user + ":" + strings.Join(fields, " "), nil
+}
+
+// buildSSHKeysMetadataValue returns the `ssh-keys` metadata value: one
+// normalized `user:key` entry per line. Empty entries are skipped.
+func buildSSHKeysMetadataValue(entries []string) (string, error) {
+	lines := make([]string, 0, len(entries))
+	for i, entry := range entries {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		line, err := parseSSHKeyEntry(entry)
+		if err != nil {
+			return "", fmt.Errorf("SSH key %d: %w", i+1, err)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// validateInstanceSSHKeys checks the SSH keys field and rejects combinations
+// where the keys would be silently ignored or overwritten. Blocking project-wide
+// keys is fine: instance-level keys keep working when project keys are blocked.
+func validateInstanceSSHKeys(config CreateVMConfig) error {
+	value, err := buildSSHKeysMetadataValue(config.SSHKeys)
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		return nil
+	}
+
+	if config.EnableOSLogin {
+		return fmt.Errorf("SSH keys are ignored when OS Login is enabled: remove the SSH keys or disable OS Login")
+	}
+
+	for _, item := range config.MetadataItems {
+		if strings.TrimSpace(item.Key) == metadataKeySSHKeys {
+			return fmt.Errorf("set SSH keys either in the SSH keys field or as an %s metadata item, not both", metadataKeySSHKeys)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSSHKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl"
+
+func Test_buildSSHKeysMetadataValue(t *testing.T) {
+	t.Run("entries are normalized and joined one per line", func(t *testing.T) {
+		value, err := buildSSHKeysMetadataValue([]string{
+			" alice:" + testSSHKey + "   alice@laptop ",
+			"",
+			"deploy_bot: " + testSSHKey,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "alice:"+testSSHKey+" alice@laptop\ndeploy_bot:"+testSSHKey, value)
+	})
+
+	t.Run("missing username is rejected", func(t *testing.T) {
+		_, err := buildSSHKeysMetadataValue([]string{testSSHKey})
+		require.ErrorContains(t, err, "SSH key 1: must be in the form user:")
+	})
+
+	t.Run("invalid username is rejected", func(t *testing.T) {
+		_, err := buildSSHKeysMetadataValue([]string{"Alice:" + testSSHKey})
+		require.ErrorContains(t, err, `invalid username "Alice"`)
+	})
+
+	t.Run("unsupported key type is rejected", func(t *testing.T) {
+		_, err := buildSSHKeysMetadataValue([]string{"alice:ssh-foo AAAA"})
+		require.ErrorContains(t, err, `unsupported key type "ssh-foo"`)
+	})
+
+	t.Run("key data must be base64", func(t *testing.T) {
+		_, err := buildSSHKeysMetadataValue([]string{"alice:ssh-ed25519 not-base64!"})
+		require.ErrorContains(t, err, "not valid base64")
+	})
+
+	t.Run("key without data is rejected", func(t *testing.T) {
+		_, err := buildSSHKeysMetadataValue([]string{"alice:ssh-ed25519"})
+		require.ErrorContains(t, err, "must include the key type and key data")
+	})
+}
+
+func Test_validateInstanceSSHKeys(t *testing.T) {
+	t.Run("keys with blocked project keys are allowed", func(t *testing.T) {
+		config := CreateVMConfig{}
+		config.SSHKeys = []string{"alice:" + testSSHKey}
+		config.BlockProjectSSHKeys = true
+		require.NoError(t, validateInstanceSSHKeys(config))
+	})
+
+	t.Run("keys with OS Login are rejected", func(t *testing.T) {
+		config := CreateVMConfig{}
+		config.SSHKeys = []string{"alice:" + testSSHKey}
+		config.EnableOSLogin = true
+		require.ErrorContains(t, validateInstanceSSHKeys(config), "SSH keys are ignored when OS Login is enabled")
+	})
+
+	t.Run("keys with an ssh-keys metadata item are rejected", func(t *testing.T) {
+		config := CreateVMConfig{
+			MetadataItems: []MetadataKeyValue{{Key: "ssh-keys", Value: "bob:" + testSSHKey}},
+		}
+		config.SSHKeys = []string{"alice:" + testSSHKey}
+		require.ErrorContains(t, validateInstanceSSHKeys(config), "not both")
+	})
+
+	t.Run("no keys with OS Login is fine", func(t *testing.T) {
+		config := CreateVMConfig{}
+		config.EnableOSLogin = true
+		require.NoError(t, validateInstanceSSHKeys(config))
+	})
+}
+
+func Test_buildInstanceMetadataFromConfig_SSHKeys(t *testing.T) {
+	config := CreateVMConfig{StartupScript: "echo hi"}
+	config.SSHKeys = []string{"alice:" + testSSHKey, "bob:" + testSSHKey + " bob@host"}
+	config.BlockProjectSSHKeys = true
+
+	metadata := buildInstanceMetadataFromConfig(managementConfigFromCreateVMConfig(config), config)
+	require.NotNil(t, metadata)
+
+	values := map[string]string{}
+	for _, item := range metadata.Items {
+		values[item.Key] = *item.Value
+	}
+	assert.Equal(t, "echo hi", values[metadataKeyStartupScript])
+	assert.Equal(t, "true", values["block-project-ssh-keys"])
+	assert.Equal(t, "alice:"+testSSHKey+"\nbob:"+testSSHKey+" bob@host", values[metadataKeySSHKeys])
+
+	withoutKeys := buildInstanceMetadataFromConfig(ManagementConfig{}, CreateVMConfig{})
+	assert.Nil(t, withoutKeys)
+}
@@ -107,7 +107,7 @@ func WaitForRegionOperation(ctx context.Context, client Client, project, region,
 				if msg == "" {
 					msg = op.Error.Errors[0].Code
 				}
-				return fmt.Errorf("operation failed: %s", msg)
+				return &OperationFailedError{Message: msg}
 			}
 			return nil
 		case opStatusPending, opStatusRunning:
@@ -12,6 +12,7 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
 )
 
 type UpdateVMInstanceType struct{}
@@ -58,7 +59,7 @@ Returns the instance state after the update completes:
 
 - Changing the machine type requires the instance to be **stopped (TERMINATED)**. A running instance is stopped automatically before the change.
 - If **Restart after update** is enabled, the instance is started again once the new machine type is applied.
-- The new machine type must be available in the instance's zone.
+- The new machine type must be available in the instance's zone. It is validated before the instance is stopped.
 - The component waits for each underlying zone operation to complete before proceeding.`
 }
 
@@ -143,6 +144,9 @@ func (u *UpdateVMInstanceType) Execute(ctx core.ExecutionContext) error {
 	if machineType == "" {
 		return ctx.ExecutionState.Fail("error", "machineType is required")
 	}
+	if err := checkMachineFamilyAllowed(gcpcommon.AllowedMachineFamilies(ctx.Integration), machineType); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
 
 	urlProject, zone, instanceName, err := parseInstancePath(spec.Instance)
 	if err != nil {
@@ -170,6 +174,12 @@ func (u *UpdateVMInstanceType) Execute(ctx core.ExecutionContext) error {
 
 	callCtx := context.Background()
 
+	// Validate the target machine type before touching the instance, so an
+	// unavailable type never leaves a running instance stopped.
+	if err := ensureMachineTypeAvailable(callCtx, client, zone, machineType); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
 	body, err := GetInstance(callCtx, client, project, zone, instanceName)
 	if err != nil {
 		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to read instance: %v", err))
@@ -218,6 +228,18 @@ func (u *UpdateVMInstanceType) Execute(ctx core.ExecutionContext) error {
 	)
 }
 
+// ensureMachineTypeAvailable checks that the machine type exists in the given zone.
+func ensureMachineTypeAvailable(ctx context.Context, client Client, zone, machineType string) error {
+	name := lastSegment(machineType)
+	if _, err := GetMachineType(ctx, client, zone, name); err != nil {
+		if gcpcommon.IsNotFoundError(err) {
+			return fmt.Errorf("machine type %q is not available in zone %q", name, zone)
+		}
+		return fmt.Errorf("failed to validate machine type %q: %v", name, err)
+	}
+	return nil
+}
+
 // setMachineType issues the setMachineType POST and waits for the zone operation.
 func setMachineType(ctx context.Context, client Client, project, zone, instanceName, machineType string) error {
 	path := fmt.Sprintf("projects/%s/zones/%s/instances/%s/setMachineType", project, zone, instanceName)
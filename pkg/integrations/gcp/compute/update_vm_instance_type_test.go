@@ -2,12 +2,14 @@ package compute
 
 import (
 	"context"
+	"net/http"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
 	"github.com/superplanehq/superplane/test/support/contexts"
 )
 
@@ -51,6 +53,9 @@ func Test__UpdateVMInstanceType__Execute(t *testing.T) {
 				if isOperationPath(path) {
 					return opDone("op-1"), nil
 				}
+				if isMachineTypePath(path) {
+					return []byte(`{"name":"n2-standard-4"}`), nil
+				}
 				getCalls++
 				// First read: RUNNING (forces a stop). Final read: new type.
 				if getCalls == 1 {
@@ -99,6 +104,9 @@ func Test__UpdateVMInstanceType__Execute(t *testing.T) {
 				if isOperationPath(path) {
 					return opDone("op-1"), nil
 				}
+				if isMachineTypePath(path) {
+					return []byte(`{"name":"n2-standard-4"}`), nil
+				}
 				return instanceGetJSON("123", "my-vm", "us-central1-a", "TERMINATED", "n2-standard-4"), nil
 			},
 		}
@@ -120,4 +128,45 @@ func Test__UpdateVMInstanceType__Execute(t *testing.T) {
 		require.Len(t, postedPaths, 1)
 		assert.True(t, strings.HasSuffix(postedPaths[0], "/setMachineType"))
 	})
+
+	t.Run("machine type unavailable in zone -> fails without stopping", func(t *testing.T) {
+		var postedPaths []string
+		var machineTypePath string
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, path string, body any) ([]byte, error) {
+				postedPaths = append(postedPaths, path)
+				return opDone("op-1"), nil
+			},
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				if isMachineTypePath(path) {
+					machineTypePath = path
+					return nil, &gcpcommon.GCPAPIError{StatusCode: http.StatusNotFound, Message: "not found"}
+				}
+				return instanceGetJSON("123", "my-vm", "us-central1-a", "RUNNING", "e2-medium"), nil
+			},
+		}
+
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"instance":    "zones/us-central1-a/instances/my-vm",
+				"machineType": "a3-megagpu-8g",
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, `machine type "a3-megagpu-8g" is not available in zone "us-central1-a"`)
+		assert.Equal(t, "projects/my-project/zones/us-central1-a/machineTypes/a3-megagpu-8g", machineTypePath)
+		assert.Empty(t, postedPaths)
+	})
+}
+
+// isMachineTypePath reports whether a Get path targets a zone machine type.
+func isMachineTypePath(path string) bool {
+	return strings.Contains(path, "/machineTypes/")
 }
@@ -0,0 +1,366 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	OperationScopeZone   = "zone"
+	OperationScopeRegion = "region"
+	OperationScopeGlobal = "global"
+
+	waitForOperationPayloadType   = "gcp.compute.operation"
+	waitForOperationPassedChannel = "passed"
+	waitForOperationFailedChannel = "failed"
+)
+
+type WaitForOperation struct{}
+
+type WaitForOperationSpec struct {
+	Operation string `mapstructure:"operation"`
+	Scope     string `mapstructure:"scope"`
+	Region    string `mapstructure:"region"`
+	Zone      string `mapstructure:"zone"`
+}
+
+// operationRef identifies a Compute Engine operation and where it runs.
+type operationRef struct {
+	Project  string
+	Scope    string
+	Location string
+	Name     string
+}
+
+type operationGetResp struct {
+	Name          string `json:"name"`
+	OperationType string `json:"operationType"`
+	TargetLink    string `json:"targetLink"`
+	Status        string `json:"status"`
+	StartTime     string `json:"startTime"`
+	EndTime       string `json:"endTime"`
+	SelfLink      string `json:"selfLink"`
+}
+
+func (w *WaitForOperation) Name() string {
+	return "gcp.compute.waitForOperation"
+}
+
+func (w *WaitForOperation) Label() string {
+	return "Compute • Wait for Operation"
+}
+
+func (w *WaitForOperation) Description() string {
+	return "Wait for a Compute Engine operation to finish"
+}
+
+func (w *WaitForOperation) Documentation() string {
+	return `The Wait for Operation component waits for a Compute Engine operation started earlier in the workflow to finish.
+
+## Use Cases
+
+- **Fire-and-forget steps**: Start a long-running change, do other work, then wait for it before continuing
+- **Branching on results**: Route the workflow depending on whether the operation succeeded
+
+## Configuration
+
+- **Operation**: The operation name, or its selfLink (e.g. ` + "`projects/my-project/zones/us-central1-a/operations/operation-123`" + `). A selfLink already carries its scope and location, so the fields below are ignored.
+- **Scope**: Whether the operation is zonal, regional or global.
+- **Region**: The region of a regional operation, or the region used to filter zones.
+- **Zone**: The zone of a zonal operation.
+
+## Output
+
+- **Passed**: The operation finished without errors. Emits the operation name, operationType, targetLink, status, startTime and endTime.
+- **Failed**: The operation finished with an error. Emits the same fields and the error message.
+
+## Important Notes
+
+- Operations must belong to the project the GCP integration is bound to.
+- The component waits up to 10 minutes; polling errors and timeouts fail the execution.`
+}
+
+func (w *WaitForOperation) Icon() string {
+	return "hourglass"
+}
+
+func (w *WaitForOperation) Color() string {
+	return "gray"
+}
+
+func (w *WaitForOperation) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: waitForOperationPassedChannel, Label: "Passed"},
+		{Name: waitForOperationFailedChannel, Label: "Failed"},
+	}
+}
+
+func (w *WaitForOperation) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "operation",
+			Label:       "Operation",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "The operation name or selfLink.",
+			Placeholder: "e.g. operation-1717000000000-abc123",
+		},
+		{
+			Name:        "scope",
+			Label:       "Scope",
+			Type:        configuration.FieldTypeSelect,
+			Required:    false,
+			Default:     OperationScopeZone,
+			Description: "Where the operation runs. Ignored when the operation is a selfLink.",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Zonal", Value: OperationScopeZone},
+						{Label: "Regional", Value: OperationScopeRegion},
+						{Label: "Global", Value: OperationScopeGlobal},
+					},
+				},
+			},
+		},
+		{
+			Name:        "region",
+			Label:       "Region",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    false,
+			Description: "Region of a regional operation. Used to filter zones for zonal operations.",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeRegion,
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "scope", Values: []string{OperationScopeZone, OperationScopeRegion}},
+			},
+		},
+		{
+			Name:        "zone",
+			Label:       "Zone",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    false,
+			Description: "Zone of a zonal operation.",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeZone,
+					Parameters: []configuration.ParameterRef{
+						{Name: "region", ValueFrom: &configuration.ParameterValueFrom{Field: "region"}},
+					},
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "scope", Values: []string{OperationScopeZone}},
+			},
+		},
+	}
+}
+
+func (w *WaitForOperation) Setup(ctx core.SetupContext) error {
+	spec := WaitForOperationSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	operation := strings.TrimSpace(spec.Operation)
+	if operation == "" {
+		return errors.New("operation is required")
+	}
+
+	// Expressions are resolved at execution time, and their scope with them.
+	if strings.Contains(operation, "{{") {
+		return nil
+	}
+
+	_, err := resolveOperationRef(spec)
+	return err
+}
+
+// resolveOperationRef reads the operation location from a selfLink, or from
+// the scope, region and zone fields when only a name is given.
+func resolveOperationRef(spec WaitForOperationSpec) (operationRef, error) {
+	operation := strings.TrimSpace(spec.Operation)
+	if operation == "" {
+		return operationRef{}, errors.New("operation is required")
+	}
+
+	if strings.Contains(operation, "/") {
+		return parseOperationPath(operation)
+	}
+
+	ref := operationRef{Scope: strings.TrimSpace(spec.Scope), Name: operation}
+	if ref.Scope == "" {
+		ref.Scope = OperationScopeZone
+	}
+
+	switch ref.Scope {
+	case OperationScopeZone:
+		ref.Location = lastSegment(strings.TrimSpace(spec.Zone))
+		if ref.Location == "" {
+			return operationRef{}, errors.New("zone is required for a zonal operation")
+		}
+	case OperationScopeRegion:
+		ref.Location = lastSegment(strings.TrimSpace(spec.Region))
+		if ref.Location == "" {
+			return operationRef{}, errors.New("region is required for a regional operation")
+		}
+	case OperationScopeGlobal:
+	default:
+		return operationRef{}, fmt.Errorf("invalid scope %q", spec.Scope)
+	}
+
+	return ref, nil
+}
+
+// parseOperationPath parses an operation selfLink or resource path, e.g.
+// projects/p/zones/z/operations/op, projects/p/regions/r/operations/op or
+// projects/p/global/operations/op.
+func parseOperationPath(value string) (operationRef, error) {
+	path := strings.TrimPrefix(strings.TrimSpace(value), "https://www.googleapis.com/compute/v1/")
+	path = strings.TrimPrefix(path, "https://compute.googleapis.com/compute/v1/")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	ref := operationRef{}
+	if len(parts) >= 2 && parts[0] == "projects" {
+		ref.Project = parts[1]
+		parts = parts[2:]
+	}
+
+	switch {
+	case len(parts) == 4 && parts[0] == "zones" && parts[2] == "operations":
+		ref.Scope, ref.Location, ref.Name = OperationScopeZone, parts[1], parts[3]
+	case len(parts) == 4 && parts[0] == "regions" && parts[2] == "operations":
+		ref.Scope, ref.Location, ref.Name = OperationScopeRegion, parts[1], parts[3]
+	case len(parts) == 3 && parts[0] == "global" && parts[1] == "operations":
+		ref.Scope, ref.Name = OperationScopeGlobal, parts[2]
+	default:
+		return operationRef{}, fmt.Errorf("invalid operation %q: expected a name or a zones/, regions/ or global/ operation path", value)
+	}
+
+	if (ref.Scope != OperationScopeGlobal && ref.Location == "") || ref.Name == "" {
+		return operationRef{}, fmt.Errorf("invalid operation %q", value)
+	}
+
+	return ref, nil
+}
+
+func (ref operationRef) path(project string) string {
+	switch ref.Scope {
+	case OperationScopeZone:
+		return fmt.Sprintf("projects/%s/zones/%s/operations/%s", project, ref.Location, ref.Name)
+	case OperationScopeRegion:
+		return fmt.Sprintf("projects/%s/regions/%s/operations/%s", project, ref.Location, ref.Name)
+	default:
+		return fmt.Sprintf("projects/%s/global/operations/%s", project, ref.Name)
+	}
+}
+
+func (ref operationRef) wait(ctx context.Context, client Client, project string) error {
+	switch ref.Scope {
+	case OperationScopeZone:
+		return WaitForZoneOperation(ctx, client, project, ref.Location, ref.Name)
+	case OperationScopeRegion:
+		return WaitForRegionOperation(ctx, client, project, ref.Location, ref.Name)
+	default:
+		return WaitForGlobalOperation(ctx, client, project, ref.Name)
+	}
+}
+
+func (w *WaitForOperation) Execute(ctx core.ExecutionContext) error {
+	spec := WaitForOperationSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to decode configuration: %v", err))
+	}
+
+	ref, err := resolveOperationRef(spec)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	project := client.ProjectID()
+	if ref.Project != "" && ref.Project != project {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf(
+			"operation belongs to project %q but this GCP integration is bound to project %q",
+			ref.Project, project,
+		))
+	}
+
+	callCtx := context.Background()
+	channel := waitForOperationPassedChannel
+	errorMessage := ""
+	if err := ref.wait(callCtx, client, project); err != nil {
+		var opErr *OperationFailedError
+		if !errors.As(err, &opErr) {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("error waiting for operation %s: %v", ref.Name, err))
+		}
+		channel = waitForOperationFailedChannel
+		errorMessage = opErr.Message
+	}
+
+	body, err := client.Get(callCtx, ref.path(project))
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to read operation %s: %v", ref.Name, err))
+	}
+
+	var op operationGetResp
+	if err := json.Unmarshal(body, &op); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to parse operation %s: %v", ref.Name, err))
+	}
+
+	payload := map[string]any{
+		"name":          op.Name,
+		"scope":         ref.Scope,
+		"location":      ref.Location,
+		"operationType": op.OperationType,
+		"targetLink":    op.TargetLink,
+		"status":        op.Status,
+		"startTime":     op.StartTime,
+		"endTime":       op.EndTime,
+		"selfLink":      op.SelfLink,
+	}
+	if errorMessage != "" {
+		payload["error"] = errorMessage
+	}
+
+	return ctx.ExecutionState.Emit(channel, waitForOperationPayloadType, []any{payload})
+}
+
+func (w *WaitForOperation) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (w *WaitForOperation) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (w *WaitForOperation) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (w *WaitForOperation) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (w *WaitForOperation) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (w *WaitForOperation) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
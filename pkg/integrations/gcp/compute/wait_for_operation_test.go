@@ -0,0 +1,217 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func operationJSON(status string, errorMessage string) []byte {
+	body := map[string]any{
+		"name":          "op-123",
+		"operationType": "insert",
+		"targetLink":    "https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/instances/vm-1",
+		"status":        status,
+		"startTime":     "2025-02-14T04:00:00.000-08:00",
+		"selfLink":      "https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/operations/op-123",
+	}
+	if status == "DONE" {
+		body["endTime"] = "2025-02-14T04:00:41.000-08:00"
+	}
+	if errorMessage != "" {
+		body["error"] = map[string]any{"errors": []map[string]any{{"code": "QUOTA_EXCEEDED", "message": errorMessage}}}
+	}
+	b, _ := json.Marshal(body)
+	return b
+}
+
+func Test__WaitForOperation__Setup(t *testing.T) {
+	component := &WaitForOperation{}
+
+	t.Run("missing operation returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"scope": "zone", "zone": "us-central1-a"}})
+		require.ErrorContains(t, err, "operation is required")
+	})
+
+	t.Run("zonal operation name without zone returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"operation": "op-123", "scope": "zone"}})
+		require.ErrorContains(t, err, "zone is required")
+	})
+
+	t.Run("regional operation name without region returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"operation": "op-123", "scope": "region"}})
+		require.ErrorContains(t, err, "region is required")
+	})
+
+	t.Run("selfLink does not need a scope", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{
+			"operation": "https://www.googleapis.com/compute/v1/projects/my-project/regions/us-central1/operations/op-123",
+		}})
+		require.NoError(t, err)
+	})
+
+	t.Run("expression is not validated", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"operation": "{{ $['Create VM'].data.operation }}"}})
+		require.NoError(t, err)
+	})
+}
+
+func Test__ResolveOperationRef(t *testing.T) {
+	cases := []struct {
+		name string
+		spec WaitForOperationSpec
+		want operationRef
+	}{
+		{
+			name: "zonal name",
+			spec: WaitForOperationSpec{Operation: "op-1", Scope: "zone", Zone: "us-central1-a"},
+			want: operationRef{Scope: OperationScopeZone, Location: "us-central1-a", Name: "op-1"},
+		},
+		{
+			name: "scope defaults to zonal",
+			spec: WaitForOperationSpec{Operation: "op-1", Zone: "projects/my-project/zones/us-central1-a"},
+			want: operationRef{Scope: OperationScopeZone, Location: "us-central1-a", Name: "op-1"},
+		},
+		{
+			name: "regional name",
+			spec: WaitForOperationSpec{Operation: "op-1", Scope: "region", Region: "us-central1"},
+			want: operationRef{Scope: OperationScopeRegion, Location: "us-central1", Name: "op-1"},
+		},
+		{
+			name: "global name",
+			spec: WaitForOperationSpec{Operation: "op-1", Scope: "global"},
+			want: operationRef{Scope: OperationScopeGlobal, Name: "op-1"},
+		},
+		{
+			name: "zonal selfLink",
+			spec: WaitForOperationSpec{Operation: "https://www.googleapis.com/compute/v1/projects/p/zones/europe-west1-b/operations/op-2", Scope: "global"},
+			want: operationRef{Project: "p", Scope: OperationScopeZone, Location: "europe-west1-b", Name: "op-2"},
+		},
+		{
+			name: "global path",
+			spec: WaitForOperationSpec{Operation: "projects/p/global/operations/op-3"},
+			want: operationRef{Project: "p", Scope: OperationScopeGlobal, Name: "op-3"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ref, err := resolveOperationRef(tc.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, ref)
+		})
+	}
+
+	t.Run("unknown path returns error", func(t *testing.T) {
+		_, err := resolveOperationRef(WaitForOperationSpec{Operation: "projects/p/zones/z/instances/vm-1"})
+		require.ErrorContains(t, err, "invalid operation")
+	})
+}
+
+func Test__WaitForOperation__Execute(t *testing.T) {
+	component := &WaitForOperation{}
+
+	t.Run("zonal operation reaching DONE emits on passed", func(t *testing.T) {
+		polls := 0
+		var paths []string
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				paths = append(paths, path)
+				polls++
+				if polls == 1 {
+					return operationJSON("RUNNING", ""), nil
+				}
+				return operationJSON("DONE", ""), nil
+			},
+		}
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"operation": "op-123", "scope": "zone", "zone": "us-central1-a"},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+		assert.Equal(t, waitForOperationPassedChannel, state.Channel)
+		assert.Equal(t, "gcp.compute.operation", state.Type)
+		for _, path := range paths {
+			assert.Equal(t, "projects/my-project/zones/us-central1-a/operations/op-123", path)
+		}
+		assert.GreaterOrEqual(t, polls, 3)
+
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "op-123", data["name"])
+		assert.Equal(t, "DONE", data["status"])
+		assert.Equal(t, "insert", data["operationType"])
+		assert.Equal(t, "us-central1-a", data["location"])
+		assert.NotContains(t, data, "error")
+	})
+
+	t.Run("operation finished with an error emits on failed", func(t *testing.T) {
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				assert.Equal(t, "projects/my-project/regions/us-central1/operations/op-123", path)
+				return operationJSON("DONE", "Quota 'CPUS' exceeded"), nil
+			},
+		}
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"operation": "projects/my-project/regions/us-central1/operations/op-123"},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, waitForOperationFailedChannel, state.Channel)
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "Quota 'CPUS' exceeded", data["error"])
+	})
+
+	t.Run("operation in another project fails", func(t *testing.T) {
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) {
+			return &mockInstanceClient{projectID: "my-project"}, nil
+		})
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"operation": "projects/other-project/global/operations/op-123"},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, `operation belongs to project "other-project"`)
+	})
+
+	t.Run("polling error fails the execution", func(t *testing.T) {
+		mc := &mockInstanceClient{
+			projectID: "my-project",
+			getFunc: func(ctx context.Context, path string) ([]byte, error) {
+				return nil, &gcpcommon.GCPAPIError{StatusCode: http.StatusNotFound, Message: "operation not found"}
+			},
+		}
+		SetClientFactory(func(ctx core.ExecutionContext) (Client, error) { return mc, nil })
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"operation": "op-123", "scope": "global"},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "error waiting for operation op-123")
+	})
+}
@@ -18,9 +18,11 @@ import (
 	"github.com/superplanehq/superplane/pkg/integrations/gcp/cloudbuild"
 	"github.com/superplanehq/superplane/pkg/integrations/gcp/clouddns"
 	"github.com/superplanehq/superplane/pkg/integrations/gcp/cloudfunctions"
+	"github.com/superplanehq/superplane/pkg/integrations/gcp/cloudrun"
 	"github.com/superplanehq/superplane/pkg/integrations/gcp/cloudsql"
 	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
 	"github.com/superplanehq/superplane/pkg/integrations/gcp/compute"
+	"github.com/superplanehq/superplane/pkg/integrations/gcp/iam"
 	"github.com/superplanehq/superplane/pkg/integrations/gcp/monitoring"
 	gcpprometheus "github.com/superplanehq/superplane/pkg/integrations/gcp/prometheus"
 	gcppubsub "github.com/superplanehq/superplane/pkg/integrations/gcp/pubsub"
@@ -39,6 +41,9 @@ func init() {
 	cloudfunctions.SetClientFactory(func(httpCtx core.HTTPContext, integration core.IntegrationContext) (cloudfunctions.Client, error) {
 		return gcpcommon.NewClient(httpCtx, integration)
 	})
+	cloudrun.SetClientFactory(func(httpCtx core.HTTPContext, integration core.IntegrationContext) (cloudrun.Client, error) {
+		return gcpcommon.NewClient(httpCtx, integration)
+	})
 	artifactregistry.SetClientFactory(func(httpCtx core.HTTPContext, integration core.IntegrationContext) (artifactregistry.Client, error) {
 		return gcpcommon.NewClient(httpCtx, integration)
 	})
@@ -48,6 +53,9 @@ func init() {
 	cloudsql.SetClientFactory(func(httpCtx core.HTTPContext, integration core.IntegrationContext) (cloudsql.Client, error) {
 		return gcpcommon.NewClient(httpCtx, integration)
 	})
+	iam.SetClientFactory(func(httpCtx core.HTTPContext, integration core.IntegrationContext) (iam.Client, error) {
+		return gcpcommon.NewClient(httpCtx, integration)
+	})
 	storage.SetClientFactory(func(httpCtx core.HTTPContext, integration core.IntegrationContext) (storage.Client, error) {
 		return gcpcommon.NewClient(httpCtx, integration)
 	})
@@ -79,6 +87,9 @@ type Configuration struct {
 	ServiceAccountKey         string `json:"serviceAccountKey" mapstructure:"serviceAccountKey"`
 	WorkloadIdentityProvider  string `json:"workloadIdentityProvider" mapstructure:"workloadIdentityProvider"`
 	WorkloadIdentityProjectID string `json:"workloadIdentityProjectId" mapstructure:"workloadIdentityProjectId"`
+	AllowedRegions            string `json:"allowedRegions" mapstructure:"allowedRegions"`
+	AllowedMachineFamilies    string `json:"allowedMachineFamilies" mapstructure:"allowedMachineFamilies"`
+	APIEndpoint               string `json:"apiEndpoint" mapstructure:"apiEndpoint"`
 }
 
 func (g *GCP) Name() string {
@@ -118,7 +129,7 @@ func (g *GCP) Instructions() string {
 
 - ` + "`roles/logging.configWriter`" + ` — create logging sinks for event triggers
 - ` + "`roles/pubsub.admin`" + ` — manage Pub/Sub topics, subscriptions, and IAM policies for event delivery
-- Additional roles depending on which components you use (e.g. ` + "`roles/compute.admin`" + ` for VM management, ` + "`roles/compute.securityAdmin`" + ` to create, update, and delete firewall rules, ` + "`roles/iam.serviceAccountViewer`" + ` to populate the firewall service-account picker, ` + "`roles/monitoring.viewer`" + ` to read VM metrics, ` + "`roles/cloudsql.admin`" + ` to manage Cloud SQL databases and instances, ` + "`roles/storage.admin`" + ` to manage Cloud Storage buckets)`
+- Additional roles depending on which components you use (e.g. ` + "`roles/compute.admin`" + ` for VM management, ` + "`roles/compute.securityAdmin`" + ` to create, update, and delete firewall rules, ` + "`roles/compute.networkAdmin`" + ` to create VPC networks and subnetworks, ` + "`roles/iam.serviceAccountViewer`" + ` to populate the firewall service-account picker, ` + "`roles/monitoring.viewer`" + ` to read VM metrics, ` + "`roles/cloudsql.admin`" + ` to manage Cloud SQL databases and instances, ` + "`roles/storage.admin`" + ` to manage Cloud Storage buckets, ` + "`roles/iam.securityReviewer`" + ` to list the service account's project roles)`
 }
 
 func (g *GCP) Configuration() []configuration.Field {
@@ -172,6 +183,30 @@ func (g *GCP) Configuration() []configuration.Field {
 				{Field: "connectionMethod", Values: []string{ConnectionMethodWIF}},
 			},
 		},
+		{
+			Name:        gcpcommon.ConfigNameAllowedRegions,
+			Label:       "Allowed Regions",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Comma-separated list of Compute Engine regions components may use. Leave empty to allow all regions.",
+			Placeholder: "e.g. us-central1, europe-west1",
+		},
+		{
+			Name:        gcpcommon.ConfigNameAllowedMachineFamilies,
+			Label:       "Allowed Machine Families",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Comma-separated list of Compute Engine machine families VMs may use. Leave empty to allow all families.",
+			Placeholder: "e.g. e2, n2, n2d",
+		},
+		{
+			Name:        gcpcommon.ConfigNameAPIEndpoint,
+			Label:       "Compute API Endpoint",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Override the Compute Engine API base URL, e.g. for an emulator or a Private Service Connect endpoint. Leave empty to use https://compute.googleapis.com/compute/v1.",
+			Placeholder: "e.g. https://compute-myendpoint.p.googleapis.com/compute/v1",
+		},
 	}
 }
 
@@ -182,13 +217,22 @@ func (g *GCP) Actions() []core.Action {
 		&compute.GetVMInstance{},
 		&compute.ManageVMInstancePower{},
 		&compute.UpdateVMInstanceType{},
+		&compute.SetVMLabels{},
+		&compute.SetDeletionProtection{},
+		&compute.SetVMScheduling{},
+		&compute.AttachAccelerator{},
 		&compute.GetVMInstanceMetrics{},
+		&compute.SnapshotVM{},
 		&compute.CreateImage{},
 		&compute.UpdateImage{},
 		&compute.DeleteImage{},
 		&compute.CreateStaticIP{},
 		&compute.DeleteStaticIP{},
 		&compute.ManageStaticIP{},
+		&compute.CreateNetwork{},
+		&compute.CreateSubnetwork{},
+		&compute.CreateSnapshotSchedule{},
+		&compute.WaitForOperation{},
 		&compute.CreateLoadBalancer{},
 		&compute.DeleteLoadBalancer{},
 		&compute.CreateFirewall{},
@@ -198,6 +242,7 @@ func (g *GCP) Actions() []core.Action {
 		&cloudbuild.GetBuild{},
 		&cloudbuild.RunTrigger{},
 		&cloudfunctions.InvokeFunction{},
+		&cloudrun.DeployService{},
 		&artifactregistry.GetArtifact{},
 		&artifactregistry.GetArtifactAnalysis{},
 		&gcppubsub.PublishMessage{},
@@ -224,6 +269,7 @@ func (g *GCP) Actions() []core.Action {
 		&storage.CreateBucket{},
 		&storage.GetBucket{},
 		&storage.DeleteBucket{},
+		&iam.GetServiceAccountRoles{},
 		&gcpprometheus.Query{},
 		&gcpprometheus.QueryRange{},
 	}
@@ -232,6 +278,7 @@ func (g *GCP) Actions() []core.Action {
 func (g *GCP) Triggers() []core.Trigger {
 	return []core.Trigger{
 		&compute.OnVMInstance{},
+		&compute.OnVMStatusChanged{},
 		&cloudbuild.OnBuildComplete{},
 		&artifactregistry.OnArtifactPush{},
 		&artifactregistry.OnArtifactAnalysis{},
@@ -246,6 +293,14 @@ func (g *GCP) Sync(ctx core.SyncContext) error {
 		return fmt.Errorf("failed to decode configuration: %w", err)
 	}
 
+	if _, err := gcpcommon.ParseAPIEndpoint(config.APIEndpoint); err != nil {
+		return err
+	}
+
+	// Secrets are replaced during sync, so clients must not reuse cached credentials.
+	gcpcommon.InvalidateCredentialsCache(ctx.Integration.ID())
+	defer gcpcommon.InvalidateCredentialsCache(ctx.Integration.ID())
+
 	switch strings.TrimSpace(config.ConnectionMethod) {
 	case ConnectionMethodServiceAccountKey:
 		return g.syncServiceAccountKey(ctx, config)
@@ -959,15 +1014,15 @@ func (g *GCP) ListResources(resourceType string, ctx core.ListResourcesContext)
 		}
 		return cloudfunctions.ListFunctionResources(reqCtx, client, p["projectId"], p["location"])
 	case compute.ResourceTypeRegion:
-		return compute.ListRegionResources(reqCtx, client)
+		return compute.ListRegionResources(reqCtx, client, gcpcommon.AllowedRegions(ctx.Integration))
 	case compute.ResourceTypeZone:
 		return compute.ListZoneResources(reqCtx, client, p["region"])
 	case compute.ResourceTypeMachineFamily:
-		return compute.ListMachineFamilyResources(reqCtx, client, p["zone"])
+		return compute.ListMachineFamilyResources(reqCtx, client, p["zone"], gcpcommon.AllowedMachineFamilies(ctx.Integration))
 	case compute.ResourceTypeMachineType:
-		return compute.ListMachineTypeResources(reqCtx, client, p["zone"], p["machineFamily"])
+		return compute.ListMachineTypeResources(reqCtx, client, p["zone"], p["machineFamily"], p["provisioningModel"], gcpcommon.AllowedMachineFamilies(ctx.Integration))
 	case compute.ResourceTypeInstanceMachineType:
-		return compute.ListMachineTypeResourcesForInstance(reqCtx, client, p["instance"])
+		return compute.ListMachineTypeResourcesForInstance(reqCtx, client, p["instance"], gcpcommon.AllowedMachineFamilies(ctx.Integration))
 	case compute.ResourceTypePublicImages:
 		return compute.ListPublicImageResources(reqCtx, client, p["project"])
 	case compute.ResourceTypeCustomImages:
@@ -999,7 +1054,7 @@ func (g *GCP) ListResources(resourceType string, ctx core.ListResourcesContext)
 	case compute.ResourceTypeServiceAccount:
 		return compute.ListServiceAccountResources(reqCtx, client, p["project"])
 	case compute.ResourceTypeInstance:
-		return compute.ListInstanceResources(reqCtx, client, p["project"])
+		return compute.ListInstanceResources(reqCtx, client, p["project"], p["zone"], p["region"])
 	case clouddns.ResourceTypeManagedZone:
 		return clouddns.ListManagedZoneResources(reqCtx, client, p["projectId"])
 	case monitoring.ResourceTypeAlertPolicy:
@@ -1,10 +1,17 @@
 package gcp
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+	"github.com/superplanehq/superplane/pkg/integrations/gcp/compute"
+	testcontexts "github.com/superplanehq/superplane/test/support/contexts"
 )
 
 func Test_validateAndParseServiceAccountKey(t *testing.T) {
@@ -51,3 +58,44 @@ func Test_validateAndParseServiceAccountKey(t *testing.T) {
 		assert.Equal(t, "sa@proj.iam.gserviceaccount.com", meta.ClientEmail)
 	})
 }
+
+func Test_ListResources_Instance(t *testing.T) {
+	var requestedPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		if r.Method != http.MethodGet || r.URL.Path != "/compute/v1/projects/demo-project/zones/us-central1-a/instances" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.String())
+		}
+		_, _ = w.Write([]byte(`{"items":[{"name":"web-1","status":"RUNNING","zone":"https://www.googleapis.com/compute/v1/projects/demo-project/zones/us-central1-a"}]}`))
+	}))
+	defer server.Close()
+
+	baseURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	resources, err := (&GCP{}).ListResources(compute.ResourceTypeInstance, core.ListResourcesContext{
+		HTTP: &rewriteHTTPContext{
+			baseURL: baseURL,
+			client:  server.Client(),
+		},
+		Integration: &testcontexts.IntegrationContext{
+			Metadata: gcpcommon.Metadata{
+				ProjectID:  "demo-project",
+				AuthMethod: gcpcommon.AuthMethodWIF,
+			},
+			CurrentSecrets: map[string]core.IntegrationSecret{
+				gcpcommon.SecretNameAccessToken: {
+					Name:  gcpcommon.SecretNameAccessToken,
+					Value: []byte("test-access-token"),
+				},
+			},
+		},
+		Parameters: map[string]string{"zone": "us-central1-a"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "/compute/v1/projects/demo-project/zones/us-central1-a/instances", requestedPath)
+	assert.Equal(t, []core.IntegrationResource{
+		{Type: compute.ResourceTypeInstance, Name: "web-1 (us-central1-a, RUNNING)", ID: "zones/us-central1-a/instances/web-1"},
+	}, resources)
+}
@@ -0,0 +1,40 @@
+package iam
+
+import (
+	"context"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// resourceManagerBaseURL is the Cloud Resource Manager API used to read
+// project IAM policies. It is hosted on its own host, so every call uses the
+// fully-qualified *URL helpers.
+const resourceManagerBaseURL = "https://cloudresourcemanager.googleapis.com/v1"
+
+// Client is the interface used by the IAM components.
+type Client interface {
+	PostURL(ctx context.Context, fullURL string, body any) ([]byte, error)
+	ProjectID() string
+}
+
+var (
+	clientFactoryMu sync.RWMutex
+	clientFactory   func(httpCtx core.HTTPContext, integration core.IntegrationContext) (Client, error)
+)
+
+func SetClientFactory(fn func(httpCtx core.HTTPContext, integration core.IntegrationContext) (Client, error)) {
+	clientFactoryMu.Lock()
+	defer clientFactoryMu.Unlock()
+	clientFactory = fn
+}
+
+func getClient(httpCtx core.HTTPContext, integration core.IntegrationContext) (Client, error) {
+	clientFactoryMu.RLock()
+	fn := clientFactory
+	clientFactoryMu.RUnlock()
+	if fn == nil {
+		panic("gcp iam: SetClientFactory was not called by the gcp integration")
+	}
+	return fn(httpCtx, integration)
+}
@@ -0,0 +1,126 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+)
+
+// serviceAccountRolesPayloadType is the type tag emitted by Get Service Account Roles.
+const serviceAccountRolesPayloadType = "gcp.iam.serviceAccountRoles"
+
+// roleHintSecurityReviewer is the read-only role that grants
+// resourcemanager.projects.getIamPolicy.
+const roleHintSecurityReviewer = "roles/iam.securityReviewer"
+
+// policyVersion 3 is requested so conditional role bindings are returned with their conditions.
+const policyVersion = 3
+
+// Policy models the subset of a project IAM policy the components use.
+type Policy struct {
+	Version  int       `json:"version"`
+	Etag     string    `json:"etag"`
+	Bindings []Binding `json:"bindings"`
+}
+
+type Binding struct {
+	Role      string     `json:"role"`
+	Members   []string   `json:"members"`
+	Condition *Condition `json:"condition,omitempty"`
+}
+
+type Condition struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Expression  string `json:"expression,omitempty"`
+}
+
+func getIamPolicyURL(project string) string {
+	return fmt.Sprintf("%s/projects/%s:getIamPolicy", resourceManagerBaseURL, url.PathEscape(project))
+}
+
+// getProjectIamPolicy reads the IAM policy set directly on the project.
+func getProjectIamPolicy(ctx context.Context, client Client, project string) (*Policy, error) {
+	body := map[string]any{
+		"options": map[string]any{"requestedPolicyVersion": policyVersion},
+	}
+
+	respBody, err := client.PostURL(ctx, getIamPolicyURL(project), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy Policy
+	if err := json.Unmarshal(respBody, &policy); err != nil {
+		return nil, fmt.Errorf("parse IAM policy response: %w", err)
+	}
+	return &policy, nil
+}
+
+// serviceAccountMember is the IAM policy member for a service account email.
+func serviceAccountMember(email string) string {
+	return "serviceAccount:" + strings.TrimSpace(email)
+}
+
+// bindingsForMember returns the bindings that grant a role to the member.
+// IAM member identifiers are matched case-insensitively, as GCP does.
+func bindingsForMember(policy *Policy, member string) []Binding {
+	var bindings []Binding
+	for _, binding := range policy.Bindings {
+		for _, m := range binding.Members {
+			if strings.EqualFold(m, member) {
+				bindings = append(bindings, binding)
+				break
+			}
+		}
+	}
+	return bindings
+}
+
+// serviceAccountRolesPayload converts the matching bindings into the component output payload.
+func serviceAccountRolesPayload(project, email string, bindings []Binding) map[string]any {
+	roles := []string{}
+	seen := map[string]bool{}
+	grants := make([]map[string]any, 0, len(bindings))
+	for _, binding := range bindings {
+		if !seen[binding.Role] {
+			seen[binding.Role] = true
+			roles = append(roles, binding.Role)
+		}
+
+		grant := map[string]any{"role": binding.Role}
+		if binding.Condition != nil {
+			grant["condition"] = map[string]any{
+				"title":      binding.Condition.Title,
+				"expression": binding.Condition.Expression,
+			}
+		}
+		grants = append(grants, grant)
+	}
+	sort.Strings(roles)
+
+	return map[string]any{
+		"projectId":      project,
+		"serviceAccount": email,
+		"member":         serviceAccountMember(email),
+		"roles":          roles,
+		"bindings":       grants,
+	}
+}
+
+// apiErrorMessage formats an API error for the execution state, appending the
+// IAM role the component needs on a 403.
+func apiErrorMessage(action string, err error, roleHint string) string {
+	var apiErr *gcpcommon.GCPAPIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden {
+		return fmt.Sprintf("%s: %v — ensure the integration's service account has the %s IAM role", action, err, roleHint)
+	}
+	return fmt.Sprintf("%s: %v", action, err)
+}
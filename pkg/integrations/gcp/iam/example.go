@@ -0,0 +1,20 @@
+package iam
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/utils"
+)
+
+//go:embed example_output_get_service_account_roles.json
+var exampleOutputGetServiceAccountRolesBytes []byte
+
+var (
+	exampleOutputGetServiceAccountRolesOnce sync.Once
+	exampleOutputGetServiceAccountRoles     map[string]any
+)
+
+func (g *GetServiceAccountRoles) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputGetServiceAccountRolesOnce, exampleOutputGetServiceAccountRolesBytes, &exampleOutputGetServiceAccountRoles)
+}
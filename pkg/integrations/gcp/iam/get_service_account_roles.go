@@ -0,0 +1,163 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+)
+
+type GetServiceAccountRoles struct{}
+
+type GetServiceAccountRolesSpec struct {
+	ServiceAccount string `json:"serviceAccount" mapstructure:"serviceAccount"`
+}
+
+func (g *GetServiceAccountRoles) Name() string {
+	return "gcp.iam.getServiceAccountRoles"
+}
+
+func (g *GetServiceAccountRoles) Label() string {
+	return "IAM • Get Service Account Roles"
+}
+
+func (g *GetServiceAccountRoles) Description() string {
+	return "List the project IAM roles granted to the integration's service account"
+}
+
+func (g *GetServiceAccountRoles) Documentation() string {
+	return `The Get Service Account Roles component reads the project's IAM policy and lists the roles granted to the integration's service account.
+
+## Use Cases
+
+- **Debugging access denied errors**: Check which roles the integration actually has before granting more
+- **Auditing**: Record the integration's effective project roles as part of a workflow
+
+## Configuration
+
+- **Service Account**: Optional service account email to look up. Defaults to the service account of the integration's key. Required for integrations that use Workload Identity Federation
+
+## Output
+
+Emits a ` + "`gcp.iam.serviceAccountRoles`" + ` payload with the ` + "`projectId`" + `, the ` + "`serviceAccount`" + ` email, the sorted list of granted ` + "`roles`" + `, and the matching ` + "`bindings`" + ` including any IAM conditions.
+
+## Important Notes
+
+- Only roles bound directly on the project are listed. Roles inherited from folders or the organization, or granted through Google groups, are not included
+- Requires the ` + "`resourcemanager.projects.getIamPolicy`" + ` permission, e.g. via the ` + "`roles/iam.securityReviewer`" + ` IAM role`
+}
+
+func (g *GetServiceAccountRoles) Icon() string {
+	return "shield"
+}
+
+func (g *GetServiceAccountRoles) Color() string {
+	return "blue"
+}
+
+func (g *GetServiceAccountRoles) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (g *GetServiceAccountRoles) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "serviceAccount",
+			Label:       "Service Account",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Service account email to look up. Defaults to the integration's service account",
+			Placeholder: "e.g. deployer@my-project.iam.gserviceaccount.com",
+		},
+	}
+}
+
+func (g *GetServiceAccountRoles) Setup(ctx core.SetupContext) error {
+	spec := GetServiceAccountRolesSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	email := strings.TrimSpace(spec.ServiceAccount)
+	if email != "" && !strings.Contains(email, "{{") && !strings.Contains(email, "@") {
+		return fmt.Errorf("service account must be an email address, got %q", email)
+	}
+	return nil
+}
+
+func (g *GetServiceAccountRoles) Execute(ctx core.ExecutionContext) error {
+	spec := GetServiceAccountRolesSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to decode configuration: %v", err))
+	}
+
+	email := strings.TrimSpace(spec.ServiceAccount)
+	if email == "" {
+		email = integrationClientEmail(ctx.Integration)
+	}
+	if email == "" {
+		return ctx.ExecutionState.Fail("error", "the integration has no service account email (it uses Workload Identity Federation): set Service Account")
+	}
+
+	client, err := getClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	project := client.ProjectID()
+	policy, err := getProjectIamPolicy(context.Background(), client, project)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", apiErrorMessage("failed to get project IAM policy", err, roleHintSecurityReviewer))
+	}
+
+	bindings := bindingsForMember(policy, serviceAccountMember(email))
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		serviceAccountRolesPayloadType,
+		[]any{serviceAccountRolesPayload(project, email, bindings)},
+	)
+}
+
+// integrationClientEmail returns the service account email stored in the
+// integration metadata. It is empty for Workload Identity Federation.
+func integrationClientEmail(integration core.IntegrationContext) string {
+	if integration == nil {
+		return ""
+	}
+
+	var metadata gcpcommon.Metadata
+	if err := mapstructure.Decode(integration.GetMetadata(), &metadata); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(metadata.ClientEmail)
+}
+
+func (g *GetServiceAccountRoles) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (g *GetServiceAccountRoles) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (g *GetServiceAccountRoles) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (g *GetServiceAccountRoles) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (g *GetServiceAccountRoles) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (g *GetServiceAccountRoles) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
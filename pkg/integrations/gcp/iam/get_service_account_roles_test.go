@@ -0,0 +1,170 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+// mockClient is a configurable iam.Client used by the component tests.
+type mockClient struct {
+	projectID string
+	postFunc  func(ctx context.Context, url string, body any) ([]byte, error)
+}
+
+func (m *mockClient) PostURL(ctx context.Context, url string, body any) ([]byte, error) {
+	if m.postFunc != nil {
+		return m.postFunc(ctx, url, body)
+	}
+	return nil, fmt.Errorf("unexpected PostURL(%s)", url)
+}
+
+func (m *mockClient) ProjectID() string { return m.projectID }
+
+func withFactory(mc *mockClient) {
+	SetClientFactory(func(httpCtx core.HTTPContext, integration core.IntegrationContext) (Client, error) {
+		return mc, nil
+	})
+}
+
+const stubPolicy = `{
+	"version": 3,
+	"etag": "BwX1",
+	"bindings": [
+		{"role": "roles/viewer", "members": ["user:alice@example.com", "serviceAccount:superplane@my-project.iam.gserviceaccount.com"]},
+		{"role": "roles/compute.instanceAdmin.v1", "members": ["serviceAccount:Superplane@my-project.iam.gserviceaccount.com"]},
+		{"role": "roles/pubsub.editor", "members": ["serviceAccount:superplane@my-project.iam.gserviceaccount.com"], "condition": {"title": "Topics only", "expression": "resource.type == \"pubsub.googleapis.com/Topic\""}},
+		{"role": "roles/pubsub.editor", "members": ["serviceAccount:superplane@my-project.iam.gserviceaccount.com"]},
+		{"role": "roles/owner", "members": ["serviceAccount:other@my-project.iam.gserviceaccount.com"]}
+	]
+}`
+
+func Test__GetServiceAccountRoles__Setup(t *testing.T) {
+	g := &GetServiceAccountRoles{}
+	setup := func(cfg map[string]any) error {
+		return g.Setup(core.SetupContext{Configuration: cfg, Metadata: &contexts.MetadataContext{}})
+	}
+
+	t.Run("service account is optional", func(t *testing.T) {
+		require.NoError(t, setup(map[string]any{}))
+	})
+
+	t.Run("service account must be an email", func(t *testing.T) {
+		require.ErrorContains(t, setup(map[string]any{"serviceAccount": "superplane"}), "must be an email address")
+		require.NoError(t, setup(map[string]any{"serviceAccount": "{{ root().data.email }}"}))
+	})
+}
+
+func Test__GetServiceAccountRoles__Execute(t *testing.T) {
+	g := &GetServiceAccountRoles{}
+
+	execute := func(cfg map[string]any, metadata any) *contexts.ExecutionStateContext {
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := g.Execute(core.ExecutionContext{
+			Configuration:  cfg,
+			Integration:    &contexts.IntegrationContext{Metadata: metadata},
+			ExecutionState: state,
+		})
+		require.NoError(t, err)
+		return state
+	}
+
+	t.Run("emits the roles bound to the integration's service account", func(t *testing.T) {
+		var postedURL string
+		var postedBody any
+		withFactory(&mockClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, url string, body any) ([]byte, error) {
+				postedURL = url
+				postedBody = body
+				return []byte(stubPolicy), nil
+			},
+		})
+
+		state := execute(map[string]any{}, gcpcommon.Metadata{
+			ProjectID:   "my-project",
+			ClientEmail: "superplane@my-project.iam.gserviceaccount.com",
+		})
+
+		require.True(t, state.Passed)
+		assert.Equal(t, "gcp.iam.serviceAccountRoles", state.Type)
+		assert.Equal(t, "https://cloudresourcemanager.googleapis.com/v1/projects/my-project:getIamPolicy", postedURL)
+		assert.Equal(t, map[string]any{"options": map[string]any{"requestedPolicyVersion": 3}}, postedBody)
+
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "my-project", data["projectId"])
+		assert.Equal(t, "superplane@my-project.iam.gserviceaccount.com", data["serviceAccount"])
+		assert.Equal(t, []string{"roles/compute.instanceAdmin.v1", "roles/pubsub.editor", "roles/viewer"}, data["roles"])
+
+		bindings := data["bindings"].([]map[string]any)
+		require.Len(t, bindings, 4)
+		assert.Equal(t, map[string]any{
+			"role": "roles/pubsub.editor",
+			"condition": map[string]any{
+				"title":      "Topics only",
+				"expression": `resource.type == "pubsub.googleapis.com/Topic"`,
+			},
+		}, bindings[2])
+	})
+
+	t.Run("configured service account overrides the integration's", func(t *testing.T) {
+		withFactory(&mockClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, url string, body any) ([]byte, error) {
+				return []byte(stubPolicy), nil
+			},
+		})
+
+		state := execute(map[string]any{"serviceAccount": "other@my-project.iam.gserviceaccount.com"}, gcpcommon.Metadata{ProjectID: "my-project"})
+
+		require.True(t, state.Passed)
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, []string{"roles/owner"}, data["roles"])
+	})
+
+	t.Run("service account without bindings emits no roles", func(t *testing.T) {
+		withFactory(&mockClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, url string, body any) ([]byte, error) {
+				return []byte(stubPolicy), nil
+			},
+		})
+
+		state := execute(map[string]any{"serviceAccount": "nobody@my-project.iam.gserviceaccount.com"}, nil)
+
+		require.True(t, state.Passed)
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, []string{}, data["roles"])
+		assert.Empty(t, data["bindings"])
+	})
+
+	t.Run("workload identity integration without a service account fails", func(t *testing.T) {
+		withFactory(&mockClient{projectID: "my-project"})
+
+		state := execute(map[string]any{}, gcpcommon.Metadata{ProjectID: "my-project", AuthMethod: gcpcommon.AuthMethodWIF})
+
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "set Service Account")
+	})
+
+	t.Run("permission denied hints at the required role", func(t *testing.T) {
+		withFactory(&mockClient{
+			projectID: "my-project",
+			postFunc: func(ctx context.Context, url string, body any) ([]byte, error) {
+				return nil, &gcpcommon.GCPAPIError{StatusCode: 403, Message: "permission denied"}
+			},
+		})
+
+		state := execute(map[string]any{}, gcpcommon.Metadata{ClientEmail: "superplane@my-project.iam.gserviceaccount.com"})
+
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "failed to get project IAM policy")
+		assert.Contains(t, state.FailureMessage, "roles/iam.securityReviewer")
+	})
+}
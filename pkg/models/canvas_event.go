@@ -143,18 +143,41 @@ func ListCanvasEventsByIDsInTransaction(tx *gorm.DB, ids []uuid.UUID) ([]CanvasE
 	return events, nil
 }
 
+// ListCanvasEventsOptions narrows the events returned by ListCanvasEventsWithOptions.
+// Before is the paging cursor: pass the CreatedAt of the last event of the
+// previous page to get the next, older page. Since drops events created
+// before it; nil means no lower bound.
+type ListCanvasEventsOptions struct {
+	Limit   int
+	Before  *time.Time
+	Since   *time.Time
+	Channel string
+}
+
 func ListCanvasEvents(canvasID uuid.UUID, nodeID string, limit int, before *time.Time) ([]CanvasEvent, error) {
+	return ListCanvasEventsWithOptions(canvasID, nodeID, ListCanvasEventsOptions{Limit: limit, Before: before})
+}
+
+func ListCanvasEventsWithOptions(canvasID uuid.UUID, nodeID string, options ListCanvasEventsOptions) ([]CanvasEvent, error) {
 	var events []CanvasEvent
 	query := database.Conn().
 		Where("workflow_id = ?", canvasID).
 		Where("node_id = ?", nodeID)
 
-	if limit > 0 {
-		query = query.Limit(limit)
+	if options.Channel != "" {
+		query = query.Where("channel = ?", options.Channel)
 	}
 
-	if before != nil {
-		query = query.Where("created_at < ?", before)
+	if options.Limit > 0 {
+		query = query.Limit(options.Limit)
+	}
+
+	if options.Before != nil {
+		query = query.Where("created_at < ?", options.Before)
+	}
+
+	if options.Since != nil {
+		query = query.Where("created_at >= ?", options.Since)
 	}
 
 	err := query.Order("created_at DESC").Find(&events).Error
@@ -166,14 +189,28 @@ func ListCanvasEvents(canvasID uuid.UUID, nodeID string, limit int, before *time
 }
 
 func CountCanvasEvents(canvasID uuid.UUID, nodeID string) (int64, error) {
+	return CountCanvasEventsWithOptions(canvasID, nodeID, ListCanvasEventsOptions{})
+}
+
+// CountCanvasEventsWithOptions counts the node's events matching the channel
+// and Since filters of options. Limit and Before are ignored.
+func CountCanvasEventsWithOptions(canvasID uuid.UUID, nodeID string, options ListCanvasEventsOptions) (int64, error) {
 	var count int64
 
-	err := database.Conn().
+	query := database.Conn().
 		Model(&CanvasEvent{}).
 		Where("workflow_id = ?", canvasID).
-		Where("node_id = ?", nodeID).
-		Count(&count).
-		Error
+		Where("node_id = ?", nodeID)
+
+	if options.Channel != "" {
+		query = query.Where("channel = ?", options.Channel)
+	}
+
+	if options.Since != nil {
+		query = query.Where("created_at >= ?", options.Since)
+	}
+
+	err := query.Count(&count).Error
 
 	if err != nil {
 		return 0, err
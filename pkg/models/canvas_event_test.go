@@ -98,6 +98,78 @@ func createOrganization(t *testing.T) *models.Organization {
 	return org
 }
 
+func Test__ListCanvasEventsWithOptions(t *testing.T) {
+	require.NoError(t, database.TruncateTables())
+
+	org := createOrganization(t)
+	canvas := createRetentionCanvas(t, org.ID)
+
+	base := time.Now().Add(-time.Hour)
+	passed1 := createNodeEventOnChannel(t, canvas.ID, "passed", base)
+	failed1 := createNodeEventOnChannel(t, canvas.ID, "failed", base.Add(time.Minute))
+	passed2 := createNodeEventOnChannel(t, canvas.ID, "passed", base.Add(2*time.Minute))
+	passed3 := createNodeEventOnChannel(t, canvas.ID, "passed", base.Add(3*time.Minute))
+
+	t.Run("defaults return all channels, newest first", func(t *testing.T) {
+		events, err := models.ListCanvasEvents(canvas.ID, "trigger", 0, nil)
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{passed3.ID, passed2.ID, failed1.ID, passed1.ID}, canvasEventIDs(events))
+	})
+
+	t.Run("channel filter only returns events on that channel", func(t *testing.T) {
+		events, err := models.ListCanvasEventsWithOptions(canvas.ID, "trigger", models.ListCanvasEventsOptions{Channel: "failed"})
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{failed1.ID}, canvasEventIDs(events))
+	})
+
+	t.Run("cursor pages through a channel", func(t *testing.T) {
+		firstPage, err := models.ListCanvasEventsWithOptions(canvas.ID, "trigger", models.ListCanvasEventsOptions{
+			Channel: "passed",
+			Limit:   2,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{passed3.ID, passed2.ID}, canvasEventIDs(firstPage))
+
+		secondPage, err := models.ListCanvasEventsWithOptions(canvas.ID, "trigger", models.ListCanvasEventsOptions{
+			Channel: "passed",
+			Limit:   2,
+			Before:  firstPage[len(firstPage)-1].CreatedAt,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{passed1.ID}, canvasEventIDs(secondPage))
+	})
+
+	t.Run("since excludes events older than the window", func(t *testing.T) {
+		since := base.Add(90 * time.Second)
+		events, err := models.ListCanvasEventsWithOptions(canvas.ID, "trigger", models.ListCanvasEventsOptions{Since: &since})
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{passed3.ID, passed2.ID}, canvasEventIDs(events))
+
+		count, err := models.CountCanvasEventsWithOptions(canvas.ID, "trigger", models.ListCanvasEventsOptions{
+			Channel: "passed",
+			Since:   &since,
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 2, count)
+	})
+}
+
+func createNodeEventOnChannel(t *testing.T, canvasID uuid.UUID, channel string, createdAt time.Time) *models.CanvasEvent {
+	t.Helper()
+
+	event := models.CanvasEvent{
+		WorkflowID: canvasID,
+		NodeID:     "trigger",
+		Channel:    channel,
+		Data:       models.NewJSONValue(map[string]any{"channel": channel}),
+		State:      models.CanvasEventStateRouted,
+		CreatedAt:  &createdAt,
+	}
+
+	require.NoError(t, database.Conn().Clauses(clause.Returning{}).Create(&event).Error)
+	return &event
+}
+
 func createRetentionCanvas(t *testing.T, orgID uuid.UUID) *models.Canvas {
 	t.Helper()
 
@@ -1310,7 +1310,7 @@ func (s *Server) executeActionNode(ctx context.Context, body []byte, headers htt
 				HTTP:           s.registry.HTTPContext(),
 				Metadata:       contexts.NewExecutionMetadataContext(tx, execution),
 				NodeMetadata:   contexts.NewNodeMetadataContext(tx, &node),
-				ExecutionState: contexts.NewExecutionStateContext(tx, execution, onNewEvents),
+				ExecutionState: contexts.NewExecutionStateContext(tx, execution, onNewEvents).WithExampleOutput(action),
 				Requests:       contexts.NewExecutionRequestContext(tx, execution),
 				Logger:         logging.ForExecution(execution),
 				CanvasMemory:   contexts.NewCanvasMemoryContext(tx, execution.WorkflowID),
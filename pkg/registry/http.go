@@ -2,6 +2,7 @@ package registry
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net"
@@ -29,6 +30,15 @@ type HTTPContext struct {
 	policyMu                    sync.RWMutex
 	policy                      compiledHTTPPolicy
 	policyExpiresAt             time.Time
+	allowInsecureTLS            bool
+
+	//
+	// Set on contexts derived with WithIntegrationTLS:
+	// the parent owns the network policy, and tlsConfig is applied to the transport.
+	//
+	parent      *HTTPContext
+	tlsConfig   *tls.Config
+	tlsContexts sync.Map
 }
 
 type HTTPOptions struct {
@@ -38,6 +48,7 @@ type HTTPOptions struct {
 	PolicyResolver              func() (HTTPPolicy, error)
 	PolicyResolverInTransaction func(*gorm.DB) (HTTPPolicy, error)
 	PolicyCacheTTL              time.Duration
+	AllowInsecureTLS            bool
 }
 
 type compiledHTTPPolicy struct {
@@ -56,6 +67,7 @@ func NewHTTPContext(options HTTPOptions) (*HTTPContext, error) {
 		policyResolver:              options.PolicyResolver,
 		policyResolverInTransaction: options.PolicyResolverInTransaction,
 		policyCacheTTL:              options.PolicyCacheTTL,
+		allowInsecureTLS:            options.AllowInsecureTLS,
 	}
 
 	if httpCtx.policyResolver == nil && httpCtx.policyResolverInTransaction == nil {
@@ -106,6 +118,7 @@ func (c *HTTPContext) transport(tx *gorm.DB, disableKeepAlives bool) *http.Trans
 		TLSHandshakeTimeout:   10 * time.Second,
 		ExpectContinueTimeout: 1 * time.Second,
 		DisableKeepAlives:     disableKeepAlives,
+		TLSClientConfig:       c.tlsConfig,
 		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
 			return c.dialer(tx).DialContext(ctx, network, addr)
 		},
@@ -331,6 +344,10 @@ func (c *HTTPContext) validateIPWithPolicy(policy compiledHTTPPolicy, ip net.IP)
 }
 
 func (c *HTTPContext) activePolicy(tx *gorm.DB) (compiledHTTPPolicy, error) {
+	if c.parent != nil {
+		return c.parent.activePolicy(tx)
+	}
+
 	if c.policyResolver == nil && c.policyResolverInTransaction == nil {
 		return c.policy, nil
 	}
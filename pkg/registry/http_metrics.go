@@ -0,0 +1,73 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const IntegrationRequestStatusError = "error"
+
+// IntegrationRequestRecorder records one outbound request made by an integration client.
+// statusClass is the response status class (e.g. "2xx", "5xx"), or "error" when no response was received.
+type IntegrationRequestRecorder func(ctx context.Context, integration, statusClass string, duration time.Duration)
+
+var integrationRequestRecorder atomic.Pointer[IntegrationRequestRecorder]
+
+/*
+ * SetIntegrationRequestRecorder sets where instrumented integration requests are reported.
+ * Integrations cannot depend on the telemetry package directly,
+ * so the server wires it in once metrics are initialized.
+ * Passing nil stops recording.
+ */
+func SetIntegrationRequestRecorder(recorder IntegrationRequestRecorder) {
+	if recorder == nil {
+		integrationRequestRecorder.Store(nil)
+		return
+	}
+
+	integrationRequestRecorder.Store(&recorder)
+}
+
+type integrationHTTPContext struct {
+	integration string
+	http        core.HTTPContext
+}
+
+/*
+ * InstrumentIntegrationHTTP wraps the HTTP context an integration client uses,
+ * so every request it makes is counted and timed under the integration name.
+ */
+func InstrumentIntegrationHTTP(integration string, httpCtx core.HTTPContext) core.HTTPContext {
+	if httpCtx == nil {
+		return nil
+	}
+
+	return &integrationHTTPContext{
+		integration: integration,
+		http:        httpCtx,
+	}
+}
+
+func (c *integrationHTTPContext) Do(request *http.Request) (*http.Response, error) {
+	start := time.Now()
+	response, err := c.http.Do(request)
+
+	if recorder := integrationRequestRecorder.Load(); recorder != nil {
+		(*recorder)(request.Context(), c.integration, requestStatusClass(response, err), time.Since(start))
+	}
+
+	return response, err
+}
+
+func requestStatusClass(response *http.Response, err error) string {
+	if err != nil || response == nil {
+		return IntegrationRequestStatusError
+	}
+
+	return fmt.Sprintf("%dxx", response.StatusCode/100)
+}
@@ -0,0 +1,99 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubHTTPContext struct {
+	response *http.Response
+	err      error
+}
+
+func (s *stubHTTPContext) Do(request *http.Request) (*http.Response, error) {
+	return s.response, s.err
+}
+
+type recordedIntegrationRequest struct {
+	integration string
+	statusClass string
+}
+
+func recordIntegrationRequests(t *testing.T) *[]recordedIntegrationRequest {
+	recorded := []recordedIntegrationRequest{}
+	SetIntegrationRequestRecorder(func(ctx context.Context, integration, statusClass string, duration time.Duration) {
+		assert.GreaterOrEqual(t, duration, time.Duration(0))
+		recorded = append(recorded, recordedIntegrationRequest{integration: integration, statusClass: statusClass})
+	})
+	t.Cleanup(func() { SetIntegrationRequestRecorder(nil) })
+	return &recorded
+}
+
+func Test__InstrumentIntegrationHTTP(t *testing.T) {
+	t.Run("successful request is recorded as 2xx", func(t *testing.T) {
+		recorded := recordIntegrationRequests(t)
+		httpCtx := InstrumentIntegrationHTTP("daytona", &stubHTTPContext{
+			response: &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))},
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "https://app.daytona.io/api/sandbox", nil)
+		require.NoError(t, err)
+		response, err := httpCtx.Do(request)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, []recordedIntegrationRequest{{integration: "daytona", statusClass: "2xx"}}, *recorded)
+	})
+
+	t.Run("failed request is recorded with its status class", func(t *testing.T) {
+		recorded := recordIntegrationRequests(t)
+		httpCtx := InstrumentIntegrationHTTP("dash0", &stubHTTPContext{
+			response: &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(strings.NewReader(""))},
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "https://api.dash0.com/api/alerting/check-rules", nil)
+		require.NoError(t, err)
+		_, err = httpCtx.Do(request)
+
+		require.NoError(t, err)
+		assert.Equal(t, []recordedIntegrationRequest{{integration: "dash0", statusClass: "5xx"}}, *recorded)
+	})
+
+	t.Run("transport error is recorded as error", func(t *testing.T) {
+		recorded := recordIntegrationRequests(t)
+		httpCtx := InstrumentIntegrationHTTP("gcp", &stubHTTPContext{err: errors.New("connection refused")})
+
+		request, err := http.NewRequest(http.MethodGet, "https://compute.googleapis.com/compute/v1/projects/p", nil)
+		require.NoError(t, err)
+		_, err = httpCtx.Do(request)
+
+		require.ErrorContains(t, err, "connection refused")
+		assert.Equal(t, []recordedIntegrationRequest{{integration: "gcp", statusClass: IntegrationRequestStatusError}}, *recorded)
+	})
+
+	t.Run("no recorder set -> request still goes through", func(t *testing.T) {
+		SetIntegrationRequestRecorder(nil)
+		httpCtx := InstrumentIntegrationHTTP("gcp", &stubHTTPContext{
+			response: &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))},
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "https://compute.googleapis.com/compute/v1/projects/p", nil)
+		require.NoError(t, err)
+		response, err := httpCtx.Do(request)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("nil HTTP context stays nil", func(t *testing.T) {
+		assert.Nil(t, InstrumentIntegrationHTTP("gcp", nil))
+	})
+}
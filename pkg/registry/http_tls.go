@@ -0,0 +1,142 @@
+package registry
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// IntegrationTLSOptions customizes how an integration verifies the TLS
+// certificate of a self-hosted endpoint.
+type IntegrationTLSOptions struct {
+	// CACertificate is a PEM bundle trusted in addition to the system roots.
+	CACertificate string
+
+	// InsecureSkipVerify disables certificate verification entirely.
+	// Only honored when the server is started with AllowInsecureTLS.
+	InsecureSkipVerify bool
+}
+
+func (o IntegrationTLSOptions) isEmpty() bool {
+	return strings.TrimSpace(o.CACertificate) == "" && !o.InsecureSkipVerify
+}
+
+func (o IntegrationTLSOptions) cacheKey() string {
+	return fmt.Sprintf("%t|%s", o.InsecureSkipVerify, strings.TrimSpace(o.CACertificate))
+}
+
+/*
+ * WithIntegrationTLS returns an HTTP context that applies the given TLS options
+ * to outbound requests, keeping the same network policy and response limits.
+ * Without options, the context is returned unchanged.
+ * Contexts not created by the registry (e.g. test doubles) are returned unchanged too.
+ */
+func WithIntegrationTLS(httpCtx core.HTTPContext, options IntegrationTLSOptions) (core.HTTPContext, error) {
+	if httpCtx == nil || options.isEmpty() {
+		return httpCtx, nil
+	}
+
+	switch c := httpCtx.(type) {
+	case *HTTPContext:
+		return c.withTLS(options)
+	case *HTTPContextInTransaction:
+		derived, err := c.httpCtx.withTLS(options)
+		if err != nil {
+			return nil, err
+		}
+
+		return &HTTPContextInTransaction{httpCtx: derived, tx: c.tx}, nil
+	default:
+		return httpCtx, nil
+	}
+}
+
+/*
+ * Derived contexts are cached per set of options,
+ * so clients built for the same integration share connections.
+ */
+func (c *HTTPContext) withTLS(options IntegrationTLSOptions) (*HTTPContext, error) {
+	root := c
+	if c.parent != nil {
+		root = c.parent
+	}
+
+	if options.InsecureSkipVerify && !root.allowInsecureTLS {
+		return nil, fmt.Errorf("skipping TLS verification is not allowed on this server")
+	}
+
+	key := options.cacheKey()
+	if derived, ok := root.tlsContexts.Load(key); ok {
+		return derived.(*HTTPContext), nil
+	}
+
+	tlsConfig, err := buildIntegrationTLSConfig(options)
+	if err != nil {
+		return nil, err
+	}
+
+	derived := &HTTPContext{
+		maxResponseBytes: root.maxResponseBytes,
+		parent:           root,
+		tlsConfig:        tlsConfig,
+	}
+
+	derived.client = &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: derived.transport(nil, false),
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return derived.checkRedirect(req, via, nil)
+		},
+	}
+
+	actual, _ := root.tlsContexts.LoadOrStore(key, derived)
+	return actual.(*HTTPContext), nil
+}
+
+func buildIntegrationTLSConfig(options IntegrationTLSOptions) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if options.InsecureSkipVerify {
+		tlsConfig.InsecureSkipVerify = true
+		return tlsConfig, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM([]byte(strings.TrimSpace(options.CACertificate))) {
+		return nil, fmt.Errorf("CA certificate must contain at least one PEM-encoded certificate")
+	}
+
+	tlsConfig.RootCAs = pool
+	return tlsConfig, nil
+}
+
+const (
+	IntegrationTLSVerify = "verify"
+	IntegrationTLSSkip   = "skip"
+)
+
+/*
+ * IntegrationTLSOptionsFromConfig reads the optional caCertificate
+ * and tlsVerification fields of an integration configuration.
+ */
+func IntegrationTLSOptionsFromConfig(ctx core.IntegrationContext) IntegrationTLSOptions {
+	options := IntegrationTLSOptions{}
+	if caCertificate, err := ctx.GetConfig("caCertificate"); err == nil {
+		options.CACertificate = string(caCertificate)
+	}
+
+	if verification, err := ctx.GetConfig("tlsVerification"); err == nil {
+		options.InsecureSkipVerify = string(verification) == IntegrationTLSSkip
+	}
+
+	return options
+}
@@ -0,0 +1,134 @@
+package registry
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func serverCAPEM(server *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+func Test__WithIntegrationTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	newRequest := func(t *testing.T) *http.Request {
+		request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		return request
+	}
+
+	t.Run("no options -> context returned unchanged", func(t *testing.T) {
+		ctx, err := NewHTTPContext(HTTPOptions{})
+		require.NoError(t, err)
+
+		httpCtx, err := WithIntegrationTLS(ctx, IntegrationTLSOptions{})
+		require.NoError(t, err)
+		assert.Same(t, ctx, httpCtx)
+	})
+
+	t.Run("private CA is not trusted by default", func(t *testing.T) {
+		ctx, err := NewHTTPContext(HTTPOptions{})
+		require.NoError(t, err)
+
+		_, err = ctx.Do(newRequest(t))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate")
+	})
+
+	t.Run("custom CA is loaded into the transport", func(t *testing.T) {
+		ctx, err := NewHTTPContext(HTTPOptions{})
+		require.NoError(t, err)
+
+		httpCtx, err := WithIntegrationTLS(ctx, IntegrationTLSOptions{CACertificate: serverCAPEM(server)})
+		require.NoError(t, err)
+
+		derived, ok := httpCtx.(*HTTPContext)
+		require.True(t, ok)
+		transport, ok := derived.client.Transport.(*http.Transport)
+		require.True(t, ok)
+		require.NotNil(t, transport.TLSClientConfig)
+		require.NotNil(t, transport.TLSClientConfig.RootCAs)
+		assert.False(t, transport.TLSClientConfig.InsecureSkipVerify)
+
+		response, err := httpCtx.Do(newRequest(t))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		_, err = ctx.Do(newRequest(t))
+		require.Error(t, err, "the shared context must not trust the custom CA")
+	})
+
+	t.Run("same options -> same derived context", func(t *testing.T) {
+		ctx, err := NewHTTPContext(HTTPOptions{})
+		require.NoError(t, err)
+
+		options := IntegrationTLSOptions{CACertificate: serverCAPEM(server)}
+		first, err := WithIntegrationTLS(ctx, options)
+		require.NoError(t, err)
+		second, err := WithIntegrationTLS(ctx, options)
+		require.NoError(t, err)
+		assert.Same(t, first, second)
+	})
+
+	t.Run("invalid CA certificate -> error", func(t *testing.T) {
+		ctx, err := NewHTTPContext(HTTPOptions{})
+		require.NoError(t, err)
+
+		_, err = WithIntegrationTLS(ctx, IntegrationTLSOptions{CACertificate: "not a certificate"})
+		require.ErrorContains(t, err, "PEM-encoded certificate")
+	})
+
+	t.Run("derived context keeps the network policy", func(t *testing.T) {
+		ctx, err := NewHTTPContext(HTTPOptions{PrivateIPRanges: []string{"127.0.0.0/8"}})
+		require.NoError(t, err)
+
+		httpCtx, err := WithIntegrationTLS(ctx, IntegrationTLSOptions{CACertificate: serverCAPEM(server)})
+		require.NoError(t, err)
+
+		_, err = httpCtx.Do(newRequest(t))
+		require.ErrorContains(t, err, "access to private IP address 127.0.0.1 is not allowed")
+	})
+
+	t.Run("insecure skip verify not allowed -> error", func(t *testing.T) {
+		ctx, err := NewHTTPContext(HTTPOptions{})
+		require.NoError(t, err)
+
+		_, err = WithIntegrationTLS(ctx, IntegrationTLSOptions{InsecureSkipVerify: true})
+		require.ErrorContains(t, err, "not allowed on this server")
+	})
+
+	t.Run("insecure skip verify allowed", func(t *testing.T) {
+		ctx, err := NewHTTPContext(HTTPOptions{AllowInsecureTLS: true})
+		require.NoError(t, err)
+
+		httpCtx, err := WithIntegrationTLS(ctx, IntegrationTLSOptions{InsecureSkipVerify: true})
+		require.NoError(t, err)
+
+		response, err := httpCtx.Do(newRequest(t))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("context in transaction", func(t *testing.T) {
+		ctx, err := NewHTTPContext(HTTPOptions{})
+		require.NoError(t, err)
+
+		httpCtx, err := WithIntegrationTLS(&HTTPContextInTransaction{httpCtx: ctx, tx: &gorm.DB{}}, IntegrationTLSOptions{CACertificate: serverCAPEM(server)})
+		require.NoError(t, err)
+
+		inTx, ok := httpCtx.(*HTTPContextInTransaction)
+		require.True(t, ok)
+		assert.NotSame(t, ctx, inTx.httpCtx)
+		assert.NotNil(t, inTx.httpCtx.tlsConfig.RootCAs)
+	})
+}
@@ -378,7 +378,7 @@ func startPublicAPI(
 	// Start the EventDistributer worker if enabled
 	if os.Getenv("START_EVENT_DISTRIBUTER") == "yes" {
 		log.Println("Starting Event Distributer Worker")
-		eventDistributer := workers.NewEventDistributer(server.WebsocketHub())
+		eventDistributer := workers.NewEventDistributer(server.WebsocketHub(), registry)
 		go eventDistributer.Start()
 	} else {
 		log.Println("Event Distributer not started (START_EVENT_DISTRIBUTER != yes)")
@@ -449,6 +449,7 @@ func setupOtel() {
 	if err := telemetry.InitMetrics(ctx); err != nil {
 		log.Warnf("Failed to initialize OpenTelemetry metrics: %v", err)
 	} else {
+		registry.SetIntegrationRequestRecorder(telemetry.RecordIntegrationRequest)
 		log.Info("OpenTelemetry metrics initialized")
 	}
 
@@ -547,7 +548,7 @@ func Start() {
 		Encryptor: encryptorInstance,
 		AppEnv:    appEnv,
 		HTTP: registry.HTTPOptions{
-			MaxResponseBytes: DefaultMaxHTTPResponseBytes,
+			MaxResponseBytes: config.MaxHTTPResponseBytes(),
 			PolicyResolver: func() (registry.HTTPPolicy, error) {
 				policy, err := networkpolicy.ResolveHTTPPolicy()
 				if err != nil {
@@ -570,7 +571,8 @@ func Start() {
 					PrivateIPRanges: policy.PrivateIPRanges,
 				}, nil
 			},
-			PolicyCacheTTL: 5 * time.Second,
+			PolicyCacheTTL:   5 * time.Second,
+			AllowInsecureTLS: os.Getenv("ALLOW_INSECURE_INTEGRATION_TLS") == "yes",
 		},
 	})
 
@@ -636,10 +638,3 @@ func getWebhookBaseURL(baseURL string) string {
 	}
 	return webhookBaseURL
 }
-
-/*
- * 8MB is the default maximum response size for HTTP responses.
- * This prevents component/trigger implementations from using too much memory,
- * and also from emitting large events.
- */
-var DefaultMaxHTTPResponseBytes int64 = 8 * 1024 * 1024
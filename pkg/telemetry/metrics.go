@@ -71,6 +71,9 @@ var (
 
 	integrationSecretWritesCounter metric.Int64Counter
 
+	integrationRequestsCounter          metric.Int64Counter
+	integrationRequestDurationHistogram metric.Float64Histogram
+
 	pendingEventsGauge     metric.Int64Gauge
 	pendingExecutionsGauge metric.Int64Gauge
 )
@@ -494,6 +497,24 @@ func InitMetrics(ctx context.Context) error {
 		return err
 	}
 
+	integrationRequestsCounter, err = meter.Int64Counter(
+		"integration.requests.total",
+		metric.WithDescription("Outbound requests made by integration clients, attributed by integration and status class"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return err
+	}
+
+	integrationRequestDurationHistogram, err = meter.Float64Histogram(
+		"integration.request.duration.seconds",
+		metric.WithDescription("Duration of outbound requests made by integration clients"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+
 	pendingEventsGauge, err = meter.Int64Gauge(
 		"workflow_events.pending.count",
 		metric.WithDescription("Current number of pending workflow events"),
@@ -886,6 +907,22 @@ func RecordIntegrationSecretWrite(ctx context.Context, appName, operation string
 	)
 }
 
+// RecordIntegrationRequest records one outbound integration request.
+// statusClass is "2xx", "4xx", "5xx", etc., or "error" when no response was received.
+func RecordIntegrationRequest(ctx context.Context, integration, statusClass string, d time.Duration) {
+	if !metricsReady.Load() {
+		return
+	}
+
+	attrs := metric.WithAttributes(
+		attribute.String("integration", integration),
+		attribute.String("status_class", statusClass),
+	)
+
+	integrationRequestsCounter.Add(ctx, 1, attrs)
+	integrationRequestDurationHistogram.Record(ctx, d.Seconds(), attrs)
+}
+
 func RecordPendingEventsCount(ctx context.Context, count int64) {
 	if !metricsReady.Load() {
 		return
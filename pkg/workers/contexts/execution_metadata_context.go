@@ -22,19 +22,54 @@ func (m *ExecutionMetadataContext) Get() any {
 }
 
 func (m *ExecutionMetadataContext) Set(value any) error {
-	b, err := json.Marshal(value)
+	v, err := metadataMap(value)
 	if err != nil {
 		return err
 	}
 
+	m.execution.Metadata = datatypes.NewJSONType(v)
+	return m.tx.Model(m.execution).
+		Update("metadata", v).
+		Error
+}
+
+/*
+ * SetIfField is a conditional update on the execution row, so concurrent
+ * callers are serialized by the database and only one of them sees it succeed.
+ */
+func (m *ExecutionMetadataContext) SetIfField(field, expected string, value any) (bool, error) {
+	v, err := metadataMap(value)
+	if err != nil {
+		return false, err
+	}
+
+	result := m.tx.Model(m.execution).
+		Where("metadata ->> ? = ?", field, expected).
+		Update("metadata", v)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	m.execution.Metadata = datatypes.NewJSONType(v)
+	return true, nil
+}
+
+func metadataMap(value any) (map[string]any, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
 	var v map[string]any
 	err = json.Unmarshal(b, &v)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	m.execution.Metadata = datatypes.NewJSONType(v)
-	return m.tx.Model(m.execution).
-		Update("metadata", v).
-		Error
+	return v, nil
 }
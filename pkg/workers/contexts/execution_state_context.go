@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/superplanehq/superplane/pkg/config"
 	"github.com/superplanehq/superplane/pkg/core"
 	"github.com/superplanehq/superplane/pkg/models"
@@ -17,6 +18,7 @@ type ExecutionStateContext struct {
 	tx             *gorm.DB
 	maxPayloadSize int
 	onNewEvents    func([]models.CanvasEvent)
+	exampleOutput  map[string]any
 }
 
 func NewExecutionStateContext(
@@ -32,6 +34,17 @@ func NewExecutionStateContext(
 	}
 }
 
+// WithExampleOutput keeps the example output of the action that owns the
+// execution, so emitted payloads can be compared with it. The example output
+// is only loaded when SUPERPLANE_VALIDATE_EMITTED_PAYLOADS is on.
+func (s *ExecutionStateContext) WithExampleOutput(action core.Action) *ExecutionStateContext {
+	if config.ValidateEmittedPayloads() {
+		s.exampleOutput = action.ExampleOutput()
+	}
+
+	return s
+}
+
 func (s *ExecutionStateContext) IsFinished() bool {
 	return s.execution.State == models.CanvasNodeExecutionStateFinished
 }
@@ -54,6 +67,8 @@ func (s *ExecutionStateContext) Emit(channel, payloadType string, payloads []any
 		return fmt.Errorf("cannot emit %d events (max %d per execution)", len(payloads), config.MaxEmitCount())
 	}
 
+	s.validatePayloads(payloadType, payloads)
+
 	outputs := map[string][]any{
 		channel: {},
 	}
@@ -94,6 +109,8 @@ func (s *ExecutionStateContext) EmitAndContinue(channel, payloadType string, pay
 		return fmt.Errorf("cannot emit %d events (max %d per execution)", len(payloads), config.MaxEmitCount())
 	}
 
+	s.validatePayloads(payloadType, payloads)
+
 	outputs := map[string][]any{
 		channel: {},
 	}
@@ -129,6 +146,26 @@ func (s *ExecutionStateContext) EmitAndContinue(channel, payloadType string, pay
 	return nil
 }
 
+// validatePayloads logs mismatches between the emitted payloads and the
+// component's example output. It never blocks the emit.
+func (s *ExecutionStateContext) validatePayloads(payloadType string, payloads []any) {
+	if s.exampleOutput == nil {
+		return
+	}
+
+	for i, payload := range payloads {
+		mismatches, err := core.ValidatePayloadAgainstExample(s.exampleOutput, payloadType, payload)
+		if err != nil {
+			log.WithError(err).Warnf("failed to validate payload %d emitted by execution %s", i, s.execution.ID)
+			continue
+		}
+
+		for _, mismatch := range mismatches {
+			log.Warnf("payload %d of type %s emitted by execution %s does not match example output: %s", i, payloadType, s.execution.ID, mismatch)
+		}
+	}
+}
+
 func (s *ExecutionStateContext) Fail(reason, message string) error {
 	if err := s.execution.FailInTransaction(s.tx, reason, message); err != nil {
 		return err
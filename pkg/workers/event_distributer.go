@@ -12,6 +12,7 @@ import (
 	"github.com/superplanehq/superplane/pkg/grpc/actions/messages"
 	"github.com/superplanehq/superplane/pkg/logging"
 	"github.com/superplanehq/superplane/pkg/public/ws"
+	"github.com/superplanehq/superplane/pkg/registry"
 	"github.com/superplanehq/superplane/pkg/workers/eventdistributer"
 )
 
@@ -27,13 +28,15 @@ const (
 // and distributes events to websocket clients
 type EventDistributer struct {
 	wsHub    *ws.Hub
+	registry *registry.Registry
 	shutdown chan struct{}
 }
 
 // NewEventDistributer creates a new event distributer coordinator
-func NewEventDistributer(wsHub *ws.Hub) *EventDistributer {
+func NewEventDistributer(wsHub *ws.Hub, registry *registry.Registry) *EventDistributer {
 	return &EventDistributer{
 		wsHub:    wsHub,
+		registry: registry,
 		shutdown: make(chan struct{}),
 	}
 }
@@ -72,7 +75,7 @@ func (e *EventDistributer) Start() error {
 		}{
 			messages.ExecutionsExchange,
 			routingKey,
-			e.createHandler(eventdistributer.HandleCanvasExecution),
+			e.createHandler(e.handleCanvasExecution),
 		})
 	}
 
@@ -86,6 +89,11 @@ func (e *EventDistributer) Start() error {
 	return nil
 }
 
+// handleCanvasExecution needs the registry to redact sensitive execution configuration
+func (e *EventDistributer) handleCanvasExecution(messageBody []byte, wsHub *ws.Hub) error {
+	return eventdistributer.HandleCanvasExecution(messageBody, wsHub, e.registry)
+}
+
 // createHandler returns a tackle handler that calls the given processing function
 func (e *EventDistributer) createHandler(processFn func([]byte, *ws.Hub) error) func(delivery tackle.Delivery) error {
 	return func(delivery tackle.Delivery) error {
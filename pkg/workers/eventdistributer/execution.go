@@ -11,6 +11,7 @@ import (
 	"github.com/superplanehq/superplane/pkg/models"
 	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
 	"github.com/superplanehq/superplane/pkg/public/ws"
+	"github.com/superplanehq/superplane/pkg/registry"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
@@ -26,7 +27,7 @@ const (
 	ExecutionStartedEvent  = "execution_started"
 )
 
-func HandleCanvasExecution(messageBody []byte, wsHub *ws.Hub) error {
+func HandleCanvasExecution(messageBody []byte, wsHub *ws.Hub, registry *registry.Registry) error {
 	log.Debugf("Received execution event")
 
 	pbMsg := &pb.CanvasNodeExecutionMessage{}
@@ -34,7 +35,7 @@ func HandleCanvasExecution(messageBody []byte, wsHub *ws.Hub) error {
 		return fmt.Errorf("failed to unmarshal execution event: %w", err)
 	}
 
-	return handleExecutionState(pbMsg.CanvasId, pbMsg.Id, wsHub)
+	return handleExecutionState(pbMsg.CanvasId, pbMsg.Id, wsHub, registry)
 }
 
 func workflowExecutionStateToWsEvent(workflowState string) string {
@@ -50,7 +51,7 @@ func workflowExecutionStateToWsEvent(workflowState string) string {
 	}
 }
 
-func handleExecutionState(workflowID string, executionID string, wsHub *ws.Hub) error {
+func handleExecutionState(workflowID string, executionID string, wsHub *ws.Hub, registry *registry.Registry) error {
 	workflowUUID, err := uuid.Parse(workflowID)
 	if err != nil {
 		return fmt.Errorf("failed to parse workflow id: %w", err)
@@ -71,7 +72,7 @@ func handleExecutionState(workflowID string, executionID string, wsHub *ws.Hub)
 		return fmt.Errorf("unknown execution state: %s", execution.State)
 	}
 
-	resources, err := canvases.LoadNodeExecutionResources(database.Conn(), []models.CanvasNodeExecution{*execution})
+	resources, err := canvases.LoadNodeExecutionResources(database.Conn(), registry, []models.CanvasNodeExecution{*execution})
 	if err != nil {
 		return fmt.Errorf("failed to load execution resources: %w", err)
 	}
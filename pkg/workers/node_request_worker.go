@@ -355,7 +355,7 @@ func (w *NodeRequestWorker) invokeExecutionComponentHook(
 		Parameters:     spec.InvokeAction.Parameters,
 		HTTP:           w.registry.HTTPContextInTransaction(tx),
 		Metadata:       contexts.NewExecutionMetadataContext(tx, execution),
-		ExecutionState: contexts.NewExecutionStateContext(tx, execution, onNewEvents),
+		ExecutionState: contexts.NewExecutionStateContext(tx, execution, onNewEvents).WithExampleOutput(hookProvider),
 		Requests:       contexts.NewExecutionRequestContext(tx, execution),
 		Auth:           contexts.NewAuthReader(tx, workflow.OrganizationID, w.authService, nil),
 		Secrets:        contexts.NewSecretsContext(tx, workflow.OrganizationID, w.encryptor),
@@ -1,6 +1,7 @@
 package contexts
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"slices"
@@ -85,6 +86,27 @@ func (m *MetadataContext) Set(metadata any) error {
 	return nil
 }
 
+func (m *MetadataContext) SetIfField(field, expected string, metadata any) (bool, error) {
+	current := map[string]any{}
+	if m.Metadata != nil {
+		b, err := json.Marshal(m.Metadata)
+		if err != nil {
+			return false, err
+		}
+
+		if err := json.Unmarshal(b, &current); err != nil {
+			return false, err
+		}
+	}
+
+	if value, ok := current[field].(string); !ok || value != expected {
+		return false, nil
+	}
+
+	m.Metadata = metadata
+	return true, nil
+}
+
 type IntegrationContext struct {
 	NewSetupFlow      bool
 	IntegrationID     string
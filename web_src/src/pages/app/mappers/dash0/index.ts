@@ -6,7 +6,9 @@ import { listIssuesMapper, LIST_ISSUES_STATE_REGISTRY } from "./list_issues";
 import { createHttpSyntheticCheckMapper } from "./create_http_synthetic_check";
 import { updateHttpSyntheticCheckMapper } from "./update_http_synthetic_check";
 import { deleteHttpSyntheticCheckMapper } from "./delete_http_synthetic_check";
+import { pauseResumeSyntheticCheckMapper } from "./pause_resume_synthetic_check";
 import { getHttpSyntheticCheckMapper, GET_HTTP_SYNTHETIC_CHECK_STATE_REGISTRY } from "./get_http_synthetic_check";
+import { waitForCheckResultMapper, WAIT_FOR_CHECK_RESULT_STATE_REGISTRY } from "./wait_for_check_result";
 import { createCheckRuleMapper } from "./create_check_rule";
 import { getCheckRuleMapper } from "./get_check_rule";
 import { updateCheckRuleMapper } from "./update_check_rule";
@@ -23,6 +25,8 @@ export const componentMappers: Record<string, ComponentBaseMapper> = {
   updateHttpSyntheticCheck: updateHttpSyntheticCheckMapper,
   deleteHttpSyntheticCheck: deleteHttpSyntheticCheckMapper,
   getHttpSyntheticCheck: getHttpSyntheticCheckMapper,
+  pauseResumeSyntheticCheck: pauseResumeSyntheticCheckMapper,
+  waitForCheckResult: waitForCheckResultMapper,
   createCheckRule: createCheckRuleMapper,
   getCheckRule: getCheckRuleMapper,
   updateCheckRule: updateCheckRuleMapper,
@@ -42,6 +46,8 @@ export const eventStateRegistry: Record<string, EventStateRegistry> = {
   updateHttpSyntheticCheck: buildActionStateRegistry("updated"),
   deleteHttpSyntheticCheck: buildActionStateRegistry("deleted"),
   getHttpSyntheticCheck: GET_HTTP_SYNTHETIC_CHECK_STATE_REGISTRY,
+  pauseResumeSyntheticCheck: buildActionStateRegistry("updated"),
+  waitForCheckResult: WAIT_FOR_CHECK_RESULT_STATE_REGISTRY,
   createCheckRule: buildActionStateRegistry("created"),
   getCheckRule: buildActionStateRegistry("fetched"),
   updateCheckRule: buildActionStateRegistry("updated"),
@@ -30,12 +30,42 @@ interface SyntheticCheckLabelValue {
   stringValue?: string;
 }
 
+interface SyntheticCheckFailedAssertion {
+  location?: string;
+  severity?: string;
+  kind?: string;
+  explanation?: string;
+}
+
+interface SyntheticCheckDetails {
+  id?: string;
+  name?: string;
+  failedAssertions?: SyntheticCheckFailedAssertion[];
+}
+
 interface SyntheticCheckNotificationEventData {
   issue?: SyntheticCheckNotificationIssue;
+  check?: SyntheticCheckDetails;
 }
 
 interface OnSyntheticCheckNotificationConfiguration {
   statuses?: string[];
+  checkNames?: string[];
+  dataset?: string;
+}
+
+function formatFailedAssertions(assertions?: SyntheticCheckFailedAssertion[]): string | undefined {
+  if (!assertions?.length) {
+    return undefined;
+  }
+
+  return assertions
+    .map((assertion) => {
+      const description = assertion.explanation || assertion.kind || "";
+      return assertion.location ? `${assertion.location}: ${description}` : description;
+    })
+    .filter(Boolean)
+    .join("; ");
 }
 
 function formatSyntheticCheckLabels(labels?: SyntheticCheckLabelTuple[]): string | undefined {
@@ -79,6 +109,7 @@ export const onSyntheticCheckNotificationTriggerRenderer: TriggerRenderer = {
     const eventData = context.event?.data as SyntheticCheckNotificationEventData | undefined;
 
     return {
+      Check: stringOrDash(eventData?.check?.name),
       "Issue ID": stringOrDash(eventData?.issue?.id),
       "Issue Identifier": stringOrDash(eventData?.issue?.issueIdentifier),
       URL: stringOrDash(eventData?.issue?.url),
@@ -86,6 +117,7 @@ export const onSyntheticCheckNotificationTriggerRenderer: TriggerRenderer = {
       Summary: stringOrDash(eventData?.issue?.summary),
       Dataset: stringOrDash(eventData?.issue?.dataset),
       Start: stringOrDash(eventData?.issue?.start),
+      "Failed Assertions": stringOrDash(formatFailedAssertions(eventData?.check?.failedAssertions)),
       Labels: stringOrDash(formatSyntheticCheckLabels(eventData?.issue?.labels)),
     };
   },
@@ -102,6 +134,20 @@ export const onSyntheticCheckNotificationTriggerRenderer: TriggerRenderer = {
       });
     }
 
+    if (configuration?.checkNames?.length) {
+      metadataItems.push({
+        icon: "activity",
+        label: `Checks: ${configuration.checkNames.join(", ")}`,
+      });
+    }
+
+    if (configuration?.dataset) {
+      metadataItems.push({
+        icon: "database",
+        label: `Dataset: ${configuration.dataset}`,
+      });
+    }
+
     const props: TriggerProps = {
       title: node.name || definition.label || "Unnamed trigger",
       iconSrc: dash0Icon,
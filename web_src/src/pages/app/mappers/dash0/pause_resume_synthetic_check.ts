@@ -0,0 +1,108 @@
+import type { ComponentBaseProps, EventSection } from "@/ui/componentBase";
+import type React from "react";
+import { getState, getStateMap, getTriggerRenderer } from "..";
+import type {
+  ComponentBaseMapper,
+  ExecutionDetailsContext,
+  ComponentBaseContext,
+  ExecutionInfo,
+  NodeInfo,
+  OutputPayload,
+  SubtitleContext,
+} from "../types";
+import type { MetadataItem } from "@/ui/metadataList";
+import dash0Icon from "@/assets/icons/integrations/dash0.svg";
+import type { PauseResumeSyntheticCheckConfiguration } from "./types";
+import { truncate } from "../safeMappers";
+import { renderTimeAgo } from "@/components/TimeAgo";
+
+export const pauseResumeSyntheticCheckMapper: ComponentBaseMapper = {
+  props(context: ComponentBaseContext): ComponentBaseProps {
+    const lastExecution = context.lastExecutions.length > 0 ? context.lastExecutions[0] : null;
+    const componentName = context.componentDefinition.name || "unknown";
+
+    return {
+      iconSrc: dash0Icon,
+      collapsedBackground: "bg-white",
+      collapsed: context.node.isCollapsed,
+      title: context.node.name || context.componentDefinition.label || "Unnamed component",
+      eventSections: lastExecution ? baseEventSections(context.nodes, lastExecution, componentName) : undefined,
+      metadata: metadataList(context.node),
+      includeEmptyState: !lastExecution,
+      eventStateMap: getStateMap(componentName),
+    };
+  },
+
+  getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+    const outputs = context.execution.outputs as { default?: OutputPayload[] } | undefined;
+
+    if (!outputs || !outputs.default || outputs.default.length === 0) {
+      return { Response: "No data returned" };
+    }
+
+    const payload = outputs.default[0];
+    const responseData = payload?.data as Record<string, any> | undefined;
+
+    const details: Record<string, string> = {};
+
+    if (payload?.timestamp) {
+      details["Updated At"] = new Date(payload.timestamp).toLocaleString();
+    }
+
+    if (responseData?.name) {
+      details["Check"] = String(responseData.name);
+    }
+
+    if (responseData?.id) {
+      details["Check ID"] = String(responseData.id);
+    }
+
+    if (typeof responseData?.enabled === "boolean") {
+      details["Status"] = responseData.enabled ? "Enabled" : "Paused";
+    }
+
+    return details;
+  },
+
+  subtitle(context: SubtitleContext): string | React.ReactNode {
+    if (!context.execution.createdAt) return "";
+    return renderTimeAgo(new Date(context.execution.createdAt));
+  },
+};
+
+function metadataList(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const configuration = node.configuration as PauseResumeSyntheticCheckConfiguration;
+
+  if (configuration?.action) {
+    const resume = configuration.action === "resume";
+    metadata.push({ icon: resume ? "play" : "pause", label: resume ? "Resume" : "Pause" });
+  }
+
+  if (configuration?.syntheticCheck) {
+    const idPreview = truncate(configuration.syntheticCheck, 24, "…");
+    metadata.push({ icon: "fingerprint", label: idPreview });
+  }
+
+  if (configuration?.dataset) {
+    metadata.push({ icon: "database", label: configuration.dataset });
+  }
+
+  return metadata;
+}
+
+function baseEventSections(nodes: NodeInfo[], execution: ExecutionInfo, componentName: string): EventSection[] {
+  const rootTriggerNode = nodes.find((n) => n.id === execution.rootEvent?.nodeId);
+  const rootTriggerRenderer = getTriggerRenderer(rootTriggerNode?.componentName!);
+  const { title } = rootTriggerRenderer.getTitleAndSubtitle({ event: execution.rootEvent });
+
+  return [
+    {
+      receivedAt: new Date(execution.createdAt!),
+      eventTitle: title,
+      eventSubtitle: renderTimeAgo(new Date(execution.createdAt!)),
+      eventState: getState(componentName)(execution),
+      eventId: execution.rootEvent!.id!,
+    },
+  ];
+}
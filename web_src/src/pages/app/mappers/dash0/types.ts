@@ -50,6 +50,18 @@ export interface GetHttpSyntheticCheckConfiguration {
   dataset: string;
 }
 
+export interface PauseResumeSyntheticCheckConfiguration {
+  syntheticCheck: string;
+  dataset: string;
+  action: "pause" | "resume";
+}
+
+export interface WaitForCheckResultConfiguration {
+  syntheticCheck: string;
+  dataset: string;
+  timeout?: number;
+}
+
 export interface GetHttpSyntheticCheckNodeMetadata {
   checkName?: string;
 }
@@ -0,0 +1,194 @@
+import type { ComponentBaseProps, EventSection, EventState, EventStateMap } from "@/ui/componentBase";
+import { DEFAULT_EVENT_STATE_MAP } from "@/ui/componentBase";
+import { getState, getStateMap, getTriggerRenderer } from "..";
+import type React from "react";
+import type {
+  ComponentBaseMapper,
+  ExecutionDetailsContext,
+  ComponentBaseContext,
+  ExecutionInfo,
+  EventStateRegistry,
+  StateFunction,
+  NodeInfo,
+  OutputPayload,
+  SubtitleContext,
+} from "../types";
+import type { MetadataItem } from "@/ui/metadataList";
+import dash0Icon from "@/assets/icons/integrations/dash0.svg";
+import type { WaitForCheckResultConfiguration } from "./types";
+import { truncate } from "../safeMappers";
+import { renderTimeAgo, renderWithTimeAgo } from "@/components/TimeAgo";
+
+// Output channel names matching the backend constants
+const CHANNEL_PASSED = "passed";
+const CHANNEL_FAILED = "failed";
+
+type WaitForCheckResultOutputs = {
+  passed?: OutputPayload[];
+  failed?: OutputPayload[];
+};
+
+export const waitForCheckResultMapper: ComponentBaseMapper = {
+  props(context: ComponentBaseContext): ComponentBaseProps {
+    const lastExecution = context.lastExecutions.length > 0 ? context.lastExecutions[0] : null;
+    const componentName = context.componentDefinition.name || "unknown";
+
+    return {
+      iconSrc: dash0Icon,
+      collapsedBackground: "bg-white",
+      collapsed: context.node.isCollapsed,
+      title: context.node.name || context.componentDefinition.label || "Unnamed component",
+      eventSections: lastExecution ? baseEventSections(context.nodes, lastExecution, componentName) : undefined,
+      metadata: metadataList(context.node),
+      includeEmptyState: !lastExecution,
+      eventStateMap: getStateMap(componentName),
+    };
+  },
+
+  getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+    const payload = getFirstPayload(context.execution);
+    const responseData = payload?.data as Record<string, unknown> | undefined;
+
+    if (!responseData) {
+      return { Response: "No data returned" };
+    }
+
+    const details: Record<string, string> = {};
+
+    if (responseData.reportedAt) {
+      details["Reported At"] = new Date(String(responseData.reportedAt)).toLocaleString();
+    }
+
+    if (responseData.outcome) {
+      details["Outcome"] = String(responseData.outcome);
+    }
+
+    if (responseData.id) {
+      details["Check ID"] = String(responseData.id);
+    }
+
+    if (responseData.waitedSeconds != null) {
+      details["Waited"] = `${responseData.waitedSeconds}s`;
+    }
+
+    return details;
+  },
+
+  subtitle(context: SubtitleContext): string | React.ReactNode {
+    if (!context.execution.createdAt) return "";
+    return renderTimeAgo(new Date(context.execution.createdAt));
+  },
+};
+
+function metadataList(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const configuration = node.configuration as WaitForCheckResultConfiguration;
+
+  if (configuration?.syntheticCheck) {
+    const idPreview = truncate(configuration.syntheticCheck, 24, "…");
+    metadata.push({ icon: "fingerprint", label: idPreview });
+  }
+
+  if (configuration?.dataset) {
+    metadata.push({ icon: "database", label: configuration.dataset });
+  }
+
+  if (configuration?.timeout) {
+    metadata.push({ icon: "clock", label: `${configuration.timeout}s timeout` });
+  }
+
+  return metadata;
+}
+
+function baseEventSections(nodes: NodeInfo[], execution: ExecutionInfo, componentName: string): EventSection[] {
+  if (!execution.createdAt || !execution.rootEvent?.id) {
+    return [];
+  }
+
+  const rootTriggerNode = nodes.find((n) => n.id === execution.rootEvent?.nodeId);
+  const rootTriggerRenderer = getTriggerRenderer(rootTriggerNode?.componentName ?? "");
+  const { title } = rootTriggerRenderer.getTitleAndSubtitle({ event: execution.rootEvent });
+
+  const date = new Date(execution.createdAt);
+  const activeChannel = getActiveChannel(execution);
+  const eventSubtitle = activeChannel ? renderWithTimeAgo(activeChannel, date) : renderTimeAgo(date);
+
+  return [
+    {
+      receivedAt: date,
+      eventTitle: title,
+      eventSubtitle,
+      eventState: getState(componentName)(execution),
+      eventId: execution.rootEvent.id,
+    },
+  ];
+}
+
+function getFirstPayload(execution: ExecutionInfo): OutputPayload | null {
+  const outputs = execution.outputs as WaitForCheckResultOutputs | undefined;
+  if (!outputs) return null;
+
+  if (outputs.failed && outputs.failed.length > 0) return outputs.failed[0];
+  if (outputs.passed && outputs.passed.length > 0) return outputs.passed[0];
+
+  return null;
+}
+
+function getActiveChannel(execution: ExecutionInfo): string | null {
+  const outputs = execution.outputs as WaitForCheckResultOutputs | undefined;
+  if (!outputs) return null;
+
+  if (outputs.failed && outputs.failed.length > 0) return CHANNEL_FAILED;
+  if (outputs.passed && outputs.passed.length > 0) return CHANNEL_PASSED;
+
+  return null;
+}
+
+// --- State registry ---
+
+export const WAIT_FOR_CHECK_RESULT_STATE_MAP: EventStateMap = {
+  ...DEFAULT_EVENT_STATE_MAP,
+  passed: {
+    icon: "circle-check",
+    textColor: "text-gray-800",
+    backgroundColor: "bg-green-100",
+    badgeColor: "bg-green-500",
+  },
+  checkFailed: {
+    icon: "circle-x",
+    textColor: "text-gray-800",
+    backgroundColor: "bg-red-100",
+    badgeColor: "bg-red-500",
+  },
+};
+
+export const waitForCheckResultStateFunction: StateFunction = (execution: ExecutionInfo): EventState => {
+  if (!execution) return "neutral";
+
+  if (
+    execution.resultMessage &&
+    (execution.resultReason === "RESULT_REASON_ERROR" ||
+      (execution.result === "RESULT_FAILED" && execution.resultReason !== "RESULT_REASON_ERROR_RESOLVED"))
+  ) {
+    return "error";
+  }
+
+  if (execution.result === "RESULT_CANCELLED") {
+    return "cancelled";
+  }
+
+  if (execution.state === "STATE_PENDING" || execution.state === "STATE_STARTED") {
+    return "running";
+  }
+
+  if (execution.state === "STATE_FINISHED" && execution.result === "RESULT_PASSED") {
+    return getActiveChannel(execution) === CHANNEL_FAILED ? "checkFailed" : "passed";
+  }
+
+  return "failed";
+};
+
+export const WAIT_FOR_CHECK_RESULT_STATE_REGISTRY: EventStateRegistry = {
+  stateMap: WAIT_FOR_CHECK_RESULT_STATE_MAP,
+  getState: waitForCheckResultStateFunction,
+};
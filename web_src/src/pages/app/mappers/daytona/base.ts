@@ -16,6 +16,8 @@ import { renderTimeAgo, renderWithTimeAgo } from "@/components/TimeAgo";
 interface ExecuteCommandOutput {
   exitCode?: number | null;
   timeout?: boolean;
+  failedCommandIndex?: number | null;
+  stage?: string;
 }
 
 export const baseMapper: ComponentBaseMapper = {
@@ -126,7 +128,7 @@ function buildExecuteCommandSubtitle(
   componentName: string,
   execution: ExecutionInfo,
 ): string | React.ReactNode | undefined {
-  if (componentName !== "daytona.executeCommand") {
+  if (componentName !== "daytona.executeCommand" && componentName !== "daytona.runCommands") {
     return undefined;
   }
 
@@ -142,6 +144,14 @@ function buildExecuteCommandSubtitle(
     return renderWithTimeAgo("timed out", date);
   }
 
+  if (typeof data?.failedCommandIndex === "number" && typeof data?.exitCode === "number") {
+    return renderWithTimeAgo(`command ${data.failedCommandIndex + 1} exit code ${data.exitCode}`, date);
+  }
+
+  if (data?.stage === "setup" && typeof data?.exitCode === "number") {
+    return renderWithTimeAgo(`setup exit code ${data.exitCode}`, date);
+  }
+
   if (typeof data?.exitCode === "number") {
     return renderWithTimeAgo(`exit code ${data.exitCode}`, date);
   }
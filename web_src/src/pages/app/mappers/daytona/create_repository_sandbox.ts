@@ -11,6 +11,7 @@ interface CreateRepositorySandboxConfiguration {
     from?: string;
     script?: string;
     path?: string;
+    steps?: Array<{ name?: string; script?: string }>;
   };
 }
 
@@ -31,6 +32,8 @@ interface CreateRepositorySandboxMetadata {
     finishedAt?: string;
     exitCode?: number;
     result?: string;
+    currentStep?: number;
+    steps?: Array<{ name?: string; exitCode?: number; finishedAt?: string }>;
   };
 }
 
@@ -66,6 +69,14 @@ export const createRepositorySandboxMapper: ComponentBaseMapper = {
       details["Directory"] = metadata.directory;
     }
 
+    const steps = metadata?.bootstrap?.steps;
+    if (steps && steps.length > 0) {
+      const current = steps[metadata?.bootstrap?.currentStep ?? 0];
+      if (current?.name) {
+        details["Bootstrap Step"] = current.name;
+      }
+    }
+
     return details;
   },
 };
@@ -101,5 +112,13 @@ function createRepositorySandboxSpecs(node: ComponentBaseContext["node"]): Compo
     specs.push({ title: "Script", value: config.bootstrap.script });
   }
 
+  if (config?.bootstrap?.from === "steps") {
+    for (const step of config.bootstrap.steps ?? []) {
+      if (step.name && step.script) {
+        specs.push({ title: step.name, value: step.script });
+      }
+    }
+  }
+
   return specs;
 }
@@ -7,9 +7,15 @@ import { EXECUTE_COMMAND_STATE_REGISTRY } from "./execute_command";
 export const componentMappers: Record<string, ComponentBaseMapper> = {
   createSandbox: baseMapper,
   createRepositorySandbox: createRepositorySandboxMapper,
+  cloneRepository: baseMapper,
   getPreviewUrl: baseMapper,
   executeCode: baseMapper,
   executeCommand: baseMapper,
+  runCommands: baseMapper,
+  runTests: baseMapper,
+  runMigration: baseMapper,
+  runCommandStreaming: baseMapper,
+  tagSandbox: baseMapper,
   deleteSandbox: baseMapper,
 };
 
@@ -18,8 +24,14 @@ export const triggerRenderers: Record<string, TriggerRenderer> = {};
 export const eventStateRegistry: Record<string, EventStateRegistry> = {
   createSandbox: buildActionStateRegistry("created"),
   createRepositorySandbox: buildActionStateRegistry("created"),
+  cloneRepository: buildActionStateRegistry("cloned"),
   getPreviewUrl: buildActionStateRegistry("generated"),
   executeCode: EXECUTE_COMMAND_STATE_REGISTRY,
   executeCommand: EXECUTE_COMMAND_STATE_REGISTRY,
+  runCommands: EXECUTE_COMMAND_STATE_REGISTRY,
+  runTests: buildActionStateRegistry("passed"),
+  runMigration: buildActionStateRegistry("migrated"),
+  runCommandStreaming: buildActionStateRegistry("finished"),
+  tagSandbox: buildActionStateRegistry("tagged"),
   deleteSandbox: buildActionStateRegistry("deleted"),
 };
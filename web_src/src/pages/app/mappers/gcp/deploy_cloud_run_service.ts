@@ -0,0 +1,60 @@
+import type { ComponentBaseProps } from "@/ui/componentBase";
+import type React from "react";
+import { renderTimeAgo } from "@/components/TimeAgo";
+import type {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  ExecutionDetailsContext,
+  OutputPayload,
+  SubtitleContext,
+} from "../types";
+import { baseMapper } from "./base";
+import gcpCloudRunIcon from "@/assets/icons/integrations/gcp.cloudrun.svg";
+
+export const deployCloudRunServiceMapper: ComponentBaseMapper = {
+  props(context: ComponentBaseContext): ComponentBaseProps {
+    return {
+      ...baseMapper.props(context),
+      iconSrc: gcpCloudRunIcon,
+    };
+  },
+
+  getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+    const outputs = context.execution.outputs as { default?: OutputPayload[] } | undefined;
+    const payload = outputs?.default?.[0];
+    const data = payload?.data as Record<string, any> | undefined;
+
+    const details: Record<string, string> = {};
+
+    if (payload?.timestamp) {
+      details["Deployed At"] = new Date(payload.timestamp).toLocaleString();
+    }
+
+    if (data?.service) {
+      details["Service"] = String(data.service);
+    }
+
+    if (data?.region) {
+      details["Region"] = String(data.region);
+    }
+
+    if (data?.url) {
+      details["URL"] = String(data.url);
+    }
+
+    if (data?.revision) {
+      details["Revision"] = String(data.revision);
+    }
+
+    if (data?.image) {
+      details["Image"] = String(data.image);
+    }
+
+    return details;
+  },
+
+  subtitle(context: SubtitleContext): string | React.ReactNode {
+    const timestamp = context.execution.updatedAt || context.execution.createdAt;
+    return timestamp ? renderTimeAgo(new Date(timestamp)) : "";
+  },
+};
@@ -0,0 +1,65 @@
+import type React from "react";
+import type {
+  ComponentBaseMapper,
+  ComponentBaseContext,
+  EventStateRegistry,
+  ExecutionDetailsContext,
+  NodeInfo,
+  SubtitleContext,
+} from "../types";
+import type { ComponentBaseProps } from "@/ui/componentBase";
+import { baseMapper } from "./base";
+import { buildActionStateRegistry } from "../utils";
+import { renderTimeAgo } from "@/components/TimeAgo";
+import type { MetadataItem } from "@/ui/metadataList";
+
+function iamSubtitle(context: SubtitleContext): string | React.ReactNode {
+  const timestamp = context.execution.updatedAt || context.execution.createdAt;
+  return timestamp ? renderTimeAgo(new Date(timestamp)) : "";
+}
+
+type ServiceAccountRolesOutputs = {
+  default?: Array<{
+    data?: {
+      projectId?: string;
+      serviceAccount?: string;
+      roles?: string[];
+    };
+  }>;
+};
+
+function serviceAccountRolesDetails(context: ExecutionDetailsContext): Record<string, string> {
+  const details: Record<string, string> = {};
+  const timestamp = context.execution.updatedAt || context.execution.createdAt;
+  if (timestamp) details["Completed At"] = new Date(timestamp).toLocaleString();
+
+  const item = (context.execution.outputs as ServiceAccountRolesOutputs | undefined)?.default?.[0]?.data;
+  if (!item) return details;
+
+  if (item.serviceAccount) details["Service Account"] = item.serviceAccount;
+  if (item.projectId) details["Project"] = item.projectId;
+  details["Roles"] = item.roles && item.roles.length > 0 ? item.roles.join(", ") : "None";
+  return details;
+}
+
+function serviceAccountRolesMetadataList(node: NodeInfo): MetadataItem[] {
+  const config = (node.configuration as Record<string, unknown> | undefined) ?? {};
+  const serviceAccount = String(config.serviceAccount ?? "").trim();
+  if (!serviceAccount || serviceAccount.includes("{{")) {
+    return [{ icon: "user", label: "Integration service account" }];
+  }
+  return [{ icon: "user", label: serviceAccount }];
+}
+
+export const getServiceAccountRolesMapper: ComponentBaseMapper = {
+  props(context: ComponentBaseContext): ComponentBaseProps {
+    return {
+      ...baseMapper.props(context),
+      metadata: serviceAccountRolesMetadataList(context.node),
+    };
+  },
+  getExecutionDetails: serviceAccountRolesDetails,
+  subtitle: iamSubtitle,
+};
+
+export const IAM_FETCHED_STATE_REGISTRY: EventStateRegistry = buildActionStateRegistry("fetched");
@@ -3,11 +3,13 @@ import { cloudBuildBaseMapper, computeBaseMapper } from "./base";
 import { buildActionStateRegistry } from "../utils";
 import { CLOUD_BUILD_EXECUTION_STATE_REGISTRY } from "./cloudbuild";
 import { onVMInstanceTriggerRenderer } from "./on_vm_instance";
+import { onVMStatusChangedTriggerRenderer } from "./on_vm_status_changed";
 import { onBuildCompleteTriggerRenderer } from "./on_build_complete";
 import { onArtifactPushTriggerRenderer } from "./on_artifact_push";
 import { onArtifactAnalysisTriggerRenderer } from "./on_artifact_analysis";
 import { runTriggerMapper } from "./run_trigger";
 import { invokeFunctionMapper } from "./invoke_function";
+import { deployCloudRunServiceMapper } from "./deploy_cloud_run_service";
 import { getArtifactMapper, getArtifactAnalysisMapper } from "./artifact_registry_mapper";
 import {
   publishMessageMapper,
@@ -24,6 +26,10 @@ import { deleteVMInstanceMapper } from "./delete_vm_instance";
 import { getVMInstanceMapper } from "./get_vm_instance";
 import { manageVMInstancePowerMapper, MANAGE_VM_INSTANCE_POWER_STATE_REGISTRY } from "./manage_vm_instance_power";
 import { updateVMInstanceTypeMapper } from "./update_vm_instance_type";
+import { attachAcceleratorMapper } from "./attach_accelerator";
+import { setVMLabelsMapper } from "./set_vm_labels";
+import { setVMDeletionProtectionMapper } from "./set_vm_deletion_protection";
+import { setVMSchedulingMapper } from "./set_vm_scheduling";
 import { getVMInstanceMetricsMapper, GET_VM_INSTANCE_METRICS_STATE_REGISTRY } from "./get_vm_instance_metrics";
 import {
   createAlertingPolicyMapper,
@@ -36,9 +42,13 @@ import { getSnoozeMapper } from "./get_snooze";
 import { expireSnoozeMapper } from "./expire_snooze";
 import { queryMapper, queryRangeMapper } from "./prometheus";
 import { createImageMapper } from "./create_image";
+import { WAIT_FOR_OPERATION_STATE_REGISTRY, waitForOperationMapper } from "./wait_for_operation";
+import { snapshotVMMapper } from "./snapshot_vm";
 import { updateImageMapper } from "./update_image";
 import { deleteImageMapper } from "./delete_image";
 import { createStaticIPMapper, deleteStaticIPMapper, manageStaticIPMapper } from "./static_ip";
+import { createNetworkMapper, createSubnetworkMapper } from "./network";
+import { createSnapshotScheduleMapper } from "./snapshot_schedule";
 import { createLoadBalancerMapper } from "./create_load_balancer";
 import { deleteLoadBalancerMapper } from "./delete_load_balancer";
 import { createFirewallRuleMapper } from "./create_firewall_rule";
@@ -63,6 +73,7 @@ import {
   STORAGE_FETCHED_STATE_REGISTRY,
   STORAGE_DELETED_STATE_REGISTRY,
 } from "./storage_mapper";
+import { getServiceAccountRolesMapper, IAM_FETCHED_STATE_REGISTRY } from "./iam_mapper";
 
 export const componentMappers: Record<string, ComponentBaseMapper> = {
   createVM: computeBaseMapper,
@@ -70,14 +81,20 @@ export const componentMappers: Record<string, ComponentBaseMapper> = {
   getVMInstance: getVMInstanceMapper,
   manageVMInstancePower: manageVMInstancePowerMapper,
   updateVMInstanceType: updateVMInstanceTypeMapper,
+  attachAccelerator: attachAcceleratorMapper,
+  setVMLabels: setVMLabelsMapper,
+  setVMDeletionProtection: setVMDeletionProtectionMapper,
+  setVMScheduling: setVMSchedulingMapper,
   getVMInstanceMetrics: getVMInstanceMetricsMapper,
   createImage: createImageMapper,
+  "compute.snapshotVM": snapshotVMMapper,
   updateImage: updateImageMapper,
   deleteImage: deleteImageMapper,
   "cloudbuild.createBuild": cloudBuildBaseMapper,
   "cloudbuild.getBuild": cloudBuildBaseMapper,
   "cloudbuild.runTrigger": runTriggerMapper,
   "cloudfunctions.invokeFunction": invokeFunctionMapper,
+  "cloudrun.deployService": deployCloudRunServiceMapper,
   "artifactregistry.getArtifact": getArtifactMapper,
   "artifactregistry.getArtifactAnalysis": getArtifactAnalysisMapper,
   "pubsub.publishMessage": publishMessageMapper,
@@ -98,8 +115,12 @@ export const componentMappers: Record<string, ComponentBaseMapper> = {
   "prometheus.query": queryMapper,
   "prometheus.queryRange": queryRangeMapper,
   "compute.createStaticIP": createStaticIPMapper,
+  "compute.waitForOperation": waitForOperationMapper,
   "compute.deleteStaticIP": deleteStaticIPMapper,
   "compute.manageStaticIP": manageStaticIPMapper,
+  "compute.createNetwork": createNetworkMapper,
+  "compute.createSubnetwork": createSubnetworkMapper,
+  "compute.createSnapshotSchedule": createSnapshotScheduleMapper,
   "compute.createLoadBalancer": createLoadBalancerMapper,
   "compute.deleteLoadBalancer": deleteLoadBalancerMapper,
   "compute.createFirewallRule": createFirewallRuleMapper,
@@ -114,10 +135,12 @@ export const componentMappers: Record<string, ComponentBaseMapper> = {
   "storage.createBucket": createBucketMapper,
   "storage.getBucket": getBucketMapper,
   "storage.deleteBucket": deleteBucketMapper,
+  "iam.getServiceAccountRoles": getServiceAccountRolesMapper,
 };
 
 export const triggerRenderers: Record<string, TriggerRenderer> = {
   onVMInstance: onVMInstanceTriggerRenderer,
+  "compute.onVMStatusChanged": onVMStatusChangedTriggerRenderer,
   "cloudbuild.onBuildComplete": onBuildCompleteTriggerRenderer,
   "artifactregistry.onArtifactPush": onArtifactPushTriggerRenderer,
   "artifactregistry.onArtifactAnalysis": onArtifactAnalysisTriggerRenderer,
@@ -131,14 +154,20 @@ export const eventStateRegistry: Record<string, EventStateRegistry> = {
   getVMInstance: buildActionStateRegistry("completed"),
   manageVMInstancePower: MANAGE_VM_INSTANCE_POWER_STATE_REGISTRY,
   updateVMInstanceType: buildActionStateRegistry("completed"),
+  attachAccelerator: buildActionStateRegistry("completed"),
+  setVMLabels: buildActionStateRegistry("completed"),
+  setVMDeletionProtection: buildActionStateRegistry("completed"),
+  setVMScheduling: buildActionStateRegistry("completed"),
   getVMInstanceMetrics: GET_VM_INSTANCE_METRICS_STATE_REGISTRY,
   createImage: buildActionStateRegistry("created"),
+  "compute.snapshotVM": buildActionStateRegistry("created"),
   updateImage: buildActionStateRegistry("updated"),
   deleteImage: buildActionStateRegistry("deleted"),
   "cloudbuild.createBuild": CLOUD_BUILD_EXECUTION_STATE_REGISTRY,
   "cloudbuild.getBuild": CLOUD_BUILD_EXECUTION_STATE_REGISTRY,
   "cloudbuild.runTrigger": CLOUD_BUILD_EXECUTION_STATE_REGISTRY,
   "cloudfunctions.invokeFunction": buildActionStateRegistry("completed"),
+  "cloudrun.deployService": buildActionStateRegistry("deployed"),
   "artifactregistry.getArtifact": buildActionStateRegistry("completed"),
   "artifactregistry.getArtifactAnalysis": buildActionStateRegistry("completed"),
   "pubsub.publishMessage": PUBSUB_ACTION_STATE_REGISTRY,
@@ -159,8 +188,12 @@ export const eventStateRegistry: Record<string, EventStateRegistry> = {
   "prometheus.query": buildActionStateRegistry("completed"),
   "prometheus.queryRange": buildActionStateRegistry("completed"),
   "compute.createStaticIP": buildActionStateRegistry("completed"),
+  "compute.waitForOperation": WAIT_FOR_OPERATION_STATE_REGISTRY,
   "compute.deleteStaticIP": buildActionStateRegistry("completed"),
   "compute.manageStaticIP": buildActionStateRegistry("completed"),
+  "compute.createNetwork": buildActionStateRegistry("created"),
+  "compute.createSubnetwork": buildActionStateRegistry("created"),
+  "compute.createSnapshotSchedule": buildActionStateRegistry("created"),
   "compute.createLoadBalancer": buildActionStateRegistry("created"),
   "compute.deleteLoadBalancer": buildActionStateRegistry("deleted"),
   "compute.createFirewallRule": buildActionStateRegistry("created"),
@@ -175,6 +208,7 @@ export const eventStateRegistry: Record<string, EventStateRegistry> = {
   "storage.createBucket": STORAGE_CREATED_STATE_REGISTRY,
   "storage.getBucket": STORAGE_FETCHED_STATE_REGISTRY,
   "storage.deleteBucket": STORAGE_DELETED_STATE_REGISTRY,
+  "iam.getServiceAccountRoles": IAM_FETCHED_STATE_REGISTRY,
 };
 
 export const customFieldRenderers: Record<string, CustomFieldRenderer> = {};
@@ -0,0 +1,150 @@
+import type { ComponentBaseProps } from "@/ui/componentBase";
+import type React from "react";
+import { getStateMap } from "..";
+import type {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  ExecutionDetailsContext,
+  NodeInfo,
+  OutputPayload,
+  SubtitleContext,
+} from "../types";
+import type { MetadataItem } from "@/ui/metadataList";
+import gcpIcon from "@/assets/icons/integrations/gcp.compute.svg";
+import { renderTimeAgo } from "@/components/TimeAgo";
+import { baseEventSections } from "./event_helpers";
+
+interface CreateNetworkConfiguration {
+  name?: string;
+  subnetMode?: string;
+}
+
+interface CreateNetworkOutputData {
+  name?: string;
+  subnetMode?: string;
+  routingMode?: string;
+  mtu?: number;
+  alreadyExisted?: boolean;
+}
+
+interface CreateSubnetworkConfiguration {
+  name?: string;
+  network?: string;
+  region?: string;
+  ipCidrRange?: string;
+}
+
+interface CreateSubnetworkOutputData {
+  name?: string;
+  network?: string;
+  region?: string;
+  ipCidrRange?: string;
+  gatewayAddress?: string;
+  privateIpGoogleAccess?: boolean;
+  alreadyExisted?: boolean;
+}
+
+function lastSegment(value: string | undefined): string | undefined {
+  if (!value) return undefined;
+  const trimmed = value.trim();
+  if (!trimmed || trimmed.includes("{{")) return undefined;
+  const idx = trimmed.lastIndexOf("/");
+  return idx >= 0 ? trimmed.slice(idx + 1).replace(/[?#].*$/, "") : trimmed;
+}
+
+function subtitle(context: SubtitleContext): string | React.ReactNode {
+  const timestamp = context.execution.updatedAt || context.execution.createdAt;
+  return timestamp ? renderTimeAgo(new Date(timestamp)) : "";
+}
+
+function baseProps(
+  context: ComponentBaseContext,
+  fallbackTitle: string,
+  metadata: MetadataItem[],
+): ComponentBaseProps {
+  const lastExecution = context.lastExecutions.length > 0 ? context.lastExecutions[0] : null;
+  const componentName = context.componentDefinition.name ?? "gcp";
+
+  return {
+    iconSrc: gcpIcon,
+    iconSlug: context.componentDefinition?.icon ?? "network",
+    collapsedBackground: "bg-white",
+    collapsed: context.node.isCollapsed,
+    title: context.node.name || context.componentDefinition?.label || fallbackTitle,
+    eventSections: lastExecution ? baseEventSections(context.nodes, lastExecution, componentName) : undefined,
+    metadata,
+    includeEmptyState: !lastExecution,
+    eventStateMap: getStateMap(componentName),
+  };
+}
+
+export const createNetworkMapper: ComponentBaseMapper = {
+  props(context: ComponentBaseContext): ComponentBaseProps {
+    return baseProps(context, "Create Network", createNetworkMetadata(context.node));
+  },
+
+  getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+    const details: Record<string, string> = {};
+    if (context.execution.createdAt) {
+      details["Executed At"] = new Date(context.execution.createdAt).toLocaleString();
+    }
+    const outputs = context.execution.outputs as { default?: OutputPayload[] } | undefined;
+    const result = outputs?.default?.[0]?.data as CreateNetworkOutputData | undefined;
+    if (!result) return details;
+
+    if (result.name) details["Network"] = result.name;
+    if (result.subnetMode) details["Subnet Mode"] = result.subnetMode;
+    if (result.routingMode) details["Routing Mode"] = result.routingMode;
+    if (result.mtu) details["MTU"] = String(result.mtu);
+    if (result.alreadyExisted) details["Status"] = "Already existed";
+    return details;
+  },
+
+  subtitle,
+};
+
+export const createSubnetworkMapper: ComponentBaseMapper = {
+  props(context: ComponentBaseContext): ComponentBaseProps {
+    return baseProps(context, "Create Subnetwork", createSubnetworkMetadata(context.node));
+  },
+
+  getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+    const details: Record<string, string> = {};
+    if (context.execution.createdAt) {
+      details["Executed At"] = new Date(context.execution.createdAt).toLocaleString();
+    }
+    const outputs = context.execution.outputs as { default?: OutputPayload[] } | undefined;
+    const result = outputs?.default?.[0]?.data as CreateSubnetworkOutputData | undefined;
+    if (!result) return details;
+
+    if (result.name) details["Subnetwork"] = result.name;
+    if (result.network) details["Network"] = result.network;
+    if (result.region) details["Region"] = result.region;
+    if (result.ipCidrRange) details["IP Range"] = result.ipCidrRange;
+    if (result.gatewayAddress) details["Gateway"] = result.gatewayAddress;
+    if (result.privateIpGoogleAccess) details["Private Google Access"] = "Enabled";
+    if (result.alreadyExisted) details["Status"] = "Already existed";
+    return details;
+  },
+
+  subtitle,
+};
+
+function createNetworkMetadata(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const config = node.configuration as CreateNetworkConfiguration | undefined;
+  if (config?.name) metadata.push({ icon: "network", label: config.name });
+  if (config?.subnetMode) metadata.push({ icon: "layers", label: config.subnetMode });
+  return metadata;
+}
+
+function createSubnetworkMetadata(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const config = node.configuration as CreateSubnetworkConfiguration | undefined;
+  const network = lastSegment(config?.network);
+  if (network) metadata.push({ icon: "network", label: network });
+  if (config?.ipCidrRange) metadata.push({ icon: "layers", label: config.ipCidrRange });
+  const region = lastSegment(config?.region);
+  if (region) metadata.push({ icon: "map-pin", label: region });
+  return metadata;
+}
@@ -0,0 +1,61 @@
+import { getColorClass, getBackgroundColorClass } from "@/lib/colors";
+import type React from "react";
+import type { TriggerEventContext, TriggerRenderer, TriggerRendererContext } from "../types";
+import type { TriggerProps } from "@/ui/trigger";
+import { flattenObject } from "@/lib/utils";
+import { renderTimeAgo } from "@/components/TimeAgo";
+import gcpComputeIcon from "@/assets/icons/integrations/gcp.compute.svg";
+
+interface VMStatusChangedData {
+  instanceName?: string;
+  resourceName?: string;
+  previousStatus?: string;
+  status?: string;
+  noticePeriodSeconds?: number;
+}
+
+function statusChangeTitle(data?: VMStatusChangedData): string {
+  if (data?.noticePeriodSeconds !== undefined) {
+    return `Preemption notice (${data.noticePeriodSeconds}s)`;
+  }
+  if (data?.previousStatus && data?.status) {
+    return `${data.previousStatus} → ${data.status}`;
+  }
+  return "VM status changed";
+}
+
+export const onVMStatusChangedTriggerRenderer: TriggerRenderer = {
+  getTitleAndSubtitle: (context: TriggerEventContext): { title: string; subtitle: string | React.ReactNode } => {
+    const data = context.event?.data as VMStatusChangedData | undefined;
+    return {
+      title: statusChangeTitle(data),
+      subtitle: data?.instanceName || data?.resourceName || "",
+    };
+  },
+
+  getRootEventValues: (context: TriggerEventContext): Record<string, string> => {
+    return flattenObject(context.event?.data || {});
+  },
+
+  getTriggerProps: (context: TriggerRendererContext): TriggerProps => {
+    const { node, definition, lastEvent } = context;
+    const lastData = lastEvent?.data as VMStatusChangedData | undefined;
+    return {
+      title: node.name || definition.label || "On VM Status Changed",
+      iconSrc: gcpComputeIcon,
+      iconSlug: definition.icon || "cloud",
+      iconColor: getColorClass("black"),
+      collapsedBackground: getBackgroundColorClass(definition.color ?? "gray"),
+      metadata: [],
+      ...(lastEvent && {
+        lastEventData: {
+          title: statusChangeTitle(lastData),
+          subtitle: lastData?.instanceName ?? renderTimeAgo(new Date(lastEvent.createdAt)),
+          receivedAt: new Date(lastEvent.createdAt),
+          state: "triggered",
+          eventId: lastEvent.id,
+        },
+      }),
+    };
+  },
+};
@@ -0,0 +1,32 @@
+import { describe, expect, it } from "vitest";
+import { setVMDeletionProtectionMapper } from "./set_vm_deletion_protection";
+import { buildDetailsCtx, buildOutput } from "./vm_mapper_test_helpers";
+
+describe("setVMDeletionProtectionMapper.getExecutionDetails", () => {
+  it("does not throw when outputs is undefined", () => {
+    const ctx = buildDetailsCtx({ execution: { outputs: undefined } });
+    expect(() => setVMDeletionProtectionMapper.getExecutionDetails(ctx)).not.toThrow();
+  });
+
+  it("extracts the instance fields and deletion protection state", () => {
+    const ctx = buildDetailsCtx({
+      execution: {
+        outputs: {
+          default: [
+            buildOutput({
+              name: "my-vm",
+              zone: "us-central1-a",
+              status: "RUNNING",
+              deletionProtection: false,
+            }),
+          ],
+        },
+      },
+    });
+    const details = setVMDeletionProtectionMapper.getExecutionDetails(ctx);
+    expect(details["Instance Name"]).toBe("my-vm");
+    expect(details["Zone"]).toBe("us-central1-a");
+    expect(details["Status"]).toBe("RUNNING");
+    expect(details["Deletion Protection"]).toBe("Disabled");
+  });
+});
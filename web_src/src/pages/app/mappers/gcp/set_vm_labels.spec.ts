@@ -0,0 +1,32 @@
+import { describe, expect, it } from "vitest";
+import { setVMLabelsMapper } from "./set_vm_labels";
+import { buildDetailsCtx, buildOutput } from "./vm_mapper_test_helpers";
+
+describe("setVMLabelsMapper.getExecutionDetails", () => {
+  it("does not throw when outputs is undefined", () => {
+    const ctx = buildDetailsCtx({ execution: { outputs: undefined } });
+    expect(() => setVMLabelsMapper.getExecutionDetails(ctx)).not.toThrow();
+  });
+
+  it("extracts the instance fields and labels", () => {
+    const ctx = buildDetailsCtx({
+      execution: {
+        outputs: {
+          default: [
+            buildOutput({
+              name: "my-vm",
+              zone: "us-central1-a",
+              status: "RUNNING",
+              labels: { env: "production", team: "platform" },
+            }),
+          ],
+        },
+      },
+    });
+    const details = setVMLabelsMapper.getExecutionDetails(ctx);
+    expect(details["Instance Name"]).toBe("my-vm");
+    expect(details["Zone"]).toBe("us-central1-a");
+    expect(details["Status"]).toBe("RUNNING");
+    expect(details["Labels"]).toBe("env=production, team=platform");
+  });
+});
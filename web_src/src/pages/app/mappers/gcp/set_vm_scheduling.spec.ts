@@ -0,0 +1,34 @@
+import { describe, expect, it } from "vitest";
+import { setVMSchedulingMapper } from "./set_vm_scheduling";
+import { buildDetailsCtx, buildOutput } from "./vm_mapper_test_helpers";
+
+describe("setVMSchedulingMapper.getExecutionDetails", () => {
+  it("does not throw when outputs is undefined", () => {
+    const ctx = buildDetailsCtx({ execution: { outputs: undefined } });
+    expect(() => setVMSchedulingMapper.getExecutionDetails(ctx)).not.toThrow();
+  });
+
+  it("extracts the new scheduling", () => {
+    const ctx = buildDetailsCtx({
+      execution: {
+        outputs: {
+          default: [
+            buildOutput({
+              name: "my-vm",
+              zone: "us-central1-a",
+              status: "RUNNING",
+              stopped: true,
+              scheduling: { provisioningModel: "SPOT", onHostMaintenance: "TERMINATE", automaticRestart: false },
+            }),
+          ],
+        },
+      },
+    });
+    const details = setVMSchedulingMapper.getExecutionDetails(ctx);
+    expect(details["Instance Name"]).toBe("my-vm");
+    expect(details["Provisioning Model"]).toBe("SPOT");
+    expect(details["On Host Maintenance"]).toBe("TERMINATE");
+    expect(details["Automatic Restart"]).toBe("No");
+    expect(details["Stopped For Update"]).toBe("Yes");
+  });
+});
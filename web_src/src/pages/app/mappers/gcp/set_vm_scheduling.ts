@@ -0,0 +1,105 @@
+import type { ComponentBaseProps } from "@/ui/componentBase";
+import type React from "react";
+import { getStateMap } from "..";
+import type {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  ExecutionDetailsContext,
+  NodeInfo,
+  OutputPayload,
+  SubtitleContext,
+} from "../types";
+import type { MetadataItem } from "@/ui/metadataList";
+import gcpIcon from "@/assets/icons/integrations/gcp.compute.svg";
+import { renderTimeAgo } from "@/components/TimeAgo";
+import { baseEventSections } from "./event_helpers";
+
+interface VMInstanceNodeMetadata {
+  instanceName?: string;
+  zone?: string;
+}
+
+interface SetVMSchedulingConfiguration {
+  instance?: string;
+  provisioningModel?: string;
+  onHostMaintenance?: string;
+}
+
+interface SetVMSchedulingOutputData {
+  name?: string;
+  zone?: string;
+  status?: string;
+  stopped?: boolean;
+  scheduling?: {
+    provisioningModel?: string;
+    onHostMaintenance?: string;
+    automaticRestart?: boolean;
+  };
+}
+
+export const setVMSchedulingMapper: ComponentBaseMapper = {
+  props(context: ComponentBaseContext): ComponentBaseProps {
+    const lastExecution = context.lastExecutions.length > 0 ? context.lastExecutions[0] : null;
+    const componentName = context.componentDefinition.name ?? "gcp";
+
+    return {
+      iconSrc: gcpIcon,
+      iconSlug: context.componentDefinition?.icon ?? "calendar-clock",
+      collapsedBackground: "bg-white",
+      collapsed: context.node.isCollapsed,
+      title: context.node.name || context.componentDefinition?.label || "Set VM Scheduling",
+      eventSections: lastExecution ? baseEventSections(context.nodes, lastExecution, componentName) : undefined,
+      metadata: metadataList(context.node),
+      includeEmptyState: !lastExecution,
+      eventStateMap: getStateMap(componentName),
+    };
+  },
+
+  getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+    const details: Record<string, string> = {};
+
+    if (context.execution.createdAt) {
+      details["Executed At"] = new Date(context.execution.createdAt).toLocaleString();
+    }
+
+    const outputs = context.execution.outputs as { default?: OutputPayload[] } | undefined;
+    const result = outputs?.default?.[0]?.data as SetVMSchedulingOutputData | undefined;
+    if (!result) return details;
+
+    if (result.name) details["Instance Name"] = result.name;
+    if (result.zone) details["Zone"] = result.zone;
+    if (result.scheduling?.provisioningModel) details["Provisioning Model"] = result.scheduling.provisioningModel;
+    if (result.scheduling?.onHostMaintenance) details["On Host Maintenance"] = result.scheduling.onHostMaintenance;
+    if (result.scheduling?.automaticRestart !== undefined) {
+      details["Automatic Restart"] = result.scheduling.automaticRestart ? "Yes" : "No";
+    }
+    if (result.stopped !== undefined) details["Stopped For Update"] = result.stopped ? "Yes" : "No";
+    if (result.status) details["Status"] = result.status;
+
+    return details;
+  },
+
+  subtitle(context: SubtitleContext): string | React.ReactNode {
+    const timestamp = context.execution.updatedAt || context.execution.createdAt;
+    return timestamp ? renderTimeAgo(new Date(timestamp)) : "";
+  },
+};
+
+function metadataList(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const nodeMetadata = node.metadata as VMInstanceNodeMetadata | undefined;
+  const configuration = node.configuration as SetVMSchedulingConfiguration | undefined;
+
+  const instanceName = nodeMetadata?.instanceName || configuration?.instance;
+  if (instanceName) {
+    metadata.push({ icon: "server", label: instanceName });
+  }
+  if (nodeMetadata?.zone) {
+    metadata.push({ icon: "map-pin", label: nodeMetadata.zone });
+  }
+  if (configuration?.provisioningModel) {
+    metadata.push({ icon: "calendar-clock", label: configuration.provisioningModel });
+  }
+
+  return metadata;
+}
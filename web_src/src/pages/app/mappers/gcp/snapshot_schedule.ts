@@ -0,0 +1,104 @@
+import type { ComponentBaseProps } from "@/ui/componentBase";
+import type React from "react";
+import { getStateMap } from "..";
+import type {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  ExecutionDetailsContext,
+  NodeInfo,
+  OutputPayload,
+  SubtitleContext,
+} from "../types";
+import type { MetadataItem } from "@/ui/metadataList";
+import gcpIcon from "@/assets/icons/integrations/gcp.compute.svg";
+import { renderTimeAgo } from "@/components/TimeAgo";
+import { baseEventSections } from "./event_helpers";
+
+interface CreateSnapshotScheduleConfiguration {
+  name?: string;
+  region?: string;
+  frequency?: string;
+  retentionDays?: number;
+}
+
+interface CreateSnapshotScheduleOutputData {
+  name?: string;
+  region?: string;
+  frequency?: string;
+  retentionDays?: number;
+  status?: string;
+  alreadyExisted?: boolean;
+}
+
+function lastSegment(value: string | undefined): string | undefined {
+  if (!value) return undefined;
+  const trimmed = value.trim();
+  if (!trimmed || trimmed.includes("{{")) return undefined;
+  const idx = trimmed.lastIndexOf("/");
+  return idx >= 0 ? trimmed.slice(idx + 1).replace(/[?#].*$/, "") : trimmed;
+}
+
+function retentionLabel(days: number | undefined): string | undefined {
+  if (!days) return undefined;
+  return days === 1 ? "1 day" : `${days} days`;
+}
+
+function subtitle(context: SubtitleContext): string | React.ReactNode {
+  const timestamp = context.execution.updatedAt || context.execution.createdAt;
+  return timestamp ? renderTimeAgo(new Date(timestamp)) : "";
+}
+
+export const createSnapshotScheduleMapper: ComponentBaseMapper = {
+  props(context: ComponentBaseContext): ComponentBaseProps {
+    const lastExecution = context.lastExecutions.length > 0 ? context.lastExecutions[0] : null;
+    const componentName = context.componentDefinition.name ?? "gcp";
+
+    return {
+      iconSrc: gcpIcon,
+      iconSlug: context.componentDefinition?.icon ?? "calendar-clock",
+      collapsedBackground: "bg-white",
+      collapsed: context.node.isCollapsed,
+      title: context.node.name || context.componentDefinition?.label || "Create Snapshot Schedule",
+      eventSections: lastExecution ? baseEventSections(context.nodes, lastExecution, componentName) : undefined,
+      metadata: createSnapshotScheduleMetadata(context.node),
+      includeEmptyState: !lastExecution,
+      eventStateMap: getStateMap(componentName),
+    };
+  },
+
+  getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+    const details: Record<string, string> = {};
+    if (context.execution.createdAt) {
+      details["Executed At"] = new Date(context.execution.createdAt).toLocaleString();
+    }
+    const outputs = context.execution.outputs as { default?: OutputPayload[] } | undefined;
+    const result = outputs?.default?.[0]?.data as CreateSnapshotScheduleOutputData | undefined;
+    if (!result) return details;
+
+    if (result.name) details["Schedule"] = result.name;
+    if (result.region) details["Region"] = result.region;
+    if (result.frequency) details["Frequency"] = result.frequency;
+    const retention = retentionLabel(result.retentionDays);
+    if (retention) details["Retention"] = retention;
+    if (result.alreadyExisted) {
+      details["Status"] = "Already existed";
+    } else if (result.status) {
+      details["Status"] = result.status;
+    }
+    return details;
+  },
+
+  subtitle,
+};
+
+function createSnapshotScheduleMetadata(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const config = node.configuration as CreateSnapshotScheduleConfiguration | undefined;
+  if (config?.name) metadata.push({ icon: "calendar-clock", label: config.name });
+  metadata.push({ icon: "repeat", label: config?.frequency || "daily" });
+  const retention = retentionLabel(config?.retentionDays);
+  if (retention) metadata.push({ icon: "archive", label: retention });
+  const region = lastSegment(config?.region);
+  if (region) metadata.push({ icon: "map-pin", label: region });
+  return metadata;
+}
@@ -0,0 +1,35 @@
+import { describe, expect, it } from "vitest";
+import { snapshotVMMapper } from "./snapshot_vm";
+import { buildDetailsCtx, buildOutput } from "./vm_mapper_test_helpers";
+
+describe("snapshotVMMapper.getExecutionDetails", () => {
+  it("does not throw when outputs is undefined", () => {
+    const ctx = buildDetailsCtx({ execution: { outputs: undefined } });
+    expect(() => snapshotVMMapper.getExecutionDetails(ctx)).not.toThrow();
+  });
+
+  it("extracts the created snapshot fields", () => {
+    const ctx = buildDetailsCtx({
+      execution: {
+        outputs: {
+          default: [
+            buildOutput({
+              name: "my-vm-20260602-120000",
+              status: "READY",
+              instance: "my-vm",
+              sourceDisk: "my-vm",
+              diskSizeGb: 10,
+              guestFlush: true,
+            }),
+          ],
+        },
+      },
+    });
+    const details = snapshotVMMapper.getExecutionDetails(ctx);
+    expect(details["Snapshot Name"]).toBe("my-vm-20260602-120000");
+    expect(details["Status"]).toBe("READY");
+    expect(details["Instance"]).toBe("my-vm");
+    expect(details["Disk Size"]).toBe("10 GB");
+    expect(details["Guest Flush"]).toBe("Yes");
+  });
+});
@@ -0,0 +1,72 @@
+import { DEFAULT_EVENT_STATE_MAP } from "@/ui/componentBase";
+import type {
+  ComponentBaseMapper,
+  EventStateRegistry,
+  ExecutionDetailsContext,
+  ExecutionInfo,
+  OutputPayload,
+} from "../types";
+import { defaultStateFunction } from "../stateRegistry";
+import { computeBaseMapper } from "./base";
+
+interface OperationOutputs {
+  passed?: OutputPayload[];
+  failed?: OutputPayload[];
+}
+
+interface OperationData {
+  name?: string;
+  scope?: string;
+  location?: string;
+  operationType?: string;
+  targetLink?: string;
+  status?: string;
+  error?: string;
+}
+
+export const waitForOperationMapper: ComponentBaseMapper = {
+  ...computeBaseMapper,
+
+  getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+    const outputs = context.execution.outputs as OperationOutputs | undefined;
+    const payload = outputs?.passed?.[0] ?? outputs?.failed?.[0];
+    const data = payload?.data as OperationData | undefined;
+
+    const details: Record<string, string> = {};
+    if (payload?.timestamp) {
+      details["Finished At"] = new Date(payload.timestamp).toLocaleString();
+    }
+    if (data?.name) {
+      details["Operation"] = data.name;
+    }
+    if (data?.operationType) {
+      details["Type"] = data.operationType;
+    }
+    if (data?.location) {
+      details["Location"] = data.location;
+    }
+    if (data?.targetLink) {
+      const parts = data.targetLink.split("/");
+      details["Target"] = parts[parts.length - 1] ?? data.targetLink;
+    }
+    if (data?.error) {
+      details["Error"] = data.error;
+    }
+
+    return details;
+  },
+};
+
+export const WAIT_FOR_OPERATION_STATE_REGISTRY: EventStateRegistry = {
+  stateMap: {
+    ...DEFAULT_EVENT_STATE_MAP,
+    completed: DEFAULT_EVENT_STATE_MAP.success,
+  },
+  getState: (execution: ExecutionInfo) => {
+    const state = defaultStateFunction(execution);
+    if (state !== "success") return state;
+
+    const outputs = execution.outputs as OperationOutputs | undefined;
+    return outputs?.failed?.length ? "failed" : "completed";
+  },
+};
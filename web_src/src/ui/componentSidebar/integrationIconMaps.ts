@@ -193,6 +193,7 @@ export const APP_LOGO_MAP: Record<string, string | Record<string, string>> = {
     deleteVMInstance: gcpComputeIcon,
     manageVMInstancePower: gcpComputeIcon,
     updateVMInstanceType: gcpComputeIcon,
+    setVMLabels: gcpComputeIcon,
     getVMInstanceMetrics: gcpComputeIcon,
     getVMInstance: gcpComputeIcon,
     createImage: gcpComputeIcon,